@@ -58,6 +58,8 @@ func (r *Repository) ApplyAttestations(ctx context.Context, remoteName string, l
 // last RSL entry for the target ref. The to ID is that of the expected Git tree
 // created by merging the feature ref into the target ref. The commit used to
 // calculate the merge tree ID is identified using the RSL for the feature ref.
+// The hat the signer acts in can be specified via opts, in which case the
+// authorization only counts towards that role's threshold.
 func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslibdsse.SignerVerifier, targetRef, featureRef string, signCommit bool, opts ...attestopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
@@ -136,7 +138,12 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 
 	// Does a reference authorization already exist for the parameters?
 	hasAuthorization := false
-	env, err := allAttestations.GetReferenceAuthorizationFor(r.r, targetRef, fromID.String(), toID.String())
+	var env *sslibdsse.Envelope
+	if options.Hat == "" {
+		env, err = allAttestations.GetReferenceAuthorizationFor(r.r, targetRef, fromID.String(), toID.String())
+	} else {
+		env, err = allAttestations.GetHattedReferenceAuthorizationFor(r.r, targetRef, fromID.String(), toID.String(), options.Hat)
+	}
 	if err == nil {
 		slog.Debug("Found existing reference authorization...")
 		hasAuthorization = true
@@ -148,9 +155,14 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		// Create a new reference authorization and embed in env
 		slog.Debug("Creating new reference authorization...")
 		var statement *ita.Statement
-		if isTag {
+		switch {
+		case isTag && options.Hat != "":
+			statement, err = attestations.NewReferenceAuthorizationForTagWithHat(targetRef, fromID.String(), toID.String(), options.Hat)
+		case isTag:
 			statement, err = attestations.NewReferenceAuthorizationForTag(targetRef, fromID.String(), toID.String())
-		} else {
+		case options.Hat != "":
+			statement, err = attestations.NewReferenceAuthorizationForCommitWithHat(targetRef, fromID.String(), toID.String(), options.Hat)
+		default:
 			statement, err = attestations.NewReferenceAuthorizationForCommit(targetRef, fromID.String(), toID.String())
 		}
 		if err != nil {
@@ -174,7 +186,12 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		return err
 	}
 
-	if err := allAttestations.SetReferenceAuthorization(r.r, env, targetRef, fromID.String(), toID.String()); err != nil {
+	if options.Hat == "" {
+		err = allAttestations.SetReferenceAuthorization(r.r, env, targetRef, fromID.String(), toID.String())
+	} else {
+		err = allAttestations.SetHattedReferenceAuthorization(r.r, env, targetRef, fromID.String(), toID.String(), options.Hat)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -182,6 +199,9 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 	if isTag {
 		commitMessage = fmt.Sprintf("Add reference authorization for '%s' at '%s'", targetRef, toID.String())
 	}
+	if options.Hat != "" {
+		commitMessage += fmt.Sprintf(" in hat '%s'", options.Hat)
+	}
 
 	slog.Debug("Committing attestations...")
 	return allAttestations.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit)
@@ -189,7 +209,7 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 
 // RemoveReferenceAuthorization removes a previously issued authorization for
 // the specified parameters. The issuer of the authorization is identified using
-// their key.
+// their key. The hat the authorization was issued in can be specified via opts.
 func (r *Repository) RemoveReferenceAuthorization(ctx context.Context, signer sslibdsse.SignerVerifier, targetRef, fromID, toID string, signCommit bool, opts ...attestopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
@@ -227,7 +247,12 @@ func (r *Repository) RemoveReferenceAuthorization(ctx context.Context, signer ss
 	}
 
 	slog.Debug("Loading reference authorization...")
-	env, err := allAttestations.GetReferenceAuthorizationFor(r.r, targetRef, fromID, toID)
+	var env *sslibdsse.Envelope
+	if options.Hat == "" {
+		env, err = allAttestations.GetReferenceAuthorizationFor(r.r, targetRef, fromID, toID)
+	} else {
+		env, err = allAttestations.GetHattedReferenceAuthorizationFor(r.r, targetRef, fromID, toID, options.Hat)
+	}
 	if err != nil {
 		if errors.Is(err, authorizations.ErrAuthorizationNotFound) {
 			// No reference authorization at all
@@ -248,19 +273,29 @@ func (r *Repository) RemoveReferenceAuthorization(ctx context.Context, signer ss
 
 	if len(newSignatures) == 0 {
 		// No signatures, we can remove the ReferenceAuthorization altogether
-		if err := allAttestations.RemoveReferenceAuthorization(targetRef, fromID, toID); err != nil {
-			return err
+		if options.Hat == "" {
+			err = allAttestations.RemoveReferenceAuthorization(targetRef, fromID, toID)
+		} else {
+			err = allAttestations.RemoveHattedReferenceAuthorization(targetRef, fromID, toID, options.Hat)
 		}
 	} else {
 		// We still have other signatures, so set the ReferenceAuthorization
 		// envelope
 		env.Signatures = newSignatures
-		if err := allAttestations.SetReferenceAuthorization(r.r, env, targetRef, fromID, toID); err != nil {
-			return err
+		if options.Hat == "" {
+			err = allAttestations.SetReferenceAuthorization(r.r, env, targetRef, fromID, toID)
+		} else {
+			err = allAttestations.SetHattedReferenceAuthorization(r.r, env, targetRef, fromID, toID, options.Hat)
 		}
 	}
+	if err != nil {
+		return err
+	}
 
 	commitMessage := fmt.Sprintf("Remove reference authorization for '%s' from '%s' to '%s' by '%s'", targetRef, fromID, toID, keyID)
+	if options.Hat != "" {
+		commitMessage += fmt.Sprintf(" in hat '%s'", options.Hat)
+	}
 
 	slog.Debug("Committing attestations...")
 	return allAttestations.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit)
@@ -372,8 +407,8 @@ func (r *Repository) AddGitHubPullRequestAttestationForNumber(ctx context.Contex
 // and stored in the repository. To find the review information, the GitHub API
 // is used and the source for the authentication token for the API is passed in
 // as an option.  If the source is not passed in, the token is read from the
-// GITHUB_TOKEN environment variable. A custom GitHub instance can be specified
-// via opts.
+// GITHUB_TOKEN environment variable. A custom GitHub instance and the hat the
+// approver acts in can be specified via opts.
 func (r *Repository) AddGitHubPullRequestApprover(ctx context.Context, signer sslibdsse.SignerVerifier, owner, repository string, pullRequestNumber int, reviewID int64, approver string, signCommit bool, opts ...githubopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
@@ -424,6 +459,7 @@ func (r *Repository) AddGitHubPullRequestApprover(ctx context.Context, signer ss
 
 	approvers := []string{approver}
 	var dismissedApprovers []string
+	hats := map[string]string{}
 	if !hasApprovalAttestation {
 		// Create a new GitHub pull request approval attestation
 		slog.Debug("Creating new GitHub pull request approval attestation...")
@@ -437,9 +473,18 @@ func (r *Repository) AddGitHubPullRequestApprover(ctx context.Context, signer ss
 
 		approvers = append(approvers, predicate.GetApprovers()...)
 		dismissedApprovers = predicate.GetDismissedApprovers()
+		for existingApprover, hat := range predicate.GetApproverHats() {
+			hats[existingApprover] = hat
+		}
+	}
+
+	// The approver's hat is updated with each approval they submit
+	delete(hats, approver)
+	if options.Hat != "" {
+		hats[approver] = options.Hat
 	}
 
-	statement, err := attestations.NewGitHubPullRequestApprovalAttestation(baseRef, fromID, toID, approvers, dismissedApprovers)
+	statement, err := attestations.NewGitHubPullRequestApprovalAttestationWithHats(baseRef, fromID, toID, approvers, dismissedApprovers, hats)
 	if err != nil {
 		return err
 	}
@@ -524,7 +569,8 @@ func (r *Repository) DismissGitHubPullRequestApprover(ctx context.Context, signe
 	fromID := predicate.GetFromID()
 	toID := predicate.GetTargetID()
 
-	statement, err := attestations.NewGitHubPullRequestApprovalAttestation(baseRef, fromID, toID, approvers, dismissedApprovers)
+	// Hats of approvers who are no longer listed are dropped
+	statement, err := attestations.NewGitHubPullRequestApprovalAttestationWithHats(baseRef, fromID, toID, approvers, dismissedApprovers, predicate.GetApproverHats())
 	if err != nil {
 		return err
 	}
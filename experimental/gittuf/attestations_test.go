@@ -200,6 +200,41 @@ func TestAddAndRemoveReferenceAuthorization(t *testing.T) {
 		}
 		assert.Len(t, env.Signatures, 1)
 		assert.Equal(t, firstKeyID, env.Signatures[0].KeyID)
+
+		// Authorization in a hat is recorded separately
+		err = repo.AddReferenceAuthorization(testCtx, secondSigner, absTargetRef, absFeatureRef, false, attestopts.WithRSLEntry(), attestopts.WithHat("security"))
+		assert.Nil(t, err)
+
+		allAttestations, err = attestations.LoadCurrentAttestations(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		env, err = allAttestations.GetHattedReferenceAuthorizationFor(r, absTargetRef, fromCommitID.String(), targetTreeID.String(), "security")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, env.Signatures, 1)
+		assert.Equal(t, secondKeyID, env.Signatures[0].KeyID)
+
+		env, err = allAttestations.GetReferenceAuthorizationFor(r, absTargetRef, fromCommitID.String(), targetTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, env.Signatures, 1)
+		assert.Equal(t, firstKeyID, env.Signatures[0].KeyID)
+
+		// Remove authorization in hat
+		err = repo.RemoveReferenceAuthorization(testCtx, secondSigner, absTargetRef, fromCommitID.String(), targetTreeID.String(), false, attestopts.WithRSLEntry(), attestopts.WithHat("security"))
+		assert.Nil(t, err)
+
+		allAttestations, err = attestations.LoadCurrentAttestations(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = allAttestations.GetHattedReferenceAuthorizationFor(r, absTargetRef, fromCommitID.String(), targetTreeID.String(), "security")
+		assert.ErrorIs(t, err, authorizations.ErrAuthorizationNotFound)
 	})
 
 	t.Run("for tag", func(t *testing.T) {
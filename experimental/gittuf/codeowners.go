@@ -0,0 +1,177 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/codeowners"
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+)
+
+const codeOwnersRuleNamePrefix = "codeowners-line-"
+
+// ImportCodeOwners creates file rules in the specified rule file from the
+// contents of a CODEOWNERS file. Each entry becomes a rule named after its
+// line, trusting the principals its owners map to with a threshold of one.
+// As later CODEOWNERS entries take precedence, each rule excludes the paths
+// matched by subsequent entries, and the imported rules are placed ahead of
+// existing rules with the highest precedence entry first.
+//
+// Owners are mapped to principals declared in the rule file: "@user" to a
+// person with the handle as their associated identity for identityProvider,
+// "@org/team" to a team with that ID, and an email address to a person with
+// the address as an associated identity. The names of the rules created and
+// the owners that could not be mapped are returned.
+func (r *Repository) ImportCodeOwners(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, codeOwnersContents []byte, identityProvider string, signCommit bool, opts ...trustpolicyopts.Option) ([]string, []string, error) {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	slog.Debug("Parsing CODEOWNERS file...")
+	entries, err := codeowners.Parse(codeOwnersContents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return nil, nil, policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existingRuleNames := []string{}
+	for _, rule := range targetsMetadata.GetRules() {
+		if rule.ID() != tuf.AllowRuleName {
+			existingRuleNames = append(existingRuleNames, rule.ID())
+		}
+	}
+
+	principals := targetsMetadata.GetPrincipals()
+	unmappedOwners := set.NewSet[string]()
+	importedRuleNames := []string{}
+
+	for i, entry := range entries {
+		principalIDs := []string{}
+		for _, owner := range entry.Owners {
+			principalID, found := findPrincipalForCodeOwner(principals, owner, identityProvider)
+			if !found {
+				unmappedOwners.Add(owner)
+				continue
+			}
+			if !slices.Contains(principalIDs, principalID) {
+				principalIDs = append(principalIDs, principalID)
+			}
+		}
+
+		if len(principalIDs) == 0 {
+			slog.Debug(fmt.Sprintf("Skipping CODEOWNERS entry on line %d as it has no mapped owners...", entry.Line))
+			continue
+		}
+
+		ruleName := fmt.Sprintf("%s%d", codeOwnersRuleNamePrefix, entry.Line)
+		if state.HasRuleName(ruleName) {
+			return nil, nil, fmt.Errorf("%w: '%s'", tuf.ErrDuplicatedRuleName, ruleName)
+		}
+
+		rulePatterns := entry.RulePatterns()
+		for _, laterEntry := range entries[i+1:] {
+			for _, pattern := range laterEntry.RulePatterns() {
+				exclusion := tuf.ExclusionPatternPrefix + pattern
+				if !slices.Contains(rulePatterns, exclusion) {
+					rulePatterns = append(rulePatterns, exclusion)
+				}
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Adding rule '%s' to rule file...", ruleName))
+		if err := targetsMetadata.AddRule(ruleName, principalIDs, rulePatterns, 1); err != nil {
+			return nil, nil, err
+		}
+		importedRuleNames = append(importedRuleNames, ruleName)
+	}
+
+	if len(importedRuleNames) == 0 {
+		return nil, unmappedOwners.Contents(), nil
+	}
+
+	slog.Debug("Reordering rules in rule file...")
+	ruleNames := slices.Clone(importedRuleNames)
+	slices.Reverse(ruleNames)
+	ruleNames = append(ruleNames, existingRuleNames...)
+	if err := targetsMetadata.ReorderRules(ruleNames); err != nil {
+		return nil, nil, err
+	}
+
+	commitMessage := fmt.Sprintf("Import CODEOWNERS rules into policy '%s'", targetsRoleName)
+	if err := r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit); err != nil {
+		return nil, nil, err
+	}
+
+	return importedRuleNames, unmappedOwners.Contents(), nil
+}
+
+// findPrincipalForCodeOwner returns the ID of the principal in principals that
+// corresponds to the CODEOWNERS owner.
+func findPrincipalForCodeOwner(principals map[string]tuf.Principal, owner, identityProvider string) (string, bool) {
+	principalIDs := make([]string, 0, len(principals))
+	for principalID := range principals {
+		principalIDs = append(principalIDs, principalID)
+	}
+	slices.Sort(principalIDs)
+
+	handle, isHandle := strings.CutPrefix(owner, "@")
+	for _, principalID := range principalIDs {
+		switch principal := principals[principalID].(type) {
+		case *tufv02.Team:
+			if isHandle && strings.Contains(handle, "/") && strings.EqualFold(principal.TeamID, handle) {
+				return principalID, true
+			}
+		case *tufv02.Person:
+			if isHandle {
+				if identity, has := principal.AssociatedIdentities[identityProvider]; has && strings.EqualFold(identity, handle) {
+					return principalID, true
+				}
+				continue
+			}
+
+			for _, identity := range principal.AssociatedIdentities {
+				if strings.EqualFold(identity, owner) {
+					return principalID, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
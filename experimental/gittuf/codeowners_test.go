@@ -0,0 +1,81 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCodeOwners(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+	key := tufv02.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH))
+	person := &tufv02.Person{
+		PersonID:             "jane.doe",
+		PublicKeys:           map[string]*tufv02.Key{key.KeyID: key},
+		AssociatedIdentities: map[string]string{"github": "JaneDoe", "sigstore": "jane@example.com"},
+		Custom:               map[string]string{},
+	}
+	err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{person}, false)
+	require.Nil(t, err)
+
+	codeOwners := []byte(`# Default owners
+*        @janedoe
+/docs/   @unknown jane@example.com
+/vendor/
+`)
+
+	importedRuleNames, unmappedOwners, err := r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, codeOwners, "github", false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"codeowners-line-2", "codeowners-line-3"}, importedRuleNames)
+	assert.Equal(t, []string{"@unknown"}, unmappedOwners)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	require.Nil(t, err)
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, false)
+	require.Nil(t, err)
+
+	rules := targetsMetadata.GetRules()
+	ruleNames := []string{}
+	for _, rule := range rules {
+		ruleNames = append(ruleNames, rule.ID())
+	}
+	assert.Equal(t, []string{"codeowners-line-3", "codeowners-line-2", "protect-main", tuf.AllowRuleName}, ruleNames)
+
+	docsRule, defaultRule := rules[0], rules[1]
+	assert.Equal(t, []string{person.PersonID}, docsRule.GetPrincipalIDs().Contents())
+	assert.True(t, docsRule.Matches("file:docs/README.md"))
+	assert.False(t, docsRule.Matches("file:src/docs/README.md"))
+
+	assert.Equal(t, []string{person.PersonID}, defaultRule.GetPrincipalIDs().Contents())
+	assert.True(t, defaultRule.Matches("file:src/main.go"))
+	assert.False(t, defaultRule.Matches("file:docs/README.md"))
+	assert.False(t, defaultRule.Matches("file:vendor/lib/lib.go"))
+
+	t.Run("import again", func(t *testing.T) {
+		_, _, err := r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, codeOwners, "github", false)
+		assert.ErrorIs(t, err, tuf.ErrDuplicatedRuleName)
+	})
+
+	t.Run("no mapped owners", func(t *testing.T) {
+		importedRuleNames, unmappedOwners, err := r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, []byte("* @org/team\n"), "github", false)
+		assert.Nil(t, err)
+		assert.Empty(t, importedRuleNames)
+		assert.Equal(t, []string{"@org/team"}, unmappedOwners)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+// DiffPolicy returns the semantic differences between the policy states
+// recorded by the fromEntryID and toEntryID RSL entries. If fromEntryID is
+// empty, the currently applied policy is used, and if toEntryID is empty, the
+// staged policy is used, so that the changes awaiting signatures or being
+// applied can be reviewed.
+func (r *Repository) DiffPolicy(ctx context.Context, fromEntryID, toEntryID string) ([]*policy.Change, error) {
+	var (
+		fromState *policy.State
+		err       error
+	)
+	if fromEntryID == "" {
+		slog.Debug("Loading current policy...")
+		fromState, err = policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	} else {
+		fromState, err = r.loadPolicyStateForEntry(ctx, fromEntryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var toState *policy.State
+	if toEntryID == "" {
+		slog.Debug("Loading staged policy...")
+		toState, err = policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	} else {
+		toState, err = r.loadPolicyStateForEntry(ctx, toEntryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.DiffStates(fromState, toState)
+}
+
+// loadPolicyStateForEntry loads the policy state recorded by the RSL entry,
+// which must be for the policy or policy staging ref.
+func (r *Repository) loadPolicyStateForEntry(ctx context.Context, entryID string) (*policy.State, error) {
+	entryIDHash, err := gitinterface.NewHash(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Loading policy at RSL entry '%s'...", entryID))
+	entry, err := rsl.GetEntry(r.r, entryIDHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load RSL entry '%s': %w", entryID, err)
+	}
+
+	referenceEntry, isReferenceEntry := entry.(rsl.ReferenceUpdaterEntry)
+	if !isReferenceEntry {
+		return nil, fmt.Errorf("%w: '%s' does not record a policy state", rsl.ErrInvalidRSLEntry, entryID)
+	}
+
+	return policy.LoadState(ctx, r.r, referenceEntry)
+}
@@ -0,0 +1,91 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPolicy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	targetsKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+	changes, err := r.DiffPolicy(testCtx, "", "")
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+
+	previousPolicyEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(policy.PolicyRef))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{targetsKey}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChanges := []*policy.Change{
+		{
+			Kind:   policy.DiffKindPrincipal,
+			Action: policy.DiffActionAdded,
+			Scope:  policy.TargetsRoleName,
+			Name:   targetsKey.KeyID,
+		},
+	}
+
+	t.Run("applied and staged policy", func(t *testing.T) {
+		changes, err := r.DiffPolicy(testCtx, "", "")
+		assert.Nil(t, err)
+		assert.Equal(t, expectedChanges, changes)
+	})
+
+	if err := r.StagePolicy(testCtx, "", true, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.Apply(testCtx, r.r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("between RSL entries", func(t *testing.T) {
+		latestPolicyEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(policy.PolicyRef))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		changes, err := r.DiffPolicy(testCtx, previousPolicyEntry.GetID().String(), latestPolicyEntry.GetID().String())
+		assert.Nil(t, err)
+		assert.Equal(t, expectedChanges, changes)
+
+		// The applied policy now matches the staged policy
+		changes, err = r.DiffPolicy(testCtx, "", "")
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("entry is not for policy", func(t *testing.T) {
+		if err := rsl.NewReferenceEntry("refs/heads/main", previousPolicyEntry.GetTargetID()).Commit(r.r, false); err != nil {
+			t.Fatal(err)
+		}
+		latestEntry, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = r.DiffPolicy(testCtx, latestEntry.GetID().String(), "")
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryDoesNotMatchRef)
+	})
+
+	t.Run("invalid entry ID", func(t *testing.T) {
+		_, err := r.DiffPolicy(testCtx, "not-an-entry", "")
+		assert.ErrorIs(t, err, gitinterface.ErrInvalidHashLength)
+	})
+}
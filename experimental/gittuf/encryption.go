@@ -0,0 +1,205 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/encryption"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+const encryptedCommitMessage = "Encrypt contents"
+
+var (
+	ErrPathOutsideOutputDir    = errors.New("encrypted contents contain a path outside the output directory")
+	ErrSymlinkOutsideOutputDir = errors.New("encrypted contents contain a symbolic link to a location outside the output directory")
+	ErrOutputDirNotEmpty       = errors.New("output directory is not empty")
+)
+
+// EncryptReference commits the contents of the source reference to the target
+// reference with every file encrypted for the readers authorized by the
+// restrict read global rules that apply to the target reference. File paths
+// are not encrypted. The target reference must be fully qualified if it does
+// not exist yet. As with any other change, the target reference must then be
+// recorded in the RSL.
+func (r *Repository) EncryptReference(ctx context.Context, sourceRef, targetRef string, signCommit bool) (gitinterface.Hash, error) {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return gitinterface.ZeroHash, err
+		}
+	}
+
+	sourceRef, err := r.r.AbsoluteReference(sourceRef)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	targetRef, err = r.r.AbsoluteReference(targetRef)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	slog.Debug(fmt.Sprintf("Identifying readers of '%s'...", targetRef))
+	readerKeys, err := state.GetReaderKeysForReference(targetRef)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	sourceCommitID, err := r.r.GetReference(sourceRef)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	sourceTreeID, err := r.r.GetCommitTreeID(sourceCommitID)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	slog.Debug(fmt.Sprintf("Encrypting contents of '%s' for %d key(s)...", sourceCommitID.String(), len(readerKeys)))
+	encryptedTreeID, err := encryption.EncryptTree(r.r, sourceTreeID, readerKeys)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	// The commit message must not identify the source commit, as the
+	// encrypted reference may be readable by anyone
+	return r.r.Commit(encryptedTreeID, targetRef, encryptedCommitMessage, signCommit)
+}
+
+// DecryptReference writes the decrypted contents of the tip of the specified
+// encrypted reference to the output directory using the SSH private key at
+// privateKeyPath, restoring executable files and symbolic links. For
+// submodules, only their directories are created. The key must be one of the
+// keys the contents were encrypted for. The output directory must be empty or
+// not exist yet, and symbolic links may only point to locations within it.
+func (r *Repository) DecryptReference(_ context.Context, refName, privateKeyPath, outputDir string) error {
+	refName, err := r.r.AbsoluteReference(refName)
+	if err != nil {
+		return err
+	}
+
+	commitID, err := r.r.GetReference(refName)
+	if err != nil {
+		return err
+	}
+
+	treeID, err := r.r.GetCommitTreeID(commitID)
+	if err != nil {
+		return err
+	}
+
+	privateKey, keyID, err := encryption.LoadPrivateKey(privateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Decrypting contents of '%s' using key '%s'...", commitID.String(), keyID))
+	files, err := encryption.DecryptTree(r.r, treeID, privateKey, keyID)
+	if err != nil {
+		return err
+	}
+
+	// The encrypted tree is untrusted input, so every path and symbolic link
+	// is checked before anything is written
+	for path, file := range files {
+		if !filepath.IsLocal(filepath.FromSlash(path)) {
+			return fmt.Errorf("%w: '%s'", ErrPathOutsideOutputDir, path)
+		}
+
+		if file.Mode == gitinterface.ModeSymbolicLink && !isLocalSymlinkTarget(path, string(file.Contents)) {
+			return fmt.Errorf("%w: '%s'", ErrSymlinkOutsideOutputDir, path)
+		}
+	}
+
+	// Existing entries in the output directory, such as symbolic links, must
+	// not be written through
+	entries, err := os.ReadDir(outputDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(entries) != 0 {
+		return fmt.Errorf("%w: '%s'", ErrOutputDirNotEmpty, outputDir)
+	}
+
+	// Symbolic links are created after all other files so that no file is
+	// written through them
+	symlinks := map[string]string{}
+	for path, file := range files {
+		outputPath := filepath.Join(outputDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+			return err
+		}
+
+		// Decrypted files are only accessible to the current user
+		switch file.Mode {
+		case gitinterface.ModeSymbolicLink:
+			symlinks[outputPath] = string(file.Contents)
+		case gitinterface.ModeGitlink:
+			// As with an uninitialized submodule, only its directory is
+			// created
+			if err := os.MkdirAll(outputPath, 0o755); err != nil {
+				return err
+			}
+		case gitinterface.ModeExecutableFile:
+			if err := os.WriteFile(outputPath, file.Contents, 0o700); err != nil {
+				return err
+			}
+		default:
+			if err := os.WriteFile(outputPath, file.Contents, 0o600); err != nil {
+				return err
+			}
+		}
+	}
+
+	for outputPath, target := range symlinks {
+		if err := os.Symlink(target, outputPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isLocalSymlinkTarget returns true if the target of the symbolic link at the
+// specified path, relative to the output directory, is within the output
+// directory. A ".." element that follows another element is rejected, as that
+// element may itself be a symbolic link and so the target cannot be resolved
+// lexically.
+func isLocalSymlinkTarget(path, target string) bool {
+	if target == "" || filepath.IsAbs(target) || filepath.VolumeName(target) != "" {
+		return false
+	}
+
+	target = filepath.FromSlash(target)
+	hasName := false
+	for _, element := range strings.Split(target, string(filepath.Separator)) {
+		switch element {
+		case "..":
+			if hasName {
+				return false
+			}
+		case "", ".":
+		default:
+			hasName = true
+		}
+	}
+
+	return filepath.IsLocal(filepath.Join(filepath.Dir(filepath.FromSlash(path)), target))
+}
@@ -0,0 +1,219 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/encryption"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecryptReference(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	readerKeyID, err := targetsSigner.KeyID()
+	require.Nil(t, err)
+
+	err = r.AddGlobalRuleRestrictRead(testCtx, rootSigner, "restrict-embargo", []string{"git:refs/heads/embargo/*"}, []string{readerKeyID}, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+	require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+	fixID, err := r.r.WriteBlob([]byte("embargoed fix"))
+	require.Nil(t, err)
+	treeID, err := gitinterface.NewTreeBuilder(r.r).WriteTreeFromEntries([]gitinterface.TreeEntry{gitinterface.NewEntryBlob("src/fix.go", fixID)})
+	require.Nil(t, err)
+	sourceCommitID, err := r.r.Commit(treeID, "refs/heads/fix", "Add fix\n", false)
+	require.Nil(t, err)
+
+	commitID, err := r.EncryptReference(testCtx, "refs/heads/fix", "refs/heads/embargo/fix", false)
+	require.Nil(t, err)
+
+	tipID, err := r.r.GetReference("refs/heads/embargo/fix")
+	require.Nil(t, err)
+	assert.Equal(t, commitID, tipID)
+
+	commitMessage, err := r.r.GetCommitMessage(commitID)
+	require.Nil(t, err)
+	assert.NotContains(t, commitMessage, sourceCommitID.String())
+
+	encryptedTreeID, err := r.r.GetCommitTreeID(commitID)
+	require.Nil(t, err)
+	manifest, err := encryption.LoadManifest(r.r, encryptedTreeID)
+	require.Nil(t, err)
+	assert.True(t, manifest.HasRecipient(readerKeyID))
+
+	readerKeyPath := filepath.Join(t.TempDir(), "reader")
+	require.Nil(t, os.WriteFile(readerKeyPath, targetsKeyBytes, 0o600))
+
+	outputDir := t.TempDir()
+	err = r.DecryptReference(testCtx, "refs/heads/embargo/fix", readerKeyPath, outputDir)
+	assert.Nil(t, err)
+
+	contents, err := os.ReadFile(filepath.Join(outputDir, "src", "fix.go"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("embargoed fix"), contents)
+
+	t.Run("not a reader", func(t *testing.T) {
+		otherKeyPath := filepath.Join(t.TempDir(), "other")
+		require.Nil(t, os.WriteFile(otherKeyPath, rootKeyBytes, 0o600))
+
+		err := r.DecryptReference(testCtx, "refs/heads/embargo/fix", otherKeyPath, t.TempDir())
+		assert.ErrorIs(t, err, encryption.ErrNotARecipient)
+	})
+
+	t.Run("path outside output directory", func(t *testing.T) {
+		// A reader with the data key can craft an encrypted tree whose
+		// paths escape the output directory
+		privateKey, keyID, err := encryption.LoadPrivateKey(readerKeyPath)
+		require.Nil(t, err)
+		dataKey, err := manifest.GetDataKey(privateKey, keyID)
+		require.Nil(t, err)
+
+		ciphertext, err := encryption.Seal(dataKey, []byte("escaped"), []byte(gitinterface.ModeRegularFile+" ../escaped"))
+		require.Nil(t, err)
+		escapedBlobID, err := r.r.WriteBlob(ciphertext)
+		require.Nil(t, err)
+
+		escapedManifest := *manifest
+		escapedManifest.Files = map[string]string{"../escaped": gitinterface.ModeRegularFile}
+		manifestBytes, err := json.Marshal(&escapedManifest)
+		require.Nil(t, err)
+		manifestBlobID, err := r.r.WriteBlob(manifestBytes)
+		require.Nil(t, err)
+		escapedTreeID, err := gitinterface.NewTreeBuilder(r.r).WriteTreeFromEntries([]gitinterface.TreeEntry{
+			gitinterface.NewEntryBlob(encryption.ManifestFileName, manifestBlobID),
+			gitinterface.NewEntryBlob("../escaped", escapedBlobID),
+		})
+		require.Nil(t, err)
+		_, err = r.r.Commit(escapedTreeID, "refs/heads/embargo/escaped", "Escape output directory\n", false)
+		require.Nil(t, err)
+
+		outputDir := filepath.Join(t.TempDir(), "output")
+		err = r.DecryptReference(testCtx, "refs/heads/embargo/escaped", readerKeyPath, outputDir)
+		assert.ErrorIs(t, err, ErrPathOutsideOutputDir)
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(outputDir), "escaped"))
+	})
+
+	t.Run("file modes", func(t *testing.T) {
+		scriptID, err := r.r.WriteBlob([]byte("#!/bin/sh\n"))
+		require.Nil(t, err)
+		linkID, err := r.r.WriteBlob([]byte("build.sh"))
+		require.Nil(t, err)
+		submoduleID, err := gitinterface.NewHash("0123456789abcdef0123456789abcdef01234567")
+		require.Nil(t, err)
+
+		// The tree builder only writes regular files
+		treeInput := fmt.Sprintf("%s blob %s\tbuild.sh\n%s blob %s\tbuild.link\n%s commit %s\tvendor\n",
+			gitinterface.ModeExecutableFile, scriptID.String(),
+			gitinterface.ModeSymbolicLink, linkID.String(),
+			gitinterface.ModeGitlink, submoduleID.String())
+		cmd := exec.Command("git", "--git-dir", r.r.GetGitDir(), "mktree")
+		cmd.Stdin = strings.NewReader(treeInput)
+		stdOut, err := cmd.Output()
+		require.Nil(t, err)
+		treeID, err := gitinterface.NewHash(strings.TrimSpace(string(stdOut)))
+		require.Nil(t, err)
+		_, err = r.r.Commit(treeID, "refs/heads/build", "Add build script\n", false)
+		require.Nil(t, err)
+
+		_, err = r.EncryptReference(testCtx, "refs/heads/build", "refs/heads/embargo/build", false)
+		require.Nil(t, err)
+
+		outputDir := t.TempDir()
+		err = r.DecryptReference(testCtx, "refs/heads/embargo/build", readerKeyPath, outputDir)
+		assert.Nil(t, err)
+
+		info, err := os.Lstat(filepath.Join(outputDir, "build.sh"))
+		require.Nil(t, err)
+		assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+		target, err := os.Readlink(filepath.Join(outputDir, "build.link"))
+		require.Nil(t, err)
+		assert.Equal(t, "build.sh", target)
+
+		assert.DirExists(t, filepath.Join(outputDir, "vendor"))
+	})
+
+	t.Run("symbolic link outside output directory", func(t *testing.T) {
+		privateKey, keyID, err := encryption.LoadPrivateKey(readerKeyPath)
+		require.Nil(t, err)
+		dataKey, err := manifest.GetDataKey(privateKey, keyID)
+		require.Nil(t, err)
+
+		for _, target := range []string{"/etc", "../escaped", "src/../../escaped", "src/.."} {
+			ciphertext, err := encryption.Seal(dataKey, []byte(target), []byte(gitinterface.ModeSymbolicLink+" link"))
+			require.Nil(t, err)
+			linkBlobID, err := r.r.WriteBlob(ciphertext)
+			require.Nil(t, err)
+
+			linkManifest := *manifest
+			linkManifest.Files = map[string]string{"link": gitinterface.ModeSymbolicLink}
+			manifestBytes, err := json.Marshal(&linkManifest)
+			require.Nil(t, err)
+			manifestBlobID, err := r.r.WriteBlob(manifestBytes)
+			require.Nil(t, err)
+			linkTreeID, err := gitinterface.NewTreeBuilder(r.r).WriteTreeFromEntries([]gitinterface.TreeEntry{
+				gitinterface.NewEntryBlob(encryption.ManifestFileName, manifestBlobID),
+				gitinterface.NewEntryBlob("link", linkBlobID),
+			})
+			require.Nil(t, err)
+			_, err = r.r.Commit(linkTreeID, "refs/heads/embargo/link", "Link outside output directory\n", false)
+			require.Nil(t, err)
+
+			outputDir := t.TempDir()
+			err = r.DecryptReference(testCtx, "refs/heads/embargo/link", readerKeyPath, outputDir)
+			assert.ErrorIs(t, err, ErrSymlinkOutsideOutputDir, target)
+			assert.NoFileExists(t, filepath.Join(outputDir, "link"))
+		}
+	})
+
+	t.Run("output directory is not empty", func(t *testing.T) {
+		outputDir := t.TempDir()
+		require.Nil(t, os.Symlink(t.TempDir(), filepath.Join(outputDir, "src")))
+
+		err := r.DecryptReference(testCtx, "refs/heads/embargo/fix", readerKeyPath, outputDir)
+		assert.ErrorIs(t, err, ErrOutputDirNotEmpty)
+	})
+
+	t.Run("reference is not read restricted", func(t *testing.T) {
+		_, err := r.EncryptReference(testCtx, "refs/heads/fix", "refs/heads/public", false)
+		assert.ErrorIs(t, err, policy.ErrReferenceNotReadRestricted)
+	})
+}
+
+func TestIsLocalSymlinkTarget(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		target   string
+		expected bool
+	}{
+		"file in same directory":          {path: "build.link", target: "build.sh", expected: true},
+		"file in parent directory":        {path: "src/build.link", target: "../build.sh", expected: true},
+		"directory":                       {path: "link", target: "src/", expected: true},
+		"output directory":                {path: "src/link", target: "..", expected: true},
+		"empty target":                    {path: "link", target: "", expected: false},
+		"absolute target":                 {path: "link", target: "/etc/passwd", expected: false},
+		"target outside output directory": {path: "src/link", target: "../../escaped", expected: false},
+		"parent after another element":    {path: "link", target: "src/..", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isLocalSymlinkTarget(test.path, test.target))
+		})
+	}
+}
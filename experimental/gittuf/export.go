@@ -0,0 +1,345 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/codeowners"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+)
+
+const (
+	gitNamespacePrefix  = "git:"
+	fileNamespacePrefix = "file:"
+	branchRefPrefix     = "refs/heads/"
+	tagRefPrefix        = "refs/tags/"
+)
+
+// ExportPolicyAsCodeOwners generates a CODEOWNERS file from the file rules in
+// the policy. As the first matching gittuf rule takes precedence while the
+// last matching CODEOWNERS entry does, rules are written in reverse order.
+// Principals are mapped to owners using the inverse of the mapping used by
+// ImportCodeOwners. Rule thresholds, exclusions, and principals that cannot be
+// mapped to owners have no CODEOWNERS equivalent and are recorded as comments.
+func (r *Repository) ExportPolicyAsCodeOwners(ctx context.Context, targetRef, identityProvider string) ([]byte, error) {
+	rules, err := r.ListRules(ctx, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	principals, err := r.loadAllPrincipals(ctx, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := &strings.Builder{}
+	fmt.Fprintln(contents, "# Generated from the gittuf policy using 'gittuf policy export'.")
+
+	for _, rule := range slices.Backward(rules) {
+		patterns := []string{}
+		for _, rulePattern := range rule.Delegation.GetProtectedNamespaces() {
+			if pattern, isFilePattern := codeowners.PatternFromRulePattern(rulePattern); isFilePattern {
+				patterns = append(patterns, pattern)
+			}
+		}
+		if len(patterns) == 0 {
+			continue
+		}
+
+		owners := []string{}
+		unmappedPrincipalIDs := []string{}
+		principalIDs := rule.Delegation.GetPrincipalIDs().Contents()
+		slices.Sort(principalIDs)
+		for _, principalID := range principalIDs {
+			owner, found := findCodeOwnerForPrincipal(principals[principalID], identityProvider)
+			if !found {
+				unmappedPrincipalIDs = append(unmappedPrincipalIDs, principalID)
+				continue
+			}
+			owners = append(owners, owner)
+		}
+
+		fmt.Fprintf(contents, "\n# Rule '%s' (threshold %d)\n", rule.Delegation.ID(), rule.Delegation.GetThreshold())
+		if excludedNamespaces := rule.Delegation.GetExcludedNamespaces(); len(excludedNamespaces) != 0 {
+			fmt.Fprintf(contents, "# Excludes: %s\n", strings.Join(excludedNamespaces, ", "))
+		}
+		if len(unmappedPrincipalIDs) != 0 {
+			fmt.Fprintf(contents, "# Principals without owner mapping: %s\n", strings.Join(unmappedPrincipalIDs, ", "))
+		}
+
+		for _, pattern := range patterns {
+			if len(owners) == 0 {
+				// An entry without owners leaves the paths unowned, which is
+				// not equivalent to the rule
+				fmt.Fprintf(contents, "# %s\n", pattern)
+				continue
+			}
+			fmt.Fprintf(contents, "%s %s\n", pattern, strings.Join(owners, " "))
+		}
+	}
+
+	return []byte(contents.String()), nil
+}
+
+// gitHubRuleset is a repository ruleset as accepted by GitHub's rulesets API.
+type gitHubRuleset struct {
+	Name        string                  `json:"name"`
+	Target      string                  `json:"target"`
+	Enforcement string                  `json:"enforcement"`
+	Conditions  gitHubRulesetConditions `json:"conditions"`
+	Rules       []gitHubRulesetRule     `json:"rules"`
+}
+
+type gitHubRulesetConditions struct {
+	RefName gitHubRulesetRefName `json:"ref_name"`
+}
+
+type gitHubRulesetRefName struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+type gitHubRulesetRule struct {
+	Type       string `json:"type"`
+	Parameters any    `json:"parameters,omitempty"`
+}
+
+type gitHubPullRequestParameters struct {
+	DismissStaleReviewsOnPush      bool `json:"dismiss_stale_reviews_on_push"`
+	RequireCodeOwnerReview         bool `json:"require_code_owner_review"`
+	RequireLastPushApproval        bool `json:"require_last_push_approval"`
+	RequiredApprovingReviewCount   int  `json:"required_approving_review_count"`
+	RequiredReviewThreadResolution bool `json:"required_review_thread_resolution"`
+}
+
+// refProtections accumulates the settings for a single ref pattern.
+type refProtections struct {
+	excludedRefs           []string
+	requiredApprovals      int
+	blockForcePushes       bool
+	requireSignedCommits   bool
+	requireLinearHistory   bool
+	requireCodeOwnerReview bool
+}
+
+// ExportPolicyAsGitHubRuleset generates GitHub repository rulesets from the
+// rules and global rules in the policy, one for each protected branch or tag
+// pattern. A rule's threshold is mapped to the number of required approving
+// reviews, not counting the author of the change whose signature gittuf counts
+// towards the threshold unless the rule requires separation of duties. Global
+// rules that block force pushes or require signed commits or linear history
+// are mapped to the equivalent ruleset rules, and code owner review is
+// required if the policy has file rules. Global rules without a ruleset
+// equivalent, such as those that require sign-offs, restrict files, freeze
+// changes, or restrict reads, are skipped with a warning.
+func (r *Repository) ExportPolicyAsGitHubRuleset(ctx context.Context, targetRef string) ([]byte, error) {
+	rules, err := r.ListRules(ctx, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	globalRules, err := r.ListGlobalRules(ctx, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	protections := map[string]*refProtections{}
+	getProtections := func(namespace string) *refProtections {
+		ref, isGitNamespace := strings.CutPrefix(namespace, gitNamespacePrefix)
+		if !isGitNamespace {
+			return nil
+		}
+		if !strings.HasPrefix(ref, branchRefPrefix) && !strings.HasPrefix(ref, tagRefPrefix) {
+			slog.Debug(fmt.Sprintf("Skipping '%s' as it is not a branch or tag...", ref))
+			return nil
+		}
+
+		if _, has := protections[ref]; !has {
+			protections[ref] = &refProtections{}
+		}
+		return protections[ref]
+	}
+
+	hasFileRules := false
+	for _, rule := range rules {
+		requiredApprovals := rule.Delegation.GetThreshold() - 1
+		if rule.Delegation.RequiresSeparationOfDuties() {
+			requiredApprovals = rule.Delegation.GetThreshold()
+		}
+
+		for _, namespace := range rule.Delegation.GetProtectedNamespaces() {
+			if _, isFilePattern := codeowners.PatternFromRulePattern(namespace); isFilePattern {
+				hasFileRules = true
+				continue
+			}
+
+			ref := getProtections(namespace)
+			if ref == nil {
+				continue
+			}
+			ref.requiredApprovals = max(ref.requiredApprovals, requiredApprovals)
+			for _, excludedNamespace := range rule.Delegation.GetExcludedNamespaces() {
+				if excludedRef, isGitNamespace := strings.CutPrefix(excludedNamespace, gitNamespacePrefix); isGitNamespace && !slices.Contains(ref.excludedRefs, excludedRef) {
+					ref.excludedRefs = append(ref.excludedRefs, excludedRef)
+				}
+			}
+		}
+	}
+
+	for _, globalRule := range globalRules {
+		switch globalRule := globalRule.(type) {
+		case tuf.GlobalRuleThreshold:
+			for _, namespace := range globalRule.GetProtectedNamespaces() {
+				if ref := getProtections(namespace); ref != nil {
+					ref.requiredApprovals = max(ref.requiredApprovals, globalRule.GetThreshold()-1)
+				}
+			}
+		case tuf.GlobalRuleRequireSignedCommits:
+			for _, namespace := range globalRule.GetProtectedNamespaces() {
+				if ref := getProtections(namespace); ref != nil {
+					ref.requireSignedCommits = true
+				}
+			}
+		case tuf.GlobalRuleRequireLinearHistory:
+			for _, namespace := range globalRule.GetProtectedNamespaces() {
+				if ref := getProtections(namespace); ref != nil {
+					ref.requireLinearHistory = true
+				}
+			}
+		case tuf.GlobalRuleRequireSignOff:
+			slog.Warn(fmt.Sprintf("Skipping global rule '%s' as rulesets cannot require sign-offs", globalRule.GetName()))
+		case tuf.GlobalRuleRestrictFiles:
+			// File path and size restrictions are only available in push
+			// rulesets, which apply to the whole repository rather than to
+			// the rule's namespaces
+			slog.Warn(fmt.Sprintf("Skipping global rule '%s' as rulesets cannot restrict files for specific references", globalRule.GetName()))
+		case tuf.GlobalRuleFreezeWindow:
+			slog.Warn(fmt.Sprintf("Skipping global rule '%s' as rulesets cannot freeze changes for a window of time", globalRule.GetName()))
+		case tuf.GlobalRuleRestrictRead:
+			slog.Warn(fmt.Sprintf("Skipping global rule '%s' as rulesets cannot restrict reads", globalRule.GetName()))
+		case tuf.GlobalRuleBlockForcePushes:
+			for _, namespace := range globalRule.GetProtectedNamespaces() {
+				if ref := getProtections(namespace); ref != nil {
+					ref.blockForcePushes = true
+				}
+			}
+		default:
+			slog.Warn(fmt.Sprintf("Skipping global rule '%s' as it has no ruleset equivalent", globalRule.GetName()))
+		}
+	}
+
+	refs := make([]string, 0, len(protections))
+	for ref := range protections {
+		refs = append(refs, ref)
+	}
+	slices.Sort(refs)
+
+	rulesets := []*gitHubRuleset{}
+	for _, ref := range refs {
+		protection := protections[ref]
+
+		target := "branch"
+		if strings.HasPrefix(ref, tagRefPrefix) {
+			target = "tag"
+		}
+
+		excludedRefs := protection.excludedRefs
+		if excludedRefs == nil {
+			excludedRefs = []string{}
+		}
+
+		ruleset := &gitHubRuleset{
+			Name:        fmt.Sprintf("gittuf: %s", ref),
+			Target:      target,
+			Enforcement: "active",
+			Conditions: gitHubRulesetConditions{
+				RefName: gitHubRulesetRefName{
+					Include: []string{ref},
+					Exclude: excludedRefs,
+				},
+			},
+			Rules: []gitHubRulesetRule{},
+		}
+
+		if protection.blockForcePushes {
+			ruleset.Rules = append(ruleset.Rules, gitHubRulesetRule{Type: "non_fast_forward"})
+		}
+		if protection.requireSignedCommits {
+			ruleset.Rules = append(ruleset.Rules, gitHubRulesetRule{Type: "required_signatures"})
+		}
+		if protection.requireLinearHistory {
+			ruleset.Rules = append(ruleset.Rules, gitHubRulesetRule{Type: "required_linear_history"})
+		}
+
+		requireCodeOwnerReview := hasFileRules && target == "branch"
+		if protection.requiredApprovals > 0 || requireCodeOwnerReview {
+			ruleset.Rules = append(ruleset.Rules, gitHubRulesetRule{
+				Type: "pull_request",
+				Parameters: &gitHubPullRequestParameters{
+					RequireCodeOwnerReview:       requireCodeOwnerReview,
+					RequiredApprovingReviewCount: protection.requiredApprovals,
+				},
+			})
+		}
+
+		rulesets = append(rulesets, ruleset)
+	}
+
+	return json.MarshalIndent(rulesets, "", "  ")
+}
+
+// loadAllPrincipals returns the principals declared across the root of trust
+// and all rule files in the policy.
+func (r *Repository) loadAllPrincipals(ctx context.Context, targetRef string) (map[string]tuf.Principal, error) {
+	if !strings.HasPrefix(targetRef, "refs/gittuf/") {
+		targetRef = "refs/gittuf/" + targetRef
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.GetAllPrincipals(), nil
+}
+
+// findCodeOwnerForPrincipal returns the CODEOWNERS owner that corresponds to
+// the principal. A person is mapped to their handle for identityProvider or
+// failing that, an email address declared as an associated identity, while a
+// team is mapped to its ID if it is of the form "org/team".
+func findCodeOwnerForPrincipal(principal tuf.Principal, identityProvider string) (string, bool) {
+	switch principal := principal.(type) {
+	case *tufv02.Person:
+		if handle, has := principal.AssociatedIdentities[identityProvider]; has {
+			return "@" + handle, true
+		}
+
+		providers := make([]string, 0, len(principal.AssociatedIdentities))
+		for provider := range principal.AssociatedIdentities {
+			providers = append(providers, provider)
+		}
+		slices.Sort(providers)
+
+		for _, provider := range providers {
+			if identity := principal.AssociatedIdentities[provider]; strings.Contains(identity, "@") {
+				return identity, true
+			}
+		}
+	case *tufv02.Team:
+		if strings.Count(principal.TeamID, "/") == 1 {
+			return "@" + principal.TeamID, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,170 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+	"time"
+
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gpgKeyID(t *testing.T) string {
+	t.Helper()
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	require.Nil(t, err)
+	return gpgKey.KeyID
+}
+
+func createTestRepositoryForExport(t *testing.T) *Repository {
+	t.Helper()
+
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+	key := tufv02.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH))
+	person := &tufv02.Person{
+		PersonID:             "jane.doe",
+		PublicKeys:           map[string]*tufv02.Key{key.KeyID: key},
+		AssociatedIdentities: map[string]string{"github": "jane-doe"},
+		Custom:               map[string]string{},
+	}
+	err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{person}, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	err = r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-docs", []string{person.PersonID}, []string{"file:docs/*", "!file:docs/drafts/*"}, 1, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	err = r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-src", []string{person.PersonID, gpgKeyID(t)}, []string{"file:*/src/*"}, 1, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	err = r.AddGlobalRuleThreshold(testCtx, rootSigner, "require-approval", []string{"git:refs/heads/main"}, 3, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	err = r.AddGlobalRuleBlockForcePushes(testCtx, rootSigner, "block-force-pushes", []string{"git:refs/heads/main", "git:refs/tags/*"}, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	err = r.AddGlobalRuleRequireLinearHistory(testCtx, rootSigner, "linear-history", []string{"git:refs/heads/main"}, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	err = policy.Apply(testCtx, r.r, false)
+	require.Nil(t, err)
+
+	return r
+}
+
+func TestExportPolicyAsCodeOwners(t *testing.T) {
+	r := createTestRepositoryForExport(t)
+
+	codeOwners, err := r.ExportPolicyAsCodeOwners(testCtx, "policy", "github")
+	assert.Nil(t, err)
+
+	expectedCodeOwners := `# Generated from the gittuf policy using 'gittuf policy export'.
+
+# Rule 'protect-src' (threshold 1)
+# Principals without owner mapping: ` + gpgKeyID(t) + `
+src/ @jane-doe
+
+# Rule 'protect-docs' (threshold 1)
+# Excludes: file:docs/drafts/*
+/docs/ @jane-doe
+`
+	assert.Equal(t, expectedCodeOwners, string(codeOwners))
+}
+
+func TestExportPolicyAsGitHubRuleset(t *testing.T) {
+	r := createTestRepositoryForExport(t)
+
+	ruleset, err := r.ExportPolicyAsGitHubRuleset(testCtx, "policy")
+	assert.Nil(t, err)
+
+	expectedRuleset := `[
+  {
+    "name": "gittuf: refs/heads/main",
+    "target": "branch",
+    "enforcement": "active",
+    "conditions": {
+      "ref_name": {
+        "include": [
+          "refs/heads/main"
+        ],
+        "exclude": []
+      }
+    },
+    "rules": [
+      {
+        "type": "non_fast_forward"
+      },
+      {
+        "type": "required_linear_history"
+      },
+      {
+        "type": "pull_request",
+        "parameters": {
+          "dismiss_stale_reviews_on_push": false,
+          "require_code_owner_review": true,
+          "require_last_push_approval": false,
+          "required_approving_review_count": 2,
+          "required_review_thread_resolution": false
+        }
+      }
+    ]
+  },
+  {
+    "name": "gittuf: refs/tags/*",
+    "target": "tag",
+    "enforcement": "active",
+    "conditions": {
+      "ref_name": {
+        "include": [
+          "refs/tags/*"
+        ],
+        "exclude": []
+      }
+    },
+    "rules": [
+      {
+        "type": "non_fast_forward"
+      }
+    ]
+  }
+]`
+	assert.Equal(t, expectedRuleset, string(ruleset))
+
+	t.Run("global rules without ruleset equivalent", func(t *testing.T) {
+		rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+		releasePatterns := []string{"git:refs/heads/release/*"}
+
+		err := r.AddGlobalRuleRequireSignOff(testCtx, rootSigner, "require-sign-off", releasePatterns, false, false, trustpolicyopts.WithRSLEntry())
+		require.Nil(t, err)
+
+		err = r.AddGlobalRuleRestrictFiles(testCtx, rootSigner, "restrict-files", releasePatterns, []string{"*.env"}, 1024, false, trustpolicyopts.WithRSLEntry())
+		require.Nil(t, err)
+
+		freezeStart := time.Date(2025, time.December, 20, 0, 0, 0, 0, time.UTC)
+		err = r.AddGlobalRuleFreezeWindow(testCtx, rootSigner, "freeze-window", releasePatterns, freezeStart, freezeStart.Add(14*24*time.Hour), 2, false, trustpolicyopts.WithRSLEntry())
+		require.Nil(t, err)
+
+		err = policy.Apply(testCtx, r.r, false)
+		require.Nil(t, err)
+
+		// None of these rules must be exported as rules that block force
+		// pushes for refs/heads/release/*
+		ruleset, err := r.ExportPolicyAsGitHubRuleset(testCtx, "policy")
+		assert.Nil(t, err)
+		assert.Equal(t, expectedRuleset, string(ruleset))
+	})
+}
@@ -142,30 +142,31 @@ func LoadPublicKeyFromGitConfig(repo *Repository) (tuf.Principal, error) {
 // must be for a GPG key (in which case the `key` is the GPG key ID), an SSH key
 // (in which case the `key` is a path to the private key) or for signing with
 // Sigstore (where `key` has a prefix `fulcio:`). If no key ID is specified,
-// this function calls LoadSignerFromGitConfig.
+// this function calls LoadSignerFromGitConfig. The repository may be nil when
+// signing without access to one, in which case a key must be specified and the
+// signer is created without options from the Git configuration.
 func LoadSigner(repo *Repository, key string) (sslibdsse.SignerVerifier, error) {
 	if key == "" {
+		if repo == nil {
+			return nil, ErrSigningKeyNotSpecified
+		}
 		return LoadSignerFromGitConfig(repo)
 	}
 
-	switch {
-	case strings.HasPrefix(key, GPGKeyPrefix):
-		keyID := strings.TrimPrefix(key, GPGKeyPrefix)
-
-		gitRepo := repo.GetGitRepository()
-		config, err := gitRepo.GetGitConfig()
+	config := map[string]string{}
+	if repo != nil && (strings.HasPrefix(key, GPGKeyPrefix) || strings.HasPrefix(key, FulcioPrefix)) {
+		var err error
+		config, err = repo.GetGitRepository().GetGitConfig()
 		if err != nil {
 			return nil, err
 		}
+	}
 
+	switch {
+	case strings.HasPrefix(key, GPGKeyPrefix):
+		keyID := strings.TrimPrefix(key, GPGKeyPrefix)
 		return gpg.NewSignerFromKeyID(keyID, getGPGOptions(config)...)
 	case strings.HasPrefix(key, FulcioPrefix):
-		gitRepo := repo.GetGitRepository()
-		config, err := gitRepo.GetGitConfig()
-		if err != nil {
-			return nil, err
-		}
-
 		return sigstore.NewSigner(getSigstoreOptions(config)...), nil
 	default:
 		return ssh.NewSignerFromFile(key)
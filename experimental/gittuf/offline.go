@@ -0,0 +1,183 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
+)
+
+var (
+	ErrSignedMetadataNotStaged  = errors.New("signed metadata does not match any staged metadata")
+	ErrNoNewSignatures          = errors.New("no signatures that are not already in the staged metadata")
+	ErrInvalidMetadataSignature = errors.New("signature is invalid or was not made using a key trusted to sign the metadata")
+)
+
+// ExportUnsignedMetadata returns the staged envelope of the root of trust, if
+// roleName is policy.RootRoleName, or of the specified rule file otherwise,
+// with its signatures removed. The envelope can be signed on a machine without
+// the repository using SignMetadataOffline, and the signatures added to the
+// staged policy using ImportMetadataSignatures.
+func (r *Repository) ExportUnsignedMetadata(ctx context.Context, roleName string) ([]byte, error) {
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, err
+	}
+
+	var env *sslibdsse.Envelope
+	switch {
+	case roleName == policy.RootRoleName:
+		env = state.Metadata.RootEnvelope
+	case !state.HasTargetsRole(roleName):
+		return nil, policy.ErrMetadataNotFound
+	case roleName == policy.TargetsRoleName:
+		env = state.Metadata.TargetsEnvelope
+	default:
+		env = state.Metadata.DelegationEnvelopes[roleName]
+	}
+
+	return json.Marshal(&sslibdsse.Envelope{
+		PayloadType: env.PayloadType,
+		Payload:     env.Payload,
+		Signatures:  []sslibdsse.Signature{},
+	})
+}
+
+// SignMetadataOffline adds a signature from the signer to an envelope exported
+// using ExportUnsignedMetadata, returning the updated envelope. It does not
+// require access to the repository.
+func SignMetadataOffline(ctx context.Context, signer sslibdsse.SignerVerifier, envelopeBytes []byte) ([]byte, error) {
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envelopeBytes, env); err != nil {
+		return nil, fmt.Errorf("unable to parse envelope: %w", err)
+	}
+
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing envelope using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(env)
+}
+
+// ImportMetadataSignatures adds the signatures in an envelope signed using
+// SignMetadataOffline to the staged metadata with the same payload, which may
+// be the root of trust or any rule file. Each signature must be valid and made
+// using a key trusted to sign the metadata, otherwise no signatures are added.
+// Valid signatures already in the staged metadata are never replaced. Note that
+// the metadata itself is not modified, so its version remains the same.
+func (r *Repository) ImportMetadataSignatures(ctx context.Context, envelopeBytes []byte, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	signedEnv := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envelopeBytes, signedEnv); err != nil {
+		return fmt.Errorf("unable to parse envelope: %w", err)
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	isSignedMetadata := func(env *sslibdsse.Envelope) bool {
+		return env != nil && env.PayloadType == signedEnv.PayloadType && env.Payload == signedEnv.Payload
+	}
+
+	var (
+		env         *sslibdsse.Envelope
+		roleName    string
+		description string
+	)
+	switch {
+	case isSignedMetadata(state.Metadata.RootEnvelope):
+		env = state.Metadata.RootEnvelope
+		roleName = policy.RootRoleName
+		description = "root metadata"
+	case isSignedMetadata(state.Metadata.TargetsEnvelope):
+		env = state.Metadata.TargetsEnvelope
+		roleName = policy.TargetsRoleName
+		description = fmt.Sprintf("policy '%s'", policy.TargetsRoleName)
+	default:
+		for delegationName, delegationEnv := range state.Metadata.DelegationEnvelopes {
+			if isSignedMetadata(delegationEnv) {
+				env = delegationEnv
+				roleName = delegationName
+				description = fmt.Sprintf("policy '%s'", delegationName)
+				break
+			}
+		}
+	}
+	if env == nil {
+		return ErrSignedMetadataNotStaged
+	}
+
+	keyIDs := []string{}
+	for _, signature := range signedEnv.Signatures {
+		slog.Debug(fmt.Sprintf("Verifying signature from key '%s'...", signature.KeyID))
+		if err := state.VerifyMetadataSignature(ctx, roleName, signature); err != nil {
+			return fmt.Errorf("%w: signature from key '%s' for %s: %w", ErrInvalidMetadataSignature, signature.KeyID, description, err)
+		}
+
+		if slices.Contains(keyIDs, signature.KeyID) {
+			continue
+		}
+
+		hasValidSignature := false
+		for _, existing := range env.Signatures {
+			if existing.KeyID == signature.KeyID && state.VerifyMetadataSignature(ctx, roleName, existing) == nil {
+				hasValidSignature = true
+				break
+			}
+		}
+		if hasValidSignature {
+			slog.Debug(fmt.Sprintf("Metadata already has a valid signature from key '%s', skipping...", signature.KeyID))
+			continue
+		}
+
+		// Replace any invalid signature from the same key
+		env.Signatures = slices.DeleteFunc(env.Signatures, func(existing sslibdsse.Signature) bool {
+			return existing.KeyID == signature.KeyID
+		})
+		env.Signatures = append(env.Signatures, signature)
+		keyIDs = append(keyIDs, signature.KeyID)
+	}
+	if len(keyIDs) == 0 {
+		return ErrNoNewSignatures
+	}
+
+	commitMessage := fmt.Sprintf("Add signature from key '%s' to %s", strings.Join(keyIDs, "', '"), description)
+
+	slog.Debug("Committing policy...")
+	return state.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit)
+}
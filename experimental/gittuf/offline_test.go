@@ -0,0 +1,153 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+	// Add targets key as a root key
+	secondKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targetsPubKeyBytes))
+	if err := r.AddRootKey(testCtx, rootSigner, secondKey, false); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("root", func(t *testing.T) {
+		unsignedEnvelope, err := r.ExportUnsignedMetadata(testCtx, policy.RootRoleName)
+		require.Nil(t, err)
+
+		env := &sslibdsse.Envelope{}
+		if err := json.Unmarshal(unsignedEnvelope, env); err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, env.Signatures)
+
+		signedEnvelope, err := SignMetadataOffline(testCtx, targetsSigner, unsignedEnvelope)
+		require.Nil(t, err)
+
+		err = r.ImportMetadataSignatures(testCtx, signedEnvelope, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, len(state.Metadata.RootEnvelope.Signatures))
+
+		err = r.ImportMetadataSignatures(testCtx, signedEnvelope, false)
+		assert.ErrorIs(t, err, ErrNoNewSignatures)
+	})
+
+	t.Run("rule file", func(t *testing.T) {
+		// Add root key as a key for the primary rule file
+		rootKey := tufv01.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+		if err := r.AddTopLevelTargetsKey(testCtx, rootSigner, rootKey, false); err != nil {
+			t.Fatal(err)
+		}
+
+		unsignedEnvelope, err := r.ExportUnsignedMetadata(testCtx, policy.TargetsRoleName)
+		require.Nil(t, err)
+
+		signedEnvelope, err := SignMetadataOffline(testCtx, rootSigner, unsignedEnvelope)
+		require.Nil(t, err)
+
+		err = r.ImportMetadataSignatures(testCtx, signedEnvelope, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, len(state.Metadata.TargetsEnvelope.Signatures))
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		unsignedEnvelope, err := r.ExportUnsignedMetadata(testCtx, policy.RootRoleName)
+		require.Nil(t, err)
+
+		signedEnvelope, err := SignMetadataOffline(testCtx, targetsSigner, unsignedEnvelope)
+		require.Nil(t, err)
+
+		err = r.ImportMetadataSignatures(testCtx, signedEnvelope, false)
+		require.Nil(t, err)
+
+		env := &sslibdsse.Envelope{}
+		if err := json.Unmarshal(signedEnvelope, env); err != nil {
+			t.Fatal(err)
+		}
+		// Use the signature over other metadata
+		otherEnvelope, err := SignMetadataOffline(testCtx, targetsSigner, []byte(`{"payloadType":"application/vnd.gittuf+json","payload":"e30=","signatures":[]}`))
+		require.Nil(t, err)
+		otherEnv := &sslibdsse.Envelope{}
+		if err := json.Unmarshal(otherEnvelope, otherEnv); err != nil {
+			t.Fatal(err)
+		}
+		env.Signatures[0].Sig = otherEnv.Signatures[0].Sig
+		signedEnvelope, err = json.Marshal(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = r.ImportMetadataSignatures(testCtx, signedEnvelope, false)
+		assert.ErrorIs(t, err, ErrInvalidMetadataSignature)
+
+		// The existing valid signature from the key is retained
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, len(state.Metadata.RootEnvelope.Signatures))
+		for _, signature := range state.Metadata.RootEnvelope.Signatures {
+			assert.Nil(t, state.VerifyMetadataSignature(testCtx, policy.RootRoleName, signature))
+		}
+	})
+
+	t.Run("untrusted key", func(t *testing.T) {
+		untrustedSigner := setupSSHKeysForSigning(t, artifacts.SSHED25519Private, artifacts.SSHED25519PublicSSH)
+
+		unsignedEnvelope, err := r.ExportUnsignedMetadata(testCtx, policy.RootRoleName)
+		require.Nil(t, err)
+
+		signedEnvelope, err := SignMetadataOffline(testCtx, untrustedSigner, unsignedEnvelope)
+		require.Nil(t, err)
+
+		err = r.ImportMetadataSignatures(testCtx, signedEnvelope, false)
+		assert.ErrorIs(t, err, ErrInvalidMetadataSignature)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, len(state.Metadata.RootEnvelope.Signatures))
+	})
+
+	t.Run("metadata not staged", func(t *testing.T) {
+		signedEnvelope, err := SignMetadataOffline(testCtx, rootSigner, []byte(`{"payloadType":"application/vnd.gittuf+json","payload":"e30=","signatures":[]}`))
+		require.Nil(t, err)
+
+		err = r.ImportMetadataSignatures(testCtx, signedEnvelope, false)
+		assert.ErrorIs(t, err, ErrSignedMetadataNotStaged)
+	})
+
+	t.Run("unknown rule file", func(t *testing.T) {
+		_, err := r.ExportUnsignedMetadata(testCtx, "does-not-exist")
+		assert.ErrorIs(t, err, policy.ErrMetadataNotFound)
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package applypolicy
+
+type Options struct {
+	SecretsKeyPaths []string
+}
+
+type Option func(o *Options)
+
+// WithSecretsKey sets the path to an SSH private key used to decrypt secrets
+// that must be re-encrypted for the policy being applied. It can be set more
+// than once for secrets with different readers.
+func WithSecretsKey(keyPath string) Option {
+	return func(o *Options) {
+		o.SecretsKeyPaths = append(o.SecretsKeyPaths, keyPath)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package applypolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPolicyOptions(t *testing.T) {
+	options := &Options{}
+
+	option := WithSecretsKey("reader")
+	option(options)
+	option = WithSecretsKey("other-reader")
+	option(options)
+
+	assert.Equal(t, []string{"reader", "other-reader"}, options.SecretsKeyPaths)
+}
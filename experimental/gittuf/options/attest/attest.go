@@ -5,6 +5,7 @@ package attest
 
 type Options struct {
 	CreateRSLEntry bool
+	Hat            string
 }
 
 type Option func(o *Options)
@@ -14,3 +15,12 @@ func WithRSLEntry() Option {
 		o.CreateRSLEntry = true
 	}
 }
+
+// WithHat sets the role, such as a team in the policy, that the signer acts in
+// for a reference authorization. The authorization then only counts towards
+// that role's threshold.
+func WithHat(hat string) Option {
+	return func(o *Options) {
+		o.Hat = hat
+	}
+}
@@ -17,3 +17,12 @@ func TestRSLOptions(t *testing.T) {
 
 	assert.True(t, options.CreateRSLEntry)
 }
+
+func TestWithHat(t *testing.T) {
+	options := &Options{}
+
+	option := WithHat("security")
+	option(options)
+
+	assert.Equal(t, "security", options.Hat)
+}
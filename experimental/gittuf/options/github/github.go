@@ -25,6 +25,7 @@ type Options struct {
 	GitHubBaseURL     string
 	CreateRSLEntry    bool
 	UseGitHubAPI      bool
+	Hat               string
 }
 
 var DefaultOptions = &Options{
@@ -56,6 +57,14 @@ func WithRSLEntry() Option {
 	}
 }
 
+// WithHat sets the role, such as a team in the policy, that the approver acts
+// in. The approval then only counts towards that role's threshold.
+func WithHat(hat string) Option {
+	return func(o *Options) {
+		o.Hat = hat
+	}
+}
+
 func WithUseGitHubAPI() Option {
 	return func(o *Options) {
 		o.UseGitHubAPI = true
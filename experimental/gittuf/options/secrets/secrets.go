@@ -0,0 +1,16 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+type Options struct {
+	CreateRSLEntry bool
+}
+
+type Option func(o *Options)
+
+func WithRSLEntry() Option {
+	return func(o *Options) {
+		o.CreateRSLEntry = true
+	}
+}
@@ -4,7 +4,10 @@
 package trustpolicy
 
 type Options struct {
-	CreateRSLEntry bool
+	CreateRSLEntry  bool
+	RuleDescription *string
+	RuleContact     *string
+	RuleLink        *string
 }
 
 type Option func(o *Options)
@@ -14,3 +17,25 @@ func WithRSLEntry() Option {
 		o.CreateRSLEntry = true
 	}
 }
+
+// WithRuleDescription sets the description of the rule being added or updated.
+func WithRuleDescription(description string) Option {
+	return func(o *Options) {
+		o.RuleDescription = &description
+	}
+}
+
+// WithRuleContact sets who to contact about the rule being added or updated.
+func WithRuleContact(contact string) Option {
+	return func(o *Options) {
+		o.RuleContact = &contact
+	}
+}
+
+// WithRuleLink sets a link to further context, such as a ticket, for the rule
+// being added or updated.
+func WithRuleLink(link string) Option {
+	return func(o *Options) {
+		o.RuleLink = &link
+	}
+}
@@ -18,3 +18,15 @@ func TestWithRSLEntry(t *testing.T) {
 
 	assert.True(t, options.CreateRSLEntry)
 }
+
+func TestWithRuleDetails(t *testing.T) {
+	options := &Options{}
+
+	WithRuleDescription("description")(options)
+	WithRuleContact("contact")(options)
+	WithRuleLink("link")(options)
+
+	assert.Equal(t, "description", *options.RuleDescription)
+	assert.Equal(t, "contact", *options.RuleContact)
+	assert.Equal(t, "link", *options.RuleLink)
+}
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
+	applypolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/applypolicy"
 	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
@@ -20,6 +22,7 @@ var (
 	ErrPushingPolicy     = errors.New("unable to push policy")
 	ErrPullingPolicy     = errors.New("unable to pull policy")
 	ErrNoRemoteSpecified = errors.New("no remote specified to push policy")
+	ErrExpiryNotInFuture = errors.New("metadata expiry must be in the future")
 )
 
 // PushPolicy pushes the local gittuf policy to the specified remote. As this
@@ -61,7 +64,15 @@ func (r *Repository) HasPolicy() (bool, error) {
 	return true, nil
 }
 
-func (r *Repository) ApplyPolicy(ctx context.Context, remoteName string, localOnly, signRSLEntry bool) error {
+// ApplyPolicy validates and applies the staged policy, recording it in the
+// RSL. Secrets whose readers or their keys are changed by the staged policy,
+// such as those still encrypted for removed readers, are re-encrypted for their
+// remaining readers and committed before the policy is applied. This requires
+// the private key of one of each such secret's readers, specified using
+// applypolicyopts.WithSecretsKey. If such a secret cannot be re-encrypted or
+// committed, the policy is not applied. No key is needed if the staged policy
+// does not change the readers of any secret.
+func (r *Repository) ApplyPolicy(ctx context.Context, remoteName string, localOnly, signRSLEntry bool, opts ...applypolicyopts.Option) error {
 	if signRSLEntry {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -74,6 +85,11 @@ func (r *Repository) ApplyPolicy(ctx context.Context, remoteName string, localOn
 		return ErrNoRemoteSpecified
 	}
 
+	options := &applypolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
 	if !localOnly {
 		_, err := r.Sync(ctx, remoteName, false, signRSLEntry)
 		if err != nil {
@@ -81,6 +97,26 @@ func (r *Repository) ApplyPolicy(ctx context.Context, remoteName string, localOn
 		}
 	}
 
+	// Secrets are re-encrypted and committed before the policy is applied,
+	// so that the policy is never in effect while secrets are still
+	// encrypted for readers it removed. If applying the policy fails
+	// afterwards, the secrets are only readable by the staged readers, and
+	// applying again does not need to re-encrypt them.
+	slog.Debug("Re-encrypting secrets for staged policy...")
+	allSecrets, reencryptedNames, err := r.reencryptSecretsForStagedPolicy(ctx, options.SecretsKeyPaths)
+	if err != nil {
+		return err
+	}
+
+	if len(reencryptedNames) > 0 {
+		commitMessage := fmt.Sprintf("Re-encrypt secrets '%s' for staged policy", strings.Join(reencryptedNames, "', '"))
+
+		slog.Debug("Committing secrets...")
+		if err := allSecrets.Commit(r.r, commitMessage, true, signRSLEntry); err != nil {
+			return err
+		}
+	}
+
 	if err := policy.Apply(ctx, r.r, signRSLEntry); err != nil {
 		return err
 	}
@@ -93,7 +129,7 @@ func (r *Repository) ApplyPolicy(ctx context.Context, remoteName string, localOn
 		return nil
 	}
 
-	_, err := r.Sync(ctx, remoteName, false, signRSLEntry)
+	_, err = r.Sync(ctx, remoteName, false, signRSLEntry)
 	return err
 }
 
@@ -217,6 +253,60 @@ func (r *Repository) ListGlobalRules(ctx context.Context, targetRef string) ([]t
 	return rootMetadata.GetGlobalRules(), nil
 }
 
+// GetMetadataExpiries returns the expiry time of the root of trust and every
+// rule file in the specified policy ref, keyed by role name.
+func (r *Repository) GetMetadataExpiries(ctx context.Context, targetRef string) (map[string]time.Time, error) {
+	if !strings.HasPrefix(targetRef, "refs/gittuf/") {
+		targetRef = "refs/gittuf/" + targetRef
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.GetMetadataExpiries()
+}
+
+// IsExpiryEnforced indicates if the root of trust in the specified policy ref
+// rejects expired metadata during verification.
+func (r *Repository) IsExpiryEnforced(ctx context.Context, targetRef string) (bool, error) {
+	if !strings.HasPrefix(targetRef, "refs/gittuf/") {
+		targetRef = "refs/gittuf/" + targetRef
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, targetRef)
+	if err != nil {
+		return false, err
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		return false, err
+	}
+
+	return rootMetadata.IsExpiryEnforced(), nil
+}
+
+// LintPolicy analyzes the rule files and global rules in the specified policy
+// ref for rules that can never apply or be met. See policy.State.Lint for the
+// checks performed.
+func (r *Repository) LintPolicy(ctx context.Context, targetRef string) ([]*policy.LintFinding, error) {
+	if !strings.HasPrefix(targetRef, "refs/gittuf/") {
+		targetRef = "refs/gittuf/" + targetRef
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, targetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Lint()
+}
+
 func (r *Repository) ListHooks(ctx context.Context, targetRef string) (map[tuf.HookStage][]tuf.Hook, error) {
 	if !strings.HasPrefix(targetRef, "refs/gittuf/") {
 		targetRef = "refs/gittuf/" + targetRef
@@ -13,6 +13,7 @@ import (
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
 	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
 	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/stretchr/testify/assert"
@@ -390,6 +391,49 @@ func TestListPrincipals(t *testing.T) {
 	})
 }
 
+func TestLintPolicy(t *testing.T) {
+	t.Run("no findings", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		findings, err := repo.LintPolicy(testCtx, policy.PolicyRef)
+		assert.Nil(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("unused principal", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+		targetsKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+		if err := repo.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{targetsKey}, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.StagePolicy(testCtx, "", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		findings, err := repo.LintPolicy(testCtx, policy.PolicyStagingRef)
+		assert.Nil(t, err)
+		assert.Equal(t, []*policy.LintFinding{
+			{
+				Check:    policy.LintCheckUnusedPrincipal,
+				RuleFile: policy.TargetsRoleName,
+				Name:     targetsKey.KeyID,
+				Message:  "principal is not referenced by any rule or team",
+			},
+		}, findings)
+	})
+
+	t.Run("policy does not exist", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo := &Repository{r: gitinterface.CreateTestGitRepository(t, tmpDir, false)}
+
+		_, err := repo.LintPolicy(testCtx, policy.PolicyRef)
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+	})
+}
+
 func TestStagePolicy(t *testing.T) {
 	remoteName := "origin"
 
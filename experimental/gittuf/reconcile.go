@@ -0,0 +1,269 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+// policyReconciliation holds the staged policy with the declaration applied
+// to its metadata in memory, along with the changes that were made.
+type policyReconciliation struct {
+	state           *policy.State
+	rootMetadata    tuf.RootMetadata
+	targetsMetadata tuf.TargetsMetadata
+	rootChanges     []*policy.Change
+	targetsChanges  []*policy.Change
+}
+
+// PlanPolicyReconciliation returns the changes ReconcilePolicy would make to
+// the staged policy for it to match the policy declaration, without modifying
+// the policy. No changes are returned if the staged policy already matches the
+// declaration.
+func (r *Repository) PlanPolicyReconciliation(ctx context.Context, declarationContents []byte) ([]*policy.Change, error) {
+	reconciliation, err := r.reconcilePolicy(ctx, declarationContents, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(reconciliation.rootChanges, reconciliation.targetsChanges), nil
+}
+
+// ReconcilePolicy updates the staged policy so that the primary rule file's
+// principals and rules, and the root of trust's global rules and hooks, match
+// the policy declaration (see policy.Declaration). Only the items that differ
+// are changed, and the changes are recorded in a single commit to the policy
+// staging ref. The changes made are returned. The signer must be trusted for
+// the root of trust if global rules or hooks are changed.
+func (r *Repository) ReconcilePolicy(ctx context.Context, signer sslibdsse.SignerVerifier, declarationContents []byte, signCommit bool, opts ...trustpolicyopts.Option) ([]*policy.Change, error) {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	reconciliation, err := r.reconcilePolicy(ctx, declarationContents, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reconciliation.rootChanges) == 0 && len(reconciliation.targetsChanges) == 0 {
+		slog.Debug("Staged policy matches declaration, nothing to do...")
+		return nil, nil
+	}
+
+	if len(reconciliation.rootChanges) != 0 {
+		for _, change := range reconciliation.rootChanges {
+			if change.Kind == policy.DiffKindHook && !dev.InDevMode() {
+				return nil, dev.ErrNotInDevMode
+			}
+		}
+
+		keyID, err := signer.KeyID()
+		if err != nil {
+			return nil, err
+		}
+
+		authorizedPrincipals, err := reconciliation.rootMetadata.GetRootPrincipals()
+		if err != nil {
+			return nil, err
+		}
+		if !isKeyAuthorized(authorizedPrincipals, keyID) {
+			return nil, ErrUnauthorizedKey
+		}
+
+		if err := signRootMetadata(ctx, reconciliation.state, signer, reconciliation.rootMetadata); err != nil {
+			return nil, err
+		}
+
+		for _, stage := range []tuf.HookStage{tuf.HookStagePreCommit, tuf.HookStagePrePush} {
+			hooks, err := reconciliation.rootMetadata.GetHooks(stage)
+			if err != nil && !errors.Is(err, tuf.ErrNoHooksDefined) {
+				return nil, err
+			}
+
+			reconciliation.state.Hooks[stage] = hooks
+		}
+	}
+
+	if len(reconciliation.targetsChanges) != 0 {
+		if err := signTargetsMetadata(ctx, reconciliation.state, signer, policy.TargetsRoleName, reconciliation.targetsMetadata); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("Committing policy...")
+	if err := reconciliation.state.Commit(r.r, "Reconcile policy with declaration", options.CreateRSLEntry, signCommit); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(reconciliation.rootChanges, reconciliation.targetsChanges), nil
+}
+
+// reconcilePolicy loads the staged policy and applies the declaration to its
+// metadata in memory. The declared hook files are written to the repository
+// if writeHooks is set, otherwise they are only hashed.
+func (r *Repository) reconcilePolicy(ctx context.Context, declarationContents []byte, writeHooks bool) (*policyReconciliation, error) {
+	slog.Debug("Parsing policy declaration...")
+	declaration, err := policy.ParseDeclaration(declarationContents)
+	if err != nil {
+		return nil, err
+	}
+
+	principals, err := loadDeclaredPrincipals(declaration)
+	if err != nil {
+		return nil, err
+	}
+
+	hookHashes := map[string]map[string]string{}
+	for _, hook := range declaration.Hooks {
+		hookBytes, err := os.ReadFile(hook.File)
+		if err != nil {
+			return nil, err
+		}
+
+		var blobID gitinterface.Hash
+		if writeHooks {
+			blobID, err = r.r.WriteBlob(hookBytes)
+		} else {
+			blobID, err = r.r.HashBlob(hookBytes)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		sha256Hash := sha256.Sum256(hookBytes)
+		hookHashes[hook.Name] = map[string]string{
+			gitinterface.GitBlobHashName: blobID.String(),
+			gitinterface.SHA256HashName:  hex.EncodeToString(sha256Hash[:]),
+		}
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, err
+	}
+
+	if !state.HasTargetsRole(policy.TargetsRoleName) {
+		return nil, policy.ErrMetadataNotFound
+	}
+
+	rootMetadata, err := state.GetRootMetadata(true)
+	if err != nil {
+		return nil, err
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Reconciling root of trust with declaration...")
+	rootChanges, err := policy.ReconcileRootMetadata(rootMetadata, declaration, hookHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Reconciling primary rule file with declaration...")
+	targetsChanges, err := policy.ReconcileTargetsMetadata(targetsMetadata, declaration, principals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyReconciliation{
+		state:           state,
+		rootMetadata:    rootMetadata,
+		targetsMetadata: targetsMetadata,
+		rootChanges:     rootChanges,
+		targetsChanges:  targetsChanges,
+	}, nil
+}
+
+// loadDeclaredPrincipals loads the keys, persons, and teams declared in the
+// policy declaration. As a key's principal ID is its key ID, teams, rules, and
+// hooks may refer to a declared key using the same reference as in the list of
+// keys, which is replaced with the key ID.
+func loadDeclaredPrincipals(declaration *policy.Declaration) ([]tuf.Principal, error) {
+	principals := []tuf.Principal{}
+
+	keyIDs := map[string]string{}
+	for _, keyRef := range declaration.Keys {
+		key, err := LoadPublicKey(keyRef)
+		if err != nil {
+			return nil, err
+		}
+		principals = append(principals, key)
+		keyIDs[keyRef] = key.ID()
+	}
+
+	resolveKeyRefs := func(principalIDs []string) {
+		for index, principalID := range principalIDs {
+			if keyID, isKeyRef := keyIDs[principalID]; isKeyRef {
+				principalIDs[index] = keyID
+			}
+		}
+	}
+	for _, team := range declaration.Teams {
+		resolveKeyRefs(team.Principals)
+	}
+	for _, rule := range declaration.Rules {
+		resolveKeyRefs(rule.Principals)
+	}
+	for _, hook := range declaration.Hooks {
+		resolveKeyRefs(hook.Principals)
+	}
+
+	for _, declaredPerson := range declaration.Persons {
+		person := &tufv02.Person{
+			PersonID:             declaredPerson.ID,
+			PublicKeys:           map[string]*tufv02.Key{},
+			AssociatedIdentities: declaredPerson.AssociatedIdentities,
+			Custom:               declaredPerson.Custom,
+		}
+
+		for _, keyRef := range declaredPerson.Keys {
+			key, err := LoadPublicKey(keyRef)
+			if err != nil {
+				return nil, err
+			}
+			person.PublicKeys[key.ID()] = key.(*tufv02.Key)
+		}
+
+		principals = append(principals, person)
+	}
+
+	for _, declaredTeam := range declaration.Teams {
+		principals = append(principals, &tufv02.Team{
+			TeamID:       declaredTeam.ID,
+			PrincipalIDs: set.NewSetFromItems(declaredTeam.Principals...),
+			Threshold:    declaredTeam.Threshold,
+		})
+	}
+
+	return principals, nil
+}
@@ -0,0 +1,99 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcilePolicy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	targetsKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+	targetsPubKeyPath := filepath.Join(t.TempDir(), "targets.pub")
+	if err := os.WriteFile(targetsPubKeyPath, targetsPubKeyBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKeyID := tufv01.NewKeyFromSSLibKey(gpgKeyR).KeyID
+
+	declaration := []byte(fmt.Sprintf(`
+keys:
+  - %[1]s
+rules:
+  - name: protect-main
+    principals: [%[1]s]
+    patterns: [git:refs/heads/main]
+`, targetsPubKeyPath))
+
+	expectedChanges := []*policy.Change{
+		{Kind: policy.DiffKindPrincipal, Action: policy.DiffActionRemoved, Scope: policy.TargetsRoleName, Name: gpgKeyID},
+		{Kind: policy.DiffKindPrincipal, Action: policy.DiffActionAdded, Scope: policy.TargetsRoleName, Name: targetsKey.KeyID},
+		{Kind: policy.DiffKindRule, Action: policy.DiffActionChanged, Scope: policy.TargetsRoleName, Name: "protect-main", Details: []string{fmt.Sprintf("principals: '%s' -> '%s'", gpgKeyID, targetsKey.KeyID)}},
+	}
+	if targetsKey.KeyID < gpgKeyID {
+		expectedChanges[0], expectedChanges[1] = expectedChanges[1], expectedChanges[0]
+	}
+
+	t.Run("plan", func(t *testing.T) {
+		changes, err := r.PlanPolicyReconciliation(testCtx, declaration)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedChanges, changes)
+
+		// The staged policy is not modified
+		changes, err = r.DiffPolicy(testCtx, "", "")
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+		changes, err := r.ReconcilePolicy(testCtx, targetsSigner, declaration, false)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedChanges, changes)
+
+		changes, err = r.PlanPolicyReconciliation(testCtx, declaration)
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+
+		changes, err = r.ReconcilePolicy(testCtx, targetsSigner, declaration, false)
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("global rules require root key", func(t *testing.T) {
+		declaration := []byte(string(declaration) + `
+globalRules:
+  - name: require-approval
+    type: threshold
+    patterns: [git:refs/heads/main]
+`)
+
+		_, err := r.ReconcilePolicy(testCtx, targetsSigner, declaration, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+		changes, err := r.ReconcilePolicy(testCtx, rootSigner, declaration, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []*policy.Change{{Kind: policy.DiffKindGlobalRule, Action: policy.DiffActionAdded, Scope: policy.DiffScopeRoot, Name: "require-approval"}}, changes)
+	})
+
+	t.Run("invalid declaration", func(t *testing.T) {
+		_, err := r.PlanPolicyReconciliation(testCtx, []byte("rule: []"))
+		assert.ErrorIs(t, err, policy.ErrInvalidDeclaration)
+	})
+}
@@ -10,13 +10,16 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf/options/root"
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/policy"
 	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier/common"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
@@ -159,6 +162,49 @@ func (r *Repository) SetRepositoryLocation(ctx context.Context, signer sslibdsse
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
+// SetDefaultBranch declares the repository's default branch in the root
+// metadata. Rule patterns that refer to the default branch, such as
+// "class:default-branch", are matched against this branch instead of the
+// branch the repository's symbolic HEAD points to.
+func (r *Repository) SetDefaultBranch(ctx context.Context, signer sslibdsse.SignerVerifier, branchName string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	refName := gitinterface.BranchReferenceName(branchName)
+	if err := rootMetadata.SetDefaultBranch(refName); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Set default branch to '%s' in root", refName)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
 // AddRootKey is the interface for the user to add an authorized key
 // for the Root role.
 func (r *Repository) AddRootKey(ctx context.Context, signer sslibdsse.SignerVerifier, newRootKey tuf.Principal, signCommit bool, opts ...trustpolicyopts.Option) error {
@@ -390,31 +436,753 @@ func (r *Repository) RemoveGitHubApp(ctx context.Context, signer sslibdsse.Signe
 		return err
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing GitHub app key...")
+	if appName == "" {
+		slog.Debug(fmt.Sprintf("Using default app name '%s'...", tuf.GitHubAppRoleName))
+		appName = tuf.GitHubAppRoleName
+	}
+	rootMetadata.DeleteGitHubAppPrincipal(appName)
+
+	commitMessage := "Remove GitHub app key from root"
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// TrustGitHubApp updates the root metadata to mark GitHub app pull request
+// approvals as trusted.
+func (r *Repository) TrustGitHubApp(ctx context.Context, signer sslibdsse.SignerVerifier, appName string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	if appName == "" {
+		slog.Debug(fmt.Sprintf("Using default app name '%s'...", tuf.GitHubAppRoleName))
+		appName = tuf.GitHubAppRoleName
+	}
+	if rootMetadata.IsGitHubAppApprovalTrusted(appName) {
+		slog.Debug("GitHub app approvals are already trusted, exiting...")
+		return nil
+	}
+
+	slog.Debug("Marking GitHub app approvals as trusted in root...")
+	rootMetadata.EnableGitHubAppApprovals(appName)
+
+	commitMessage := "Mark GitHub app approvals as trusted"
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// UntrustGitHubApp updates the root metadata to mark GitHub app pull request
+// approvals as untrusted.
+func (r *Repository) UntrustGitHubApp(ctx context.Context, signer sslibdsse.SignerVerifier, appName string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	if appName == "" {
+		slog.Debug(fmt.Sprintf("Using default app name '%s'...", tuf.GitHubAppRoleName))
+		appName = tuf.GitHubAppRoleName
+	}
+	if !rootMetadata.IsGitHubAppApprovalTrusted(appName) {
+		slog.Debug("GitHub app approvals are already untrusted, exiting...")
+		return nil
+	}
+
+	slog.Debug("Marking GitHub app approvals as untrusted in root...")
+	rootMetadata.DisableGitHubAppApprovals(appName)
+
+	commitMessage := "Mark GitHub app approvals as untrusted"
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// UpdateRootThreshold sets the threshold of valid signatures required for the
+// Root role.
+func (r *Repository) UpdateRootThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Updating root threshold...")
+	if err := rootMetadata.UpdateRootThreshold(threshold); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update root threshold to %d", threshold)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// UpdateTopLevelTargetsThreshold sets the threshold of valid signatures
+// required for the top level Targets role.
+func (r *Repository) UpdateTopLevelTargetsThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Updating policy threshold...")
+	if err := rootMetadata.UpdatePrimaryRuleFileThreshold(threshold); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update policy threshold to %d", threshold)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleThreshold adds a threshold global rule to the root metadata.
+func (r *Repository) AddGlobalRuleThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding threshold global rule...")
+	if err := rootMetadata.AddGlobalRule(tufv01.NewGlobalRuleThreshold(name, patterns, threshold)); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleThresholdType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleBlockForcePushes adds a global rule that blocks force pushes to the root metadata.
+func (r *Repository) AddGlobalRuleBlockForcePushes(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleBlockForcePushes(name, patterns)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding threshold global rule...")
+	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleBlockForcePushesType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleRequireSignedCommits adds a global rule that requires every new
+// commit to be signed by a principal declared in the policy to the root
+// metadata.
+func (r *Repository) AddGlobalRuleRequireSignedCommits(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleRequireSignedCommits(name, patterns)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding require-signed-commits global rule...")
+	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleRequireSignedCommitsType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleRequireSignOff adds a global rule that requires every new commit
+// to have a Signed-off-by trailer matching its author to the root metadata. If
+// allowAssociatedIdentities is set, sign-offs using another associated identity
+// of the person whose identities include the author's email are also accepted.
+func (r *Repository) AddGlobalRuleRequireSignOff(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, allowAssociatedIdentities, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleRequireSignOff(name, patterns, allowAssociatedIdentities)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding require-sign-off global rule...")
+	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleRequireSignOffType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleRequireLinearHistory adds a global rule that rejects merge
+// commits to the root metadata.
+func (r *Repository) AddGlobalRuleRequireLinearHistory(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleRequireLinearHistory(name, patterns)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding require-linear-history global rule...")
+	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleRequireLinearHistoryType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleRestrictFiles adds a global rule that rejects files matching
+// the denied patterns or blobs larger than maxBlobSize bytes to the root
+// metadata. A maxBlobSize of zero leaves blob sizes unrestricted.
+func (r *Repository) AddGlobalRuleRestrictFiles(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns, deniedPatterns []string, maxBlobSize uint64, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleRestrictFiles(name, patterns, deniedPatterns, maxBlobSize)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding restrict-files global rule...")
+	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleRestrictFilesType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleFreezeWindow adds a global rule that rejects changes recorded
+// between start and end unless they meet the override threshold to the root
+// metadata.
+func (r *Repository) AddGlobalRuleFreezeWindow(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, start, end time.Time, overrideThreshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleFreezeWindow(name, patterns, start, end, overrideThreshold)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding freeze-window global rule...")
+	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleFreezeWindowType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// AddGlobalRuleRestrictRead adds a global rule that requires the contents of
+// the matching references to be encrypted to the specified readers to the root
+// metadata.
+func (r *Repository) AddGlobalRuleRestrictRead(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns, readerPrincipalIDs []string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleRestrictRead(name, patterns, readerPrincipalIDs)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding restrict-read global rule...")
+	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleRestrictReadType, name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// UpdateGlobalRuleThreshold updates an existing threshold global rule in the root metadata.
+func (r *Repository) UpdateGlobalRuleThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Updating threshold global rule...")
+	if err := rootMetadata.UpdateGlobalRule(tufv01.NewGlobalRuleThreshold(name, patterns, threshold)); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// UpdateGlobalRuleBlockForcePushes updates an existing block-force-pushes global rule in the root metadata.
+func (r *Repository) UpdateGlobalRuleBlockForcePushes(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleBlockForcePushes(name, patterns)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Updating block-force-pushes global rule...")
+	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// UpdateGlobalRuleRequireSignedCommits updates an existing
+// require-signed-commits global rule in the root metadata.
+func (r *Repository) UpdateGlobalRuleRequireSignedCommits(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	globalRule, err := tufv01.NewGlobalRuleRequireSignedCommits(name, patterns)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Updating require-signed-commits global rule...")
+	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// UpdateGlobalRuleRequireSignOff updates an existing require-sign-off global
+// rule in the root metadata.
+func (r *Repository) UpdateGlobalRuleRequireSignOff(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, allowAssociatedIdentities, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
 	if err != nil {
 		return err
 	}
 
-	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	globalRule, err := tufv01.NewGlobalRuleRequireSignOff(name, patterns, allowAssociatedIdentities)
 	if err != nil {
 		return err
 	}
 
-	slog.Debug("Removing GitHub app key...")
-	if appName == "" {
-		slog.Debug(fmt.Sprintf("Using default app name '%s'...", tuf.GitHubAppRoleName))
-		appName = tuf.GitHubAppRoleName
+	slog.Debug("Updating require-sign-off global rule...")
+	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
+		return err
 	}
-	rootMetadata.DeleteGitHubAppPrincipal(appName)
 
-	commitMessage := "Remove GitHub app key from root"
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// TrustGitHubApp updates the root metadata to mark GitHub app pull request
-// approvals as trusted.
-func (r *Repository) TrustGitHubApp(ctx context.Context, signer sslibdsse.SignerVerifier, appName string, signCommit bool, opts ...trustpolicyopts.Option) error {
+// UpdateGlobalRuleRequireLinearHistory updates an existing
+// require-linear-history global rule in the root metadata.
+func (r *Repository) UpdateGlobalRuleRequireLinearHistory(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -434,7 +1202,7 @@ func (r *Repository) TrustGitHubApp(ctx context.Context, signer sslibdsse.Signer
 	}
 
 	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
 	if err != nil {
 		return err
 	}
@@ -444,25 +1212,23 @@ func (r *Repository) TrustGitHubApp(ctx context.Context, signer sslibdsse.Signer
 		return err
 	}
 
-	if appName == "" {
-		slog.Debug(fmt.Sprintf("Using default app name '%s'...", tuf.GitHubAppRoleName))
-		appName = tuf.GitHubAppRoleName
-	}
-	if rootMetadata.IsGitHubAppApprovalTrusted(appName) {
-		slog.Debug("GitHub app approvals are already trusted, exiting...")
-		return nil
+	globalRule, err := tufv01.NewGlobalRuleRequireLinearHistory(name, patterns)
+	if err != nil {
+		return err
 	}
 
-	slog.Debug("Marking GitHub app approvals as trusted in root...")
-	rootMetadata.EnableGitHubAppApprovals(appName)
+	slog.Debug("Updating require-linear-history global rule...")
+	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
+		return err
+	}
 
-	commitMessage := "Mark GitHub app approvals as trusted"
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// UntrustGitHubApp updates the root metadata to mark GitHub app pull request
-// approvals as untrusted.
-func (r *Repository) UntrustGitHubApp(ctx context.Context, signer sslibdsse.SignerVerifier, appName string, signCommit bool, opts ...trustpolicyopts.Option) error {
+// UpdateGlobalRuleRestrictFiles updates an existing restrict-files global rule
+// in the root metadata.
+func (r *Repository) UpdateGlobalRuleRestrictFiles(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns, deniedPatterns []string, maxBlobSize uint64, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -482,7 +1248,7 @@ func (r *Repository) UntrustGitHubApp(ctx context.Context, signer sslibdsse.Sign
 	}
 
 	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
 	if err != nil {
 		return err
 	}
@@ -492,25 +1258,23 @@ func (r *Repository) UntrustGitHubApp(ctx context.Context, signer sslibdsse.Sign
 		return err
 	}
 
-	if appName == "" {
-		slog.Debug(fmt.Sprintf("Using default app name '%s'...", tuf.GitHubAppRoleName))
-		appName = tuf.GitHubAppRoleName
-	}
-	if !rootMetadata.IsGitHubAppApprovalTrusted(appName) {
-		slog.Debug("GitHub app approvals are already untrusted, exiting...")
-		return nil
+	globalRule, err := tufv01.NewGlobalRuleRestrictFiles(name, patterns, deniedPatterns, maxBlobSize)
+	if err != nil {
+		return err
 	}
 
-	slog.Debug("Marking GitHub app approvals as untrusted in root...")
-	rootMetadata.DisableGitHubAppApprovals(appName)
+	slog.Debug("Updating restrict-files global rule...")
+	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
+		return err
+	}
 
-	commitMessage := "Mark GitHub app approvals as untrusted"
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// UpdateRootThreshold sets the threshold of valid signatures required for the
-// Root role.
-func (r *Repository) UpdateRootThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+// UpdateGlobalRuleFreezeWindow updates an existing freeze-window global rule in
+// the root metadata.
+func (r *Repository) UpdateGlobalRuleFreezeWindow(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, start, end time.Time, overrideThreshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -530,7 +1294,7 @@ func (r *Repository) UpdateRootThreshold(ctx context.Context, signer sslibdsse.S
 	}
 
 	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
 	if err != nil {
 		return err
 	}
@@ -540,18 +1304,23 @@ func (r *Repository) UpdateRootThreshold(ctx context.Context, signer sslibdsse.S
 		return err
 	}
 
-	slog.Debug("Updating root threshold...")
-	if err := rootMetadata.UpdateRootThreshold(threshold); err != nil {
+	globalRule, err := tufv01.NewGlobalRuleFreezeWindow(name, patterns, start, end, overrideThreshold)
+	if err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Update root threshold to %d", threshold)
+	slog.Debug("Updating freeze-window global rule...")
+	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// UpdateTopLevelTargetsThreshold sets the threshold of valid signatures
-// required for the top level Targets role.
-func (r *Repository) UpdateTopLevelTargetsThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+// UpdateGlobalRuleRestrictRead updates an existing restrict-read global rule in
+// the root metadata.
+func (r *Repository) UpdateGlobalRuleRestrictRead(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns, readerPrincipalIDs []string, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -571,7 +1340,7 @@ func (r *Repository) UpdateTopLevelTargetsThreshold(ctx context.Context, signer
 	}
 
 	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
 	if err != nil {
 		return err
 	}
@@ -581,22 +1350,22 @@ func (r *Repository) UpdateTopLevelTargetsThreshold(ctx context.Context, signer
 		return err
 	}
 
-	slog.Debug("Updating policy threshold...")
-	if err := rootMetadata.UpdatePrimaryRuleFileThreshold(threshold); err != nil {
+	globalRule, err := tufv01.NewGlobalRuleRestrictRead(name, patterns, readerPrincipalIDs)
+	if err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Update policy threshold to %d", threshold)
+	slog.Debug("Updating restrict-read global rule...")
+	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// AddGlobalRuleThreshold adds a threshold global rule to the root metadata.
-func (r *Repository) AddGlobalRuleThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
-	options := &trustpolicyopts.Options{}
-	for _, fn := range opts {
-		fn(options)
-	}
-
+// RemoveGlobalRule removes a global rule from the root metadata.
+func (r *Repository) RemoveGlobalRule(ctx context.Context, signer sslibdsse.SignerVerifier, name string, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -605,6 +1374,11 @@ func (r *Repository) AddGlobalRuleThreshold(ctx context.Context, signer sslibdss
 		}
 	}
 
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
 	rootKeyID, err := signer.KeyID()
 	if err != nil {
 		return err
@@ -621,22 +1395,16 @@ func (r *Repository) AddGlobalRuleThreshold(ctx context.Context, signer sslibdss
 		return err
 	}
 
-	slog.Debug("Adding threshold global rule...")
-	if err := rootMetadata.AddGlobalRule(tufv01.NewGlobalRuleThreshold(name, patterns, threshold)); err != nil {
+	slog.Debug("Removing global rule...")
+	if err := rootMetadata.DeleteGlobalRule(name); err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleThresholdType, name)
+	commitMessage := fmt.Sprintf("Remove global rule '%s' from root metadata", name)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// AddGlobalRuleBlockForcePushes adds a global rule that blocks force pushes to the root metadata.
-func (r *Repository) AddGlobalRuleBlockForcePushes(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
-	options := &trustpolicyopts.Options{}
-	for _, fn := range opts {
-		fn(options)
-	}
-
+func (r *Repository) AddPropagationDirective(ctx context.Context, signer sslibdsse.SignerVerifier, directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath string, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -645,6 +1413,11 @@ func (r *Repository) AddGlobalRuleBlockForcePushes(ctx context.Context, signer s
 		}
 	}
 
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
 	rootKeyID, err := signer.KeyID()
 	if err != nil {
 		return err
@@ -661,22 +1434,28 @@ func (r *Repository) AddGlobalRuleBlockForcePushes(ctx context.Context, signer s
 		return err
 	}
 
-	globalRule, err := tufv01.NewGlobalRuleBlockForcePushes(name, patterns)
-	if err != nil {
-		return err
+	slog.Debug("Adding propagation directive...")
+	var directive tuf.PropagationDirective
+	switch rootMetadata.(type) {
+	case *tufv01.RootMetadata:
+		directive = tufv01.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
+	case *tufv02.RootMetadata:
+		directive = tufv02.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
 	}
 
-	slog.Debug("Adding threshold global rule...")
-	if err := rootMetadata.AddGlobalRule(globalRule); err != nil {
+	if err := rootMetadata.AddPropagationDirective(directive); err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Add global rule (%s) '%s' to root metadata", tuf.GlobalRuleBlockForcePushesType, name)
+	commitMessage := fmt.Sprintf("Add propagation directive '%s' to root metadata", directiveName)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// UpdateGlobalRuleThreshold updates an existing threshold global rule in the root metadata.
-func (r *Repository) UpdateGlobalRuleThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, threshold int, signCommit bool, opts ...trustpolicyopts.Option) error {
+func (r *Repository) UpdatePropagationDirective(ctx context.Context, signer sslibdsse.SignerVerifier, directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -706,17 +1485,24 @@ func (r *Repository) UpdateGlobalRuleThreshold(ctx context.Context, signer sslib
 		return err
 	}
 
-	slog.Debug("Updating threshold global rule...")
-	if err := rootMetadata.UpdateGlobalRule(tufv01.NewGlobalRuleThreshold(name, patterns, threshold)); err != nil {
+	slog.Debug("Updating propagation directive...")
+	var directive tuf.PropagationDirective
+	switch rootMetadata.(type) {
+	case *tufv01.RootMetadata:
+		directive = tufv01.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
+	case *tufv02.RootMetadata:
+		directive = tufv02.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
+	}
+
+	if err := rootMetadata.UpdatePropagationDirective(directive); err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
+	commitMessage := fmt.Sprintf("Update propagation directive '%s' in root metadata", directiveName)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// UpdateGlobalRuleBlockForcePushes updates an existing block-force-pushes global rule in the root metadata.
-func (r *Repository) UpdateGlobalRuleBlockForcePushes(ctx context.Context, signer sslibdsse.SignerVerifier, name string, patterns []string, signCommit bool, opts ...trustpolicyopts.Option) error {
+func (r *Repository) IncrementRootVersion(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -746,22 +1532,16 @@ func (r *Repository) UpdateGlobalRuleBlockForcePushes(ctx context.Context, signe
 		return err
 	}
 
-	globalRule, err := tufv01.NewGlobalRuleBlockForcePushes(name, patterns)
-	if err != nil {
-		return err
-	}
-
-	slog.Debug("Updating block-force-pushes global rule...")
-	if err := rootMetadata.UpdateGlobalRule(globalRule); err != nil {
-		return err
-	}
-
-	commitMessage := fmt.Sprintf("Update global rule '%s' in root metadata", name)
+	// Just pass it to updateRootMetadata as it will increment the version
+	commitMessage := "Increment root version"
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-// RemoveGlobalRule removes a global rule from the root metadata.
-func (r *Repository) RemoveGlobalRule(ctx context.Context, signer sslibdsse.SignerVerifier, name string, signCommit bool, opts ...trustpolicyopts.Option) error {
+// RevokeKey revokes the key identified by keyID as of the specified RSL entry.
+// Signatures made using the key on RSL entries and attestations after the entry
+// are disregarded during verification, while those made on the entry or before
+// it remain valid.
+func (r *Repository) RevokeKey(ctx context.Context, signer sslibdsse.SignerVerifier, keyID string, effectiveEntryID gitinterface.Hash, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -775,6 +1555,11 @@ func (r *Repository) RemoveGlobalRule(ctx context.Context, signer sslibdsse.Sign
 		fn(options)
 	}
 
+	slog.Debug("Checking if RSL entry exists...")
+	if _, err := rsl.GetEntry(r.r, effectiveEntryID); err != nil {
+		return fmt.Errorf("unable to load RSL entry '%s': %w", effectiveEntryID.String(), err)
+	}
+
 	rootKeyID, err := signer.KeyID()
 	if err != nil {
 		return err
@@ -791,21 +1576,27 @@ func (r *Repository) RemoveGlobalRule(ctx context.Context, signer sslibdsse.Sign
 		return err
 	}
 
-	slog.Debug("Removing global rule...")
-	if err := rootMetadata.DeleteGlobalRule(name); err != nil {
+	slog.Debug("Revoking key...")
+	if err := rootMetadata.RevokeKey(keyID, effectiveEntryID.String()); err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Remove global rule '%s' from root metadata", name)
+	commitMessage := fmt.Sprintf("Revoke key '%s' after RSL entry '%s'", keyID, effectiveEntryID.String())
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-func (r *Repository) AddPropagationDirective(ctx context.Context, signer sslibdsse.SignerVerifier, directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath string, signCommit bool, opts ...trustpolicyopts.Option) error {
+// RotateKey replaces the key identified by oldKeyID with newKey everywhere it
+// is trusted: in the root of trust, including the principals of the primary
+// rule file and the readers of restrict-read global rules, and in every rule
+// file. All affected metadata is updated in a single policy staging commit,
+// and the names of the modified roles and global rules are returned. The
+// signer must be a root principal if the root of trust is modified.
+func (r *Repository) RotateKey(ctx context.Context, signer sslibdsse.SignerVerifier, oldKeyID string, newKey tuf.Principal, signCommit bool, opts ...trustpolicyopts.Option) ([]string, error) {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -814,44 +1605,103 @@ func (r *Repository) AddPropagationDirective(ctx context.Context, signer sslibds
 		fn(options)
 	}
 
-	rootKeyID, err := signer.KeyID()
+	signerKeyID, err := signer.KeyID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	slog.Debug("Loading current policy...")
 	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	rootMetadata, err := state.GetRootMetadata(true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	slog.Debug("Adding propagation directive...")
-	var directive tuf.PropagationDirective
-	switch rootMetadata.(type) {
-	case *tufv01.RootMetadata:
-		directive = tufv01.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
-	case *tufv02.RootMetadata:
-		directive = tufv02.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
+	authorizedPrincipals, err := rootMetadata.GetRootPrincipals()
+	if err != nil {
+		return nil, err
 	}
 
-	if err := rootMetadata.AddPropagationDirective(directive); err != nil {
-		return err
+	rotatedRoles := []string{}
+
+	// Readers are identified before rotation to list the modified global
+	// rules
+	rotatedGlobalRules := []string{}
+	for _, globalRule := range rootMetadata.GetGlobalRules() {
+		if restrictRead, isRestrictRead := globalRule.(tuf.GlobalRuleRestrictRead); isRestrictRead && slices.Contains(restrictRead.GetReaderPrincipalIDs(), oldKeyID) {
+			rotatedGlobalRules = append(rotatedGlobalRules, fmt.Sprintf("global rule '%s'", restrictRead.GetName()))
+		}
 	}
 
-	commitMessage := fmt.Sprintf("Add propagation directive '%s' to root metadata", directiveName)
-	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
-}
+	slog.Debug("Rotating key in root metadata...")
+	rotated, err := rootMetadata.RotateKey(oldKeyID, newKey)
+	if err != nil {
+		return nil, err
+	}
+	if rotated {
+		if !isKeyAuthorized(authorizedPrincipals, signerKeyID) {
+			return nil, ErrUnauthorizedKey
+		}
 
-func (r *Repository) UpdatePropagationDirective(ctx context.Context, signer sslibdsse.SignerVerifier, directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath string, signCommit bool, opts ...trustpolicyopts.Option) error {
-	if !dev.InDevMode() {
-		return dev.ErrNotInDevMode
+		if err := signRootMetadata(ctx, state, signer, rootMetadata); err != nil {
+			return nil, err
+		}
+		rotatedRoles = append(rotatedRoles, policy.RootRoleName)
+		rotatedRoles = append(rotatedRoles, rotatedGlobalRules...)
+	}
+
+	if state.HasTargetsRole(policy.TargetsRoleName) {
+		ruleFileNames := make([]string, 0, len(state.Metadata.DelegationEnvelopes))
+		for ruleFileName := range state.Metadata.DelegationEnvelopes {
+			ruleFileNames = append(ruleFileNames, ruleFileName)
+		}
+		slices.Sort(ruleFileNames)
+		ruleFileNames = append([]string{policy.TargetsRoleName}, ruleFileNames...)
+
+		for _, ruleFileName := range ruleFileNames {
+			slog.Debug(fmt.Sprintf("Rotating key in rule file '%s'...", ruleFileName))
+			targetsMetadata, err := state.GetTargetsMetadata(ruleFileName, true)
+			if err != nil {
+				return nil, err
+			}
+
+			rotated, err := targetsMetadata.RotateKey(oldKeyID, newKey)
+			if err != nil {
+				return nil, err
+			}
+			if !rotated {
+				continue
+			}
+
+			if err := signTargetsMetadata(ctx, state, signer, ruleFileName, targetsMetadata); err != nil {
+				return nil, err
+			}
+			rotatedRoles = append(rotatedRoles, ruleFileName)
+		}
+	}
+
+	if len(rotatedRoles) == 0 {
+		return nil, fmt.Errorf("%w: '%s'", tuf.ErrPrincipalNotFound, oldKeyID)
+	}
+
+	commitMessage := fmt.Sprintf("Rotate key '%s' to '%s'", oldKeyID, newKey.ID())
+
+	slog.Debug("Committing policy...")
+	if err := state.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit); err != nil {
+		return nil, err
 	}
 
+	return rotatedRoles, nil
+}
+
+// SetRootExpiry sets the expiry time of the root of trust metadata and opts the
+// root of trust in to enforcing expiry. Policy verification fails once the root
+// of trust has expired.
+func (r *Repository) SetRootExpiry(ctx context.Context, signer sslibdsse.SignerVerifier, expires time.Time, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -865,13 +1715,17 @@ func (r *Repository) UpdatePropagationDirective(ctx context.Context, signer ssli
 		fn(options)
 	}
 
+	if !expires.After(time.Now()) {
+		return ErrExpiryNotInFuture
+	}
+
 	rootKeyID, err := signer.KeyID()
 	if err != nil {
 		return err
 	}
 
 	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
 	if err != nil {
 		return err
 	}
@@ -881,24 +1735,22 @@ func (r *Repository) UpdatePropagationDirective(ctx context.Context, signer ssli
 		return err
 	}
 
-	slog.Debug("Updating propagation directive...")
-	var directive tuf.PropagationDirective
-	switch rootMetadata.(type) {
-	case *tufv01.RootMetadata:
-		directive = tufv01.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
-	case *tufv02.RootMetadata:
-		directive = tufv02.NewPropagationDirective(directiveName, upstreamRepository, upstreamReference, upstreamPath, downstreamReference, downstreamPath)
-	}
+	expiry := expires.UTC().Format(time.RFC3339)
 
-	if err := rootMetadata.UpdatePropagationDirective(directive); err != nil {
+	slog.Debug("Updating root expiry...")
+	rootMetadata.SetExpires(expiry)
+	if err := rootMetadata.SetExpiryEnforced(true); err != nil {
 		return err
 	}
 
-	commitMessage := fmt.Sprintf("Update propagation directive '%s' in root metadata", directiveName)
+	commitMessage := fmt.Sprintf("Set root expiry to '%s'", expiry)
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
-func (r *Repository) IncrementRootVersion(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool, opts ...trustpolicyopts.Option) error {
+// DisableRootExpiry opts the root of trust out of enforcing expiry, so that
+// expired root of trust and rule file metadata no longer fails verification.
+// Setting the root expiry using SetRootExpiry opts the root of trust back in.
+func (r *Repository) DisableRootExpiry(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool, opts ...trustpolicyopts.Option) error {
 	if signCommit {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -918,7 +1770,7 @@ func (r *Repository) IncrementRootVersion(ctx context.Context, signer sslibdsse.
 	}
 
 	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef)
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
 	if err != nil {
 		return err
 	}
@@ -928,8 +1780,12 @@ func (r *Repository) IncrementRootVersion(ctx context.Context, signer sslibdsse.
 		return err
 	}
 
-	// Just pass it to updateRootMetadata as it will increment the version
-	commitMessage := "Increment root version"
+	slog.Debug("Disabling root expiry...")
+	if err := rootMetadata.SetExpiryEnforced(false); err != nil {
+		return err
+	}
+
+	commitMessage := "Disable expiry enforcement in root"
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
@@ -1421,6 +2277,15 @@ func (r *Repository) loadRootMetadata(state *policy.State, keyID string) (tuf.Ro
 }
 
 func (r *Repository) updateRootMetadata(ctx context.Context, state *policy.State, signer sslibdsse.SignerVerifier, rootMetadata tuf.RootMetadata, commitMessage string, createRSLEntry, signCommit bool) error {
+	if err := signRootMetadata(ctx, state, signer, rootMetadata); err != nil {
+		return err
+	}
+
+	slog.Debug("Committing policy...")
+	return state.Commit(r.r, commitMessage, createRSLEntry, signCommit)
+}
+
+func signRootMetadata(ctx context.Context, state *policy.State, signer sslibdsse.SignerVerifier, rootMetadata tuf.RootMetadata) error {
 	rootMetadata.IncrementVersion()
 
 	env, err := dsse.CreateEnvelope(rootMetadata)
@@ -1435,7 +2300,5 @@ func (r *Repository) updateRootMetadata(ctx context.Context, state *policy.State
 	}
 
 	state.Metadata.RootEnvelope = env
-
-	slog.Debug("Committing policy...")
-	return state.Commit(r.r, commitMessage, createRSLEntry, signCommit)
+	return nil
 }
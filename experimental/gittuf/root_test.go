@@ -6,13 +6,19 @@ package gittuf
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	rootopts "github.com/gittuf/gittuf/experimental/gittuf/options/root"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/encryption"
 	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
@@ -220,6 +226,38 @@ func TestSetRepositoryLocation(t *testing.T) {
 	})
 }
 
+func TestSetDefaultBranch(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	sv := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	err := r.SetDefaultBranch(testCtx, sv, "main", false)
+	assert.Nil(t, err)
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	assert.Nil(t, err)
+	assert.Equal(t, "refs/heads/main", rootMetadata.GetDefaultBranch())
+
+	t.Run("invalid branch", func(t *testing.T) {
+		err := r.SetDefaultBranch(testCtx, sv, "refs/heads/", false)
+		assert.ErrorIs(t, err, tuf.ErrInvalidDefaultBranch)
+	})
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		unauthorizedSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+		err := r.SetDefaultBranch(testCtx, unauthorizedSigner, "main", false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+}
+
 func TestAddRootKey(t *testing.T) {
 	r := createTestRepositoryWithRoot(t, "")
 
@@ -1275,6 +1313,286 @@ func TestAddGlobalRuleBlockForcePushes(t *testing.T) {
 		assert.ErrorIs(t, err, ErrUnauthorizedKey)
 	})
 }
+
+func TestAddGlobalRuleRequireSignedCommits(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	err := r.AddGlobalRuleRequireSignedCommits(testCtx, rootSigner, "require-signed-commits-for-main", []string{"git:refs/heads/main"}, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef) // we haven't applied
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules := rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.Equal(t, "require-signed-commits-for-main", globalRules[0].GetName())
+	assert.Equal(t, []string{"git:refs/heads/main"}, globalRules[0].(tuf.GlobalRuleRequireSignedCommits).GetProtectedNamespaces())
+
+	err = r.AddGlobalRuleRequireSignedCommits(testCtx, rootSigner, "require-signed-commits-for-files", []string{"file:*"}, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleRequireSignedCommitsOnlyAppliesToGitPaths)
+
+	err = r.UpdateGlobalRuleRequireSignedCommits(testCtx, rootSigner, "require-signed-commits-for-main", []string{"git:refs/heads/*"}, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules = rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.Equal(t, []string{"git:refs/heads/*"}, globalRules[0].(tuf.GlobalRuleRequireSignedCommits).GetProtectedNamespaces())
+
+	err = r.UpdateGlobalRuleBlockForcePushes(testCtx, rootSigner, "require-signed-commits-for-main", []string{"git:refs/heads/*"}, false)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+}
+
+func TestAddGlobalRuleRequireSignOff(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	err := r.AddGlobalRuleRequireSignOff(testCtx, rootSigner, "require-sign-off-for-main", []string{"git:refs/heads/main"}, false, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef) // we haven't applied
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules := rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.Equal(t, "require-sign-off-for-main", globalRules[0].GetName())
+	assert.Equal(t, []string{"git:refs/heads/main"}, globalRules[0].(tuf.GlobalRuleRequireSignOff).GetProtectedNamespaces())
+	assert.False(t, globalRules[0].(tuf.GlobalRuleRequireSignOff).AllowsAssociatedIdentities())
+
+	err = r.AddGlobalRuleRequireSignOff(testCtx, rootSigner, "require-sign-off-for-files", []string{"file:*"}, false, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleRequireSignOffOnlyAppliesToGitPaths)
+
+	err = r.UpdateGlobalRuleRequireSignOff(testCtx, rootSigner, "require-sign-off-for-main", []string{"git:refs/heads/main"}, true, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules = rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.True(t, globalRules[0].(tuf.GlobalRuleRequireSignOff).AllowsAssociatedIdentities())
+
+	err = r.UpdateGlobalRuleRequireSignedCommits(testCtx, rootSigner, "require-sign-off-for-main", []string{"git:refs/heads/main"}, false)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+}
+
+func TestAddGlobalRuleRequireLinearHistory(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	err := r.AddGlobalRuleRequireLinearHistory(testCtx, rootSigner, "linear-history-for-main", []string{"git:refs/heads/main"}, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	err = r.UpdateGlobalRuleRequireLinearHistory(testCtx, rootSigner, "linear-history-for-main", []string{"git:refs/heads/main", "git:refs/heads/release/*"}, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef) // we haven't applied
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules := rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.Equal(t, "linear-history-for-main", globalRules[0].GetName())
+	assert.Equal(t, []string{"git:refs/heads/main", "git:refs/heads/release/*"}, globalRules[0].(tuf.GlobalRuleRequireLinearHistory).GetProtectedNamespaces())
+
+	err = r.AddGlobalRuleRequireLinearHistory(testCtx, rootSigner, "linear-history-for-files", []string{"file:*"}, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleRequireLinearHistoryOnlyAppliesToGitPaths)
+
+	err = r.UpdateGlobalRuleBlockForcePushes(testCtx, rootSigner, "linear-history-for-main", []string{"git:refs/heads/main"}, false)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+}
+
+func TestAddGlobalRuleRestrictFiles(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	err := r.AddGlobalRuleRestrictFiles(testCtx, rootSigner, "restrict-files-for-main", []string{"git:refs/heads/main"}, []string{"*.env"}, 0, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	err = r.UpdateGlobalRuleRestrictFiles(testCtx, rootSigner, "restrict-files-for-main", []string{"git:refs/heads/main"}, []string{"*.env", "*.pem"}, 1048576, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef) // we haven't applied
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules := rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.Equal(t, "restrict-files-for-main", globalRules[0].GetName())
+	assert.Equal(t, []string{"*.env", "*.pem"}, globalRules[0].(tuf.GlobalRuleRestrictFiles).GetDeniedPatterns())
+	assert.Equal(t, uint64(1048576), globalRules[0].(tuf.GlobalRuleRestrictFiles).GetMaxBlobSize())
+
+	err = r.AddGlobalRuleRestrictFiles(testCtx, rootSigner, "restrict-files-for-files", []string{"file:*"}, []string{"*.env"}, 0, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleRestrictFilesOnlyAppliesToGitPaths)
+
+	err = r.AddGlobalRuleRestrictFiles(testCtx, rootSigner, "restrict-nothing", []string{"git:refs/heads/main"}, nil, 0, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleRestrictFilesHasNoConstraints)
+
+	err = r.UpdateGlobalRuleBlockForcePushes(testCtx, rootSigner, "restrict-files-for-main", []string{"git:refs/heads/main"}, false)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+}
+
+func TestAddGlobalRuleFreezeWindow(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	start := time.Date(2025, time.December, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(14 * 24 * time.Hour)
+
+	err := r.AddGlobalRuleFreezeWindow(testCtx, rootSigner, "freeze-main", []string{"git:refs/heads/main"}, start, end, 2, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	err = r.UpdateGlobalRuleFreezeWindow(testCtx, rootSigner, "freeze-main", []string{"git:refs/heads/main"}, start, end.Add(24*time.Hour), 3, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef) // we haven't applied
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules := rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.Equal(t, "freeze-main", globalRules[0].GetName())
+	assert.True(t, start.Equal(globalRules[0].(tuf.GlobalRuleFreezeWindow).GetStart()))
+	assert.True(t, end.Add(24*time.Hour).Equal(globalRules[0].(tuf.GlobalRuleFreezeWindow).GetEnd()))
+	assert.Equal(t, 3, globalRules[0].(tuf.GlobalRuleFreezeWindow).GetOverrideThreshold())
+
+	err = r.AddGlobalRuleFreezeWindow(testCtx, rootSigner, "freeze-files", []string{"file:*"}, start, end, 2, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleFreezeWindowOnlyAppliesToGitPaths)
+
+	err = r.AddGlobalRuleFreezeWindow(testCtx, rootSigner, "freeze-backwards", []string{"git:refs/heads/main"}, end, start, 2, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleFreezeWindowInvalidWindow)
+
+	err = r.UpdateGlobalRuleBlockForcePushes(testCtx, rootSigner, "freeze-main", []string{"git:refs/heads/main"}, false)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+}
+
+func TestAddGlobalRuleRestrictRead(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	err := r.AddGlobalRuleRestrictRead(testCtx, rootSigner, "restrict-embargo", []string{"git:refs/heads/embargo/*"}, []string{"alice"}, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	err = r.UpdateGlobalRuleRestrictRead(testCtx, rootSigner, "restrict-embargo", []string{"git:refs/heads/embargo/*"}, []string{"alice", "bob"}, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef) // we haven't applied
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalRules := rootMetadata.GetGlobalRules()
+	assert.Len(t, globalRules, 1)
+	assert.Equal(t, "restrict-embargo", globalRules[0].GetName())
+	assert.Equal(t, []string{"git:refs/heads/embargo/*"}, globalRules[0].(tuf.GlobalRuleRestrictRead).GetProtectedNamespaces())
+	assert.Equal(t, []string{"alice", "bob"}, globalRules[0].(tuf.GlobalRuleRestrictRead).GetReaderPrincipalIDs())
+
+	err = r.AddGlobalRuleRestrictRead(testCtx, rootSigner, "restrict-files", []string{"file:*"}, []string{"alice"}, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleRestrictReadOnlyAppliesToGitPaths)
+
+	err = r.AddGlobalRuleRestrictRead(testCtx, rootSigner, "restrict-no-readers", []string{"git:refs/heads/main"}, nil, false)
+	assert.ErrorIs(t, err, tuf.ErrGlobalRuleRestrictReadHasNoReaders)
+
+	err = r.UpdateGlobalRuleBlockForcePushes(testCtx, rootSigner, "restrict-embargo", []string{"git:refs/heads/embargo/*"}, false)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+}
+
 func TestRemoveGlobalRule(t *testing.T) {
 	t.Run("remove threshold global rule", func(t *testing.T) {
 		r := createTestRepositoryWithRoot(t, "")
@@ -1750,7 +2068,7 @@ func TestUpdateGlobalRule(t *testing.T) {
 		globalRules = rootMetadata.GetGlobalRules()
 		assert.Len(t, globalRules, 1)
 		assert.Equal(t, "require-approval-for-main", globalRules[0].GetName())
-		assert.Equal(t, []string{"git:refs/heads/main"}, globalRules[0].(tuf.GlobalRuleBlockForcePushes).GetProtectedNamespaces())
+		assert.Equal(t, []string{"git:refs/heads/main"}, globalRules[0].(tuf.GlobalRuleThreshold).GetProtectedNamespaces())
 	})
 
 	t.Run("miscellaneous error checking", func(t *testing.T) {
@@ -3031,3 +3349,278 @@ func getPrimaryRuleFilePrincipalIDs(t *testing.T, rootMetadata tuf.RootMetadata)
 
 	return principalIDs
 }
+
+func TestSetRootExpiry(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	expires := time.Now().AddDate(0, 1, 0).UTC().Truncate(time.Second)
+	err := r.SetRootExpiry(testCtx, signer, expires, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	require.Nil(t, err)
+	assert.Equal(t, expires.Format(time.RFC3339), rootMetadata.GetExpires())
+	assert.True(t, rootMetadata.IsExpiryEnforced())
+
+	expiries, err := r.GetMetadataExpiries(testCtx, "policy-staging")
+	require.Nil(t, err)
+	assert.Equal(t, expires, expiries[policy.RootRoleName])
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		err := r.SetRootExpiry(testCtx, signer, time.Now().Add(-time.Hour), false)
+		assert.ErrorIs(t, err, ErrExpiryNotInFuture)
+	})
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		sv := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+		err := r.SetRootExpiry(testCtx, sv, expires, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+
+	t.Run("other changes do not renew expired root", func(t *testing.T) {
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		require.Nil(t, err)
+		rootMetadata, err := state.GetRootMetadata(false)
+		require.Nil(t, err)
+		expired := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		rootMetadata.SetExpires(expired)
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		require.Nil(t, err)
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+		require.Nil(t, err)
+		state.Metadata.RootEnvelope = rootEnv
+		require.Nil(t, state.Commit(r.r, "Expire root", false, false))
+
+		err = r.SetDefaultBranch(testCtx, signer, "main", false)
+		require.Nil(t, err)
+
+		state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		require.Nil(t, err)
+		rootMetadata, err = state.GetRootMetadata(false)
+		require.Nil(t, err)
+		assert.Equal(t, expired, rootMetadata.GetExpires())
+	})
+
+	t.Run("tufv01 root of trust", func(t *testing.T) {
+		r := createTestRepositoryWithRoot(t, "")
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		require.Nil(t, err)
+		rootMetadata := tufv01.NewRootMetadata()
+		rootMetadata.SetExpires(time.Now().AddDate(1, 0, 0).UTC().Format(time.RFC3339))
+		require.Nil(t, rootMetadata.AddRootPrincipal(tufv01.NewKeyFromSSLibKey(signer.MetadataKey())))
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		require.Nil(t, err)
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+		require.Nil(t, err)
+		state.Metadata.RootEnvelope = rootEnv
+		require.Nil(t, state.Commit(r.r, "Use tufv01 root", false, false))
+
+		// The root of trust is migrated so that it can opt in
+		err = r.SetRootExpiry(testCtx, signer, expires, false)
+		assert.Nil(t, err)
+
+		state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+		require.Nil(t, err)
+		migratedRootMetadata, err := state.GetRootMetadata(false)
+		require.Nil(t, err)
+		assert.IsType(t, &tufv02.RootMetadata{}, migratedRootMetadata)
+		assert.True(t, migratedRootMetadata.IsExpiryEnforced())
+	})
+}
+
+func TestDisableRootExpiry(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	require.Nil(t, err)
+	rootMetadata, err := state.GetRootMetadata(false)
+	require.Nil(t, err)
+	assert.True(t, rootMetadata.IsExpiryEnforced())
+
+	err = r.DisableRootExpiry(testCtx, signer, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	require.Nil(t, err)
+	rootMetadata, err = state.GetRootMetadata(false)
+	require.Nil(t, err)
+	assert.False(t, rootMetadata.IsExpiryEnforced())
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		sv := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+		err := r.DisableRootExpiry(testCtx, sv, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+}
+
+func TestRevokeKey(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	err := r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	latestEntry, err := rsl.GetLatestEntry(r.r)
+	require.Nil(t, err)
+
+	keyID := "SHA256:compromised"
+	err = r.RevokeKey(testCtx, signer, keyID, latestEntry.GetID(), false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyRevocations, err := state.GetKeyRevocations()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]gitinterface.Hash{keyID: latestEntry.GetID()}, keyRevocations)
+
+	t.Run("unknown RSL entry", func(t *testing.T) {
+		err := r.RevokeKey(testCtx, signer, keyID, gitinterface.ZeroHash, false)
+		assert.ErrorContains(t, err, "unable to load RSL entry")
+	})
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		sv := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+		err := r.RevokeKey(testCtx, sv, keyID, latestEntry.GetID(), false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+}
+
+func TestRotateKey(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	targetsKeyID, err := targetsSigner.KeyID()
+	require.Nil(t, err)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	require.Nil(t, err)
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	newKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH))
+
+	t.Run("rotate key in rule file", func(t *testing.T) {
+		rotatedRoles, err := r.RotateKey(testCtx, targetsSigner, gpgKey.KeyID, newKey, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{policy.TargetsRoleName}, rotatedRoles)
+
+		err = r.StagePolicy(testCtx, "", true, false)
+		require.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+		require.Nil(t, err)
+
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		require.Nil(t, err)
+
+		principals := targetsMetadata.GetPrincipals()
+		assert.Contains(t, principals, newKey.KeyID)
+		assert.NotContains(t, principals, gpgKey.KeyID)
+		assert.Equal(t, []string{newKey.KeyID}, targetsMetadata.GetRules()[0].GetPrincipalIDs().Contents())
+	})
+
+	t.Run("rotate primary rule file key with unauthorized signer", func(t *testing.T) {
+		_, err := r.RotateKey(testCtx, targetsSigner, targetsKeyID, newKey, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+
+	t.Run("rotate primary rule file key", func(t *testing.T) {
+		rotatedRoles, err := r.RotateKey(testCtx, rootSigner, targetsKeyID, newKey, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{policy.RootRoleName}, rotatedRoles)
+
+		err = r.StagePolicy(testCtx, "", true, false)
+		require.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+		require.Nil(t, err)
+
+		rootMetadata, err := state.GetRootMetadata(false)
+		require.Nil(t, err)
+
+		principals, err := rootMetadata.GetPrimaryRuleFilePrincipals()
+		require.Nil(t, err)
+		assert.Equal(t, []tuf.Principal{newKey}, principals)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := r.RotateKey(testCtx, rootSigner, "SHA256:unknown", newKey, false)
+		assert.ErrorIs(t, err, tuf.ErrPrincipalNotFound)
+	})
+}
+
+func TestRotateKeyOfReader(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	oldKeyID, err := targetsSigner.KeyID()
+	require.Nil(t, err)
+
+	newSigner := setupSSHKeysForSigning(t, artifacts.SSHED25519Private, artifacts.SSHED25519PublicSSH)
+	newKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH))
+
+	err = r.AddGlobalRuleRestrictRead(testCtx, rootSigner, "restrict-embargo", []string{"git:refs/heads/embargo/*"}, []string{oldKeyID}, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+	require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+	rotatedRoles, err := r.RotateKey(testCtx, rootSigner, oldKeyID, newKey, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+	assert.Equal(t, []string{policy.RootRoleName, "global rule 'restrict-embargo'"}, rotatedRoles)
+
+	// The primary rule file must be signed using the new key
+	err = r.SignTargets(testCtx, newSigner, policy.TargetsRoleName, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+	require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+	fixID, err := r.r.WriteBlob([]byte("embargoed fix"))
+	require.Nil(t, err)
+	treeID, err := gitinterface.NewTreeBuilder(r.r).WriteTreeFromEntries([]gitinterface.TreeEntry{gitinterface.NewEntryBlob("src/fix.go", fixID)})
+	require.Nil(t, err)
+	_, err = r.r.Commit(treeID, "refs/heads/fix", "Add fix\n", false)
+	require.Nil(t, err)
+
+	// The contents are encrypted for the rotated reader key
+	commitID, err := r.EncryptReference(testCtx, "refs/heads/fix", "refs/heads/embargo/fix", false)
+	require.Nil(t, err)
+
+	encryptedTreeID, err := r.r.GetCommitTreeID(commitID)
+	require.Nil(t, err)
+	manifest, err := encryption.LoadManifest(r.r, encryptedTreeID)
+	require.Nil(t, err)
+	assert.True(t, manifest.HasRecipient(newKey.KeyID))
+	assert.False(t, manifest.HasRecipient(oldKeyID))
+
+	err = r.RecordRSLEntryForReference(testCtx, "refs/heads/embargo/fix", false, rslopts.WithRecordLocalOnly())
+	require.Nil(t, err)
+	err = r.VerifyRef(testCtx, "refs/heads/embargo/fix")
+	assert.Nil(t, err)
+
+	newKeyPath := filepath.Join(t.TempDir(), "new")
+	require.Nil(t, os.WriteFile(newKeyPath, artifacts.SSHED25519Private, 0o600))
+	err = r.DecryptReference(testCtx, "refs/heads/embargo/fix", newKeyPath, t.TempDir())
+	assert.Nil(t, err)
+}
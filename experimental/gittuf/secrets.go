@@ -0,0 +1,407 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	secretsopts "github.com/gittuf/gittuf/experimental/gittuf/options/secrets"
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/encryption"
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/secrets"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+)
+
+var ErrSecretsKeyRequired = errors.New("the policy being applied changes the readers of secrets, which must be re-encrypted using the private key of one of their readers")
+
+// ApplySecrets records the state of the secrets reference and syncs it with the
+// specified remote.
+func (r *Repository) ApplySecrets(ctx context.Context, remoteName string, localOnly, signRSLEntry bool) error {
+	if signRSLEntry {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	opts := []rslopts.RecordOption{rslopts.WithRecordRemote(remoteName)}
+	if localOnly {
+		opts = append(opts, rslopts.WithRecordLocalOnly())
+	}
+
+	return r.RecordRSLEntryForReference(ctx, secrets.Ref, signRSLEntry, opts...)
+}
+
+// SetSecret encrypts the value for the keys of the specified principals in the
+// current policy and stores it in the secrets reference under the specified
+// name, replacing any existing secret with that name. Only SSH keys can be
+// encrypted for, so every principal must have at least one.
+func (r *Repository) SetSecret(ctx context.Context, name string, value []byte, readers []string, signCommit bool, opts ...secretsopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &secretsopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		return err
+	}
+
+	readerKeys, err := state.GetEncryptionKeysForPrincipalIDs(readers)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current secrets...")
+	allSecrets, err := secrets.LoadCurrentSecrets(r.r)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Encrypting secret '%s' for %d key(s)...", name, len(readerKeys)))
+	secret, err := secrets.NewSecret(name, value, readers, readerKeys)
+	if err != nil {
+		return err
+	}
+
+	if err := allSecrets.Set(name, secret); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Set secret '%s'", name)
+
+	slog.Debug("Committing secrets...")
+	return allSecrets.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// GetSecret returns the value of the specified secret using the SSH private key
+// at privateKeyPath. The key must be one of the keys the secret was encrypted
+// for. A warning is logged if the secret is not up to date with the current
+// policy, i.e., if it has not been rotated after any of its readers were
+// removed from the policy or their keys changed.
+func (r *Repository) GetSecret(ctx context.Context, name, privateKeyPath string) ([]byte, error) {
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Loading current secrets...")
+	allSecrets, err := secrets.LoadCurrentSecrets(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := allSecrets.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, isStale, err := getCurrentReadersForSecret(state, name, secret)
+	if err != nil && !isStale {
+		return nil, err
+	}
+	if isStale {
+		slog.Warn(fmt.Sprintf("Secret '%s' is not encrypted for the current readers and keys in the policy, rotate it using 'gittuf secrets rotate'", name))
+	}
+
+	privateKey, keyID, err := encryption.LoadPrivateKey(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Decrypting secret '%s' using key '%s'...", name, keyID))
+	return secret.Open(name, privateKey, keyID)
+}
+
+// RotateSecrets re-encrypts secrets with a new data key for the current keys of
+// their readers, using the SSH private key at privateKeyPath to decrypt them.
+// Readers that are no longer principals in the policy are dropped. If no names
+// are specified, only the secrets whose readers or keys have changed in the
+// policy are rotated. Otherwise, the specified secrets are rotated regardless.
+// The names of the rotated secrets are returned.
+func (r *Repository) RotateSecrets(ctx context.Context, privateKeyPath string, names []string, signCommit bool, opts ...secretsopts.Option) ([]string, error) {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	options := &secretsopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	state, allSecrets, staleNames, err := r.loadStaleSecrets(ctx, policy.PolicyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		names = staleNames
+	}
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	if err := rotateSecrets(state, allSecrets, names, []string{privateKeyPath}); err != nil {
+		return nil, err
+	}
+
+	commitMessage := fmt.Sprintf("Rotate secrets '%s'", strings.Join(names, "', '"))
+
+	slog.Debug("Committing secrets...")
+	if err := allSecrets.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// GetStaleSecrets returns the names of the secrets that are not up to date
+// with the current policy, i.e., that are still encrypted for readers that were
+// removed from the policy or for keys readers no longer have. Applying a policy
+// re-encrypts such secrets, so they are only found if the policy was applied
+// by other means. They must be rotated using RotateSecrets.
+func (r *Repository) GetStaleSecrets(ctx context.Context) ([]string, error) {
+	_, _, staleNames, err := r.loadStaleSecrets(ctx, policy.PolicyRef)
+	return staleNames, err
+}
+
+// warnIfSecretsStale logs a warning for every secret that is not up to date
+// with the policy in the specified reference, such as after a principal or key
+// is removed from it. Such secrets are re-encrypted when a policy that changes
+// their readers is applied, which requires the private key of one of their
+// readers. Errors are not returned, as this must not fail the policy change
+// that was already made.
+func (r *Repository) warnIfSecretsStale(ctx context.Context, policyRef string, opts ...policyopts.LoadStateOption) {
+	_, _, staleNames, err := r.loadStaleSecrets(ctx, policyRef, opts...)
+	if err != nil {
+		slog.Debug(fmt.Sprintf("Unable to check if secrets are up to date with the policy: %v", err))
+		return
+	}
+
+	for _, name := range staleNames {
+		slog.Warn(fmt.Sprintf("Secret '%s' is not encrypted for the current readers and keys in the staged policy, and must be re-encrypted using the private key of one of its readers", name))
+	}
+}
+
+// reencryptSecretsForStagedPolicy re-encrypts the secrets whose recipients
+// the staged policy changes, such as those with a reader removed from it, for
+// the current keys of their remaining readers in the staged policy. Each is
+// decrypted using one of the SSH private keys at privateKeyPaths, and an error
+// is returned if any of them cannot be re-encrypted. Secrets that are already
+// stale under the applied policy and whose recipients the staged policy does
+// not change are left as they are, so that applying unrelated policy changes
+// does not require a key. The secrets are returned without being committed
+// along with the names of those that were re-encrypted.
+func (r *Repository) reencryptSecretsForStagedPolicy(ctx context.Context, privateKeyPaths []string) (*secrets.Secrets, []string, error) {
+	stagedState, allSecrets, staleNames, err := r.loadStaleSecrets(ctx, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(staleNames) == 0 {
+		return allSecrets, staleNames, nil
+	}
+
+	slog.Debug("Loading applied policy...")
+	appliedState, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil && !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return nil, nil, err
+	}
+
+	changedNames := []string{}
+	for _, name := range staleNames {
+		secret, err := allSecrets.Get(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if appliedState != nil && haveSameRecipients(appliedState, stagedState, name, secret) {
+			slog.Debug(fmt.Sprintf("Recipients of secret '%s' are unchanged by the staged policy, skipping...", name))
+			continue
+		}
+		changedNames = append(changedNames, name)
+	}
+
+	if len(changedNames) == 0 {
+		return allSecrets, changedNames, nil
+	}
+
+	if len(privateKeyPaths) == 0 {
+		return nil, nil, fmt.Errorf("%w: '%s'", ErrSecretsKeyRequired, strings.Join(changedNames, "', '"))
+	}
+
+	if err := rotateSecrets(stagedState, allSecrets, changedNames, privateKeyPaths); err != nil {
+		return nil, nil, err
+	}
+
+	return allSecrets, changedNames, nil
+}
+
+// haveSameRecipients indicates if the secret would be encrypted for the same
+// readers and keys under both policy states.
+func haveSameRecipients(state, otherState *policy.State, name string, secret *secrets.Secret) bool {
+	readers, readerKeys, _, err := getCurrentReadersForSecret(state, name, secret)
+	otherReaders, otherReaderKeys, _, otherErr := getCurrentReadersForSecret(otherState, name, secret)
+	if err != nil || otherErr != nil {
+		// The secret cannot be rotated under either policy
+		return err != nil && otherErr != nil
+	}
+
+	keyIDs := set.NewSet[string]()
+	for _, key := range readerKeys {
+		keyIDs.Add(key.KeyID)
+	}
+	otherKeyIDs := set.NewSet[string]()
+	for _, key := range otherReaderKeys {
+		otherKeyIDs.Add(key.KeyID)
+	}
+
+	return slices.Equal(readers, otherReaders) && keyIDs.Equal(otherKeyIDs)
+}
+
+// loadStaleSecrets loads the current secrets and the policy in the specified
+// reference, and returns them along with the names of the secrets that are not
+// up to date with the policy.
+func (r *Repository) loadStaleSecrets(ctx context.Context, policyRef string, opts ...policyopts.LoadStateOption) (*policy.State, *secrets.Secrets, []string, error) {
+	slog.Debug("Loading current secrets...")
+	allSecrets, err := secrets.LoadCurrentSecrets(r.r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	staleNames := []string{}
+	if len(allSecrets.Names()) == 0 {
+		return nil, allSecrets, staleNames, nil
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policyRef, opts...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, name := range allSecrets.Names() {
+		secret, err := allSecrets.Get(name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		_, _, isStale, err := getCurrentReadersForSecret(state, name, secret)
+		if err != nil && !isStale {
+			return nil, nil, nil, err
+		}
+		if isStale {
+			staleNames = append(staleNames, name)
+		}
+	}
+
+	return state, allSecrets, staleNames, nil
+}
+
+// rotateSecrets re-encrypts the specified secrets with a new data key for the
+// current keys of their readers in the policy. Each secret is decrypted using
+// the first of the SSH private keys at privateKeyPaths that it was encrypted
+// for.
+func rotateSecrets(state *policy.State, allSecrets *secrets.Secrets, names []string, privateKeyPaths []string) error {
+	privateKeys := make([]crypto.PrivateKey, 0, len(privateKeyPaths))
+	keyIDs := make([]string, 0, len(privateKeyPaths))
+	for _, privateKeyPath := range privateKeyPaths {
+		privateKey, keyID, err := encryption.LoadPrivateKey(privateKeyPath)
+		if err != nil {
+			return err
+		}
+
+		privateKeys = append(privateKeys, privateKey)
+		keyIDs = append(keyIDs, keyID)
+	}
+
+	for _, name := range names {
+		secret, err := allSecrets.Get(name)
+		if err != nil {
+			return err
+		}
+
+		readers, readerKeys, _, err := getCurrentReadersForSecret(state, name, secret)
+		if err != nil {
+			return fmt.Errorf("unable to rotate secret '%s': %w", name, err)
+		}
+
+		index := slices.IndexFunc(keyIDs, secret.Manifest.HasRecipient)
+		if index == -1 {
+			return fmt.Errorf("unable to decrypt secret '%s': %w", name, encryption.ErrNotARecipient)
+		}
+
+		value, err := secret.Open(name, privateKeys[index], keyIDs[index])
+		if err != nil {
+			return fmt.Errorf("unable to decrypt secret '%s': %w", name, err)
+		}
+
+		slog.Debug(fmt.Sprintf("Encrypting secret '%s' for %d key(s)...", name, len(readerKeys)))
+		newSecret, err := secrets.NewSecret(name, value, readers, readerKeys)
+		if err != nil {
+			return fmt.Errorf("unable to rotate secret '%s': %w", name, err)
+		}
+
+		if err := allSecrets.Set(name, newSecret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getCurrentReadersForSecret returns the readers of the secret that are still
+// principals in the policy along with their current keys that the secret can
+// be encrypted for. The returned boolean indicates if the secret is stale,
+// i.e., if it is not encrypted for exactly these readers' keys. A secret is
+// also stale if a reader no longer has any key it can be encrypted for, in
+// which case the error is returned as well, as the secret cannot be rotated.
+func getCurrentReadersForSecret(state *policy.State, name string, secret *secrets.Secret) ([]string, []*signerverifier.SSLibKey, bool, error) {
+	allPrincipals := state.GetAllPrincipals()
+
+	readers := []string{}
+	for _, reader := range secret.Readers {
+		if _, has := allPrincipals[reader]; !has {
+			slog.Debug(fmt.Sprintf("Reader '%s' of secret '%s' is no longer in the policy...", reader, name))
+			continue
+		}
+		readers = append(readers, reader)
+	}
+
+	readerKeys, err := state.GetEncryptionKeysForPrincipalIDs(readers)
+	if err != nil {
+		if errors.Is(err, policy.ErrReaderHasNoEncryptionKey) {
+			return nil, nil, true, err
+		}
+		return nil, nil, false, err
+	}
+
+	isStale := len(readers) != len(secret.Readers) || secret.NeedsRotation(readerKeys)
+	return readers, readerKeys, isStale, nil
+}
@@ -0,0 +1,327 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	applypolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/applypolicy"
+	secretsopts "github.com/gittuf/gittuf/experimental/gittuf/options/secrets"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/encryption"
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/secrets"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySecrets(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	readerKeyID, err := targetsSigner.KeyID()
+	require.Nil(t, err)
+
+	err = r.SetSecret(testCtx, "ci-token", []byte("token"), []string{readerKeyID}, false)
+	require.Nil(t, err)
+
+	secretsCommitID, err := r.r.GetReference(secrets.Ref)
+	require.Nil(t, err)
+
+	_, _, err = rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(secrets.Ref))
+	assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+
+	err = r.ApplySecrets(testCtx, "", true, false)
+	assert.Nil(t, err)
+
+	entry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(secrets.Ref))
+	require.Nil(t, err)
+	assert.Equal(t, secretsCommitID, entry.GetTargetID())
+}
+
+func TestSetGetSecret(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	readerKeyID, err := targetsSigner.KeyID()
+	require.Nil(t, err)
+
+	err = r.SetSecret(testCtx, "ci-token", []byte("token"), []string{readerKeyID}, false, secretsopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	readerKeyPath := filepath.Join(t.TempDir(), "reader")
+	require.Nil(t, os.WriteFile(readerKeyPath, targetsKeyBytes, 0o600))
+
+	value, err := r.GetSecret(testCtx, "ci-token", readerKeyPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("token"), value)
+
+	t.Run("not a reader", func(t *testing.T) {
+		otherKeyPath := filepath.Join(t.TempDir(), "other")
+		require.Nil(t, os.WriteFile(otherKeyPath, rootKeyBytes, 0o600))
+
+		_, err := r.GetSecret(testCtx, "ci-token", otherKeyPath)
+		assert.ErrorIs(t, err, encryption.ErrNotARecipient)
+	})
+
+	t.Run("secret does not exist", func(t *testing.T) {
+		_, err := r.GetSecret(testCtx, "deploy-token", readerKeyPath)
+		assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+	})
+
+	t.Run("reader is not in policy", func(t *testing.T) {
+		err := r.SetSecret(testCtx, "deploy-token", []byte("token"), []string{"missing"}, false)
+		assert.ErrorIs(t, err, tuf.ErrPrincipalNotFound)
+	})
+
+	t.Run("reader has no SSH key", func(t *testing.T) {
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		require.Nil(t, err)
+
+		err = r.SetSecret(testCtx, "deploy-token", []byte("token"), []string{readerKeyID, gpgKey.KeyID}, false)
+		assert.ErrorIs(t, err, policy.ErrReaderHasNoEncryptionKey)
+	})
+}
+
+func TestRotateSecrets(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	readerKeyID, err := targetsSigner.KeyID()
+	require.Nil(t, err)
+
+	removedKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH))
+	err = r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{removedKey}, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+	require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+	err = r.SetSecret(testCtx, "ci-token", []byte("ci"), []string{readerKeyID, removedKey.KeyID}, false, secretsopts.WithRSLEntry())
+	require.Nil(t, err)
+	err = r.SetSecret(testCtx, "deploy-token", []byte("deploy"), []string{readerKeyID}, false, secretsopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	readerKeyPath := filepath.Join(t.TempDir(), "reader")
+	require.Nil(t, os.WriteFile(readerKeyPath, targetsKeyBytes, 0o600))
+	removedKeyPath := filepath.Join(t.TempDir(), "removed")
+	require.Nil(t, os.WriteFile(removedKeyPath, artifacts.SSHED25519Private, 0o600))
+
+	// Nothing has changed in the policy yet
+	rotated, err := r.RotateSecrets(testCtx, readerKeyPath, nil, false, secretsopts.WithRSLEntry())
+	assert.Nil(t, err)
+	assert.Empty(t, rotated)
+
+	staleNames, err := r.GetStaleSecrets(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, staleNames)
+
+	err = r.RemovePrincipalFromTargets(testCtx, targetsSigner, policy.TargetsRoleName, removedKey.KeyID, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	// The staged removal is reported before it is applied
+	_, _, staleNames, err = r.loadStaleSecrets(testCtx, policy.PolicyStagingRef, policyopts.BypassRSL())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ci-token"}, staleNames)
+	staleNames, err = r.GetStaleSecrets(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, staleNames)
+
+	require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+	// The removed reader can still decrypt the secret until it is rotated
+	staleNames, err = r.GetStaleSecrets(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ci-token"}, staleNames)
+
+	// Stale secrets can still be used, and the secrets reference is still
+	// verified as the policy does not constrain its contents
+	value, err := r.GetSecret(testCtx, "ci-token", readerKeyPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("ci"), value)
+	err = r.VerifyRef(testCtx, secrets.Ref)
+	assert.Nil(t, err)
+
+	rotated, err = r.RotateSecrets(testCtx, readerKeyPath, nil, false, secretsopts.WithRSLEntry())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ci-token"}, rotated)
+
+	staleNames, err = r.GetStaleSecrets(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, staleNames)
+
+	allSecrets, err := secrets.LoadCurrentSecrets(r.r)
+	require.Nil(t, err)
+	secret, err := allSecrets.Get("ci-token")
+	require.Nil(t, err)
+	assert.Equal(t, []string{readerKeyID}, secret.Readers)
+
+	value, err = r.GetSecret(testCtx, "ci-token", readerKeyPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("ci"), value)
+
+	_, err = r.GetSecret(testCtx, "ci-token", removedKeyPath)
+	assert.ErrorIs(t, err, encryption.ErrNotARecipient)
+
+	t.Run("rotate named secret", func(t *testing.T) {
+		rotated, err := r.RotateSecrets(testCtx, readerKeyPath, []string{"deploy-token"}, false, secretsopts.WithRSLEntry())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"deploy-token"}, rotated)
+
+		value, err := r.GetSecret(testCtx, "deploy-token", readerKeyPath)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("deploy"), value)
+	})
+
+	t.Run("not a reader", func(t *testing.T) {
+		_, err := r.RotateSecrets(testCtx, removedKeyPath, []string{"deploy-token"}, false)
+		assert.ErrorIs(t, err, encryption.ErrNotARecipient)
+	})
+}
+
+func TestApplyPolicyReencryptsSecrets(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	readerKeyID, err := targetsSigner.KeyID()
+	require.Nil(t, err)
+
+	removedKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH))
+	err = r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{removedKey}, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+	require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+	err = r.SetSecret(testCtx, "ci-token", []byte("ci"), []string{readerKeyID, removedKey.KeyID}, false, secretsopts.WithRSLEntry())
+	require.Nil(t, err)
+	err = r.SetSecret(testCtx, "deploy-token", []byte("deploy"), []string{removedKey.KeyID}, false, secretsopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	readerKeyPath := filepath.Join(t.TempDir(), "reader")
+	require.Nil(t, os.WriteFile(readerKeyPath, targetsKeyBytes, 0o600))
+	removedKeyPath := filepath.Join(t.TempDir(), "removed")
+	require.Nil(t, os.WriteFile(removedKeyPath, artifacts.SSHED25519Private, 0o600))
+
+	// The removed principal can decrypt the secret before the removal
+	value, err := r.GetSecret(testCtx, "ci-token", removedKeyPath)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("ci"), value)
+
+	// The principal is the only reader of deploy-token, so it cannot be
+	// re-encrypted and must be set for other readers first
+	err = r.SetSecret(testCtx, "deploy-token", []byte("deploy"), []string{readerKeyID}, false, secretsopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	err = r.RemovePrincipalFromTargets(testCtx, targetsSigner, policy.TargetsRoleName, removedKey.KeyID, false, trustpolicyopts.WithRSLEntry())
+	require.Nil(t, err)
+
+	policyTip, err := r.r.GetReference(policy.PolicyRef)
+	require.Nil(t, err)
+
+	t.Run("no reader key", func(t *testing.T) {
+		err := r.ApplyPolicy(testCtx, "", true, false)
+		assert.ErrorIs(t, err, ErrSecretsKeyRequired)
+
+		// The policy is not applied
+		currentPolicyTip, err := r.r.GetReference(policy.PolicyRef)
+		require.Nil(t, err)
+		assert.Equal(t, policyTip, currentPolicyTip)
+	})
+
+	t.Run("not a reader", func(t *testing.T) {
+		otherKeyPath := filepath.Join(t.TempDir(), "other")
+		require.Nil(t, os.WriteFile(otherKeyPath, rootKeyBytes, 0o600))
+
+		err := r.ApplyPolicy(testCtx, "", true, false, applypolicyopts.WithSecretsKey(otherKeyPath))
+		assert.ErrorIs(t, err, encryption.ErrNotARecipient)
+
+		currentPolicyTip, err := r.r.GetReference(policy.PolicyRef)
+		require.Nil(t, err)
+		assert.Equal(t, policyTip, currentPolicyTip)
+	})
+
+	t.Run("secrets cannot be committed", func(t *testing.T) {
+		secretsTip, err := r.r.GetReference(secrets.Ref)
+		require.Nil(t, err)
+
+		// Lock the secrets reference so that it cannot be updated
+		lockPath := filepath.Join(r.r.GetGitDir(), "refs", "gittuf", "secrets.lock")
+		require.Nil(t, os.WriteFile(lockPath, nil, 0o600))
+		defer os.Remove(lockPath) //nolint:errcheck
+
+		err = r.ApplyPolicy(testCtx, "", true, false, applypolicyopts.WithSecretsKey(readerKeyPath))
+		assert.NotNil(t, err)
+
+		// Neither the policy nor the secrets are changed, so the removed
+		// principal is still a reader under the applied policy
+		currentPolicyTip, err := r.r.GetReference(policy.PolicyRef)
+		require.Nil(t, err)
+		assert.Equal(t, policyTip, currentPolicyTip)
+
+		currentSecretsTip, err := r.r.GetReference(secrets.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, secretsTip, currentSecretsTip)
+	})
+
+	err = r.ApplyPolicy(testCtx, "", true, false, applypolicyopts.WithSecretsKey(readerKeyPath))
+	require.Nil(t, err)
+
+	staleNames, err := r.GetStaleSecrets(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, staleNames)
+
+	entry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(secrets.Ref))
+	require.Nil(t, err)
+	secretsTip, err := r.r.GetReference(secrets.Ref)
+	require.Nil(t, err)
+	assert.Equal(t, secretsTip, entry.GetTargetID())
+
+	allSecrets, err := secrets.LoadCurrentSecrets(r.r)
+	require.Nil(t, err)
+	secret, err := allSecrets.Get("ci-token")
+	require.Nil(t, err)
+	assert.Equal(t, []string{readerKeyID}, secret.Readers)
+
+	value, err = r.GetSecret(testCtx, "ci-token", readerKeyPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("ci"), value)
+
+	// The removed principal can no longer decrypt the secret
+	_, err = r.GetSecret(testCtx, "ci-token", removedKeyPath)
+	assert.ErrorIs(t, err, encryption.ErrNotARecipient)
+
+	t.Run("staged policy does not change readers", func(t *testing.T) {
+		err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{removedKey}, false, trustpolicyopts.WithRSLEntry())
+		require.Nil(t, err)
+		require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+		err = r.SetSecret(testCtx, "stale-token", []byte("stale"), []string{readerKeyID, removedKey.KeyID}, false, secretsopts.WithRSLEntry())
+		require.Nil(t, err)
+
+		// The policy is applied without re-encrypting the secret, so it is
+		// already stale under the applied policy
+		err = r.RemovePrincipalFromTargets(testCtx, targetsSigner, policy.TargetsRoleName, removedKey.KeyID, false, trustpolicyopts.WithRSLEntry())
+		require.Nil(t, err)
+		require.Nil(t, policy.Apply(testCtx, r.r, false))
+
+		rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+		err = r.SetDefaultBranch(testCtx, rootSigner, "main", false, trustpolicyopts.WithRSLEntry())
+		require.Nil(t, err)
+
+		// No key is needed as the staged policy does not change the secret's
+		// readers
+		err = r.ApplyPolicy(testCtx, "", true, false)
+		assert.Nil(t, err)
+
+		staleNames, err := r.GetStaleSecrets(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"stale-token"}, staleNames)
+	})
+}
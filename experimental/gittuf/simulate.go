@@ -0,0 +1,39 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+// SimulatePolicy replays the reference entries recorded in the RSL since the
+// specified entry against the staged policy, returning the entries that would
+// fail verification if the staged policy had been in effect. This is useful to
+// check the impact of a stricter policy before it is applied.
+func (r *Repository) SimulatePolicy(ctx context.Context, sinceEntryID string) ([]*policy.SimulatedFailure, error) {
+	sinceEntryIDHash, err := gitinterface.NewHash(sinceEntryID)
+	if err != nil {
+		return nil, err
+	}
+
+	sinceEntry, err := rsl.GetEntry(r.r, sinceEntryIDHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load RSL entry '%s': %w", sinceEntryID, err)
+	}
+
+	slog.Debug("Loading staged policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.SimulatePolicy(ctx, r.r, state, sinceEntry)
+}
@@ -0,0 +1,64 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatePolicy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	targetsKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+	policyEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(policy.PolicyRef))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	t.Run("staged policy unchanged", func(t *testing.T) {
+		failures, err := r.SimulatePolicy(testCtx, policyEntry.GetID().String())
+		assert.Nil(t, err)
+		assert.Empty(t, failures)
+	})
+
+	// Stage a policy that only trusts the targets key for the main branch
+	if err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{targetsKey}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdateDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []string{targetsKey.KeyID}, []string{"git:refs/heads/main"}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("stricter staged policy", func(t *testing.T) {
+		failures, err := r.SimulatePolicy(testCtx, policyEntry.GetID().String())
+		assert.Nil(t, err)
+		require.Len(t, failures, 1)
+		assert.Equal(t, entryID, failures[0].EntryID)
+		assert.Equal(t, refName, failures[0].RefName)
+		assert.ErrorIs(t, failures[0].Err, policy.ErrVerificationFailed)
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		_, err := r.SimulatePolicy(testCtx, "not-an-entry")
+		assert.ErrorIs(t, err, gitinterface.ErrInvalidHashLength)
+
+		_, err = r.SimulatePolicy(testCtx, gitinterface.ZeroHash.String())
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+	})
+}
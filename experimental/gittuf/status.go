@@ -0,0 +1,61 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+)
+
+// GetRootSignatureStatus returns which of the root principals have signed the
+// staged root of trust, and whether the root threshold is met.
+func (r *Repository) GetRootSignatureStatus(ctx context.Context) (*policy.SignatureStatus, error) {
+	slog.Debug("Loading staged policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, err
+	}
+
+	return state.GetRootSignatureStatus(ctx)
+}
+
+// GetAppliedRootSignatureStatus returns which of the root principals of the
+// applied root of trust have signed the staged root of trust, and whether the
+// applied root threshold is met, as is required to apply the staged policy. If
+// no policy has been applied yet, nil is returned.
+func (r *Repository) GetAppliedRootSignatureStatus(ctx context.Context) (*policy.SignatureStatus, error) {
+	slog.Debug("Loading applied policy...")
+	appliedState, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	slog.Debug("Loading staged policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, err
+	}
+
+	return appliedState.GetNewRootSignatureStatus(ctx, state)
+}
+
+// GetRuleFileSignatureStatus returns which of the trusted principals have
+// signed each staged rule file, and whether each rule file's threshold is met.
+func (r *Repository) GetRuleFileSignatureStatus(ctx context.Context) ([]*policy.SignatureStatus, error) {
+	slog.Debug("Loading staged policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return nil, err
+	}
+
+	return state.GetRuleFileSignatureStatus(ctx)
+}
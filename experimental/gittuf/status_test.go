@@ -0,0 +1,55 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSignatureStatus(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	rootKey := tufv01.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+	targetsKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targetsPubKeyBytes))
+
+	// Require a second root signature
+	if err := r.AddRootKey(testCtx, rootSigner, targetsKey, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdateRootThreshold(testCtx, rootSigner, 2, false); err != nil {
+		t.Fatal(err)
+	}
+
+	rootStatus, err := r.GetRootSignatureStatus(testCtx)
+	require.Nil(t, err)
+	assert.Equal(t, policy.RootRoleName, rootStatus.Name)
+	assert.Equal(t, 2, rootStatus.Threshold)
+	assert.Equal(t, []string{rootKey.KeyID}, rootStatus.SignedPrincipalIDs)
+	assert.Equal(t, []string{targetsKey.KeyID}, rootStatus.UnsignedPrincipalIDs)
+	assert.False(t, rootStatus.ThresholdMet)
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	if err := r.SignRoot(testCtx, targetsSigner, false); err != nil {
+		t.Fatal(err)
+	}
+
+	rootStatus, err = r.GetRootSignatureStatus(testCtx)
+	require.Nil(t, err)
+	assert.Empty(t, rootStatus.UnsignedPrincipalIDs)
+	assert.True(t, rootStatus.ThresholdMet)
+
+	ruleFileStatuses, err := r.GetRuleFileSignatureStatus(testCtx)
+	require.Nil(t, err)
+	require.Len(t, ruleFileStatuses, 1)
+	assert.Equal(t, policy.TargetsRoleName, ruleFileStatuses[0].Name)
+	assert.Equal(t, []string{targetsKey.KeyID}, ruleFileStatuses[0].SignedPrincipalIDs)
+	assert.True(t, ruleFileStatuses[0].ThresholdMet)
+}
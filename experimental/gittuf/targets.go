@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
 	"github.com/gittuf/gittuf/internal/policy"
@@ -134,6 +135,10 @@ func (r *Repository) AddDelegation(ctx context.Context, signer sslibdsse.SignerV
 		return err
 	}
 
+	if err := setRuleDetails(targetsMetadata, ruleName, options); err != nil {
+		return err
+	}
+
 	commitMessage := fmt.Sprintf("Add rule '%s' to policy '%s'", ruleName, targetsRoleName)
 	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
@@ -184,6 +189,10 @@ func (r *Repository) UpdateDelegation(ctx context.Context, signer sslibdsse.Sign
 		return err
 	}
 
+	if err := setRuleDetails(targetsMetadata, ruleName, options); err != nil {
+		return err
+	}
+
 	commitMessage := fmt.Sprintf("Update rule '%s' in policy '%s'", ruleName, targetsRoleName)
 	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
@@ -275,6 +284,90 @@ func (r *Repository) RemoveDelegation(ctx context.Context, signer sslibdsse.Sign
 	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
+// EnableSeparationOfDuties is the interface for the user to require
+// separation of duties for a rule in gittuf policy. When enabled, the principal
+// who signed a change cannot count towards the rule's threshold for that
+// change.
+func (r *Repository) EnableSeparationOfDuties(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, ruleName string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName, true)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Enabling separation of duties for rule...")
+	if err := targetsMetadata.EnableSeparationOfDuties(ruleName); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Enable separation of duties for rule '%s' in policy '%s'", ruleName, targetsRoleName)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// DisableSeparationOfDuties is the interface for the user to no longer require
+// separation of duties for a rule in gittuf policy.
+func (r *Repository) DisableSeparationOfDuties(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, ruleName string, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName, true)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Disabling separation of duties for rule...")
+	if err := targetsMetadata.DisableSeparationOfDuties(ruleName); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Disable separation of duties for rule '%s' in policy '%s'", ruleName, targetsRoleName)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
 // AddPrincipalToTargets is the interface for a user to add a trusted principal
 // to gittuf rule file metadata.
 func (r *Repository) AddPrincipalToTargets(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, authorizedPrincipals []tuf.Principal, signCommit bool, opts ...trustpolicyopts.Option) error {
@@ -364,7 +457,14 @@ func (r *Repository) UpdatePrincipalInTargets(ctx context.Context, signer sslibd
 	}
 
 	commitMessage := fmt.Sprintf("Update principal '%s' in policy '%s'", principal.ID(), targetsRoleName)
-	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+	if err := r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit); err != nil {
+		return err
+	}
+
+	// Secrets encrypted for the principal's removed keys must be re-encrypted
+	// when the policy is applied
+	r.warnIfSecretsStale(ctx, policy.PolicyStagingRef, policyopts.BypassRSL())
+	return nil
 }
 
 // RemovePrincipalFromTargets is the interface for a user to remove a principal
@@ -404,7 +504,14 @@ func (r *Repository) RemovePrincipalFromTargets(ctx context.Context, signer ssli
 	}
 
 	commitMessage := fmt.Sprintf("Remove principal from policy '%s'\n%s", targetsRoleName, principalID)
-	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+	if err := r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit); err != nil {
+		return err
+	}
+
+	// Secrets encrypted for the principal's removed keys must be re-encrypted
+	// when the policy is applied
+	r.warnIfSecretsStale(ctx, policy.PolicyStagingRef, policyopts.BypassRSL())
+	return nil
 }
 
 // SignTargets adds a signature to specified Targets role's envelope. Note that
@@ -496,7 +603,89 @@ func (r *Repository) IncrementTargetsVersion(ctx context.Context, signer sslibds
 	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
+// SetTargetsExpiry sets the expiry time of the specified rule file. Policy
+// verification fails once the rule file has expired.
+func (r *Repository) SetTargetsExpiry(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, expires time.Time, signCommit bool, opts ...trustpolicyopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &trustpolicyopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if !expires.After(time.Now()) {
+		return ErrExpiryNotInFuture
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyStagingRef, policyopts.BypassRSL())
+	if err != nil {
+		return err
+	}
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	slog.Debug("Loading current rule file...")
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName, true)
+	if err != nil {
+		return err
+	}
+
+	expiry := expires.UTC().Format(time.RFC3339)
+
+	slog.Debug("Updating rule file expiry...")
+	targetsMetadata.SetExpires(expiry)
+
+	commitMessage := fmt.Sprintf("Set rule file '%s' expiry to '%s'", targetsRoleName, expiry)
+	return r.updateTargetsMetadata(ctx, state, signer, targetsRoleName, targetsMetadata, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
+// setRuleDetails records the rule details specified using options, retaining
+// the rule's existing values for those that weren't specified.
+func setRuleDetails(targetsMetadata tuf.TargetsMetadata, ruleName string, options *trustpolicyopts.Options) error {
+	if options.RuleDescription == nil && options.RuleContact == nil && options.RuleLink == nil {
+		return nil
+	}
+
+	var description, contact, link string
+	for _, rule := range targetsMetadata.GetRules() {
+		if rule.ID() == ruleName {
+			description, contact, link = rule.GetDescription(), rule.GetContact(), rule.GetLink()
+			break
+		}
+	}
+
+	if options.RuleDescription != nil {
+		description = *options.RuleDescription
+	}
+	if options.RuleContact != nil {
+		contact = *options.RuleContact
+	}
+	if options.RuleLink != nil {
+		link = *options.RuleLink
+	}
+
+	slog.Debug("Setting rule details...")
+	return targetsMetadata.SetRuleDetails(ruleName, description, contact, link)
+}
+
 func (r *Repository) updateTargetsMetadata(ctx context.Context, state *policy.State, signer sslibdsse.SignerVerifier, targetsMetadataName string, targetsMetadata tuf.TargetsMetadata, commitMessage string, createRSLEntry, signCommit bool) error {
+	if err := signTargetsMetadata(ctx, state, signer, targetsMetadataName, targetsMetadata); err != nil {
+		return err
+	}
+
+	slog.Debug("Committing policy...")
+	return state.Commit(r.r, commitMessage, createRSLEntry, signCommit)
+}
+
+func signTargetsMetadata(ctx context.Context, state *policy.State, signer sslibdsse.SignerVerifier, targetsMetadataName string, targetsMetadata tuf.TargetsMetadata) error {
 	targetsMetadata.IncrementVersion()
 
 	env, err := dsse.CreateEnvelope(targetsMetadata)
@@ -516,6 +705,5 @@ func (r *Repository) updateTargetsMetadata(ctx context.Context, state *policy.St
 		state.Metadata.DelegationEnvelopes[targetsMetadataName] = env
 	}
 
-	slog.Debug("Committing policy...")
-	return state.Commit(r.r, commitMessage, createRSLEntry, signCommit)
+	return nil
 }
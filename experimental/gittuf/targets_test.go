@@ -5,7 +5,9 @@ package gittuf
 
 import (
 	"testing"
+	"time"
 
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
@@ -133,6 +135,23 @@ func TestAddDelegation(t *testing.T) {
 		assert.Contains(t, targetsMetadata.GetRules(), tufv02.AllowRule())
 	})
 
+	t.Run("rule for default branch", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		targetsPubKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+		if err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{targetsPubKey}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// The default branch need not be declared, as it follows the
+		// repository's symbolic HEAD by default
+		err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-default", []string{targetsPubKey.KeyID}, []string{"class:default-branch"}, 1, false)
+		assert.Nil(t, err)
+
+		err = r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-branches", []string{targetsPubKey.KeyID}, []string{"class:branches", "!class:default-branch"}, 1, false)
+		assert.Nil(t, err)
+	})
+
 	t.Run("invalid rule name", func(t *testing.T) {
 		r := createTestRepositoryWithPolicy(t, "")
 
@@ -343,6 +362,141 @@ func TestRemoveDelegation(t *testing.T) {
 	})
 }
 
+func TestSeparationOfDuties(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	targetsPubKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+	ruleName := "test-rule"
+	rulePatterns := []string{"git:branch=main"}
+
+	if err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{targetsPubKey}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, []string{targetsPubKey.KeyID}, rulePatterns, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	getRule := func(t *testing.T) tuf.Rule {
+		t.Helper()
+
+		err := r.StagePolicy(testCtx, "", true, false)
+		require.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, rule := range targetsMetadata.GetRules() {
+			if rule.ID() == ruleName {
+				return rule
+			}
+		}
+
+		t.Fatalf("rule '%s' not found", ruleName)
+		return nil
+	}
+
+	assert.False(t, getRule(t).RequiresSeparationOfDuties())
+
+	err := r.EnableSeparationOfDuties(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, false)
+	assert.Nil(t, err)
+	assert.True(t, getRule(t).RequiresSeparationOfDuties())
+
+	err = r.DisableSeparationOfDuties(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, false)
+	assert.Nil(t, err)
+	assert.False(t, getRule(t).RequiresSeparationOfDuties())
+
+	err = r.EnableSeparationOfDuties(testCtx, targetsSigner, policy.TargetsRoleName, "missing-rule", false)
+	assert.ErrorIs(t, err, tuf.ErrRuleNotFound)
+
+	err = r.EnableSeparationOfDuties(testCtx, targetsSigner, "missing-policy", ruleName, false)
+	assert.ErrorIs(t, err, policy.ErrMetadataNotFound)
+
+	t.Run("miscellaneous error checking", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+		nr := &Repository{r: repo}
+
+		// Test signCommit
+		err := repo.SetGitConfig("user.signingkey", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = nr.EnableSeparationOfDuties(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, true)
+		assert.ErrorIs(t, err, gitinterface.ErrSigningKeyNotSpecified)
+
+		err = nr.DisableSeparationOfDuties(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, true)
+		assert.ErrorIs(t, err, gitinterface.ErrSigningKeyNotSpecified)
+	})
+}
+
+func TestRuleDetails(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	targetsPubKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+	ruleName := "test-rule"
+	rulePatterns := []string{"git:refs/heads/main"}
+
+	if err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{targetsPubKey}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	getRule := func(t *testing.T) tuf.Rule {
+		t.Helper()
+
+		err := r.StagePolicy(testCtx, "", true, false)
+		require.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, rule := range targetsMetadata.GetRules() {
+			if rule.ID() == ruleName {
+				return rule
+			}
+		}
+
+		t.Fatalf("rule '%s' not found", ruleName)
+		return nil
+	}
+
+	err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, []string{targetsPubKey.KeyID}, rulePatterns, 1, false, trustpolicyopts.WithRuleDescription("Protect the main branch"), trustpolicyopts.WithRuleContact("jane.doe@example.com"), trustpolicyopts.WithRuleLink("https://example.com/issues/1"))
+	assert.Nil(t, err)
+
+	rule := getRule(t)
+	assert.Equal(t, "Protect the main branch", rule.GetDescription())
+	assert.Equal(t, "jane.doe@example.com", rule.GetContact())
+	assert.Equal(t, "https://example.com/issues/1", rule.GetLink())
+
+	// Details not specified when updating the rule are retained
+	err = r.UpdateDelegation(testCtx, targetsSigner, policy.TargetsRoleName, ruleName, []string{targetsPubKey.KeyID}, rulePatterns, 1, false, trustpolicyopts.WithRuleLink(""))
+	assert.Nil(t, err)
+
+	rule = getRule(t)
+	assert.Equal(t, "Protect the main branch", rule.GetDescription())
+	assert.Equal(t, "jane.doe@example.com", rule.GetContact())
+	assert.Empty(t, rule.GetLink())
+}
+
 func TestAddPrincipalToTargets(t *testing.T) {
 	r := createTestRepositoryWithPolicy(t, "")
 
@@ -617,3 +771,39 @@ func TestIncrementTargetsVersion(t *testing.T) {
 		assert.ErrorIs(t, err, gitinterface.ErrSigningKeyNotSpecified)
 	})
 }
+
+func TestSetTargetsExpiry(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+	expires := time.Now().AddDate(0, 1, 0).UTC().Truncate(time.Second)
+	err := r.SetTargetsExpiry(testCtx, targetsSigner, policy.TargetsRoleName, expires, false)
+	assert.Nil(t, err)
+
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, false)
+	require.Nil(t, err)
+	assert.Equal(t, expires.Format(time.RFC3339), targetsMetadata.GetExpires())
+
+	expiries, err := r.GetMetadataExpiries(testCtx, "policy-staging")
+	require.Nil(t, err)
+	assert.Equal(t, expires, expiries[policy.TargetsRoleName])
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		err := r.SetTargetsExpiry(testCtx, targetsSigner, policy.TargetsRoleName, time.Now().Add(-time.Hour), false)
+		assert.ErrorIs(t, err, ErrExpiryNotInFuture)
+	})
+
+	t.Run("unknown rule file", func(t *testing.T) {
+		err := r.SetTargetsExpiry(testCtx, targetsSigner, "does-not-exist", expires, false)
+		assert.ErrorIs(t, err, policy.ErrMetadataNotFound)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/policy"
+)
+
+// WhoCan returns the rules and global threshold rules that changes to the path
+// must satisfy, which identify who can write to it. The policy in targetRef is
+// used unless entryID is set, in which case the policy state recorded by that
+// RSL entry is used instead, which is useful to investigate past changes.
+//
+// A path without a namespace prefix is treated as a Git reference if it
+// starts with "refs/" and as a file path otherwise.
+func (r *Repository) WhoCan(ctx context.Context, path, targetRef, entryID string) ([]*policy.PathAuthorization, error) {
+	var (
+		state *policy.State
+		err   error
+	)
+	if entryID != "" {
+		state, err = r.loadPolicyStateForEntry(ctx, entryID)
+	} else {
+		if !strings.HasPrefix(targetRef, "refs/gittuf/") {
+			targetRef = "refs/gittuf/" + targetRef
+		}
+
+		slog.Debug("Loading current policy...")
+		state, err = policy.LoadCurrentState(ctx, r.r, targetRef)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(path, gitNamespacePrefix) && !strings.HasPrefix(path, fileNamespacePrefix) {
+		if strings.HasPrefix(path, "refs/") {
+			path = gitNamespacePrefix + path
+		} else {
+			path = fileNamespacePrefix + path
+		}
+	}
+
+	return state.FindAuthorizationsForPath(path)
+}
@@ -0,0 +1,64 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhoCan(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	targetsKey := tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+	previousPolicyEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(policy.PolicyRef))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{targetsKey}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-deploy", []string{targetsKey.KeyID}, []string{"file:deploy/*"}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.StagePolicy(testCtx, "", true, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.Apply(testCtx, r.r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("reference", func(t *testing.T) {
+		authorizations, err := r.WhoCan(testCtx, "refs/heads/main", policy.PolicyRef, "")
+		assert.Nil(t, err)
+		assert.Len(t, authorizations, 1)
+		assert.Equal(t, "protect-main", authorizations[0].Name)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		authorizations, err := r.WhoCan(testCtx, "deploy/prod.yaml", policy.PolicyRef, "")
+		assert.Nil(t, err)
+		assert.Len(t, authorizations, 1)
+		assert.Equal(t, "protect-deploy", authorizations[0].Name)
+		assert.Equal(t, []tuf.Principal{targetsKey}, authorizations[0].Principals)
+
+		authorizations, err = r.WhoCan(testCtx, "file:deploy/prod.yaml", "policy", "")
+		assert.Nil(t, err)
+		assert.Len(t, authorizations, 1)
+	})
+
+	t.Run("historical entry", func(t *testing.T) {
+		authorizations, err := r.WhoCan(testCtx, "deploy/prod.yaml", policy.PolicyRef, previousPolicyEntry.GetID().String())
+		assert.Nil(t, err)
+		assert.Empty(t, authorizations)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/gittuf/gittuf/internal/attestations/authorizations"
 	authorizationsv01 "github.com/gittuf/gittuf/internal/attestations/authorizations/v01"
@@ -16,6 +17,10 @@ import (
 	ita "github.com/in-toto/attestation/go/v1"
 )
 
+// hatSeparator separates the hat from the rest of the path of a reference
+// authorization attestation made in a specific hat.
+const hatSeparator = "@"
+
 // NewReferenceAuthorizationForCommit creates a new reference authorization for
 // the provided information. The authorization is embedded in an in-toto
 // "statement" and returned with the appropriate "predicate type" set. The
@@ -36,29 +41,48 @@ func NewReferenceAuthorizationForTag(targetRef, fromID, toID string) (*ita.State
 	return authorizationsv02.NewReferenceAuthorizationForTag(targetRef, fromID, toID)
 }
 
+// NewReferenceAuthorizationForCommitWithHat creates a new reference
+// authorization for a commit whose signers act in the specified hat, such as a
+// team in the policy.
+func NewReferenceAuthorizationForCommitWithHat(targetRef, fromID, toID, hat string) (*ita.Statement, error) {
+	if err := validateHat(hat); err != nil {
+		return nil, err
+	}
+
+	return authorizationsv02.NewReferenceAuthorizationForCommitWithHat(targetRef, fromID, toID, hat)
+}
+
+// NewReferenceAuthorizationForTagWithHat creates a new reference authorization
+// for a tag whose signers act in the specified hat, such as a team in the
+// policy.
+func NewReferenceAuthorizationForTagWithHat(targetRef, fromID, toID, hat string) (*ita.Statement, error) {
+	if err := validateHat(hat); err != nil {
+		return nil, err
+	}
+
+	return authorizationsv02.NewReferenceAuthorizationForTagWithHat(targetRef, fromID, toID, hat)
+}
+
 // SetReferenceAuthorization writes the new reference authorization attestation
 // to the object store and tracks it in the current attestations state.
 func (a *Attestations) SetReferenceAuthorization(repo *gitinterface.Repository, env *sslibdsse.Envelope, refName, fromID, toID string) error {
-	payloadBytes, err := env.DecodeB64Payload()
-	if err != nil {
-		return fmt.Errorf("unable to inspect reference authorization: %w", err)
-	}
+	return a.setReferenceAuthorization(repo, env, refName, fromID, toID, "")
+}
 
-	inspectAuthorization := map[string]any{}
-	if err := json.Unmarshal(payloadBytes, &inspectAuthorization); err != nil {
-		return fmt.Errorf("unable to inspect reference authorization: %w", err)
+// SetHattedReferenceAuthorization writes the new reference authorization
+// attestation whose signers act in the specified hat to the object store and
+// tracks it in the current attestations state.
+func (a *Attestations) SetHattedReferenceAuthorization(repo *gitinterface.Repository, env *sslibdsse.Envelope, refName, fromID, toID, hat string) error {
+	if err := validateHat(hat); err != nil {
+		return err
 	}
-	switch inspectAuthorization["predicate_type"] {
-	case authorizationsv01.PredicateType:
-		if err := authorizationsv01.Validate(env, refName, fromID, toID); err != nil {
-			return err
-		}
-	case authorizationsv02.PredicateType:
-		if err := authorizationsv02.Validate(env, refName, fromID, toID); err != nil {
-			return err
-		}
-	default:
-		return authorizations.ErrUnknownAuthorizationVersion
+
+	return a.setReferenceAuthorization(repo, env, refName, fromID, toID, hat)
+}
+
+func (a *Attestations) setReferenceAuthorization(repo *gitinterface.Repository, env *sslibdsse.Envelope, refName, fromID, toID, hat string) error {
+	if err := validateReferenceAuthorization(env, refName, fromID, toID, hat); err != nil {
+		return err
 	}
 
 	envBytes, err := json.Marshal(env)
@@ -75,7 +99,7 @@ func (a *Attestations) SetReferenceAuthorization(repo *gitinterface.Repository,
 		a.referenceAuthorizations = map[string]gitinterface.Hash{}
 	}
 
-	a.referenceAuthorizations[ReferenceAuthorizationPath(refName, fromID, toID)] = blobID
+	a.referenceAuthorizations[hattedReferenceAuthorizationPath(refName, fromID, toID, hat)] = blobID
 	return nil
 }
 
@@ -83,7 +107,22 @@ func (a *Attestations) SetReferenceAuthorization(repo *gitinterface.Repository,
 // attestation entirely. The object, however, isn't removed from the object
 // store as prior states may still need it.
 func (a *Attestations) RemoveReferenceAuthorization(refName, fromID, toID string) error {
-	authPath := ReferenceAuthorizationPath(refName, fromID, toID)
+	return a.removeReferenceAuthorization(refName, fromID, toID, "")
+}
+
+// RemoveHattedReferenceAuthorization removes a set reference authorization
+// attestation for the specified hat entirely. The object, however, isn't
+// removed from the object store as prior states may still need it.
+func (a *Attestations) RemoveHattedReferenceAuthorization(refName, fromID, toID, hat string) error {
+	if err := validateHat(hat); err != nil {
+		return err
+	}
+
+	return a.removeReferenceAuthorization(refName, fromID, toID, hat)
+}
+
+func (a *Attestations) removeReferenceAuthorization(refName, fromID, toID, hat string) error {
+	authPath := hattedReferenceAuthorizationPath(refName, fromID, toID, hat)
 	if _, has := a.referenceAuthorizations[authPath]; !has {
 		return authorizations.ErrAuthorizationNotFound
 	}
@@ -95,7 +134,44 @@ func (a *Attestations) RemoveReferenceAuthorization(refName, fromID, toID string
 // GetReferenceAuthorizationFor returns the requested reference authorization
 // attestation (with its signatures).
 func (a *Attestations) GetReferenceAuthorizationFor(repo *gitinterface.Repository, refName, fromID, toID string) (*sslibdsse.Envelope, error) {
-	blobID, has := a.referenceAuthorizations[ReferenceAuthorizationPath(refName, fromID, toID)]
+	return a.getReferenceAuthorizationFor(repo, refName, fromID, toID, "")
+}
+
+// GetHattedReferenceAuthorizationFor returns the requested reference
+// authorization attestation (with its signatures) for the specified hat.
+func (a *Attestations) GetHattedReferenceAuthorizationFor(repo *gitinterface.Repository, refName, fromID, toID, hat string) (*sslibdsse.Envelope, error) {
+	if err := validateHat(hat); err != nil {
+		return nil, err
+	}
+
+	return a.getReferenceAuthorizationFor(repo, refName, fromID, toID, hat)
+}
+
+// GetHattedReferenceAuthorizationsFor returns all the reference authorization
+// attestations recorded for the change with a hat. The returned map is keyed
+// by the hat.
+func (a *Attestations) GetHattedReferenceAuthorizationsFor(repo *gitinterface.Repository, refName, fromID, toID string) (map[string]*sslibdsse.Envelope, error) {
+	hatPrefix := ReferenceAuthorizationPath(refName, fromID, toID) + hatSeparator
+
+	hattedAuthorizations := map[string]*sslibdsse.Envelope{}
+	for authPath := range a.referenceAuthorizations {
+		if !strings.HasPrefix(authPath, hatPrefix) {
+			continue
+		}
+
+		hat := strings.TrimPrefix(authPath, hatPrefix)
+		env, err := a.getReferenceAuthorizationFor(repo, refName, fromID, toID, hat)
+		if err != nil {
+			return nil, err
+		}
+		hattedAuthorizations[hat] = env
+	}
+
+	return hattedAuthorizations, nil
+}
+
+func (a *Attestations) getReferenceAuthorizationFor(repo *gitinterface.Repository, refName, fromID, toID, hat string) (*sslibdsse.Envelope, error) {
+	blobID, has := a.referenceAuthorizations[hattedReferenceAuthorizationPath(refName, fromID, toID, hat)]
 	if !has {
 		return nil, authorizations.ErrAuthorizationNotFound
 	}
@@ -110,33 +186,65 @@ func (a *Attestations) GetReferenceAuthorizationFor(repo *gitinterface.Repositor
 		return nil, err
 	}
 
+	if err := validateReferenceAuthorization(env, refName, fromID, toID, hat); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// ReferenceAuthorizationPath constructs the expected path on-disk for the
+// reference authorization attestation.
+func ReferenceAuthorizationPath(refName, fromID, toID string) string {
+	return path.Join(refName, fmt.Sprintf("%s-%s", fromID, toID))
+}
+
+// hattedReferenceAuthorizationPath constructs the expected path on-disk for
+// the reference authorization attestation with the specified hat. An empty hat
+// returns the same path as ReferenceAuthorizationPath.
+func hattedReferenceAuthorizationPath(refName, fromID, toID, hat string) string {
+	authPath := ReferenceAuthorizationPath(refName, fromID, toID)
+	if hat == "" {
+		return authPath
+	}
+
+	return authPath + hatSeparator + hat
+}
+
+func validateHat(hat string) error {
+	if hat == "" || strings.Contains(hat, "/") {
+		return authorizations.ErrInvalidHat
+	}
+
+	return nil
+}
+
+func validateReferenceAuthorization(env *sslibdsse.Envelope, refName, fromID, toID, hat string) error {
 	payloadBytes, err := env.DecodeB64Payload()
 	if err != nil {
-		return nil, fmt.Errorf("unable to inspect reference authorization: %w", err)
+		return fmt.Errorf("unable to inspect reference authorization: %w", err)
 	}
 
 	inspectAuthorization := map[string]any{}
 	if err := json.Unmarshal(payloadBytes, &inspectAuthorization); err != nil {
-		return nil, fmt.Errorf("unable to inspect reference authorization: %w", err)
+		return fmt.Errorf("unable to inspect reference authorization: %w", err)
 	}
 	switch inspectAuthorization["predicate_type"] {
 	case authorizationsv01.PredicateType:
+		if hat != "" {
+			// Hats are not supported in v0.1 of the predicate
+			return authorizations.ErrInvalidAuthorization
+		}
 		if err := authorizationsv01.Validate(env, refName, fromID, toID); err != nil {
-			return nil, err
+			return err
 		}
 	case authorizationsv02.PredicateType:
-		if err := authorizationsv02.Validate(env, refName, fromID, toID); err != nil {
-			return nil, err
+		if err := authorizationsv02.ValidateWithHat(env, refName, fromID, toID, hat); err != nil {
+			return err
 		}
 	default:
-		return nil, authorizations.ErrUnknownAuthorizationVersion
+		return authorizations.ErrUnknownAuthorizationVersion
 	}
 
-	return env, nil
-}
-
-// ReferenceAuthorizationPath constructs the expected path on-disk for the
-// reference authorization attestation.
-func ReferenceAuthorizationPath(refName, fromID, toID string) string {
-	return path.Join(refName, fmt.Sprintf("%s-%s", fromID, toID))
+	return nil
 }
@@ -265,6 +265,61 @@ func TestGetReferenceAuthorizationFor(t *testing.T) {
 	})
 }
 
+func TestHattedReferenceAuthorization(t *testing.T) {
+	testRef := "refs/heads/main"
+	testID := gitinterface.ZeroHash.String()
+
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	unhatted := createReferenceAuthorizationAttestationEnvelopes(t, testRef, testID, testID, false)
+	securityHat := createHattedReferenceAuthorizationAttestationEnvelope(t, testRef, testID, testID, "security")
+	maintainersHat := createHattedReferenceAuthorizationAttestationEnvelope(t, testRef, testID, testID, "maintainers")
+
+	attestations := &Attestations{}
+
+	err := attestations.SetReferenceAuthorization(repo, unhatted, testRef, testID, testID)
+	require.Nil(t, err)
+
+	// An authorization cannot be set for a different hat than the one it declares
+	err = attestations.SetHattedReferenceAuthorization(repo, securityHat, testRef, testID, testID, "maintainers")
+	assert.ErrorIs(t, err, authorizations.ErrInvalidAuthorization)
+
+	// A hatted authorization cannot be set as an unhatted one
+	err = attestations.SetReferenceAuthorization(repo, securityHat, testRef, testID, testID)
+	assert.ErrorIs(t, err, authorizations.ErrInvalidAuthorization)
+
+	err = attestations.SetHattedReferenceAuthorization(repo, securityHat, testRef, testID, testID, "invalid/hat")
+	assert.ErrorIs(t, err, authorizations.ErrInvalidHat)
+
+	err = attestations.SetHattedReferenceAuthorization(repo, securityHat, testRef, testID, testID, "security")
+	assert.Nil(t, err)
+	err = attestations.SetHattedReferenceAuthorization(repo, maintainersHat, testRef, testID, testID, "maintainers")
+	assert.Nil(t, err)
+
+	env, err := attestations.GetHattedReferenceAuthorizationFor(repo, testRef, testID, testID, "security")
+	assert.Nil(t, err)
+	assert.Equal(t, securityHat, env)
+
+	env, err = attestations.GetReferenceAuthorizationFor(repo, testRef, testID, testID)
+	assert.Nil(t, err)
+	assert.Equal(t, unhatted, env)
+
+	hattedAuthorizations, err := attestations.GetHattedReferenceAuthorizationsFor(repo, testRef, testID, testID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*sslibdsse.Envelope{"security": securityHat, "maintainers": maintainersHat}, hattedAuthorizations)
+
+	err = attestations.RemoveHattedReferenceAuthorization(testRef, testID, testID, "security")
+	assert.Nil(t, err)
+
+	_, err = attestations.GetHattedReferenceAuthorizationFor(repo, testRef, testID, testID, "security")
+	assert.ErrorIs(t, err, authorizations.ErrAuthorizationNotFound)
+
+	hattedAuthorizations, err = attestations.GetHattedReferenceAuthorizationsFor(repo, testRef, testID, testID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*sslibdsse.Envelope{"maintainers": maintainersHat}, hattedAuthorizations)
+}
+
 func createReferenceAuthorizationAttestationEnvelopes(t *testing.T, refName, fromID, toID string, tag bool) *sslibdsse.Envelope {
 	t.Helper()
 
@@ -321,3 +376,19 @@ func createReferenceAuthorizationAttestationEnvelopeWithPredicateType(t *testing
 
 	return env
 }
+
+func createHattedReferenceAuthorizationAttestationEnvelope(t *testing.T, refName, fromID, toID, hat string) *sslibdsse.Envelope {
+	t.Helper()
+
+	authorization, err := NewReferenceAuthorizationForCommitWithHat(refName, fromID, toID, hat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := dsse.CreateEnvelope(authorization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return env
+}
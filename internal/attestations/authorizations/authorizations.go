@@ -9,6 +9,7 @@ var (
 	ErrInvalidAuthorization        = errors.New("authorization attestation does not match expected details")
 	ErrAuthorizationNotFound       = errors.New("requested authorization not found")
 	ErrUnknownAuthorizationVersion = errors.New("unknown reference authorization version")
+	ErrInvalidHat                  = errors.New("hat must be non-empty and cannot contain '/'")
 )
 
 // ReferenceAuthorization represents an attestation that approves a change to a
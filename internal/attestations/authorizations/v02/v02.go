@@ -23,6 +23,7 @@ const (
 	targetRefKey       = "targetRef"
 	fromIDKey          = "fromID"
 	targetIDKey        = "targetID"
+	hatKey             = "hat"
 )
 
 // ReferenceAuthorization is a lightweight record of a detached authorization in
@@ -32,6 +33,11 @@ type ReferenceAuthorization struct {
 	TargetRef string `json:"targetRef"`
 	FromID    string `json:"fromID"`
 	TargetID  string `json:"targetID"`
+
+	// Hat is the role, such as a team in the policy, that the signers of the
+	// authorization act in. When set, the authorization counts only towards
+	// that role's threshold.
+	Hat string `json:"hat,omitempty"`
 }
 
 func (r *ReferenceAuthorization) GetRef() string {
@@ -46,6 +52,10 @@ func (r *ReferenceAuthorization) GetTargetID() string {
 	return r.TargetID
 }
 
+func (r *ReferenceAuthorization) GetHat() string {
+	return r.Hat
+}
+
 // NewReferenceAuthorizationForCommit creates a new reference authorization for
 // the provided information. The authorization is embedded in an in-toto
 // "statement" and returned with the appropriate "predicate type" set. The
@@ -53,7 +63,15 @@ func (r *ReferenceAuthorization) GetTargetID() string {
 // authorized by invoking this function. The targetID is expected to be the Git
 // tree ID of the resultant commit.
 func NewReferenceAuthorizationForCommit(targetRef, fromID, targetID string) (*ita.Statement, error) {
-	predicateStruct, err := newReferenceAuthorizationStruct(targetRef, fromID, targetID)
+	return NewReferenceAuthorizationForCommitWithHat(targetRef, fromID, targetID, "")
+}
+
+// NewReferenceAuthorizationForCommitWithHat creates a new reference
+// authorization for a commit like NewReferenceAuthorizationForCommit, recording
+// the hat its signers wear. An empty hat creates an authorization that is not
+// restricted to any role.
+func NewReferenceAuthorizationForCommitWithHat(targetRef, fromID, targetID, hat string) (*ita.Statement, error) {
+	predicateStruct, err := newReferenceAuthorizationStruct(targetRef, fromID, targetID, hat)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +95,15 @@ func NewReferenceAuthorizationForCommit(targetRef, fromID, targetID string) (*it
 // invoking this function. The targetID is expected to be the ID of the commit
 // the tag will point to.
 func NewReferenceAuthorizationForTag(targetRef, fromID, targetID string) (*ita.Statement, error) {
-	predicateStruct, err := newReferenceAuthorizationStruct(targetRef, fromID, targetID)
+	return NewReferenceAuthorizationForTagWithHat(targetRef, fromID, targetID, "")
+}
+
+// NewReferenceAuthorizationForTagWithHat creates a new reference authorization
+// for a tag like NewReferenceAuthorizationForTag, recording the hat its signers
+// wear. An empty hat creates an authorization that is not restricted to any
+// role.
+func NewReferenceAuthorizationForTagWithHat(targetRef, fromID, targetID, hat string) (*ita.Statement, error) {
+	predicateStruct, err := newReferenceAuthorizationStruct(targetRef, fromID, targetID, hat)
 	if err != nil {
 		return nil, err
 	}
@@ -95,8 +121,15 @@ func NewReferenceAuthorizationForTag(targetRef, fromID, targetID string) (*ita.S
 }
 
 // Validate checks that the returned envelope contains the expected in-toto
-// attestation and predicate contents.
+// attestation and predicate contents. The authorization must not record a hat.
 func Validate(env *sslibdsse.Envelope, targetRef, fromID, targetID string) error {
+	return ValidateWithHat(env, targetRef, fromID, targetID, "")
+}
+
+// ValidateWithHat checks that the returned envelope contains the expected
+// in-toto attestation and predicate contents, including the hat worn by the
+// authorization's signers.
+func ValidateWithHat(env *sslibdsse.Envelope, targetRef, fromID, targetID, hat string) error {
 	payload, err := env.DecodeB64Payload()
 	if err != nil {
 		return err
@@ -141,14 +174,21 @@ func Validate(env *sslibdsse.Envelope, targetRef, fromID, targetID string) error
 		return authorizations.ErrInvalidAuthorization
 	}
 
+	// The hat is omitted when unset
+	predicateHat, _ := predicate[hatKey].(string)
+	if predicateHat != hat {
+		return authorizations.ErrInvalidAuthorization
+	}
+
 	return nil
 }
 
-func newReferenceAuthorizationStruct(targetRef, fromID, targetID string) (*structpb.Struct, error) {
+func newReferenceAuthorizationStruct(targetRef, fromID, targetID, hat string) (*structpb.Struct, error) {
 	predicate := &ReferenceAuthorization{
 		TargetRef: targetRef,
 		FromID:    fromID,
 		TargetID:  targetID,
+		Hat:       hat,
 	}
 
 	return common.PredicateToPBStruct(predicate)
@@ -23,11 +23,13 @@ func TestGetters(t *testing.T) {
 		TargetRef: testRef,
 		FromID:    testID,
 		TargetID:  testID,
+		Hat:       "security",
 	}
 
 	assert.Equal(t, testRef, authorization.GetRef())
 	assert.Equal(t, testID, authorization.GetFromID())
 	assert.Equal(t, testID, authorization.GetTargetID())
+	assert.Equal(t, "security", authorization.GetHat())
 }
 
 func TestNewReferenceAuthorization(t *testing.T) {
@@ -80,6 +82,24 @@ func TestNewReferenceAuthorization(t *testing.T) {
 		assert.Equal(t, predicate[targetIDKey], testID)
 		assert.Equal(t, predicate[fromIDKey], testID)
 	})
+
+	t.Run("with hat", func(t *testing.T) {
+		testRef := "refs/heads/main"
+		testID := gitinterface.ZeroHash.String()
+
+		authorization, err := NewReferenceAuthorizationForCommitWithHat(testRef, testID, testID, "security")
+		assert.Nil(t, err)
+
+		predicate := authorization.Predicate.AsMap()
+		assert.Equal(t, predicate[targetRefKey], testRef)
+		assert.Equal(t, predicate[hatKey], "security")
+
+		authorization, err = NewReferenceAuthorizationForCommit(testRef, testID, testID)
+		assert.Nil(t, err)
+
+		predicate = authorization.Predicate.AsMap()
+		assert.NotContains(t, predicate, hatKey)
+	})
 }
 
 func TestValidate(t *testing.T) {
@@ -109,6 +129,30 @@ func TestValidate(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("with hat", func(t *testing.T) {
+		testRef := "refs/heads/main"
+		testID := gitinterface.ZeroHash.String()
+
+		authorization, err := NewReferenceAuthorizationForCommitWithHat(testRef, testID, testID, "security")
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = ValidateWithHat(env, testRef, testID, testID, "security")
+		assert.Nil(t, err)
+
+		err = ValidateWithHat(env, testRef, testID, testID, "maintainers")
+		assert.ErrorIs(t, err, authorizations.ErrInvalidAuthorization)
+
+		// A hatted authorization cannot be used as one without a hat
+		err = Validate(env, testRef, testID, testID)
+		assert.ErrorIs(t, err, authorizations.ErrInvalidAuthorization)
+	})
+
 	t.Run("invalid subject", func(t *testing.T) {
 		testRef := "refs/heads/main"
 		testID := gitinterface.ZeroHash.String()
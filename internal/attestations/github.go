@@ -57,6 +57,13 @@ func NewGitHubPullRequestApprovalAttestation(targetRef, fromRevisionID, targetTr
 	return githubv01.NewPullRequestApprovalAttestation(targetRef, fromRevisionID, targetTreeID, approvers, dismissedApprovers)
 }
 
+// NewGitHubPullRequestApprovalAttestationWithHats creates a new GitHub pull
+// request approval attestation that additionally records the hat, such as a
+// team in the policy, that each approver in `hats` approved the change in.
+func NewGitHubPullRequestApprovalAttestationWithHats(targetRef, fromRevisionID, targetTreeID string, approvers, dismissedApprovers []string, hats map[string]string) (*ita.Statement, error) {
+	return githubv01.NewPullRequestApprovalAttestationWithHats(targetRef, fromRevisionID, targetTreeID, approvers, dismissedApprovers, hats)
+}
+
 // SetGitHubPullRequestApprovalAttestation writes the new GitHub pull request
 // approval attestation to the object store and tracks it in the current
 // attestations state. The refName, fromRevisionID, targetTreeID parameters are
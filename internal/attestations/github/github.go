@@ -30,5 +30,9 @@ type PullRequestApprovalAttestation interface {
 	// their review.
 	GetDismissedApprovers() []string
 
+	// GetApproverHats returns the roles that approvers declared they approved
+	// the change in, keyed by approver.
+	GetApproverHats() map[string]string
+
 	authorizations.ReferenceAuthorization
 }
@@ -26,6 +26,11 @@ type PullRequestApprovalAttestation struct {
 	// their approval.
 	DismissedApprovers *set.Set[string] `json:"dismissedApprovers"`
 
+	// Hats maps approvers to the role, such as a team in the policy, they
+	// approved the change in. Approvers without a hat are not restricted to a
+	// role.
+	Hats map[string]string `json:"hats,omitempty"`
+
 	*authorizationsv01.ReferenceAuthorization
 }
 
@@ -37,12 +42,24 @@ func (pra *PullRequestApprovalAttestation) GetDismissedApprovers() []string {
 	return pra.DismissedApprovers.Contents()
 }
 
+func (pra *PullRequestApprovalAttestation) GetApproverHats() map[string]string {
+	return pra.Hats
+}
+
 // NewPullRequestApprovalAttestation creates a new GitHub pull request approval
 // attestation for the provided information. The attestation is embedded in an
 // in-toto "statement" and returned with the appropriate "predicate type" set.
 // The `fromTargetID` and `toTargetID` specify the change to `targetRef` that is
 // approved on the corresponding GitHub pull request.
 func NewPullRequestApprovalAttestation(targetRef, fromRevisionID, targetTreeID string, approvers, dismissedApprovers []string) (*ita.Statement, error) {
+	return NewPullRequestApprovalAttestationWithHats(targetRef, fromRevisionID, targetTreeID, approvers, dismissedApprovers, nil)
+}
+
+// NewPullRequestApprovalAttestationWithHats creates a new GitHub pull request
+// approval attestation like NewPullRequestApprovalAttestation, additionally
+// recording the hats approvers wear. Hats for identities that are not current
+// approvers are dropped.
+func NewPullRequestApprovalAttestationWithHats(targetRef, fromRevisionID, targetTreeID string, approvers, dismissedApprovers []string, hats map[string]string) (*ita.Statement, error) {
 	if len(approvers) == 0 && len(dismissedApprovers) == 0 {
 		return nil, github.ErrInvalidPullRequestApprovalAttestation
 	}
@@ -57,6 +74,17 @@ func NewPullRequestApprovalAttestation(targetRef, fromRevisionID, targetTreeID s
 		DismissedApprovers: set.NewSetFromItems(dismissedApprovers...),
 	}
 
+	for approver, hat := range hats {
+		if hat == "" || !predicate.Approvers.Has(approver) {
+			continue
+		}
+
+		if predicate.Hats == nil {
+			predicate.Hats = map[string]string{}
+		}
+		predicate.Hats[approver] = hat
+	}
+
 	predicateStruct, err := common.PredicateToPBStruct(predicate)
 	if err != nil {
 		return nil, err
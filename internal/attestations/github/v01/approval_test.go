@@ -32,6 +32,7 @@ func TestGetters(t *testing.T) {
 
 	assert.Equal(t, approvers.Contents(), authorization.GetApprovers())
 	assert.Equal(t, approvers.Contents(), authorization.GetDismissedApprovers())
+	assert.Nil(t, authorization.GetApproverHats())
 }
 
 func TestNewGitHubPullRequestApprovalAttestation(t *testing.T) {
@@ -66,6 +67,29 @@ func TestNewGitHubPullRequestApprovalAttestation(t *testing.T) {
 	assert.Equal(t, approvers[0], predicate["approvers"].([]any)[0])
 }
 
+func TestNewGitHubPullRequestApprovalAttestationWithHats(t *testing.T) {
+	testRef := "refs/heads/main"
+	testID := gitinterface.ZeroHash.String()
+	approvers := []string{"jane.doe@example.com", "john.doe@example.com"}
+	hats := map[string]string{
+		"jane.doe@example.com":  "security",
+		"john.doe@example.com":  "",
+		"alice.doe@example.com": "maintainers", // not an approver
+	}
+
+	approvalAttestation, err := NewPullRequestApprovalAttestationWithHats(testRef, testID, testID, approvers, nil, hats)
+	assert.Nil(t, err)
+
+	predicate := approvalAttestation.Predicate.AsMap()
+	assert.Equal(t, map[string]any{"jane.doe@example.com": "security"}, predicate["hats"])
+
+	approvalAttestation, err = NewPullRequestApprovalAttestation(testRef, testID, testID, approvers, nil)
+	assert.Nil(t, err)
+
+	predicate = approvalAttestation.Predicate.AsMap()
+	assert.NotContains(t, predicate, "hats")
+}
+
 func TestValidatePullRequestApproval(t *testing.T) {
 	testRef := "refs/heads/main"
 	testAnotherRef := "refs/heads/feature"
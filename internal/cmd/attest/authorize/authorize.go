@@ -16,6 +16,7 @@ type options struct {
 	p       *persistent.Options
 	fromRef string
 	revoke  bool
+	hat     string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -35,6 +36,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		false,
 		"revoke existing authorization",
 	)
+
+	cmd.Flags().StringVar(
+		&o.hat,
+		"hat",
+		"",
+		"role, such as a team in the policy, that the authorization is made in",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
@@ -48,15 +56,19 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	opts := []attestopts.Option{}
+	if o.hat != "" {
+		opts = append(opts, attestopts.WithHat(o.hat))
+	}
+
 	if o.revoke {
 		if len(args) < 3 {
 			return fmt.Errorf("insufficient parameters for revoking authorization, requires <targetRef> <fromID> <targetTreeID>")
 		}
 
-		return repo.RemoveReferenceAuthorization(cmd.Context(), signer, args[0], args[1], args[2], true)
+		return repo.RemoveReferenceAuthorization(cmd.Context(), signer, args[0], args[1], args[2], true, opts...)
 	}
 
-	opts := []attestopts.Option{}
 	if o.p.WithRSLEntry {
 		opts = append(opts, attestopts.WithRSLEntry())
 	}
@@ -69,7 +81,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "authorize",
 		Short:             "Add or revoke reference authorization",
-		Long:              `Authorize or revoke permission to merge changes from one ref to another. Use '--from-ref' to specify the source reference.`,
+		Long:              `Authorize or revoke permission to merge changes from one ref to another. Use '--from-ref' to specify the source reference. Use '--hat' to declare the role, such as a team in the policy, that the authorization is made in; it then only counts towards that team's threshold.`,
 		Args:              cobra.MinimumNArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -20,6 +20,7 @@ type options struct {
 	pullRequestNumber int
 	reviewID          int64
 	approver          string
+	hat               string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -61,6 +62,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"identity of the reviewer who approved the change",
 	)
 	cmd.MarkFlagRequired("approver") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.hat,
+		"hat",
+		"",
+		"role, such as a team in the policy, that the reviewer approved the change in",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
@@ -83,6 +91,9 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 	if o.p.WithRSLEntry {
 		opts = append(opts, githubopts.WithRSLEntry())
 	}
+	if o.hat != "" {
+		opts = append(opts, githubopts.WithHat(o.hat))
+	}
 
 	return repo.AddGitHubPullRequestApprover(cmd.Context(), signer, repositoryParts[0], repositoryParts[1], o.pullRequestNumber, o.reviewID, o.approver, true, opts...)
 }
@@ -92,7 +103,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "record-approval",
 		Short: "Record GitHub pull request approval",
-		Long:  `The 'record-approval' command creates an attestation for an approval action on a GitHub pull request. This command requires the repository in the {owner}/{repo} format, the pull request number, the specific review ID, and the identity of the reviewer who approved the pull request. The command also supports custom GitHub base URLs for enterprise GitHub instances, with the flag '--base-URL'.`,
+		Long:  `The 'record-approval' command creates an attestation for an approval action on a GitHub pull request. This command requires the repository in the {owner}/{repo} format, the pull request number, the specific review ID, and the identity of the reviewer who approved the pull request. The command also supports custom GitHub base URLs for enterprise GitHub instances, with the flag '--base-URL'. The role, such as a team in the policy, that the reviewer approved in can be recorded with the flag '--hat'.`,
 		RunE:  o.Run,
 	}
 	o.AddFlags(cmd)
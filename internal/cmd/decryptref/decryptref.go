@@ -0,0 +1,58 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decryptref
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	keyPath   string
+	outputDir string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.keyPath,
+		"key",
+		"k",
+		"",
+		"path to the SSH private key to decrypt with",
+	)
+	cmd.MarkFlagRequired("key") //nolint:errcheck
+
+	cmd.Flags().StringVarP(
+		&o.outputDir,
+		"output",
+		"o",
+		"",
+		"empty or new directory to write the decrypted contents to",
+	)
+	cmd.MarkFlagRequired("output") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	return repo.DecryptReference(cmd.Context(), args[0], o.keyPath, o.outputDir)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "decrypt-ref <ref>",
+		Short:             "Decrypt the contents of a read-restricted reference",
+		Long:              "The 'decrypt-ref' command writes the decrypted contents of the tip of a reference encrypted using 'encrypt-ref' to the output directory, restoring executable files and symbolic links. The output directory must be empty or not exist yet, and symbolic links that point outside it are rejected. The SSH private key must be unencrypted and belong to one of the principals the contents were encrypted for.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
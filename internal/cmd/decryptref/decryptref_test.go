@@ -0,0 +1,111 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decryptref
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	rootopts "github.com/gittuf/gittuf/experimental/gittuf/options/root"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecryptRef(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "refs/heads/embargo/fix", "--key", "test-key", "--output", "out")
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitRepo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		keyPath := filepath.Join(tmpDir, "test-key")
+		if err := os.WriteFile(keyPath, artifacts.SSHED25519Private, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(keyPath+".pub", artifacts.SSHED25519PublicSSH, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		repo, err := gittuf.LoadRepository(".")
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := gittuf.LoadSigner(repo, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		readerKeyID, err := signer.KeyID()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repo.InitializeRoot(t.Context(), signer, false, rootopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.AddGlobalRuleRestrictRead(t.Context(), signer, "restrict-embargo", []string{"git:refs/heads/embargo/*"}, []string{readerKeyID}, false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.ApplyPolicy(t.Context(), "", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		blobID, err := gitRepo.WriteBlob([]byte("embargoed fix"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := gitinterface.NewTreeBuilder(gitRepo).WriteTreeFromEntries([]gitinterface.TreeEntry{gitinterface.NewEntryBlob("src/fix.go", blobID)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := gitRepo.Commit(treeID, "refs/heads/fix", "Add fix\n", false); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.EncryptReference(t.Context(), "refs/heads/fix", "refs/heads/embargo/fix", false); err != nil {
+			t.Fatal(err)
+		}
+
+		outputDir := filepath.Join(tmpDir, "out")
+		_, _, _, err = cmd.ExecuteCommandC(New(), "refs/heads/embargo/fix", "--key", keyPath, "--output", outputDir)
+		assert.NoError(t, err)
+
+		contents, err := os.ReadFile(filepath.Join(outputDir, "src", "fix.go"))
+		require.Nil(t, err)
+		assert.Equal(t, []byte("embargoed fix"), contents)
+	})
+}
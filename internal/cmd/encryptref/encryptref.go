@@ -0,0 +1,45 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encryptref
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	commitID, err := repo.EncryptReference(cmd.Context(), args[0], args[1], true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), commitID.String())
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "encrypt-ref <source-ref> <target-ref>",
+		Short:             "Encrypt the contents of a reference for its authorized readers",
+		Long:              "The 'encrypt-ref' command commits the contents of the source reference to the target reference with every file encrypted for the principals allowed to read the target reference by the policy's restrict-read global rules. Contents are encrypted for the SSH keys of each reader, and every reader must have at least one SSH key; GPG and Sigstore keys cannot be encrypted for. File paths and modes are not encrypted. The target reference must be fully qualified if it does not exist yet. The new commit must then be recorded in the RSL as with any other change.",
+		Args:              cobra.ExactArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -8,6 +8,7 @@ import (
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +19,11 @@ type options struct {
 	authorizedKeys         []string
 	authorizedPrincipalIDs []string
 	rulePatterns           []string
+	excludePatterns        []string
 	threshold              int
+	description            string
+	contact                string
+	link                   string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -49,7 +54,7 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		&o.authorizedPrincipalIDs,
 		"authorize",
 		[]string{},
-		"authorize the principal IDs for the rule",
+		"authorize the principal IDs for the rule (persons, keys, or teams)",
 	)
 	cmd.MarkFlagsOneRequired("authorize", "authorize-key")
 
@@ -61,12 +66,40 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 	)
 	cmd.MarkFlagRequired("rule-pattern") //nolint:errcheck
 
+	cmd.Flags().StringArrayVar(
+		&o.excludePatterns,
+		"exclude-pattern",
+		[]string{},
+		"patterns used to identify namespaces excluded from the rule",
+	)
+
 	cmd.Flags().IntVar(
 		&o.threshold,
 		"threshold",
 		1,
 		"threshold of required valid signatures",
 	)
+
+	cmd.Flags().StringVar(
+		&o.description,
+		"description",
+		"",
+		"description of why the rule exists",
+	)
+
+	cmd.Flags().StringVar(
+		&o.contact,
+		"contact",
+		"",
+		"who to contact about the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.link,
+		"link",
+		"",
+		"link to further context for the rule, such as a ticket",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
@@ -91,11 +124,25 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 	}
 	authorizedPrincipalIDs = append(authorizedPrincipalIDs, o.authorizedPrincipalIDs...)
 
+	rulePatterns := append([]string{}, o.rulePatterns...)
+	for _, pattern := range o.excludePatterns {
+		rulePatterns = append(rulePatterns, tuf.ExclusionPatternPrefix+pattern)
+	}
+
 	opts := []trustpolicyopts.Option{}
 	if o.p.WithRSLEntry {
 		opts = append(opts, trustpolicyopts.WithRSLEntry())
 	}
-	return repo.AddDelegation(cmd.Context(), signer, o.policyName, o.ruleName, authorizedPrincipalIDs, o.rulePatterns, o.threshold, true, opts...)
+	if cmd.Flags().Changed("description") {
+		opts = append(opts, trustpolicyopts.WithRuleDescription(o.description))
+	}
+	if cmd.Flags().Changed("contact") {
+		opts = append(opts, trustpolicyopts.WithRuleContact(o.contact))
+	}
+	if cmd.Flags().Changed("link") {
+		opts = append(opts, trustpolicyopts.WithRuleLink(o.link))
+	}
+	return repo.AddDelegation(cmd.Context(), signer, o.policyName, o.ruleName, authorizedPrincipalIDs, rulePatterns, o.threshold, true, opts...)
 }
 
 func New(persistent *persistent.Options) *cobra.Command {
@@ -103,7 +150,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "add-rule",
 		Short:             "Add a new rule to a policy file",
-		Long:              "The 'add-rule' command adds a new rule to a gittuf policy file. It is used to authorize a set of principals to sign changes to the namespaces the rule protects, subject to a signature threshold.",
+		Long:              "The 'add-rule' command adds a new rule to a gittuf policy file. It is used to authorize a set of principals to sign changes to the namespaces the rule protects, subject to a signature threshold. A team authorized by the rule counts towards the threshold once its own threshold of members has signed, and a member of several teams authorized by the rule counts towards only one of them. A rule can also record a description of why it exists, who to contact about it, and a link to further context such as a ticket. A rule pattern is a glob by default. Patterns prefixed with 're:' are regular expressions that must match the entire namespace and must start with a literal 'git:' or 'file:', and the 'class:branches', 'class:tags', 'class:default-branch', and 'class:branches-except-default' patterns match classes of Git references, where the default branch is the branch the repository's symbolic HEAD points to, unless a default branch is declared using 'gittuf trust set-default-branch'.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/policy"
+	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
@@ -153,8 +154,22 @@ func TestAddRule(t *testing.T) {
 		pOpts := &persistent.Options{
 			SigningKey: keyPath,
 		}
-		_, _, _, err = cmd.ExecuteCommandC(New(pOpts), "--rule-name", "test-rule", "--authorize", newKey.ID(), "--rule-pattern", "git:refs/heads/main", "--threshold", "1")
+		_, _, _, err = cmd.ExecuteCommandC(New(pOpts), "--rule-name", "test-rule", "--authorize", newKey.ID(), "--rule-pattern", "git:refs/heads/main", "--threshold", "1", "--description", "Protect the main branch", "--contact", "jane.doe@example.com", "--link", "https://example.com/issues/1")
 		assert.NoError(t, err)
+
+		state, err := policy.LoadCurrentState(t.Context(), repo.GetGitRepository(), policy.PolicyStagingRef, policyopts.BypassRSL())
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules := targetsMetadata.GetRules()
+		assert.Equal(t, "test-rule", rules[0].ID())
+		assert.Equal(t, "Protect the main branch", rules[0].GetDescription())
+		assert.Equal(t, "jane.doe@example.com", rules[0].GetContact())
+		assert.Equal(t, "https://example.com/issues/1", rules[0].GetLink())
 	})
 
 	t.Run("success with authorize-key", func(t *testing.T) {
@@ -280,5 +295,25 @@ func TestAddRule(t *testing.T) {
 		}
 		_, _, _, err = cmd.ExecuteCommandC(New(pOpts), "--rule-name", "test-rule", "--authorize", newKey.ID(), "--rule-pattern", "git:refs/heads/main")
 		assert.NoError(t, err)
+
+		_, _, _, err = cmd.ExecuteCommandC(New(pOpts), "--rule-name", "test-rule-with-exclusions", "--authorize", newKey.ID(), "--rule-pattern", "file:src/*", "--exclude-pattern", "file:src/generated/*")
+		assert.NoError(t, err)
+
+		state, err := policy.LoadCurrentState(t.Context(), repo.GetGitRepository(), policy.PolicyStagingRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules := targetsMetadata.GetRules()
+		assert.Equal(t, "test-rule-with-exclusions", rules[1].ID())
+		assert.Equal(t, []string{"file:src/*"}, rules[1].GetProtectedNamespaces())
+		assert.Equal(t, []string{"file:src/generated/*"}, rules[1].GetExcludedNamespaces())
+
+		// A rule cannot consist only of exclusions
+		_, _, _, err = cmd.ExecuteCommandC(New(pOpts), "--rule-name", "test-rule-only-exclusions", "--authorize", newKey.ID(), "--rule-pattern", "!file:src/*")
+		assert.ErrorIs(t, err, tuf.ErrRuleHasOnlyExclusionPatterns)
 	})
 }
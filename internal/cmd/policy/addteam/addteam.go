@@ -0,0 +1,114 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package addteam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p              *persistent.Options
+	policyName     string
+	teamID         string
+	members        []string
+	threshold      int
+	customMetadata []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add team to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.teamID,
+		"team-ID",
+		"",
+		"team ID",
+	)
+	cmd.MarkFlagRequired("team-ID") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.members,
+		"member",
+		[]string{},
+		"ID of a person or key in the policy file that is a member of the team",
+	)
+	cmd.MarkFlagRequired("member") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.threshold,
+		"threshold",
+		1,
+		"number of team members required to act on behalf of the team",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.customMetadata,
+		"custom",
+		[]string{},
+		"additional custom metadata in the form KEY=VALUE",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	custom := map[string]string{}
+	for _, customEntry := range o.customMetadata {
+		split := strings.Split(customEntry, "=")
+		if len(split) != 2 {
+			return fmt.Errorf("invalid format for custom metadata '%s'", customEntry)
+		}
+		custom[split[0]] = split[1]
+	}
+
+	team := &tufv02.Team{
+		TeamID:       o.teamID,
+		PrincipalIDs: set.NewSetFromItems(o.members...),
+		Threshold:    o.threshold,
+		Custom:       custom,
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.AddPrincipalToTargets(cmd.Context(), signer, o.policyName, []tuf.Principal{team}, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-team",
+		Short:             "Add a team of trusted principals to a policy file",
+		Long:              "The 'add-team' command adds a team to a gittuf policy file. A team groups persons or keys already declared in the policy file and carries its own threshold, which is the number of members required to act on behalf of the team. Once added, the team can be named in policy rules like any other principal.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+const indentString = "    "
+
+var actionSymbols = map[string]string{
+	policy.DiffActionAdded:   "+",
+	policy.DiffActionRemoved: "-",
+	policy.DiffActionChanged: "~",
+}
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	var fromEntryID, toEntryID string
+	if len(args) > 0 {
+		fromEntryID = args[0]
+	}
+	if len(args) > 1 {
+		toEntryID = args[1]
+	}
+
+	changes, err := repo.DiffPolicy(cmd.Context(), fromEntryID, toEntryID)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	if len(changes) == 0 {
+		fmt.Fprintln(stdOut, "No policy changes")
+		return nil
+	}
+
+	currentScope := ""
+	for _, change := range changes {
+		if change.Scope != currentScope {
+			currentScope = change.Scope
+			if currentScope == policy.DiffScopeRoot {
+				fmt.Fprintln(stdOut, "Root of trust:")
+			} else {
+				fmt.Fprintf(stdOut, "Rule file '%s':\n", currentScope)
+			}
+		}
+
+		fmt.Fprintf(stdOut, "%s%s %s '%s'\n", indentString, actionSymbols[change.Action], strings.ReplaceAll(change.Kind, "-", " "), change.Name)
+		for _, detail := range change.Details {
+			fmt.Fprintf(stdOut, "%s%s\n", strings.Repeat(indentString, 2), detail)
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "diff [<from-entry>] [<to-entry>]",
+		Short:             "Show changes between two policy states",
+		Long:              "The 'diff' command shows the semantic differences between the policy states recorded by two RSL entries. It reports added, removed, and changed principals, roles and their thresholds, rules, global rules, hooks, propagation directives, and controller and network repositories. By default, the currently applied policy is compared with the staged policy, so that changes can be reviewed before they are signed or applied. If only one entry is specified, its policy state is compared with the staged policy.",
+		Args:              cobra.MaximumNArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
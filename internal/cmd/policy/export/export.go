@@ -0,0 +1,86 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatCodeOwners    = "codeowners"
+	formatGitHubRuleset = "github-ruleset"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+type options struct {
+	format           string
+	targetRef        string
+	identityProvider string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.format,
+		"format",
+		"",
+		fmt.Sprintf("format to export policy in (%s, %s)", formatCodeOwners, formatGitHubRuleset),
+	)
+	cmd.MarkFlagRequired("format") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.targetRef,
+		"target-ref",
+		"policy",
+		"specify which policy ref should be exported",
+	)
+
+	cmd.Flags().StringVar(
+		&o.identityProvider,
+		"identity-provider",
+		"github",
+		"provider of the associated identities that persons are mapped to \"@user\" owners with",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	var exported []byte
+	switch o.format {
+	case formatCodeOwners:
+		exported, err = repo.ExportPolicyAsCodeOwners(cmd.Context(), o.targetRef, o.identityProvider)
+	case formatGitHubRuleset:
+		exported, err = repo.ExportPolicyAsGitHubRuleset(cmd.Context(), o.targetRef)
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnknownFormat, o.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), string(exported))
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "export",
+		Short:             "Export policy as a CODEOWNERS file or GitHub rulesets",
+		Long:              fmt.Sprintf("The 'export' command generates forge settings from the gittuf policy so they can be kept in sync with it. The '%s' format writes a CODEOWNERS file from the policy's file rules, mapping persons to owners using their associated identities. The '%s' format writes GitHub repository rulesets for the protected branches and tags, mapping rule thresholds to required approvals and global rules that block force pushes or require signed commits or linear history to the equivalent ruleset rules. Policy that has no equivalent in the chosen format is noted in comments or omitted with a warning.", formatCodeOwners, formatGitHubRuleset),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
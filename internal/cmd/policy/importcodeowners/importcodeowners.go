@@ -0,0 +1,92 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package importcodeowners
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p                *persistent.Options
+	policyName       string
+	codeOwnersPath   string
+	identityProvider string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		"targets",
+		"name of policy file to add imported rules to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.codeOwnersPath,
+		"file",
+		".github/CODEOWNERS",
+		"path to CODEOWNERS file to import",
+	)
+
+	cmd.Flags().StringVar(
+		&o.identityProvider,
+		"identity-provider",
+		"github",
+		"provider of the associated identities that \"@user\" owners are matched against",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	codeOwnersContents, err := os.ReadFile(o.codeOwnersPath)
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	importedRuleNames, unmappedOwners, err := repo.ImportCodeOwners(cmd.Context(), signer, o.policyName, codeOwnersContents, o.identityProvider, true, opts...)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	fmt.Fprintf(stdOut, "Imported %d rule(s) into policy '%s'\n", len(importedRuleNames), o.policyName)
+	for _, owner := range unmappedOwners {
+		fmt.Fprintf(stdOut, "Could not map owner '%s' to a principal\n", owner)
+	}
+
+	return nil
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "import-codeowners",
+		Short:             "Create file rules from a CODEOWNERS file",
+		Long:              "The 'import-codeowners' command creates file rules in the specified policy file from the entries of a CODEOWNERS file. Each entry becomes a rule with a threshold of one that excludes paths owned by later entries, preserving the precedence of later entries. Owners are mapped to principals in the policy file: \"@user\" to a person whose associated identity for the identity provider is the handle, \"@org/team\" to a team with that ID, and an email address to a person with the address as an associated identity. Owners that cannot be mapped are reported.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
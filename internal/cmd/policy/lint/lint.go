@@ -0,0 +1,100 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown output format")
+	ErrLintFindings  = errors.New("policy has lint findings")
+)
+
+type options struct {
+	targetRef string
+	format    string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.targetRef,
+		"target-ref",
+		"policy",
+		"specify which policy ref should be inspected",
+	)
+
+	cmd.Flags().StringVar(
+		&o.format,
+		"format",
+		formatText,
+		fmt.Sprintf("format to report findings in (%s, %s)", formatText, formatJSON),
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	if o.format != formatText && o.format != formatJSON {
+		return fmt.Errorf("%w: '%s'", ErrUnknownFormat, o.format)
+	}
+
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	findings, err := repo.LintPolicy(cmd.Context(), o.targetRef)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	switch o.format {
+	case formatJSON:
+		findingsJSON, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdOut, string(findingsJSON))
+	default:
+		if len(findings) == 0 {
+			fmt.Fprintln(stdOut, "No issues found")
+		}
+		for _, finding := range findings {
+			if finding.RuleFile != "" {
+				fmt.Fprintf(stdOut, "[%s] '%s' in rule file '%s': %s\n", finding.Check, finding.Name, finding.RuleFile, finding.Message)
+				continue
+			}
+			fmt.Fprintf(stdOut, "[%s] '%s': %s\n", finding.Check, finding.Name, finding.Message)
+		}
+	}
+
+	if len(findings) != 0 {
+		return fmt.Errorf("%w: %d finding(s)", ErrLintFindings, len(findings))
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "lint",
+		Short:             "Check policy for rules that never apply or cannot be met",
+		Long:              "The 'lint' command analyzes the root of trust and every rule file in the policy for mistakes that are easy to make with ordered and terminating rules. It reports rules shadowed by an earlier terminating rule, rule thresholds greater than the number of distinct principals they trust, principals that no rule or team references, rule files that no reachable rule delegates to, and global rules of the same type with overlapping or conflicting namespaces. Findings are reported as text or JSON, and the command exits with an error if there are any.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -5,12 +5,19 @@ package listrules
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/spf13/cobra"
 )
 
+// expiryWarningWindow is how close to expiring metadata must be for
+// list-rules to warn about it.
+const expiryWarningWindow = 30 * 24 * time.Hour
+
 type options struct {
 	targetRef string
 }
@@ -35,6 +42,32 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	expiryEnforced, err := repo.IsExpiryEnforced(cmd.Context(), o.targetRef)
+	if err != nil {
+		return err
+	}
+
+	// Expiring metadata only fails verification if expiry is enforced
+	if expiryEnforced {
+		expiries, err := repo.GetMetadataExpiries(cmd.Context(), o.targetRef)
+		if err != nil {
+			return err
+		}
+
+		roleNames := make([]string, 0, len(expiries))
+		for roleName := range expiries {
+			roleNames = append(roleNames, roleName)
+		}
+		slices.Sort(roleNames)
+
+		warningThreshold := time.Now().Add(expiryWarningWindow)
+		for _, roleName := range roleNames {
+			if expiries[roleName].Before(warningThreshold) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: metadata for '%s' expires at '%s'\n", roleName, expiries[roleName].Format(time.RFC3339))
+			}
+		}
+	}
+
 	stdOut := cmd.OutOrStdout()
 
 	// Iterate through the rules, they are already in order, and the depth tells us how to indent.
@@ -42,9 +75,18 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 
 	for i, curRule := range rules {
 		fmt.Fprintf(stdOut, strings.Repeat("    ", curRule.Depth)+"Rule %s:\n", curRule.Delegation.ID())
+		if description := curRule.Delegation.GetDescription(); description != "" {
+			fmt.Fprintln(stdOut, strings.Repeat("    ", curRule.Depth+1)+fmt.Sprintf("Description: %s", description))
+		}
+		if contact := curRule.Delegation.GetContact(); contact != "" {
+			fmt.Fprintln(stdOut, strings.Repeat("    ", curRule.Depth+1)+fmt.Sprintf("Contact: %s", contact))
+		}
+		if link := curRule.Delegation.GetLink(); link != "" {
+			fmt.Fprintln(stdOut, strings.Repeat("    ", curRule.Depth+1)+fmt.Sprintf("Link: %s", link))
+		}
 		gitpaths, filepaths := []string{}, []string{}
 		for _, path := range curRule.Delegation.GetProtectedNamespaces() {
-			if strings.HasPrefix(path, "git:") {
+			if tuf.IsGitReferencePattern(path) {
 				gitpaths = append(gitpaths, path)
 			} else {
 				filepaths = append(filepaths, path)
@@ -63,12 +105,22 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 			}
 		}
 
+		if excludedPaths := curRule.Delegation.GetExcludedNamespaces(); len(excludedPaths) > 0 {
+			fmt.Fprintln(stdOut, strings.Repeat("    ", curRule.Depth+1)+"Excluded:")
+			for _, v := range excludedPaths {
+				fmt.Fprintf(stdOut, strings.Repeat("    ", curRule.Depth+2)+"%s\n", v)
+			}
+		}
+
 		fmt.Fprintln(stdOut, strings.Repeat("    ", curRule.Depth+1)+"Authorized keys:")
 		for _, key := range curRule.Delegation.GetPrincipalIDs().Contents() {
 			fmt.Fprintf(stdOut, strings.Repeat("    ", curRule.Depth+2)+"%s\n", key)
 		}
 
 		fmt.Fprintln(stdOut, strings.Repeat("    ", curRule.Depth+1)+fmt.Sprintf("Required valid signatures: %d", curRule.Delegation.GetThreshold()))
+		if curRule.Delegation.RequiresSeparationOfDuties() {
+			fmt.Fprintln(stdOut, strings.Repeat("    ", curRule.Depth+1)+"Separation of duties: required")
+		}
 		if i < len(rules)-1 {
 			fmt.Fprintln(stdOut)
 		}
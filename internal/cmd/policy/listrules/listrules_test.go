@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	rootopts "github.com/gittuf/gittuf/experimental/gittuf/options/root"
@@ -98,7 +99,15 @@ func TestListRules(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if err := repo.AddDelegation(t.Context(), signer, policy.TargetsRoleName, "protect-tags", []string{newKey.ID()}, []string{"git:refs/tags/"}, 1, false, trustpolicyopts.WithRSLEntry()); err != nil {
+		if err := repo.AddDelegation(t.Context(), signer, policy.TargetsRoleName, "protect-tags", []string{newKey.ID()}, []string{"git:refs/tags/"}, 1, false, trustpolicyopts.WithRSLEntry(), trustpolicyopts.WithRuleDescription("Only maintainers cut releases"), trustpolicyopts.WithRuleContact("release-team@example.com"), trustpolicyopts.WithRuleLink("https://example.com/issues/1")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repo.EnableSeparationOfDuties(t.Context(), signer, policy.TargetsRoleName, "protect-tags", false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repo.AddDelegation(t.Context(), signer, policy.TargetsRoleName, "protect-src", []string{newKey.ID()}, []string{"file:src/*", "!file:src/generated/*"}, 1, false, trustpolicyopts.WithRSLEntry()); err != nil {
 			t.Fatal(err)
 		}
 
@@ -106,8 +115,9 @@ func TestListRules(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, stdout, _, err := cmd.ExecuteCommandC(New())
+		_, stdout, stderr, err := cmd.ExecuteCommandC(New())
 		assert.NoError(t, err)
+		assert.Empty(t, stderr.String())
 
 		out := strings.ReplaceAll(stdout.String(), "\r\n", "\n")
 		expectedProtectMain := fmt.Sprintf(
@@ -115,11 +125,41 @@ func TestListRules(t *testing.T) {
 			newKey.ID(),
 		)
 		expectedProtectTags := fmt.Sprintf(
-			"Rule protect-tags:\n    Refs affected:\n        git:refs/tags/\n    Authorized keys:\n        %s\n    Required valid signatures: 1",
+			"Rule protect-tags:\n    Description: Only maintainers cut releases\n    Contact: release-team@example.com\n    Link: https://example.com/issues/1\n    Refs affected:\n        git:refs/tags/\n    Authorized keys:\n        %s\n    Required valid signatures: 1\n    Separation of duties: required",
+			newKey.ID(),
+		)
+		expectedProtectSrc := fmt.Sprintf(
+			"Rule protect-src:\n    Paths affected:\n        file:src/*\n    Excluded:\n        file:src/generated/*\n    Authorized keys:\n        %s\n    Required valid signatures: 1\n",
 			newKey.ID(),
 		)
 		assert.Contains(t, out, expectedProtectMain)
 		assert.Contains(t, out, expectedProtectTags)
+		assert.Contains(t, out, expectedProtectSrc)
+
+		// Bring the rule file close to expiry to trigger the warning
+		if err := repo.SetTargetsExpiry(t.Context(), signer, policy.TargetsRoleName, time.Now().Add(24*time.Hour), false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.ApplyPolicy(t.Context(), "", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, stderr, err = cmd.ExecuteCommandC(New())
+		assert.NoError(t, err)
+		assert.Contains(t, stderr.String(), "Warning: metadata for 'targets' expires at")
+		assert.NotContains(t, stderr.String(), "'root'")
+
+		// Expiry is no longer enforced, so there is no warning
+		if err := repo.DisableRootExpiry(t.Context(), signer, false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.ApplyPolicy(t.Context(), "", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, stderr, err = cmd.ExecuteCommandC(New())
+		assert.NoError(t, err)
+		assert.Empty(t, stderr.String())
 	})
 
 	t.Run("invalid target ref", func(t *testing.T) {
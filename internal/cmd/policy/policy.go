@@ -7,18 +7,30 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd/policy/addkey"
 	"github.com/gittuf/gittuf/internal/cmd/policy/addperson"
 	"github.com/gittuf/gittuf/internal/cmd/policy/addrule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/addteam"
+	"github.com/gittuf/gittuf/internal/cmd/policy/diff"
+	"github.com/gittuf/gittuf/internal/cmd/policy/disableseparationofduties"
+	"github.com/gittuf/gittuf/internal/cmd/policy/enableseparationofduties"
+	"github.com/gittuf/gittuf/internal/cmd/policy/export"
+	"github.com/gittuf/gittuf/internal/cmd/policy/importcodeowners"
 	"github.com/gittuf/gittuf/internal/cmd/policy/incrementversion"
 	i "github.com/gittuf/gittuf/internal/cmd/policy/init"
+	"github.com/gittuf/gittuf/internal/cmd/policy/lint"
 	"github.com/gittuf/gittuf/internal/cmd/policy/listprincipals"
 	"github.com/gittuf/gittuf/internal/cmd/policy/listrules"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/cmd/policy/reconcile"
 	"github.com/gittuf/gittuf/internal/cmd/policy/removekey"
 	"github.com/gittuf/gittuf/internal/cmd/policy/removeperson"
 	"github.com/gittuf/gittuf/internal/cmd/policy/removerule"
 	"github.com/gittuf/gittuf/internal/cmd/policy/reorderrules"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setexpiry"
 	"github.com/gittuf/gittuf/internal/cmd/policy/sign"
+	"github.com/gittuf/gittuf/internal/cmd/policy/simulate"
+	"github.com/gittuf/gittuf/internal/cmd/policy/status"
 	"github.com/gittuf/gittuf/internal/cmd/policy/updateperson"
 	"github.com/gittuf/gittuf/internal/cmd/policy/updaterule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/whocan"
 	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/apply"
 	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/discard"
 	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/remote"
@@ -39,21 +51,33 @@ func New() *cobra.Command {
 	cmd.AddCommand(addkey.New(o))
 	cmd.AddCommand(addperson.New(o))
 	cmd.AddCommand(addrule.New(o))
+	cmd.AddCommand(addteam.New(o))
 	cmd.AddCommand(apply.New())
+	cmd.AddCommand(diff.New())
+	cmd.AddCommand(disableseparationofduties.New(o))
 	cmd.AddCommand(discard.New())
+	cmd.AddCommand(enableseparationofduties.New(o))
+	cmd.AddCommand(export.New())
 	cmd.AddCommand(i.New(o))
+	cmd.AddCommand(importcodeowners.New(o))
 	cmd.AddCommand(incrementversion.New(o))
+	cmd.AddCommand(lint.New())
 	cmd.AddCommand(listprincipals.New())
 	cmd.AddCommand(listrules.New())
+	cmd.AddCommand(reconcile.New(o))
 	cmd.AddCommand(remote.New())
 	cmd.AddCommand(removekey.New(o))
 	cmd.AddCommand(removeperson.New(o))
 	cmd.AddCommand(removerule.New(o))
 	cmd.AddCommand(reorderrules.New(o))
+	cmd.AddCommand(setexpiry.New(o))
 	cmd.AddCommand(sign.New(o))
+	cmd.AddCommand(simulate.New())
 	cmd.AddCommand(stage.New())
+	cmd.AddCommand(status.New())
 	cmd.AddCommand(updateperson.New(o))
 	cmd.AddCommand(updaterule.New(o))
+	cmd.AddCommand(whocan.New())
 
 	return cmd
 }
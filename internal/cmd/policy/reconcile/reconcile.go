@@ -0,0 +1,137 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconcile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+const indentString = "    "
+
+var ErrPolicyDrift = errors.New("staged policy does not match declaration")
+
+var actionSymbols = map[string]string{
+	policy.DiffActionAdded:   "+",
+	policy.DiffActionRemoved: "-",
+	policy.DiffActionChanged: "~",
+}
+
+type options struct {
+	p               *persistent.Options
+	declarationPath string
+	check           bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.declarationPath,
+		"file",
+		"f",
+		"",
+		"path to YAML or JSON policy declaration",
+	)
+	cmd.MarkFlagRequired("file") //nolint:errcheck
+
+	cmd.Flags().BoolVar(
+		&o.check,
+		"check",
+		false,
+		"report the changes needed without modifying the staged policy, exiting with an error if there are any",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	declarationContents, err := os.ReadFile(o.declarationPath)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+
+	if o.check {
+		changes, err := repo.PlanPolicyReconciliation(cmd.Context(), declarationContents)
+		if err != nil {
+			return err
+		}
+
+		if len(changes) == 0 {
+			fmt.Fprintln(stdOut, "Staged policy matches declaration")
+			return nil
+		}
+
+		printChanges(cmd, changes)
+		return fmt.Errorf("%w: %d change(s) needed", ErrPolicyDrift, len(changes))
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	changes, err := repo.ReconcilePolicy(cmd.Context(), signer, declarationContents, true, opts...)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(stdOut, "Staged policy matches declaration")
+		return nil
+	}
+
+	printChanges(cmd, changes)
+	return nil
+}
+
+func printChanges(cmd *cobra.Command, changes []*policy.Change) {
+	stdOut := cmd.OutOrStdout()
+
+	currentScope := ""
+	for _, change := range changes {
+		if change.Scope != currentScope {
+			currentScope = change.Scope
+			if currentScope == policy.DiffScopeRoot {
+				fmt.Fprintln(stdOut, "Root of trust:")
+			} else {
+				fmt.Fprintf(stdOut, "Rule file '%s':\n", currentScope)
+			}
+		}
+
+		fmt.Fprintf(stdOut, "%s%s %s '%s'\n", indentString, actionSymbols[change.Action], strings.ReplaceAll(change.Kind, "-", " "), change.Name)
+		for _, detail := range change.Details {
+			fmt.Fprintf(stdOut, "%s%s\n", strings.Repeat(indentString, 2), detail)
+		}
+	}
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "reconcile",
+		Short:             "Update the staged policy to match a policy declaration",
+		Long:              "The 'reconcile' command updates the staged policy to match a declarative YAML or JSON policy file describing keys, persons, teams, and rules of the primary rule file, and global rules and hooks of the root of trust. Only the items that differ from the declaration are added, updated, or removed, and the changes are recorded in a single commit to the policy staging ref. Keys are referenced as with the '--public-key' flag of other commands, and rules, teams, and hooks may refer to a declared key using the same reference. With '--check', the changes needed are reported without modifying the policy, and the command exits with an error if there are any, which is useful in CI.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
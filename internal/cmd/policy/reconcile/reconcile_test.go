@@ -0,0 +1,111 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconcile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		pOpts := &persistent.Options{
+			SigningKey: "dummy-key",
+		}
+		_, _, _, err = cmd.ExecuteCommandC(New(pOpts), "--file", "policy.yaml")
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		keyPath := filepath.Join(tmpDir, "test-key")
+		if err := os.WriteFile(keyPath, artifacts.SSHED25519Private, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(keyPath+".pub", artifacts.SSHED25519PublicSSH, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		repo, err := gittuf.LoadRepository(".")
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := gittuf.LoadSigner(repo, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.InitializeRoot(t.Context(), signer, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.InitializeTargets(t.Context(), signer, policy.TargetsRoleName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		declarationPath := filepath.Join(tmpDir, "policy.yaml")
+		declaration := "keys:\n  - " + keyPath + ".pub\nrules:\n  - name: protect-main\n    principals:\n      - " + keyPath + ".pub\n    patterns:\n      - git:refs/heads/main\n"
+		if err := os.WriteFile(declarationPath, []byte(declaration), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		pOpts := &persistent.Options{
+			SigningKey: keyPath,
+		}
+
+		_, stdOut, _, err := cmd.ExecuteCommandC(New(pOpts), "--file", declarationPath, "--check")
+		assert.ErrorIs(t, err, ErrPolicyDrift)
+		assert.Contains(t, stdOut.String(), "Rule file 'targets':\n")
+		assert.Contains(t, stdOut.String(), "    + rule 'protect-main'\n")
+
+		_, stdOut, _, err = cmd.ExecuteCommandC(New(pOpts), "--file", declarationPath)
+		assert.NoError(t, err)
+		assert.Contains(t, stdOut.String(), "    + rule 'protect-main'\n")
+
+		_, stdOut, _, err = cmd.ExecuteCommandC(New(pOpts), "--file", declarationPath, "--check")
+		assert.NoError(t, err)
+		assert.Equal(t, "Staged policy matches declaration\n", stdOut.String())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		pOpts := &persistent.Options{}
+		_, _, _, err := cmd.ExecuteCommandC(New(pOpts))
+		assert.ErrorContains(t, err, `required flag(s) "file" not set`)
+	})
+}
@@ -57,7 +57,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "remove-key",
 		Short:             "Remove a key from a policy file",
-		Long:              "The 'remove-key' command removes a public key from a gittuf policy file. The key must first be removed from all rules that reference it before this command will succeed.",
+		Long:              "The 'remove-key' command removes a public key from a gittuf policy file. The key must first be removed from all rules that reference it before this command will succeed. A warning is printed for every secret that was encrypted for the key, which must be re-encrypted when the policy is applied using the private key of one of its remaining readers.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
@@ -57,7 +57,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "remove-person",
 		Short:             "Remove a person from a policy file",
-		Long:              "The 'remove-person' command removes a trusted person from a gittuf policy file. The person must first be removed from all rules that reference them before this command will succeed.",
+		Long:              "The 'remove-person' command removes a trusted person from a gittuf policy file. The person must first be removed from all rules that reference them before this command will succeed. A warning is printed for every secret that was encrypted for the person, which must be re-encrypted when the policy is applied using the private key of one of its remaining readers.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
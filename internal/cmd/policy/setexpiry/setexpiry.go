@@ -0,0 +1,99 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package setexpiry
+
+import (
+	"time"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	expires    string
+	extendDays int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to set expiry of",
+	)
+
+	cmd.Flags().StringVar(
+		&o.expires,
+		"expires",
+		"",
+		"new expiry time for the policy file in the RFC 3339 format",
+	)
+
+	cmd.Flags().IntVar(
+		&o.extendDays,
+		"extend-days",
+		0,
+		"number of days to extend the current expiry of the policy file by",
+	)
+
+	cmd.MarkFlagsOneRequired("expires", "extend-days")
+	cmd.MarkFlagsMutuallyExclusive("expires", "extend-days")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	var expires time.Time
+	if o.expires != "" {
+		expires, err = time.Parse(time.RFC3339, o.expires)
+		if err != nil {
+			return err
+		}
+	} else {
+		expiries, err := repo.GetMetadataExpiries(cmd.Context(), policy.PolicyStagingRef)
+		if err != nil {
+			return err
+		}
+
+		// Extend from the current expiry unless it has already passed
+		expires = time.Now()
+		if current, has := expiries[o.policyName]; has && current.After(expires) {
+			expires = current
+		}
+		expires = expires.AddDate(0, 0, o.extendDays)
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.SetTargetsExpiry(cmd.Context(), signer, o.policyName, expires, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-expiry",
+		Short:             "Set or extend the expiry of the specified policy file metadata",
+		Long:              "The 'set-expiry' command sets the expiry of the specified policy file metadata, either to an explicit time using --expires or by extending the current expiry with --extend-days. Expired policy file metadata fails verification unless the root of trust predates expiry enforcement or has opted out using 'gittuf trust disable-expiry'. Only this command changes the expiry, so an expired policy file must be renewed explicitly.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -48,7 +48,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "sign",
 		Short:             "Sign policy file",
-		Long:              "The 'sign' command adds a signature to a gittuf policy file using the supplied signing key. It is used to meet a policy file's signature threshold when multiple keys are required to approve it.",
+		Long:              "The 'sign' command adds a signature to a gittuf policy file using the supplied signing key. It is used to meet a policy file's signature threshold when multiple keys are required to approve it. To sign without a copy of the repository, see 'gittuf trust export-unsigned'.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
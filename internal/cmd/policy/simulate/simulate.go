@@ -0,0 +1,66 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package simulate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+var ErrSimulatedFailures = errors.New("entries would fail verification against staged policy")
+
+type options struct {
+	since string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.since,
+		"since",
+		"",
+		"RSL entry to start replaying from",
+	)
+	cmd.MarkFlagRequired("since") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	failures, err := repo.SimulatePolicy(cmd.Context(), o.since)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	if len(failures) == 0 {
+		fmt.Fprintln(stdOut, "No entries would fail verification against the staged policy")
+		return nil
+	}
+
+	for _, failure := range failures {
+		fmt.Fprintf(stdOut, "Entry '%s' for '%s' would fail: %s\n", failure.EntryID.String(), failure.RefName, failure.Err.Error())
+	}
+
+	return fmt.Errorf("%w: %d entry(s)", ErrSimulatedFailures, len(failures))
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "simulate",
+		Short:             "Replay RSL entries against the staged policy",
+		Long:              "The 'simulate' command verifies the reference entries recorded in the RSL since the specified entry using the staged policy instead of the policy that was in effect when each entry was recorded. It reports the entries and references that would have been rejected, so the impact of a stricter policy can be assessed before it is applied. As with verify-ref, an entry is rejected if the staged policy had expired when the entry was recorded and the root of trust enforces expiry. Nothing is written to the repository. The command exits with an error if any entry would fail.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+const indentString = "    "
+
+var ErrThresholdNotMet = errors.New("signature threshold for rule file not met")
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	statuses, err := repo.GetRuleFileSignatureStatus(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	unmetRuleFiles := []string{}
+	for _, status := range statuses {
+		fmt.Fprintf(cmd.OutOrStdout(), "Rule file '%s':\n", status.Name)
+		printSignatureStatus(cmd, status)
+
+		if !status.ThresholdMet {
+			unmetRuleFiles = append(unmetRuleFiles, status.Name)
+		}
+	}
+
+	if len(unmetRuleFiles) != 0 {
+		return fmt.Errorf("%w: '%s'", ErrThresholdNotMet, strings.Join(unmetRuleFiles, "', '"))
+	}
+	return nil
+}
+
+func printSignatureStatus(cmd *cobra.Command, status *policy.SignatureStatus) {
+	stdOut := cmd.OutOrStdout()
+
+	thresholdState := "met"
+	if !status.ThresholdMet {
+		thresholdState = "not met"
+	}
+	fmt.Fprintf(stdOut, "%sThreshold: %d (%s)\n", indentString, status.Threshold, thresholdState)
+
+	fmt.Fprintf(stdOut, "%sSigned by:\n", indentString)
+	for _, principalID := range status.SignedPrincipalIDs {
+		fmt.Fprintf(stdOut, "%s%s\n", strings.Repeat(indentString, 2), principalID)
+	}
+
+	fmt.Fprintf(stdOut, "%sCan still sign:\n", indentString)
+	for _, principalID := range status.UnsignedPrincipalIDs {
+		fmt.Fprintf(stdOut, "%s%s\n", strings.Repeat(indentString, 2), principalID)
+	}
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "status",
+		Short:             "Show signature status of staged rule files",
+		Long:              "The 'status' command checks the signatures on the staged primary rule file and each reachable delegated rule file, listing the principals who have signed each rule file, those who can still sign it, and whether its threshold is met. It exits with an error if any threshold is not met, as applying the policy would then fail.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,116 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/policy"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, _, err = cmd.ExecuteCommandC(New())
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		keyPath := filepath.Join(tmpDir, "test-key")
+		if err := os.WriteFile(keyPath, artifacts.SSHED25519Private, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(keyPath+".pub", artifacts.SSHED25519PublicSSH, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		secondKeyPath := filepath.Join(tmpDir, "second-test-key")
+		if err := os.WriteFile(secondKeyPath+".pub", artifacts.SSHRSAPublicSSH, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		repo, err := gittuf.LoadRepository(".")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := gittuf.LoadSigner(repo, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := gittuf.LoadPublicKey(keyPath + ".pub")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repo.InitializeRoot(t.Context(), signer, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// The primary rule file has not been initialized
+		_, _, _, err = cmd.ExecuteCommandC(New())
+		assert.ErrorIs(t, err, policy.ErrMetadataNotFound)
+
+		if err := repo.AddTopLevelTargetsKey(t.Context(), signer, key, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.InitializeTargets(t.Context(), signer, policy.TargetsRoleName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, stdOut, _, err := cmd.ExecuteCommandC(New())
+		assert.NoError(t, err)
+		assert.Equal(t, "Rule file 'targets':\n    Threshold: 1 (met)\n    Signed by:\n        "+key.ID()+"\n    Can still sign:\n", stdOut.String())
+
+		secondKey, err := gittuf.LoadPublicKey(secondKeyPath + ".pub")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.AddTopLevelTargetsKey(t.Context(), signer, secondKey, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.UpdateTopLevelTargetsThreshold(t.Context(), signer, 2, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, stdOut, _, err = cmd.ExecuteCommandC(New())
+		assert.ErrorIs(t, err, ErrThresholdNotMet)
+		assert.Contains(t, stdOut.String(), "Rule file 'targets':\n    Threshold: 2 (not met)\n    Signed by:\n        "+key.ID()+"\n    Can still sign:\n        "+secondKey.ID()+"\n")
+	})
+}
@@ -127,7 +127,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "update-person",
 		Short:             "Update a person in a policy file",
-		Long:              "The 'update-person' command updates a trusted person's definition in a gittuf policy file. It is used to change a person's keys, associated identities, or custom metadata. The update replaces the person entirely, so any field not provided is cleared rather than preserved.",
+		Long:              "The 'update-person' command updates a trusted person's definition in a gittuf policy file. It is used to change a person's keys, associated identities, or custom metadata. The update replaces the person entirely, so any field not provided is cleared rather than preserved. If keys are removed, a warning is printed for every secret that was encrypted for them, which must be re-encrypted when the policy is applied using the private key of one of its remaining readers.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
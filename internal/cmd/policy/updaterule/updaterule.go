@@ -8,6 +8,7 @@ import (
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +19,11 @@ type options struct {
 	authorizedKeys         []string
 	authorizedPrincipalIDs []string
 	rulePatterns           []string
+	excludePatterns        []string
 	threshold              int
+	description            string
+	contact                string
+	link                   string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -49,7 +54,7 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		&o.authorizedPrincipalIDs,
 		"authorize",
 		[]string{},
-		"authorize the principal IDs for the rule",
+		"authorize the principal IDs for the rule (persons, keys, or teams)",
 	)
 	cmd.MarkFlagsOneRequired("authorize", "authorize-key")
 
@@ -61,12 +66,40 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 	)
 	cmd.MarkFlagRequired("rule-pattern") //nolint:errcheck
 
+	cmd.Flags().StringArrayVar(
+		&o.excludePatterns,
+		"exclude-pattern",
+		[]string{},
+		"patterns used to identify namespaces excluded from the rule",
+	)
+
 	cmd.Flags().IntVar(
 		&o.threshold,
 		"threshold",
 		1,
 		"threshold of required valid signatures",
 	)
+
+	cmd.Flags().StringVar(
+		&o.description,
+		"description",
+		"",
+		"description of why the rule exists",
+	)
+
+	cmd.Flags().StringVar(
+		&o.contact,
+		"contact",
+		"",
+		"who to contact about the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.link,
+		"link",
+		"",
+		"link to further context for the rule, such as a ticket",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
@@ -91,11 +124,25 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 	}
 	authorizedPrincipalIDs = append(authorizedPrincipalIDs, o.authorizedPrincipalIDs...)
 
+	rulePatterns := append([]string{}, o.rulePatterns...)
+	for _, pattern := range o.excludePatterns {
+		rulePatterns = append(rulePatterns, tuf.ExclusionPatternPrefix+pattern)
+	}
+
 	opts := []trustpolicyopts.Option{}
 	if o.p.WithRSLEntry {
 		opts = append(opts, trustpolicyopts.WithRSLEntry())
 	}
-	return repo.UpdateDelegation(cmd.Context(), signer, o.policyName, o.ruleName, authorizedPrincipalIDs, o.rulePatterns, o.threshold, true, opts...)
+	if cmd.Flags().Changed("description") {
+		opts = append(opts, trustpolicyopts.WithRuleDescription(o.description))
+	}
+	if cmd.Flags().Changed("contact") {
+		opts = append(opts, trustpolicyopts.WithRuleContact(o.contact))
+	}
+	if cmd.Flags().Changed("link") {
+		opts = append(opts, trustpolicyopts.WithRuleLink(o.link))
+	}
+	return repo.UpdateDelegation(cmd.Context(), signer, o.policyName, o.ruleName, authorizedPrincipalIDs, rulePatterns, o.threshold, true, opts...)
 }
 
 func New(persistent *persistent.Options) *cobra.Command {
@@ -103,7 +150,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "update-rule",
 		Short:             "Update an existing rule in a policy file",
-		Long:              "The 'update-rule' command updates an existing rule in a gittuf policy file. It is used to change the principals, patterns, or signature threshold that the rule enforces.",
+		Long:              "The 'update-rule' command updates an existing rule in a gittuf policy file. It is used to change the principals, patterns, or signature threshold that the rule enforces. The rule's description, contact, and link are only changed if the corresponding flags are specified.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
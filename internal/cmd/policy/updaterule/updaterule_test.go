@@ -125,6 +125,52 @@ func TestUpdateRule(t *testing.T) {
 		assert.Equal(t, "rule-1", rules[0].ID())
 		assert.Equal(t, []string{"git:refs/heads/feature"}, rules[0].GetProtectedNamespaces())
 		assert.Equal(t, 1, rules[0].GetThreshold())
+
+		// Update rule with exclusions
+		command = New(&persistent.Options{SigningKey: keyPath, WithRSLEntry: true})
+		_, _, _, err = cmd.ExecuteCommandC(command, "--rule-name", "rule-1", "--authorize-key", keyPath+".pub", "--rule-pattern", "git:refs/heads/*", "--exclude-pattern", "git:refs/heads/dev/*")
+		assert.NoError(t, err)
+
+		state, err = policy.LoadCurrentState(t.Context(), repo.GetGitRepository(), policy.PolicyStagingRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		assert.Nil(t, err)
+		rules = targetsMetadata.GetRules()
+		assert.Equal(t, []string{"git:refs/heads/*"}, rules[0].GetProtectedNamespaces())
+		assert.Equal(t, []string{"git:refs/heads/dev/*"}, rules[0].GetExcludedNamespaces())
+
+		// Update rule details, leaving the link unset
+		command = New(&persistent.Options{SigningKey: keyPath, WithRSLEntry: true})
+		_, _, _, err = cmd.ExecuteCommandC(command, "--rule-name", "rule-1", "--authorize-key", keyPath+".pub", "--rule-pattern", "git:refs/heads/*", "--description", "Protect all branches", "--contact", "jane.doe@example.com")
+		assert.NoError(t, err)
+
+		state, err = policy.LoadCurrentState(t.Context(), repo.GetGitRepository(), policy.PolicyStagingRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		assert.Nil(t, err)
+		rules = targetsMetadata.GetRules()
+		assert.Equal(t, "Protect all branches", rules[0].GetDescription())
+		assert.Equal(t, "jane.doe@example.com", rules[0].GetContact())
+		assert.Empty(t, rules[0].GetLink())
+
+		// Details not specified are retained
+		command = New(&persistent.Options{SigningKey: keyPath, WithRSLEntry: true})
+		_, _, _, err = cmd.ExecuteCommandC(command, "--rule-name", "rule-1", "--authorize-key", keyPath+".pub", "--rule-pattern", "git:refs/heads/*", "--link", "https://example.com/issues/1")
+		assert.NoError(t, err)
+
+		state, err = policy.LoadCurrentState(t.Context(), repo.GetGitRepository(), policy.PolicyStagingRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = state.GetTargetsMetadata(policy.TargetsRoleName, false)
+		assert.Nil(t, err)
+		rules = targetsMetadata.GetRules()
+		assert.Equal(t, "Protect all branches", rules[0].GetDescription())
+		assert.Equal(t, "https://example.com/issues/1", rules[0].GetLink())
 	})
 
 	t.Run("success with custom policy name", func(t *testing.T) {
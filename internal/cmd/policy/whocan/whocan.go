@@ -0,0 +1,133 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package whocan
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/spf13/cobra"
+)
+
+const indentString = "    "
+
+type options struct {
+	targetRef string
+	entryID   string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.targetRef,
+		"target-ref",
+		"policy",
+		"specify which policy ref should be inspected",
+	)
+
+	cmd.Flags().StringVar(
+		&o.entryID,
+		"entry",
+		"",
+		"ID of an RSL entry for the policy to inspect instead of the latest policy",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("target-ref", "entry")
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	authorizations, err := repo.WhoCan(cmd.Context(), args[0], o.targetRef, o.entryID)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	if len(authorizations) == 0 {
+		fmt.Fprintf(stdOut, "'%s' is not protected by any rule\n", args[0])
+		return nil
+	}
+
+	for _, authorization := range authorizations {
+		if !authorization.IsGlobalRule {
+			fmt.Fprintf(stdOut, "Rule '%s':\n", authorization.Name)
+			fmt.Fprintf(stdOut, indentString+"Threshold: %d\n", authorization.Threshold)
+			if authorization.SeparationOfDuties {
+				fmt.Fprintln(stdOut, indentString+"Separation of duties: enabled")
+			}
+			for _, principal := range authorization.Principals {
+				printPrincipal(stdOut, principal, authorization, 1)
+			}
+			continue
+		}
+
+		if authorization.ControllerName != "" {
+			fmt.Fprintf(stdOut, "Global Rule '%s' (controller '%s'):\n", authorization.Name, authorization.ControllerName)
+		} else {
+			fmt.Fprintf(stdOut, "Global Rule '%s':\n", authorization.Name)
+		}
+		fmt.Fprintf(stdOut, indentString+"Threshold: %d\n", authorization.Threshold)
+		fmt.Fprintln(stdOut, indentString+"Principals: any principal in the policy")
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "who-can <path>",
+		Short:             "List who can write to a reference or file",
+		Long:              "The 'who-can' command lists the rules that changes to a Git reference (e.g., 'git:refs/heads/main') or file (e.g., 'file:deploy/prod.yaml') must satisfy, along with the global threshold rules that apply to it. For each rule, it prints the threshold and the trusted principals, with persons expanded to their keys and associated identities and teams to their members. A path without a namespace prefix is treated as a reference if it starts with 'refs/' and as a file otherwise. The '--entry' flag inspects the policy recorded by a past RSL entry, which is useful when investigating an incident.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func printPrincipal(stdOut io.Writer, principal tuf.Principal, authorization *policy.PathAuthorization, depth int) {
+	indent := strings.Repeat(indentString, depth)
+
+	team, isTeam := principal.(tuf.Team)
+	if isTeam {
+		fmt.Fprintf(stdOut, indent+"Team %s (threshold %d):\n", principal.ID(), team.GetThreshold())
+		for _, member := range authorization.TeamMembers[principal.ID()] {
+			printPrincipal(stdOut, member, authorization, depth+1)
+		}
+		return
+	}
+
+	fmt.Fprintf(stdOut, indent+"Principal %s:\n", principal.ID())
+	fmt.Fprintln(stdOut, indent+indentString+"Keys:")
+	for _, key := range principal.Keys() {
+		fmt.Fprintf(stdOut, indent+strings.Repeat(indentString, 2)+"%s (%s)\n", key.KeyID, key.KeyType)
+	}
+
+	person, isPerson := principal.(*tufv02.Person)
+	if !isPerson || len(person.AssociatedIdentities) == 0 {
+		return
+	}
+
+	fmt.Fprintln(stdOut, indent+indentString+"Associated Identities:")
+	providers := make([]string, 0, len(person.AssociatedIdentities))
+	for provider := range person.AssociatedIdentities {
+		providers = append(providers, provider)
+	}
+	slices.Sort(providers)
+	for _, provider := range providers {
+		fmt.Fprintf(stdOut, indent+strings.Repeat(indentString, 2)+"%s: %s\n", provider, person.AssociatedIdentities[provider])
+	}
+}
@@ -12,10 +12,13 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd/attest"
 	"github.com/gittuf/gittuf/internal/cmd/cache"
 	"github.com/gittuf/gittuf/internal/cmd/clone"
+	"github.com/gittuf/gittuf/internal/cmd/decryptref"
+	"github.com/gittuf/gittuf/internal/cmd/encryptref"
 	"github.com/gittuf/gittuf/internal/cmd/policy"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/cmd/profile"
 	"github.com/gittuf/gittuf/internal/cmd/rsl"
+	"github.com/gittuf/gittuf/internal/cmd/secrets"
 	"github.com/gittuf/gittuf/internal/cmd/sync"
 	"github.com/gittuf/gittuf/internal/cmd/trust"
 	"github.com/gittuf/gittuf/internal/cmd/tui"
@@ -115,9 +118,12 @@ func New() *cobra.Command {
 	cmd.AddCommand(attest.New())
 	cmd.AddCommand(cache.New())
 	cmd.AddCommand(clone.New())
+	cmd.AddCommand(decryptref.New())
+	cmd.AddCommand(encryptref.New())
 	cmd.AddCommand(trust.New())
 	cmd.AddCommand(policy.New())
 	cmd.AddCommand(rsl.New())
+	cmd.AddCommand(secrets.New())
 	cmd.AddCommand(sync.New())
 	cmd.AddCommand(verifymergeable.New())
 	cmd.AddCommand(verifynetwork.New())
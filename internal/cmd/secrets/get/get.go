@@ -0,0 +1,54 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	keyPath string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.keyPath,
+		"key",
+		"k",
+		"",
+		"path to the SSH private key to decrypt with",
+	)
+	cmd.MarkFlagRequired("key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	value, err := repo.GetSecret(cmd.Context(), args[0], o.keyPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.OutOrStdout().Write(value)
+	return err
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "get <name>",
+		Short:             "Get the value of a secret",
+		Long:              "The 'get' command writes the decrypted value of a secret to standard output. The SSH private key must be unencrypted and belong to one of the readers the secret was encrypted for. A warning is printed if the secret's readers were removed from the policy or their keys changed, in which case the secret must be rotated using 'gittuf secrets rotate'.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistent
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	WithRSLEntry bool
+}
+
+func (o *Options) AddPersistentFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(
+		&o.WithRSLEntry,
+		"create-rsl-entry",
+		false,
+		"create RSL entry for secrets change immediately (note: the new entry to the RSL will not be synced with the remote)",
+	)
+}
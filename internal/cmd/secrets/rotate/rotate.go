@@ -0,0 +1,66 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rotate
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	secretsopts "github.com/gittuf/gittuf/experimental/gittuf/options/secrets"
+	"github.com/gittuf/gittuf/internal/cmd/secrets/persistent"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p       *persistent.Options
+	keyPath string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.keyPath,
+		"key",
+		"k",
+		"",
+		"path to the SSH private key to decrypt with",
+	)
+	cmd.MarkFlagRequired("key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	opts := []secretsopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, secretsopts.WithRSLEntry())
+	}
+
+	rotated, err := repo.RotateSecrets(cmd.Context(), o.keyPath, args, true, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range rotated {
+		fmt.Fprintln(cmd.OutOrStdout(), name)
+	}
+
+	return nil
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "rotate [name...]",
+		Short:             "Re-encrypt secrets for the current keys of their readers",
+		Long:              "The 'rotate' command re-encrypts secrets with a new data key for the keys their readers currently have in the policy, dropping readers that are no longer principals in the policy, and prints the names of the rotated secrets. Without any names, only the secrets affected by changes to the policy are rotated. Secrets are decrypted using the specified SSH private key, which must belong to one of their readers. Secrets affected by changes to the policy are also re-encrypted when the policy is applied, so this is only needed if the policy was applied by other means, as reported by 'gittuf secrets verify'. Rotating only re-encrypts the same value and does not revoke a removed reader's access: older versions of the secret remain in the repository's history and can still be decrypted by the removed reader, so the value itself must be changed using 'gittuf secrets set'.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,33 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/secrets/apply"
+	"github.com/gittuf/gittuf/internal/cmd/secrets/get"
+	"github.com/gittuf/gittuf/internal/cmd/secrets/persistent"
+	"github.com/gittuf/gittuf/internal/cmd/secrets/rotate"
+	"github.com/gittuf/gittuf/internal/cmd/secrets/set"
+	"github.com/gittuf/gittuf/internal/cmd/secrets/verify"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	o := &persistent.Options{}
+	cmd := &cobra.Command{
+		Use:               "secrets",
+		Short:             "Manage secrets encrypted for principals in the policy",
+		Long:              "The 'secrets' command group contains subcommands to manage secrets such as CI tokens and deploy credentials in the repository's gittuf secrets namespace. Each secret is encrypted for the SSH keys of a set of principals in the policy. Changes to the secrets namespace are recorded in the RSL and can be protected using rules for 'git:refs/gittuf/secrets' like any other reference. When readers or their keys are removed from the policy, the affected secrets are re-encrypted for their remaining readers when the policy is applied, which requires the private key of one of those readers.",
+		DisableAutoGenTag: true,
+	}
+	o.AddPersistentFlags(cmd)
+
+	cmd.AddCommand(apply.New())
+	cmd.AddCommand(get.New())
+	cmd.AddCommand(rotate.New(o))
+	cmd.AddCommand(set.New(o))
+	cmd.AddCommand(verify.New())
+
+	return cmd
+}
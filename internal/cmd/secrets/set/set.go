@@ -0,0 +1,62 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package set
+
+import (
+	"io"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	secretsopts "github.com/gittuf/gittuf/experimental/gittuf/options/secrets"
+	"github.com/gittuf/gittuf/internal/cmd/secrets/persistent"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p       *persistent.Options
+	readers []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&o.readers,
+		"reader",
+		[]string{},
+		"principal allowed to read the secret",
+	)
+	cmd.MarkFlagRequired("reader") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	value, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	opts := []secretsopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, secretsopts.WithRSLEntry())
+	}
+
+	return repo.SetSecret(cmd.Context(), args[0], value, o.readers, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set <name>",
+		Short:             "Set the value of a secret",
+		Long:              "The 'set' command reads the value of a secret from standard input and encrypts it for the SSH keys of the specified readers, which must be principals in the policy. Other keys, such as GPG and Sigstore keys, are skipped, so every reader must have at least one SSH key. An existing secret with the same name is replaced. Secret names may only contain letters, digits, '.', '_', and '-'.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,54 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/secrets"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	staleNames, err := repo.GetStaleSecrets(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if len(staleNames) == 0 {
+		return nil
+	}
+
+	for _, name := range staleNames {
+		fmt.Fprintln(cmd.OutOrStdout(), name)
+	}
+
+	return fmt.Errorf("%w: '%s'", secrets.ErrSecretStale, strings.Join(staleNames, "', '"))
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "verify",
+		Short:             "Check that secrets are encrypted for the current readers in the policy",
+		Long:              "The 'verify' command checks that every secret is encrypted for exactly the keys its readers currently have in the policy, printing the names of the secrets that are not and failing if there are any. Such secrets are stale: they are still encrypted for readers that were removed from the policy or for keys readers no longer have, and must be rotated using 'gittuf secrets rotate'. Secrets are re-encrypted when the policy is applied, so this only happens if the policy was applied by other means. Stale secrets can still be read using 'gittuf secrets get', which warns about them.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exportunsigned
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	policyName string
+	outputPath string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.RootRoleName,
+		"name of policy file to export, or 'root' for the root of trust",
+	)
+
+	cmd.Flags().StringVarP(
+		&o.outputPath,
+		"output",
+		"o",
+		"",
+		"path to write unsigned metadata envelope to",
+	)
+	cmd.MarkFlagRequired("output") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	envelope, err := repo.ExportUnsignedMetadata(cmd.Context(), o.policyName)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(o.outputPath, envelope, 0o600)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "export-unsigned",
+		Short:             "Export staged root of trust or policy file for offline signing",
+		Long:              "The 'export-unsigned' command writes the staged root of trust, or the specified policy file, to a standalone metadata envelope without its signatures. The envelope can be copied to a machine without the repository, signed there using 'gittuf trust sign-offline', and the signatures added back to the staged policy using 'gittuf trust import-signature'. This allows signature thresholds to be met without every signer having a copy of the repository.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,125 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package importsignature
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportSignature(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, _, err = cmd.ExecuteCommandC(New(&persistent.Options{}), "root.json")
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		keyPath := filepath.Join(tmpDir, "test-key")
+		if err := os.WriteFile(keyPath, artifacts.SSHED25519Private, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(keyPath+".pub", artifacts.SSHED25519PublicSSH, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		secondKeyPath := filepath.Join(tmpDir, "second-test-key")
+		if err := os.WriteFile(secondKeyPath, artifacts.SSHRSAPrivate, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(secondKeyPath+".pub", artifacts.SSHRSAPublicSSH, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			_ = os.Chdir(cwd)
+		}()
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		repo, err := gittuf.LoadRepository(".")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := gittuf.LoadSigner(repo, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repo.InitializeRoot(t.Context(), signer, false); err != nil {
+			t.Fatal(err)
+		}
+
+		secondKey, err := gittuf.LoadPublicKey(secondKeyPath + ".pub")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.AddRootKey(t.Context(), signer, secondKey, false); err != nil {
+			t.Fatal(err)
+		}
+
+		envelope, err := repo.ExportUnsignedMetadata(t.Context(), policy.RootRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		secondSigner, err := gittuf.LoadSigner(nil, secondKeyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		envelope, err = gittuf.SignMetadataOffline(t.Context(), secondSigner, envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		envelopePath := filepath.Join(tmpDir, "root.json")
+		if err := os.WriteFile(envelopePath, envelope, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, _, err = cmd.ExecuteCommandC(New(&persistent.Options{}), envelopePath)
+		assert.NoError(t, err)
+
+		// The signature has already been added
+		_, _, _, err = cmd.ExecuteCommandC(New(&persistent.Options{}), envelopePath)
+		assert.ErrorIs(t, err, gittuf.ErrNoNewSignatures)
+	})
+
+	t.Run("missing envelope", func(t *testing.T) {
+		_, _, _, err := cmd.ExecuteCommandC(New(&persistent.Options{}))
+		assert.ErrorContains(t, err, "accepts 1 arg(s), received 0")
+	})
+}
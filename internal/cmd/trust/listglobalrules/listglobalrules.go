@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	"github.com/gittuf/gittuf/internal/tuf"
@@ -46,10 +47,28 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 
 	thresholdRules := []tuf.GlobalRuleThreshold{}
 	blockForcePushesRules := []tuf.GlobalRuleBlockForcePushes{}
+	requireSignedCommitsRules := []tuf.GlobalRuleRequireSignedCommits{}
+	requireSignOffRules := []tuf.GlobalRuleRequireSignOff{}
+	requireLinearHistoryRules := []tuf.GlobalRuleRequireLinearHistory{}
+	restrictFilesRules := []tuf.GlobalRuleRestrictFiles{}
+	freezeWindowRules := []tuf.GlobalRuleFreezeWindow{}
+	restrictReadRules := []tuf.GlobalRuleRestrictRead{}
 	for _, curRule := range rules {
 		switch globalRule := curRule.(type) {
 		case tuf.GlobalRuleThreshold:
 			thresholdRules = append(thresholdRules, globalRule)
+		case tuf.GlobalRuleRequireSignedCommits:
+			requireSignedCommitsRules = append(requireSignedCommitsRules, globalRule)
+		case tuf.GlobalRuleRequireSignOff:
+			requireSignOffRules = append(requireSignOffRules, globalRule)
+		case tuf.GlobalRuleRequireLinearHistory:
+			requireLinearHistoryRules = append(requireLinearHistoryRules, globalRule)
+		case tuf.GlobalRuleRestrictFiles:
+			restrictFilesRules = append(restrictFilesRules, globalRule)
+		case tuf.GlobalRuleFreezeWindow:
+			freezeWindowRules = append(freezeWindowRules, globalRule)
+		case tuf.GlobalRuleRestrictRead:
+			restrictReadRules = append(restrictReadRules, globalRule)
 		case tuf.GlobalRuleBlockForcePushes:
 			blockForcePushesRules = append(blockForcePushesRules, globalRule)
 		}
@@ -68,6 +87,59 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		printNamespaces(stdOut, curRule.GetProtectedNamespaces())
 	}
 
+	for _, curRule := range requireSignedCommitsRules {
+		fmt.Fprintf(stdOut, "Global Rule: %v\n", curRule.GetName())
+		fmt.Fprintln(stdOut, indentString+"Type: "+tuf.GlobalRuleRequireSignedCommitsType)
+		printNamespaces(stdOut, curRule.GetProtectedNamespaces())
+	}
+
+	for _, curRule := range requireSignOffRules {
+		fmt.Fprintf(stdOut, "Global Rule: %v\n", curRule.GetName())
+		fmt.Fprintln(stdOut, indentString+"Type: "+tuf.GlobalRuleRequireSignOffType)
+		printNamespaces(stdOut, curRule.GetProtectedNamespaces())
+		fmt.Fprintf(stdOut, indentString+"Allow Associated Identities: %t\n", curRule.AllowsAssociatedIdentities())
+	}
+
+	for _, curRule := range requireLinearHistoryRules {
+		fmt.Fprintf(stdOut, "Global Rule: %v\n", curRule.GetName())
+		fmt.Fprintln(stdOut, indentString+"Type: "+tuf.GlobalRuleRequireLinearHistoryType)
+		printNamespaces(stdOut, curRule.GetProtectedNamespaces())
+	}
+
+	for _, curRule := range restrictFilesRules {
+		fmt.Fprintf(stdOut, "Global Rule: %v\n", curRule.GetName())
+		fmt.Fprintln(stdOut, indentString+"Type: "+tuf.GlobalRuleRestrictFilesType)
+		printNamespaces(stdOut, curRule.GetProtectedNamespaces())
+		if deniedPatterns := curRule.GetDeniedPatterns(); len(deniedPatterns) > 0 {
+			fmt.Fprintln(stdOut, indentString+"Denied patterns:")
+			for _, pattern := range deniedPatterns {
+				fmt.Fprintln(stdOut, strings.Repeat(indentString, 2)+pattern)
+			}
+		}
+		if maxBlobSize := curRule.GetMaxBlobSize(); maxBlobSize > 0 {
+			fmt.Fprintf(stdOut, indentString+"Max blob size: %d bytes\n", maxBlobSize)
+		}
+	}
+
+	for _, curRule := range freezeWindowRules {
+		fmt.Fprintf(stdOut, "Global Rule: %v\n", curRule.GetName())
+		fmt.Fprintln(stdOut, indentString+"Type: "+tuf.GlobalRuleFreezeWindowType)
+		printNamespaces(stdOut, curRule.GetProtectedNamespaces())
+		fmt.Fprintf(stdOut, indentString+"Start: %s\n", curRule.GetStart().Format(time.RFC3339))
+		fmt.Fprintf(stdOut, indentString+"End: %s\n", curRule.GetEnd().Format(time.RFC3339))
+		fmt.Fprintf(stdOut, indentString+"Override Threshold: %d\n", curRule.GetOverrideThreshold())
+	}
+
+	for _, curRule := range restrictReadRules {
+		fmt.Fprintf(stdOut, "Global Rule: %v\n", curRule.GetName())
+		fmt.Fprintln(stdOut, indentString+"Type: "+tuf.GlobalRuleRestrictReadType)
+		printNamespaces(stdOut, curRule.GetProtectedNamespaces())
+		fmt.Fprintln(stdOut, indentString+"Readers:")
+		for _, readerID := range curRule.GetReaderPrincipalIDs() {
+			fmt.Fprintln(stdOut, strings.Repeat(indentString, 2)+readerID)
+		}
+	}
+
 	return nil
 }
 
@@ -88,7 +160,7 @@ func New() *cobra.Command {
 func printNamespaces(stdOut io.Writer, namespaces []string) {
 	gitpaths, filepaths := []string{}, []string{}
 	for _, path := range namespaces {
-		if strings.HasPrefix(path, "git:") {
+		if tuf.IsGitReferencePattern(path) {
 			gitpaths = append(gitpaths, path)
 		} else {
 			filepaths = append(filepaths, path)
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	rootopts "github.com/gittuf/gittuf/experimental/gittuf/options/root"
@@ -149,6 +150,26 @@ func TestListGlobalRules(t *testing.T) {
 			t.Fatal(err)
 		}
 
+		// Add require linear history global rule
+		if err := repo.AddGlobalRuleRequireLinearHistory(t.Context(), signer, "linear-history-for-release", []string{"git:refs/heads/release/*"}, false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add restrict files global rule
+		if err := repo.AddGlobalRuleRestrictFiles(t.Context(), signer, "restrict-files-for-main", []string{"git:refs/heads/main"}, []string{"*.env"}, 1048576, false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add freeze window global rule
+		if err := repo.AddGlobalRuleFreezeWindow(t.Context(), signer, "freeze-main", []string{"git:refs/heads/main"}, time.Date(2025, time.December, 20, 0, 0, 0, 0, time.UTC), time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC), 2, false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add restrict read global rule
+		if err := repo.AddGlobalRuleRestrictRead(t.Context(), signer, "restrict-read-for-embargo", []string{"git:refs/heads/embargo/*"}, []string{"jane.doe"}, false, trustpolicyopts.WithRSLEntry()); err != nil {
+			t.Fatal(err)
+		}
+
 		_, stdout, _, err := cmd.ExecuteCommandC(New(), "--target-ref", "policy-staging")
 		assert.NoError(t, err)
 
@@ -163,6 +184,30 @@ Global Rule: block-force-pushes-for-main
     Type: block-force-pushes
     Refs affected:
         git:refs/heads/main
+Global Rule: linear-history-for-release
+    Type: require-linear-history
+    Refs affected:
+        git:refs/heads/release/*
+Global Rule: restrict-files-for-main
+    Type: restrict-files
+    Refs affected:
+        git:refs/heads/main
+    Denied patterns:
+        *.env
+    Max blob size: 1048576 bytes
+Global Rule: freeze-main
+    Type: freeze-window
+    Refs affected:
+        git:refs/heads/main
+    Start: 2025-12-20T00:00:00Z
+    End: 2026-01-05T00:00:00Z
+    Override Threshold: 2
+Global Rule: restrict-read-for-embargo
+    Type: restrict-read
+    Refs affected:
+        git:refs/heads/embargo/*
+    Readers:
+        jane.doe
 `
 
 		output := strings.ReplaceAll(stdout.String(), "\r\n", "\n")
@@ -0,0 +1,73 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package revokekey
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p        *persistent.Options
+	keyID    string
+	rslEntry string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.keyID,
+		"key-ID",
+		"",
+		"ID of the key to revoke",
+	)
+	cmd.MarkFlagRequired("key-ID") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.rslEntry,
+		"rsl-entry",
+		"",
+		"ID of the last RSL entry that may be signed using the key",
+	)
+	cmd.MarkFlagRequired("rsl-entry") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	rslEntryID, err := gitinterface.NewHash(o.rslEntry)
+	if err != nil {
+		return err
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.RevokeKey(cmd.Context(), signer, o.keyID, rslEntryID, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "revoke-key",
+		Short:             "Revoke a key as of an RSL entry",
+		Long:              "The 'revoke-key' command revokes a compromised key as of the specified RSL entry. Signatures made using the key on RSL entries and attestations recorded after that entry fail verification, while signatures on the entry and those before it remain valid. Unlike removing a key from the policy, this also applies to entries recorded before the revocation was added.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
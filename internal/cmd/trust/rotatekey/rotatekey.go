@@ -0,0 +1,87 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rotatekey
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/spf13/cobra"
+)
+
+const indentString = "    "
+
+type options struct {
+	p        *persistent.Options
+	oldKeyID string
+	newKey   string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.oldKeyID,
+		"old",
+		"",
+		"ID of the key to replace",
+	)
+	cmd.MarkFlagRequired("old") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.newKey,
+		"new",
+		"",
+		"replacement key (path to SSH public key, \"gpg:<fingerprint>\" for GPG, or \"fulcio:<identity>::<issuer>\" for Sigstore)",
+	)
+	cmd.MarkFlagRequired("new") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	newKey, err := gittuf.LoadPublicKey(o.newKey)
+	if err != nil {
+		return err
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	rotatedRoles, err := repo.RotateKey(cmd.Context(), signer, o.oldKeyID, newKey, true, opts...)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	fmt.Fprintf(stdOut, "Replaced key '%s' with '%s' in:\n", o.oldKeyID, newKey.ID())
+	for _, roleName := range rotatedRoles {
+		fmt.Fprintf(stdOut, indentString+"%s\n", roleName)
+	}
+
+	return nil
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "rotate-key",
+		Short:             "Replace a key everywhere it is trusted in the policy",
+		Long:              "The 'rotate-key' command replaces a key with a new key everywhere it is trusted: as a root or primary rule file principal, as a hook or GitHub app principal, as a reader in a restrict-read global rule, as a principal or team member in every rule file, and as one of a person's keys. All affected metadata is updated in a single policy staging change, and the roles and global rules that were modified are printed. The signing key must be a root key if the root of trust is modified.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
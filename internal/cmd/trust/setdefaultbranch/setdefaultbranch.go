@@ -0,0 +1,58 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package setdefaultbranch
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p      *persistent.Options
+	branch string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.branch,
+		"branch",
+		"",
+		"default branch of the repository to record in the root of trust",
+	)
+	cmd.MarkFlagRequired("branch") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.SetDefaultBranch(cmd.Context(), signer, o.branch, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-default-branch",
+		Short:             "Set default branch",
+		Long:              "The 'set-default-branch' command records the repository's default branch in its root of trust. Rule patterns that refer to the default branch, such as 'class:default-branch' and 'class:branches-except-default', follow the repository's symbolic HEAD by default. As HEAD can differ across clones, such as when another branch is checked out, declaring the default branch pins these patterns to it so that they apply the same way in every clone.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
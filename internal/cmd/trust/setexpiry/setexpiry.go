@@ -0,0 +1,91 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package setexpiry
+
+import (
+	"time"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	expires    string
+	extendDays int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.expires,
+		"expires",
+		"",
+		"new expiry time for the root of trust in the RFC 3339 format",
+	)
+
+	cmd.Flags().IntVar(
+		&o.extendDays,
+		"extend-days",
+		0,
+		"number of days to extend the current expiry of the root of trust by",
+	)
+
+	cmd.MarkFlagsOneRequired("expires", "extend-days")
+	cmd.MarkFlagsMutuallyExclusive("expires", "extend-days")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	var expires time.Time
+	if o.expires != "" {
+		expires, err = time.Parse(time.RFC3339, o.expires)
+		if err != nil {
+			return err
+		}
+	} else {
+		expiries, err := repo.GetMetadataExpiries(cmd.Context(), policy.PolicyStagingRef)
+		if err != nil {
+			return err
+		}
+
+		// Extend from the current expiry unless it has already passed
+		expires = time.Now()
+		if current, has := expiries[policy.RootRoleName]; has && current.After(expires) {
+			expires = current
+		}
+		expires = expires.AddDate(0, 0, o.extendDays)
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.SetRootExpiry(cmd.Context(), signer, expires, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-expiry",
+		Short:             "Set or extend the expiry of the gittuf root of trust",
+		Long:              "The 'set-expiry' command sets the expiry of the repository's root of trust metadata, either to an explicit time using --expires or by extending the current expiry with --extend-days. Expiry is enforced by default for new repositories: expired root of trust and policy file metadata fails verification, and 'gittuf policy list-rules' warns about metadata that expires within 30 days. Repositories whose root of trust predates expiry enforcement, or that opted out using 'gittuf trust disable-expiry', opt in by setting the expiry using this command. Only this command changes the expiry, so expired root metadata must be renewed explicitly.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
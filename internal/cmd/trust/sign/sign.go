@@ -39,7 +39,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "sign",
 		Short:             "Sign root of trust",
-		Long:              "The 'sign' command adds a signature to the repository's root of trust using the supplied signing key. It is used to meet the root of trust's signature threshold when multiple root keys are required.",
+		Long:              "The 'sign' command adds a signature to the repository's root of trust using the supplied signing key. It is used to meet the root of trust's signature threshold when multiple root keys are required. To sign without a copy of the repository, see 'gittuf trust export-unsigned'.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
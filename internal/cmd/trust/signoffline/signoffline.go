@@ -0,0 +1,59 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package signoffline
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p *persistent.Options
+}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	// The repository is only used to load signer options from the Git
+	// configuration, and may not be available on the signing machine
+	var repo *gittuf.Repository
+	if r, err := gittuf.LoadRepository("."); err == nil {
+		repo = r
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	envelope, err = gittuf.SignMetadataOffline(cmd.Context(), signer, envelope)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(args[0], envelope, 0o600)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "sign-offline <envelope>",
+		Short:             "Sign exported root of trust or policy file without the repository",
+		Long:              "The 'sign-offline' command adds a signature to a metadata envelope written by 'gittuf trust export-unsigned' using the supplied signing key, updating the file in place. It does not require a copy of the repository, so the signing key must be specified. The file may be signed by several keys in turn before its signatures are added to the staged policy using 'gittuf trust import-signature'.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
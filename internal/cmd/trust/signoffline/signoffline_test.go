@@ -0,0 +1,78 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package signoffline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignOffline(t *testing.T) {
+	// Signing does not require a repository
+	tmpDir := t.TempDir()
+
+	keyPath := filepath.Join(tmpDir, "test-key")
+	if err := os.WriteFile(keyPath, artifacts.SSHED25519Private, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath+".pub", artifacts.SSHED25519PublicSSH, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Chdir(cwd)
+	}()
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	envelopePath := filepath.Join(tmpDir, "root.json")
+	if err := os.WriteFile(envelopePath, []byte(`{"payloadType":"application/vnd.gittuf+json","payload":"e30=","signatures":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("no signing key", func(t *testing.T) {
+		_, _, _, err := cmd.ExecuteCommandC(New(&persistent.Options{}), envelopePath)
+		assert.ErrorIs(t, err, gittuf.ErrSigningKeyNotSpecified)
+	})
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		invalidPath := filepath.Join(tmpDir, "invalid.json")
+		if err := os.WriteFile(invalidPath, []byte("not an envelope"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, _, err := cmd.ExecuteCommandC(New(&persistent.Options{SigningKey: keyPath}), invalidPath)
+		assert.ErrorContains(t, err, "unable to parse envelope")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		_, _, _, err := cmd.ExecuteCommandC(New(&persistent.Options{SigningKey: keyPath}), envelopePath)
+		assert.NoError(t, err)
+
+		envelopeBytes, err := os.ReadFile(envelopePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		envelope := &sslibdsse.Envelope{}
+		if err := json.Unmarshal(envelopeBytes, envelope); err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, envelope.Signatures, 1)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+const indentString = "    "
+
+var ErrThresholdNotMet = errors.New("signature threshold for root of trust not met")
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	status, err := repo.GetRootSignatureStatus(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	appliedStatus, err := repo.GetAppliedRootSignatureStatus(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "Root of trust:")
+	printSignatureStatus(cmd, status)
+
+	if appliedStatus != nil {
+		fmt.Fprintln(cmd.OutOrStdout(), "Applied root of trust:")
+		printSignatureStatus(cmd, appliedStatus)
+	}
+
+	if !status.ThresholdMet || (appliedStatus != nil && !appliedStatus.ThresholdMet) {
+		return ErrThresholdNotMet
+	}
+	return nil
+}
+
+func printSignatureStatus(cmd *cobra.Command, status *policy.SignatureStatus) {
+	stdOut := cmd.OutOrStdout()
+
+	thresholdState := "met"
+	if !status.ThresholdMet {
+		thresholdState = "not met"
+	}
+	fmt.Fprintf(stdOut, "%sThreshold: %d (%s)\n", indentString, status.Threshold, thresholdState)
+
+	fmt.Fprintf(stdOut, "%sSigned by:\n", indentString)
+	for _, principalID := range status.SignedPrincipalIDs {
+		fmt.Fprintf(stdOut, "%s%s\n", strings.Repeat(indentString, 2), principalID)
+	}
+
+	fmt.Fprintf(stdOut, "%sCan still sign:\n", indentString)
+	for _, principalID := range status.UnsignedPrincipalIDs {
+		fmt.Fprintf(stdOut, "%s%s\n", strings.Repeat(indentString, 2), principalID)
+	}
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "status",
+		Short:             "Show signature status of staged root of trust",
+		Long:              "The 'status' command checks the signatures on the staged root of trust, listing the root principals who have signed it, those who can still sign it, and whether the root threshold is met. The staged root of trust is checked using both its own root principals and threshold and those of the applied root of trust, if any, as applying the policy requires both thresholds to be met. It exits with an error if either threshold is not met, as applying the policy would then fail.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
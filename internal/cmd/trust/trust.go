@@ -12,8 +12,11 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd/trust/addpolicykey"
 	"github.com/gittuf/gittuf/internal/cmd/trust/addpropagationdirective"
 	"github.com/gittuf/gittuf/internal/cmd/trust/addrootkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/disableexpiry"
 	"github.com/gittuf/gittuf/internal/cmd/trust/disablegithubappapprovals"
 	"github.com/gittuf/gittuf/internal/cmd/trust/enablegithubappapprovals"
+	"github.com/gittuf/gittuf/internal/cmd/trust/exportunsigned"
+	"github.com/gittuf/gittuf/internal/cmd/trust/importsignature"
 	"github.com/gittuf/gittuf/internal/cmd/trust/incrementversion"
 	i "github.com/gittuf/gittuf/internal/cmd/trust/init"
 	"github.com/gittuf/gittuf/internal/cmd/trust/listglobalrules"
@@ -27,8 +30,14 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd/trust/removepolicykey"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removepropagationdirective"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removerootkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/revokekey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/rotatekey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/setdefaultbranch"
+	"github.com/gittuf/gittuf/internal/cmd/trust/setexpiry"
 	"github.com/gittuf/gittuf/internal/cmd/trust/setrepositorylocation"
 	"github.com/gittuf/gittuf/internal/cmd/trust/sign"
+	"github.com/gittuf/gittuf/internal/cmd/trust/signoffline"
+	"github.com/gittuf/gittuf/internal/cmd/trust/status"
 	"github.com/gittuf/gittuf/internal/cmd/trust/updateglobalrule"
 	"github.com/gittuf/gittuf/internal/cmd/trust/updatehook"
 	"github.com/gittuf/gittuf/internal/cmd/trust/updatepolicythreshold"
@@ -60,8 +69,11 @@ func New() *cobra.Command {
 	cmd.AddCommand(addpropagationdirective.New(o))
 	cmd.AddCommand(addrootkey.New(o))
 	cmd.AddCommand(apply.New())
+	cmd.AddCommand(disableexpiry.New(o))
 	cmd.AddCommand(disablegithubappapprovals.New(o))
 	cmd.AddCommand(enablegithubappapprovals.New(o))
+	cmd.AddCommand(exportunsigned.New())
+	cmd.AddCommand(importsignature.New(o))
 	cmd.AddCommand(incrementversion.New(o))
 	cmd.AddCommand(listglobalrules.New())
 	cmd.AddCommand(listhooks.New())
@@ -74,9 +86,15 @@ func New() *cobra.Command {
 	cmd.AddCommand(removepolicykey.New(o))
 	cmd.AddCommand(removepropagationdirective.New(o))
 	cmd.AddCommand(removerootkey.New(o))
+	cmd.AddCommand(revokekey.New(o))
+	cmd.AddCommand(rotatekey.New(o))
+	cmd.AddCommand(setdefaultbranch.New(o))
+	cmd.AddCommand(setexpiry.New(o))
 	cmd.AddCommand(setrepositorylocation.New(o))
 	cmd.AddCommand(sign.New(o))
+	cmd.AddCommand(signoffline.New(o))
 	cmd.AddCommand(stage.New())
+	cmd.AddCommand(status.New())
 	cmd.AddCommand(updateglobalrule.New(o))
 	cmd.AddCommand(updatehook.New(o))
 	cmd.AddCommand(updatepolicythreshold.New(o))
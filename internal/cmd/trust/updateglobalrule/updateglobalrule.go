@@ -5,6 +5,7 @@ package updateglobalrule
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
@@ -14,11 +15,20 @@ import (
 )
 
 type options struct {
-	p            *persistent.Options
-	ruleName     string
-	ruleType     string
-	rulePatterns []string
-	threshold    int
+	p                         *persistent.Options
+	ruleName                  string
+	ruleType                  string
+	rulePatterns              []string
+	threshold                 int
+	allowAssociatedIdentities bool
+
+	deniedPatterns []string
+	maxBlobSize    uint64
+
+	freezeStart string
+	freezeEnd   string
+
+	readers []string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -34,7 +44,7 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		&o.ruleType,
 		"type",
 		"",
-		fmt.Sprintf("type of rule (%s|%s)", tuf.GlobalRuleThresholdType, tuf.GlobalRuleBlockForcePushesType),
+		fmt.Sprintf("type of rule (%s|%s|%s|%s|%s|%s|%s|%s)", tuf.GlobalRuleThresholdType, tuf.GlobalRuleBlockForcePushesType, tuf.GlobalRuleRequireSignedCommitsType, tuf.GlobalRuleRequireSignOffType, tuf.GlobalRuleRequireLinearHistoryType, tuf.GlobalRuleRestrictFilesType, tuf.GlobalRuleFreezeWindowType, tuf.GlobalRuleRestrictReadType),
 	)
 	cmd.MarkFlagRequired("type") //nolint:errcheck
 
@@ -49,7 +59,49 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		&o.threshold,
 		"threshold",
 		1,
-		"threshold of required valid signatures",
+		fmt.Sprintf("threshold of required valid signatures (for %s, the threshold required to override the freeze)", tuf.GlobalRuleFreezeWindowType),
+	)
+
+	cmd.Flags().BoolVar(
+		&o.allowAssociatedIdentities,
+		"allow-associated-identities",
+		false,
+		fmt.Sprintf("accept sign-offs using other associated identities of the commit author's person in the policy (%s only)", tuf.GlobalRuleRequireSignOffType),
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.deniedPatterns,
+		"deny-pattern",
+		[]string{},
+		fmt.Sprintf("patterns of file paths that may not be introduced (%s only)", tuf.GlobalRuleRestrictFilesType),
+	)
+
+	cmd.Flags().Uint64Var(
+		&o.maxBlobSize,
+		"max-blob-size",
+		0,
+		fmt.Sprintf("maximum size in bytes of files that may be introduced, 0 for no limit (%s only)", tuf.GlobalRuleRestrictFilesType),
+	)
+
+	cmd.Flags().StringVar(
+		&o.freezeStart,
+		"freeze-start",
+		"",
+		fmt.Sprintf("start of the freeze in RFC 3339 format, e.g. 2025-12-20T00:00:00Z (%s only)", tuf.GlobalRuleFreezeWindowType),
+	)
+
+	cmd.Flags().StringVar(
+		&o.freezeEnd,
+		"freeze-end",
+		"",
+		fmt.Sprintf("end of the freeze in RFC 3339 format, e.g. 2026-01-05T00:00:00Z (%s only)", tuf.GlobalRuleFreezeWindowType),
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.readers,
+		"reader",
+		[]string{},
+		fmt.Sprintf("principal allowed to read the contents of matching references (%s only)", tuf.GlobalRuleRestrictReadType),
 	)
 }
 
@@ -84,6 +136,63 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 
 		return repo.UpdateGlobalRuleBlockForcePushes(cmd.Context(), signer, o.ruleName, o.rulePatterns, true, opts...)
 
+	case tuf.GlobalRuleRequireSignedCommitsType:
+		if len(o.rulePatterns) == 0 {
+			return fmt.Errorf("required flag --rule-pattern not set for global rule type '%s'", tuf.GlobalRuleRequireSignedCommitsType)
+		}
+
+		return repo.UpdateGlobalRuleRequireSignedCommits(cmd.Context(), signer, o.ruleName, o.rulePatterns, true, opts...)
+
+	case tuf.GlobalRuleRequireSignOffType:
+		if len(o.rulePatterns) == 0 {
+			return fmt.Errorf("required flag --rule-pattern not set for global rule type '%s'", tuf.GlobalRuleRequireSignOffType)
+		}
+
+		return repo.UpdateGlobalRuleRequireSignOff(cmd.Context(), signer, o.ruleName, o.rulePatterns, o.allowAssociatedIdentities, true, opts...)
+
+	case tuf.GlobalRuleRequireLinearHistoryType:
+		if len(o.rulePatterns) == 0 {
+			return fmt.Errorf("required flag --rule-pattern not set for global rule type '%s'", tuf.GlobalRuleRequireLinearHistoryType)
+		}
+
+		return repo.UpdateGlobalRuleRequireLinearHistory(cmd.Context(), signer, o.ruleName, o.rulePatterns, true, opts...)
+
+	case tuf.GlobalRuleRestrictFilesType:
+		if len(o.rulePatterns) == 0 {
+			return fmt.Errorf("required flag --rule-pattern not set for global rule type '%s'", tuf.GlobalRuleRestrictFilesType)
+		}
+
+		return repo.UpdateGlobalRuleRestrictFiles(cmd.Context(), signer, o.ruleName, o.rulePatterns, o.deniedPatterns, o.maxBlobSize, true, opts...)
+
+	case tuf.GlobalRuleFreezeWindowType:
+		if len(o.rulePatterns) == 0 {
+			return fmt.Errorf("required flag --rule-pattern not set for global rule type '%s'", tuf.GlobalRuleFreezeWindowType)
+		}
+		if o.freezeStart == "" || o.freezeEnd == "" {
+			return fmt.Errorf("required flags --freeze-start and --freeze-end not set for global rule type '%s'", tuf.GlobalRuleFreezeWindowType)
+		}
+
+		start, err := time.Parse(time.RFC3339, o.freezeStart)
+		if err != nil {
+			return fmt.Errorf("invalid value for --freeze-start: %w", err)
+		}
+		end, err := time.Parse(time.RFC3339, o.freezeEnd)
+		if err != nil {
+			return fmt.Errorf("invalid value for --freeze-end: %w", err)
+		}
+
+		return repo.UpdateGlobalRuleFreezeWindow(cmd.Context(), signer, o.ruleName, o.rulePatterns, start, end, o.threshold, true, opts...)
+
+	case tuf.GlobalRuleRestrictReadType:
+		if len(o.rulePatterns) == 0 {
+			return fmt.Errorf("required flag --rule-pattern not set for global rule type '%s'", tuf.GlobalRuleRestrictReadType)
+		}
+		if len(o.readers) == 0 {
+			return fmt.Errorf("required flag --reader not set for global rule type '%s'", tuf.GlobalRuleRestrictReadType)
+		}
+
+		return repo.UpdateGlobalRuleRestrictRead(cmd.Context(), signer, o.ruleName, o.rulePatterns, o.readers, true, opts...)
+
 	default:
 		return tuf.ErrUnknownGlobalRuleType
 	}
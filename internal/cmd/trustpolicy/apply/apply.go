@@ -5,11 +5,13 @@ package apply
 
 import (
 	"github.com/gittuf/gittuf/experimental/gittuf"
+	applypolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/applypolicy"
 	"github.com/spf13/cobra"
 )
 
 type options struct {
-	localOnly bool
+	localOnly       bool
+	secretsKeyPaths []string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -19,6 +21,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		false,
 		"apply policy changes locally without pushing to a remote repository",
 	)
+
+	cmd.Flags().StringArrayVar(
+		&o.secretsKeyPaths,
+		"secrets-key",
+		[]string{},
+		"path to the SSH private key of a reader to re-encrypt secrets with",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
@@ -32,7 +41,12 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		remoteName = args[0]
 	}
 
-	return repo.ApplyPolicy(cmd.Context(), remoteName, o.localOnly, true)
+	opts := []applypolicyopts.Option{}
+	for _, keyPath := range o.secretsKeyPaths {
+		opts = append(opts, applypolicyopts.WithSecretsKey(keyPath))
+	}
+
+	return repo.ApplyPolicy(cmd.Context(), remoteName, o.localOnly, true, opts...)
 }
 
 func New() *cobra.Command {
@@ -40,7 +54,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "apply",
 		Short: "Validate and apply changes from policy-staging to policy",
-		Long:  "The 'apply' command validates and applies changes from the policy-staging area to the repository's policy. It is used to make staged policy updates effective and records the change in the RSL. Pass '--local-only' to apply without pushing upstream. Otherwise, supply the remote name as the first positional argument.",
+		Long:  "The 'apply' command validates and applies changes from the policy-staging area to the repository's policy. It is used to make staged policy updates effective and records the change in the RSL. Pass '--local-only' to apply without pushing upstream. Otherwise, supply the remote name as the first positional argument. If the staged policy changes the readers of a secret or their keys, such as when a reader is removed, the secret is re-encrypted for its remaining readers when the policy is applied. Applying such a policy requires the SSH private key of one of each affected secret's readers, passed using '--secrets-key', which can be repeated for secrets with different readers, and fails if the key is missing or any affected secret cannot be re-encrypted. Policy changes that do not affect the readers of any secret do not need a key. Re-encrypting does not revoke a removed reader's access to older versions of a secret that remain in the repository's history, so the value itself must be changed using 'gittuf secrets set'.",
 		RunE:  o.Run,
 	}
 	o.AddFlags(cmd)
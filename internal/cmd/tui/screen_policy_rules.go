@@ -14,13 +14,18 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/tuf"
 )
 
 type rule struct {
-	name      string
-	pattern   string
-	key       string
-	threshold int
+	name        string
+	pattern     string
+	key         string
+	threshold   int
+	description string
+	contact     string
+	link        string
 }
 
 type policyRulesScreen struct {
@@ -40,7 +45,17 @@ func (s *policyRulesScreen) refreshRules(ctx context.Context, o *options) {
 func (s *policyRulesScreen) updateRuleList() {
 	items := make([]list.Item, len(s.rules))
 	for i, r := range s.rules {
-		items[i] = item{title: r.name, desc: fmt.Sprintf("Pattern: %s, Key: %s, Threshold: %d", r.pattern, r.key, r.threshold)}
+		desc := fmt.Sprintf("Pattern: %s, Key: %s, Threshold: %d", r.pattern, r.key, r.threshold)
+		if r.description != "" {
+			desc += fmt.Sprintf(", Description: %s", r.description)
+		}
+		if r.contact != "" {
+			desc += fmt.Sprintf(", Contact: %s", r.contact)
+		}
+		if r.link != "" {
+			desc += fmt.Sprintf(", Link: %s", r.link)
+		}
+		items[i] = item{title: r.name, desc: desc}
 	}
 	s.ruleList.SetItems(items)
 }
@@ -51,6 +66,9 @@ func (s *policyRulesScreen) initRuleInputs() {
 		{"Enter Rule Pattern Here", "Rule Pattern:"},
 		{"Enter Principal IDs Here (comma-separated)", "Authorized Principals:"},
 		{"Enter Threshold", "Threshold:"},
+		{"Enter Description Here (optional)", "Description:"},
+		{"Enter Contact Here (optional)", "Contact:"},
+		{"Enter Link Here (optional)", "Link:"},
 	})
 	s.focusIndex = 0
 }
@@ -61,6 +79,9 @@ func (s *policyRulesScreen) initRuleInputsPrefilled(r rule) {
 	s.inputs[1].SetValue(r.pattern)
 	s.inputs[2].SetValue(r.key)
 	s.inputs[3].SetValue(fmt.Sprintf("%d", r.threshold))
+	s.inputs[4].SetValue(r.description)
+	s.inputs[5].SetValue(r.contact)
+	s.inputs[6].SetValue(r.link)
 }
 
 func (s *policyRulesScreen) cycleFocus(key string) {
@@ -201,10 +222,13 @@ func (s *policyRulesScreen) handlePolicyFormSubmit(m *model) (tea.Model, tea.Cmd
 
 	thr, _ := strconv.Atoi(s.inputs[3].Value())
 	r := rule{
-		name:      s.inputs[0].Value(),
-		pattern:   s.inputs[1].Value(),
-		key:       s.inputs[2].Value(),
-		threshold: thr,
+		name:        s.inputs[0].Value(),
+		pattern:     s.inputs[1].Value(),
+		key:         s.inputs[2].Value(),
+		threshold:   thr,
+		description: strings.TrimSpace(s.inputs[4].Value()),
+		contact:     strings.TrimSpace(s.inputs[5].Value()),
+		link:        strings.TrimSpace(s.inputs[6].Value()),
 	}
 	authorizedKeys := splitAndTrim(s.inputs[2].Value())
 	protectedNamespaces := splitAndTrim(s.inputs[1].Value())
@@ -289,11 +313,21 @@ func getCurrRules(ctx context.Context, o *options) []rule {
 
 	var currRules = make([]rule, len(rules))
 	for i, r := range rules {
+		// Exclusions are shown with their prefix so that editing the rule
+		// preserves them
+		patterns := append([]string{}, r.Delegation.GetProtectedNamespaces()...)
+		for _, excludedPattern := range r.Delegation.GetExcludedNamespaces() {
+			patterns = append(patterns, tuf.ExclusionPatternPrefix+excludedPattern)
+		}
+
 		currRules[i] = rule{
-			name:      r.Delegation.ID(),
-			pattern:   strings.Join(r.Delegation.GetProtectedNamespaces(), ", "),
-			key:       strings.Join(r.Delegation.GetPrincipalIDs().Contents(), ", "),
-			threshold: r.Delegation.GetThreshold(),
+			name:        r.Delegation.ID(),
+			pattern:     strings.Join(patterns, ", "),
+			key:         strings.Join(r.Delegation.GetPrincipalIDs().Contents(), ", "),
+			threshold:   r.Delegation.GetThreshold(),
+			description: r.Delegation.GetDescription(),
+			contact:     r.Delegation.GetContact(),
+			link:        r.Delegation.GetLink(),
 		}
 	}
 	return currRules
@@ -311,7 +345,7 @@ func repoAddRule(ctx context.Context, o *options, rule rule, authorizedPrincipal
 		return err
 	}
 
-	return repo.AddDelegation(ctx, signer, o.policyName, rule.name, authorizedPrincipalIDs, protectedNamespaces, rule.threshold, true)
+	return repo.AddDelegation(ctx, signer, o.policyName, rule.name, authorizedPrincipalIDs, protectedNamespaces, rule.threshold, true, ruleDetailsOptions(rule)...)
 }
 
 // repoUpdateRule updates an existing rule in the policy file.
@@ -326,7 +360,17 @@ func repoUpdateRule(ctx context.Context, o *options, r rule, authorizedPrincipal
 		return err
 	}
 
-	return repo.UpdateDelegation(ctx, signer, o.policyName, r.name, authorizedPrincipalIDs, protectedNamespaces, r.threshold, true)
+	return repo.UpdateDelegation(ctx, signer, o.policyName, r.name, authorizedPrincipalIDs, protectedNamespaces, r.threshold, true, ruleDetailsOptions(r)...)
+}
+
+// ruleDetailsOptions returns the options to record the rule's description,
+// contact, and link as entered in the form.
+func ruleDetailsOptions(r rule) []trustpolicyopts.Option {
+	return []trustpolicyopts.Option{
+		trustpolicyopts.WithRuleDescription(r.description),
+		trustpolicyopts.WithRuleContact(r.contact),
+		trustpolicyopts.WithRuleLink(r.link),
+	}
 }
 
 // repoRemoveRule removes a rule from the policy file.
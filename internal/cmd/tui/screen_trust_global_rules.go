@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -37,6 +38,18 @@ func (s *trustGlobalRulesScreen) updateGlobalRuleList() {
 		if gr.ruleType == tuf.GlobalRuleThresholdType {
 			desc += fmt.Sprintf("\nThreshold: %d", gr.threshold)
 		}
+		if gr.ruleType == tuf.GlobalRuleRequireSignOffType {
+			desc += fmt.Sprintf("\nAllow Associated Identities: %t", gr.allowAssociatedIdentities)
+		}
+		if gr.ruleType == tuf.GlobalRuleFreezeWindowType {
+			desc += fmt.Sprintf("\nFreeze: %s to %s\nOverride Threshold: %d", gr.freezeStart.Format(time.RFC3339), gr.freezeEnd.Format(time.RFC3339), gr.threshold)
+		}
+		if gr.ruleType == tuf.GlobalRuleRestrictFilesType {
+			desc += fmt.Sprintf("\nDenied Patterns: %s\nMax Blob Size: %d", strings.Join(gr.deniedPatterns, ", "), gr.maxBlobSize)
+		}
+		if gr.ruleType == tuf.GlobalRuleRestrictReadType {
+			desc += fmt.Sprintf("\nReaders: %s", strings.Join(gr.readers, ", "))
+		}
 		items[i] = item{title: gr.ruleName, desc: desc}
 	}
 	s.globalRuleList.SetItems(items)
@@ -45,9 +58,15 @@ func (s *trustGlobalRulesScreen) updateGlobalRuleList() {
 func (s *trustGlobalRulesScreen) initGlobalRuleInputs() {
 	s.inputs = initInputs([]inputField{
 		{"Enter Global Rule Name Here", "Rule Name:"},
-		{"Enter Global Rule Type (threshold|block-force-pushes)", "Type:"},
+		{"Enter Global Rule Type (threshold|block-force-pushes|require-signed-commits|require-sign-off|require-linear-history|restrict-files|freeze-window|restrict-read)", "Type:"},
 		{"Enter Namespaces (comma-separated)", "Namespaces:"},
-		{"Enter Threshold (if threshold type)", "Threshold:"},
+		{"Enter Threshold (if threshold type, or override threshold if freeze-window type)", "Threshold:"},
+		{"Allow Associated Identities (if require-sign-off type, true|false)", "Allow Associated Identities:"},
+		{"Enter Denied Patterns (if restrict-files type, comma-separated)", "Denied Patterns:"},
+		{"Enter Max Blob Size in Bytes (if restrict-files type, 0 for no limit)", "Max Blob Size:"},
+		{"Enter Freeze Start (if freeze-window type, e.g. 2025-12-20T00:00:00Z)", "Freeze Start:"},
+		{"Enter Freeze End (if freeze-window type, e.g. 2026-01-05T00:00:00Z)", "Freeze End:"},
+		{"Enter Readers (if restrict-read type, comma-separated)", "Readers:"},
 	})
 	s.focusIndex = 0
 }
@@ -57,9 +76,23 @@ func (s *trustGlobalRulesScreen) initGlobalRuleInputsPrefilled(gr globalRule) {
 	s.inputs[0].SetValue(gr.ruleName)
 	s.inputs[1].SetValue(gr.ruleType)
 	s.inputs[2].SetValue(strings.Join(gr.rulePatterns, ", "))
-	if gr.ruleType == tuf.GlobalRuleThresholdType {
+	if gr.ruleType == tuf.GlobalRuleThresholdType || gr.ruleType == tuf.GlobalRuleFreezeWindowType {
 		s.inputs[3].SetValue(fmt.Sprintf("%d", gr.threshold))
 	}
+	if gr.ruleType == tuf.GlobalRuleRequireSignOffType {
+		s.inputs[4].SetValue(strconv.FormatBool(gr.allowAssociatedIdentities))
+	}
+	if gr.ruleType == tuf.GlobalRuleRestrictFilesType {
+		s.inputs[5].SetValue(strings.Join(gr.deniedPatterns, ", "))
+		s.inputs[6].SetValue(strconv.FormatUint(gr.maxBlobSize, 10))
+	}
+	if gr.ruleType == tuf.GlobalRuleFreezeWindowType {
+		s.inputs[7].SetValue(gr.freezeStart.Format(time.RFC3339))
+		s.inputs[8].SetValue(gr.freezeEnd.Format(time.RFC3339))
+	}
+	if gr.ruleType == tuf.GlobalRuleRestrictReadType {
+		s.inputs[9].SetValue(strings.Join(gr.readers, ", "))
+	}
 }
 
 func (s *trustGlobalRulesScreen) cycleFocus(key string) {
@@ -170,14 +203,50 @@ func (s *trustGlobalRulesScreen) handleGlobalFormSubmit(m *model) (tea.Model, te
 
 	parts := splitAndTrim(s.inputs[2].Value())
 	thr := 0
-	if s.inputs[1].Value() == tuf.GlobalRuleThresholdType {
+	if s.inputs[1].Value() == tuf.GlobalRuleThresholdType || s.inputs[1].Value() == tuf.GlobalRuleFreezeWindowType {
 		thr, _ = strconv.Atoi(s.inputs[3].Value())
 	}
+	allowAssociatedIdentities := false
+	if s.inputs[1].Value() == tuf.GlobalRuleRequireSignOffType {
+		allowAssociatedIdentities, _ = strconv.ParseBool(s.inputs[4].Value())
+	}
+	var deniedPatterns []string
+	var maxBlobSize uint64
+	if s.inputs[1].Value() == tuf.GlobalRuleRestrictFilesType {
+		if s.inputs[5].Value() != "" {
+			deniedPatterns = splitAndTrim(s.inputs[5].Value())
+		}
+		maxBlobSize, _ = strconv.ParseUint(s.inputs[6].Value(), 10, 64)
+	}
+	var freezeStart, freezeEnd time.Time
+	if s.inputs[1].Value() == tuf.GlobalRuleFreezeWindowType {
+		var err error
+		freezeStart, err = time.Parse(time.RFC3339, s.inputs[7].Value())
+		if err != nil {
+			m.errorMsg = fmt.Sprintf("Error: invalid freeze start: %v", err)
+			return *m, nil
+		}
+		freezeEnd, err = time.Parse(time.RFC3339, s.inputs[8].Value())
+		if err != nil {
+			m.errorMsg = fmt.Sprintf("Error: invalid freeze end: %v", err)
+			return *m, nil
+		}
+	}
+	var readers []string
+	if s.inputs[1].Value() == tuf.GlobalRuleRestrictReadType && s.inputs[9].Value() != "" {
+		readers = splitAndTrim(s.inputs[9].Value())
+	}
 	gr := globalRule{
-		ruleName:     s.inputs[0].Value(),
-		ruleType:     s.inputs[1].Value(),
-		rulePatterns: parts,
-		threshold:    thr,
+		ruleName:                  s.inputs[0].Value(),
+		ruleType:                  s.inputs[1].Value(),
+		rulePatterns:              parts,
+		threshold:                 thr,
+		allowAssociatedIdentities: allowAssociatedIdentities,
+		deniedPatterns:            deniedPatterns,
+		maxBlobSize:               maxBlobSize,
+		freezeStart:               freezeStart,
+		freezeEnd:                 freezeEnd,
+		readers:                   readers,
 	}
 
 	var err error
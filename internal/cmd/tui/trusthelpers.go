@@ -6,6 +6,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
@@ -13,10 +14,16 @@ import (
 )
 
 type globalRule struct {
-	ruleName     string
-	ruleType     string
-	rulePatterns []string
-	threshold    int
+	ruleName                  string
+	ruleType                  string
+	rulePatterns              []string
+	threshold                 int
+	allowAssociatedIdentities bool
+	deniedPatterns            []string
+	maxBlobSize               uint64
+	freezeStart               time.Time
+	freezeEnd                 time.Time
+	readers                   []string
 }
 
 // getGlobalRules returns a slice of globalRule for the TUI
@@ -41,6 +48,49 @@ func getGlobalRules(ctx context.Context, o *options) []globalRule {
 				rulePatterns: gRule.GetProtectedNamespaces(),
 				threshold:    gRule.GetThreshold(),
 			}
+		case tuf.GlobalRuleRequireSignedCommits:
+			currRules[i] = globalRule{
+				ruleName:     gRule.GetName(),
+				ruleType:     tuf.GlobalRuleRequireSignedCommitsType,
+				rulePatterns: gRule.GetProtectedNamespaces(),
+			}
+		case tuf.GlobalRuleRequireSignOff:
+			currRules[i] = globalRule{
+				ruleName:                  gRule.GetName(),
+				ruleType:                  tuf.GlobalRuleRequireSignOffType,
+				rulePatterns:              gRule.GetProtectedNamespaces(),
+				allowAssociatedIdentities: gRule.AllowsAssociatedIdentities(),
+			}
+		case tuf.GlobalRuleRequireLinearHistory:
+			currRules[i] = globalRule{
+				ruleName:     gRule.GetName(),
+				ruleType:     tuf.GlobalRuleRequireLinearHistoryType,
+				rulePatterns: gRule.GetProtectedNamespaces(),
+			}
+		case tuf.GlobalRuleRestrictFiles:
+			currRules[i] = globalRule{
+				ruleName:       gRule.GetName(),
+				ruleType:       tuf.GlobalRuleRestrictFilesType,
+				rulePatterns:   gRule.GetProtectedNamespaces(),
+				deniedPatterns: gRule.GetDeniedPatterns(),
+				maxBlobSize:    gRule.GetMaxBlobSize(),
+			}
+		case tuf.GlobalRuleFreezeWindow:
+			currRules[i] = globalRule{
+				ruleName:     gRule.GetName(),
+				ruleType:     tuf.GlobalRuleFreezeWindowType,
+				rulePatterns: gRule.GetProtectedNamespaces(),
+				threshold:    gRule.GetOverrideThreshold(),
+				freezeStart:  gRule.GetStart(),
+				freezeEnd:    gRule.GetEnd(),
+			}
+		case tuf.GlobalRuleRestrictRead:
+			currRules[i] = globalRule{
+				ruleName:     gRule.GetName(),
+				ruleType:     tuf.GlobalRuleRestrictReadType,
+				rulePatterns: gRule.GetProtectedNamespaces(),
+				readers:      gRule.GetReaderPrincipalIDs(),
+			}
 		case tuf.GlobalRuleBlockForcePushes:
 			currRules[i] = globalRule{
 				ruleName:     gRule.GetName(),
@@ -85,6 +135,62 @@ func repoAddGlobalRule(ctx context.Context, o *options, gr globalRule) error {
 			gr.ruleName, gr.rulePatterns,
 			true, opts...,
 		)
+	case tuf.GlobalRuleRequireSignedCommitsType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRequireSignedCommitsType)
+		}
+		return repo.AddGlobalRuleRequireSignedCommits(
+			ctx, signer,
+			gr.ruleName, gr.rulePatterns,
+			true, opts...,
+		)
+	case tuf.GlobalRuleRequireSignOffType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRequireSignOffType)
+		}
+		return repo.AddGlobalRuleRequireSignOff(
+			ctx, signer,
+			gr.ruleName, gr.rulePatterns,
+			gr.allowAssociatedIdentities, true, opts...,
+		)
+	case tuf.GlobalRuleRequireLinearHistoryType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRequireLinearHistoryType)
+		}
+		return repo.AddGlobalRuleRequireLinearHistory(
+			ctx, signer,
+			gr.ruleName, gr.rulePatterns,
+			true, opts...,
+		)
+	case tuf.GlobalRuleRestrictFilesType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRestrictFilesType)
+		}
+		return repo.AddGlobalRuleRestrictFiles(
+			ctx, signer,
+			gr.ruleName, gr.rulePatterns,
+			gr.deniedPatterns, gr.maxBlobSize,
+			true, opts...,
+		)
+	case tuf.GlobalRuleFreezeWindowType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleFreezeWindowType)
+		}
+		return repo.AddGlobalRuleFreezeWindow(
+			ctx, signer,
+			gr.ruleName, gr.rulePatterns,
+			gr.freezeStart, gr.freezeEnd,
+			gr.threshold, true, opts...,
+		)
+	case tuf.GlobalRuleRestrictReadType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRestrictReadType)
+		}
+		return repo.AddGlobalRuleRestrictRead(
+			ctx, signer,
+			gr.ruleName, gr.rulePatterns,
+			gr.readers, true, opts...,
+		)
 	default:
 		return fmt.Errorf("unknown global rule type %q", gr.ruleType)
 	}
@@ -138,6 +244,50 @@ func repoUpdateGlobalRule(ctx context.Context, o *options, gr globalRule) error
 
 		return repo.UpdateGlobalRuleBlockForcePushes(ctx, signer, gr.ruleName, gr.rulePatterns, true, opts...)
 
+	case tuf.GlobalRuleRequireSignedCommitsType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRequireSignedCommitsType)
+		}
+
+		return repo.UpdateGlobalRuleRequireSignedCommits(ctx, signer, gr.ruleName, gr.rulePatterns, true, opts...)
+
+	case tuf.GlobalRuleRequireSignOffType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRequireSignOffType)
+		}
+
+		return repo.UpdateGlobalRuleRequireSignOff(ctx, signer, gr.ruleName, gr.rulePatterns, gr.allowAssociatedIdentities, true, opts...)
+
+	case tuf.GlobalRuleRequireLinearHistoryType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRequireLinearHistoryType)
+		}
+
+		return repo.UpdateGlobalRuleRequireLinearHistory(ctx, signer, gr.ruleName, gr.rulePatterns, true, opts...)
+
+	case tuf.GlobalRuleRestrictFilesType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRestrictFilesType)
+		}
+
+		return repo.UpdateGlobalRuleRestrictFiles(ctx, signer, gr.ruleName, gr.rulePatterns, gr.deniedPatterns, gr.maxBlobSize, true, opts...)
+
+	case tuf.GlobalRuleFreezeWindowType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleFreezeWindowType)
+		}
+
+		return repo.UpdateGlobalRuleFreezeWindow(ctx, signer, gr.ruleName, gr.rulePatterns, gr.freezeStart, gr.freezeEnd, gr.threshold, true, opts...)
+
+	case tuf.GlobalRuleRestrictReadType:
+		if len(gr.rulePatterns) == 0 {
+			return fmt.Errorf("namespaces not set for global rule type '%s'", tuf.GlobalRuleRestrictReadType)
+		}
+		return repo.UpdateGlobalRuleRestrictRead(
+			ctx, signer,
+			gr.ruleName, gr.rulePatterns,
+			gr.readers, true, opts...,
+		)
 	default:
 		return tuf.ErrUnknownGlobalRuleType
 	}
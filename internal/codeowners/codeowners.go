@@ -0,0 +1,176 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	commentPrefix     = "#"
+	filePatternPrefix = "file:"
+)
+
+var ErrInvalidEntry = errors.New("invalid CODEOWNERS entry")
+
+// Entry is a single line of a CODEOWNERS file, associating a pattern with the
+// owners of the paths it matches. An entry may have no owners, in which case
+// the matching paths are explicitly left unowned.
+type Entry struct {
+	Line    int
+	Pattern string
+	Owners  []string
+}
+
+// Parse reads the entries of a CODEOWNERS file. Entries are returned in the
+// order they are declared, which means later entries take precedence over
+// earlier ones for paths they both match. Section headers used by some forges
+// (e.g., "[Docs]") are skipped.
+func Parse(contents []byte) ([]*Entry, error) {
+	entries := []*Entry{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := stripComment(scanner.Text())
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			// Section header
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		if strings.HasPrefix(pattern, "!") {
+			// Negation is not supported in CODEOWNERS files
+			return nil, fmt.Errorf("%w: line %d: '%s'", ErrInvalidEntry, lineNumber, fields[0])
+		}
+
+		entries = append(entries, &Entry{
+			Line:    lineNumber,
+			Pattern: pattern,
+			Owners:  fields[1:],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// RulePatterns returns the gittuf file rule patterns equivalent to the entry's
+// pattern. As gittuf patterns are matched against the full path, a pattern that
+// is not anchored to the root of the repository is expanded to also match in
+// any subdirectory, and a pattern that may name a directory is expanded to
+// match the directory's contents. Note that a single "*" in a gittuf pattern
+// also matches across directories, so entries such as "docs/*" also protect
+// files nested more deeply under "docs/".
+func (e *Entry) RulePatterns() []string {
+	pattern := e.Pattern
+
+	anchored := false
+	if rest, hasPrefix := strings.CutPrefix(pattern, "**/"); hasPrefix {
+		pattern = rest
+	} else if rest, hasPrefix := strings.CutPrefix(pattern, "/"); hasPrefix {
+		pattern = rest
+		anchored = true
+	}
+
+	directoryOnly := false
+	if rest, hasSuffix := strings.CutSuffix(pattern, "/"); hasSuffix {
+		pattern = rest
+		directoryOnly = true
+	}
+
+	if strings.Contains(pattern, "/") {
+		// A separator in the middle of the pattern makes it relative to the
+		// root of the repository
+		anchored = true
+	}
+
+	pattern = strings.ReplaceAll(pattern, "**", "*")
+
+	var candidates []string
+	switch {
+	case pattern == "*":
+		candidates = []string{"*"}
+	case directoryOnly:
+		candidates = []string{pattern + "/*"}
+	case strings.HasSuffix(pattern, "*"):
+		candidates = []string{pattern}
+	default:
+		candidates = []string{pattern, pattern + "/*"}
+	}
+
+	rulePatterns := []string{}
+	for _, candidate := range candidates {
+		rulePatterns = append(rulePatterns, filePatternPrefix+candidate)
+		if !anchored && candidate != "*" && !strings.HasPrefix(candidate, "*") {
+			rulePatterns = append(rulePatterns, filePatternPrefix+"*/"+candidate)
+		}
+	}
+
+	return rulePatterns
+}
+
+// PatternFromRulePattern returns the CODEOWNERS pattern that approximates the
+// gittuf file rule pattern. A pattern that matches a directory's contents is
+// converted to match the directory, and a pattern prefixed with "*/" is treated
+// as matching in any directory. It returns false if rulePattern does not
+// protect files.
+func PatternFromRulePattern(rulePattern string) (string, bool) {
+	pattern, isFilePattern := strings.CutPrefix(rulePattern, filePatternPrefix)
+	if !isFilePattern || pattern == "" {
+		return "", false
+	}
+
+	if pattern == "*" {
+		return pattern, true
+	}
+
+	anchored := true
+	if rest, hasPrefix := strings.CutPrefix(pattern, "*/"); hasPrefix {
+		pattern = rest
+		anchored = false
+	} else if strings.HasPrefix(pattern, "*") {
+		anchored = false
+	}
+
+	if rest, hasSuffix := strings.CutSuffix(pattern, "/*"); hasSuffix {
+		pattern = rest + "/"
+	}
+
+	if anchored {
+		pattern = "/" + pattern
+	}
+
+	return strings.ReplaceAll(pattern, "#", `\#`), true
+}
+
+// stripComment removes a trailing comment from the line. An escaped "\#" is
+// not treated as the start of a comment.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(line[i:], commentPrefix) {
+			return line[:i]
+		}
+	}
+
+	return line
+}
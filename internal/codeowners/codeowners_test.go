@@ -0,0 +1,136 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		contents := []byte(`# Default owners
+*       @global-owner
+
+[Documentation]
+/docs/  @octocat docs@example.com # docs team
+*.js    @js-owner
+\#notes
+/build/logs/
+`)
+
+		entries, err := Parse(contents)
+		assert.Nil(t, err)
+		assert.Equal(t, []*Entry{
+			{Line: 2, Pattern: "*", Owners: []string{"@global-owner"}},
+			{Line: 5, Pattern: "/docs/", Owners: []string{"@octocat", "docs@example.com"}},
+			{Line: 6, Pattern: "*.js", Owners: []string{"@js-owner"}},
+			{Line: 7, Pattern: "#notes", Owners: []string{}},
+			{Line: 8, Pattern: "/build/logs/", Owners: []string{}},
+		}, entries)
+	})
+
+	t.Run("negated pattern", func(t *testing.T) {
+		_, err := Parse([]byte("!/docs/ @octocat\n"))
+		assert.ErrorIs(t, err, ErrInvalidEntry)
+	})
+}
+
+func TestRulePatterns(t *testing.T) {
+	tests := map[string]struct {
+		pattern          string
+		expectedPatterns []string
+	}{
+		"everything": {
+			pattern:          "*",
+			expectedPatterns: []string{"file:*"},
+		},
+		"extension": {
+			pattern:          "*.js",
+			expectedPatterns: []string{"file:*.js", "file:*.js/*"},
+		},
+		"anchored directory": {
+			pattern:          "/docs/",
+			expectedPatterns: []string{"file:docs/*"},
+		},
+		"unanchored directory": {
+			pattern:          "apps/",
+			expectedPatterns: []string{"file:apps/*", "file:*/apps/*"},
+		},
+		"unanchored name": {
+			pattern:          "Makefile",
+			expectedPatterns: []string{"file:Makefile", "file:*/Makefile", "file:Makefile/*", "file:*/Makefile/*"},
+		},
+		"nested path": {
+			pattern:          "src/main.go",
+			expectedPatterns: []string{"file:src/main.go", "file:src/main.go/*"},
+		},
+		"directory contents": {
+			pattern:          "docs/*",
+			expectedPatterns: []string{"file:docs/*"},
+		},
+		"any depth": {
+			pattern:          "**/logs",
+			expectedPatterns: []string{"file:logs", "file:*/logs", "file:logs/*", "file:*/logs/*"},
+		},
+		"trailing double star": {
+			pattern:          "/vendor/**",
+			expectedPatterns: []string{"file:vendor/*"},
+		},
+	}
+
+	for name, test := range tests {
+		entry := &Entry{Pattern: test.pattern}
+		assert.Equal(t, test.expectedPatterns, entry.RulePatterns(), name)
+	}
+}
+
+func TestPatternFromRulePattern(t *testing.T) {
+	tests := map[string]struct {
+		rulePattern     string
+		expectedPattern string
+		expectedOK      bool
+	}{
+		"everything": {
+			rulePattern:     "file:*",
+			expectedPattern: "*",
+			expectedOK:      true,
+		},
+		"extension": {
+			rulePattern:     "file:*.js",
+			expectedPattern: "*.js",
+			expectedOK:      true,
+		},
+		"directory": {
+			rulePattern:     "file:docs/*",
+			expectedPattern: "/docs/",
+			expectedOK:      true,
+		},
+		"directory in any location": {
+			rulePattern:     "file:*/docs/*",
+			expectedPattern: "docs/",
+			expectedOK:      true,
+		},
+		"file": {
+			rulePattern:     "file:src/main.go",
+			expectedPattern: "/src/main.go",
+			expectedOK:      true,
+		},
+		"escaped comment": {
+			rulePattern:     "file:#notes",
+			expectedPattern: `/\#notes`,
+			expectedOK:      true,
+		},
+		"git namespace": {
+			rulePattern: "git:refs/heads/main",
+		},
+	}
+
+	for name, test := range tests {
+		pattern, ok := PatternFromRulePattern(test.rulePattern)
+		assert.Equal(t, test.expectedOK, ok, name)
+		assert.Equal(t, test.expectedPattern, pattern, name)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hpke"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"filippo.io/edwards25519"
+	sslibsvssh "github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DataKeySize is the size in bytes of the symmetric keys used to encrypt
+	// contents.
+	DataKeySize = 32
+
+	keyWrapInfo = "gittuf encryption key wrap"
+)
+
+var rsaOAEPLabel = []byte("gittuf")
+
+var (
+	ErrUnsupportedKeyType = errors.New("key type is not supported for encryption")
+	ErrInvalidDataKey     = errors.New("invalid data key")
+	ErrDecryptionFailed   = errors.New("unable to decrypt contents")
+)
+
+// NewDataKey returns a random symmetric key used to encrypt contents.
+func NewDataKey() ([]byte, error) {
+	dataKey := make([]byte, DataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+
+	return dataKey, nil
+}
+
+// Seal encrypts the plaintext using the data key with AES-256-GCM. The
+// additional data is authenticated but not encrypted, and the same additional
+// data must be presented to Open. The nonce is prepended to the returned
+// ciphertext.
+func Seal(dataKey, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts ciphertext created by Seal using the data key. Decryption fails
+// if the additional data does not match the additional data used with Seal.
+func Open(dataKey, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+
+	return plaintext, nil
+}
+
+// WrapKey encrypts the data key to the specified public key so that only the
+// holder of the corresponding private key can recover it. SSH ed25519, ECDSA,
+// and RSA keys are supported. RSA keys use RSA-OAEP with SHA-256. ECDSA keys,
+// and ed25519 keys converted to X25519, use single-shot HPKE (RFC 9180) in
+// base mode with the DHKEM for the key's curve, HKDF-SHA256, and AES-256-GCM.
+func WrapKey(dataKey []byte, key *signerverifier.SSLibKey) ([]byte, error) {
+	if len(dataKey) != DataKeySize {
+		return nil, ErrInvalidDataKey
+	}
+
+	publicKey, err := loadPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, dataKey, rsaOAEPLabel)
+	case *ecdh.PublicKey:
+		hpkeKey, err := hpke.NewDHKEMPublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return hpke.Seal(hpkeKey, hpke.HKDFSHA256(), hpke.AES256GCM(), []byte(keyWrapInfo), dataKey)
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
+
+// SupportsKey returns true if data keys can be wrapped for the specified key
+// using WrapKey.
+func SupportsKey(key *signerverifier.SSLibKey) bool {
+	_, err := loadPublicKey(key)
+	return err == nil
+}
+
+// UnwrapKey recovers a data key encrypted by WrapKey using the private key.
+func UnwrapKey(wrappedKey []byte, privateKey crypto.PrivateKey) ([]byte, error) {
+	var (
+		dataKey []byte
+		err     error
+	)
+
+	switch privateKey := privateKey.(type) {
+	case *rsa.PrivateKey:
+		dataKey, err = rsa.DecryptOAEP(sha256.New(), nil, privateKey, wrappedKey, rsaOAEPLabel)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+		}
+	case *ecdh.PrivateKey:
+		hpkeKey, err := hpke.NewDHKEMPrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		dataKey, err = hpke.Open(hpkeKey, hpke.HKDFSHA256(), hpke.AES256GCM(), []byte(keyWrapInfo), wrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+		}
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+
+	if len(dataKey) != DataKeySize {
+		return nil, ErrInvalidDataKey
+	}
+
+	return dataKey, nil
+}
+
+// LoadPrivateKey loads the unencrypted SSH private key at the specified path
+// for decryption. It returns the private key along with the ID of the
+// corresponding public key in gittuf's policy.
+func LoadPrivateKey(path string) (crypto.PrivateKey, string, error) {
+	keyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rawKey, err := ssh.ParseRawPrivateKey(keyBytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to load private key '%s': %w", path, err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(rawKey)
+	if err != nil {
+		return nil, "", err
+	}
+	keyID := ssh.FingerprintSHA256(signer.PublicKey())
+
+	switch rawKey := rawKey.(type) {
+	case *rsa.PrivateKey:
+		return rawKey, keyID, nil
+	case *ecdsa.PrivateKey:
+		privateKey, err := rawKey.ECDH()
+		if err != nil {
+			return nil, "", err
+		}
+		return privateKey, keyID, nil
+	case *ed25519.PrivateKey:
+		privateKey, err := ed25519PrivateKeyToX25519(*rawKey)
+		if err != nil {
+			return nil, "", err
+		}
+		return privateKey, keyID, nil
+	default:
+		return nil, "", ErrUnsupportedKeyType
+	}
+}
+
+// loadPublicKey returns the public key to encrypt to for the gittuf key. RSA
+// keys are returned as is, while ECDSA and ed25519 keys are converted to keys
+// for ECDH.
+func loadPublicKey(key *signerverifier.SSLibKey) (crypto.PublicKey, error) {
+	if key.KeyType != sslibsvssh.KeyType {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyType, key.KeyType)
+	}
+
+	keyBytes, err := base64.StdEncoding.DecodeString(key.KeyVal.Public)
+	if err != nil {
+		return nil, err
+	}
+
+	sshKey, err := ssh.ParsePublicKey(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoKey, ok := sshKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyType, sshKey.Type())
+	}
+
+	switch publicKey := cryptoKey.CryptoPublicKey().(type) {
+	case *rsa.PublicKey:
+		return publicKey, nil
+	case *ecdsa.PublicKey:
+		return publicKey.ECDH()
+	case ed25519.PublicKey:
+		point, err := new(edwards25519.Point).SetBytes(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		return ecdh.X25519().NewPublicKey(point.BytesMontgomery())
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyType, sshKey.Type())
+	}
+}
+
+// ed25519PrivateKeyToX25519 converts an ed25519 private key to the X25519
+// private key corresponding to its converted public key, as described in RFC
+// 8032 section 5.1.5.
+func ed25519PrivateKeyToX25519(privateKey ed25519.PrivateKey) (*ecdh.PrivateKey, error) {
+	digest := sha512.Sum512(privateKey.Seed())
+	return ecdh.X25519().NewPrivateKey(digest[:32])
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != DataKeySize {
+		return nil, ErrInvalidDataKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,171 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	sslibsvssh "github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpen(t *testing.T) {
+	dataKey, err := NewDataKey()
+	require.Nil(t, err)
+
+	ciphertext, err := Seal(dataKey, []byte("secret contents"), []byte("README.md"))
+	require.Nil(t, err)
+	assert.NotContains(t, string(ciphertext), "secret contents")
+
+	plaintext, err := Open(dataKey, ciphertext, []byte("README.md"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("secret contents"), plaintext)
+
+	otherDataKey, err := NewDataKey()
+	require.Nil(t, err)
+
+	_, err = Open(otherDataKey, ciphertext, []byte("README.md"))
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = Open(dataKey, ciphertext, []byte("src/fix.go"))
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = Seal([]byte("short"), []byte("secret contents"), nil)
+	assert.ErrorIs(t, err, ErrInvalidDataKey)
+}
+
+func TestWrapUnwrapKey(t *testing.T) {
+	tests := map[string]struct {
+		privateKeyBytes []byte
+		publicKeyBytes  []byte
+	}{
+		"ed25519": {
+			privateKeyBytes: artifacts.SSHED25519Private,
+			publicKeyBytes:  artifacts.SSHED25519PublicSSH,
+		},
+		"ecdsa": {
+			privateKeyBytes: artifacts.SSHECDSAPrivate,
+			publicKeyBytes:  artifacts.SSHECDSAPublicSSH,
+		},
+		"rsa": {
+			privateKeyBytes: artifacts.SSHRSAPrivate,
+			publicKeyBytes:  artifacts.SSHRSAPublicSSH,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			publicKey := sslibsvssh.NewKeyFromBytes(t, test.publicKeyBytes)
+
+			privateKeyPath := filepath.Join(t.TempDir(), "key")
+			require.Nil(t, os.WriteFile(privateKeyPath, test.privateKeyBytes, 0o600))
+
+			privateKey, keyID, err := LoadPrivateKey(privateKeyPath)
+			require.Nil(t, err)
+			assert.Equal(t, publicKey.KeyID, keyID)
+
+			dataKey, err := NewDataKey()
+			require.Nil(t, err)
+
+			wrappedKey, err := WrapKey(dataKey, publicKey)
+			require.Nil(t, err)
+
+			unwrappedKey, err := UnwrapKey(wrappedKey, privateKey)
+			assert.Nil(t, err)
+			assert.Equal(t, dataKey, unwrappedKey)
+		})
+	}
+
+	t.Run("wrong private key", func(t *testing.T) {
+		publicKey := sslibsvssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH)
+
+		privateKeyPath := filepath.Join(t.TempDir(), "key")
+		require.Nil(t, os.WriteFile(privateKeyPath, artifacts.SSHECDSAPrivate, 0o600))
+
+		privateKey, _, err := LoadPrivateKey(privateKeyPath)
+		require.Nil(t, err)
+
+		dataKey, err := NewDataKey()
+		require.Nil(t, err)
+
+		wrappedKey, err := WrapKey(dataKey, publicKey)
+		require.Nil(t, err)
+
+		_, err = UnwrapKey(wrappedKey, privateKey)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		publicKey, err := gpg.LoadGPGKeyFromBytes(artifacts.GPGKey1Public)
+		require.Nil(t, err)
+
+		dataKey, err := NewDataKey()
+		require.Nil(t, err)
+
+		_, err = WrapKey(dataKey, publicKey)
+		assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+	})
+}
+
+func TestSupportsKey(t *testing.T) {
+	assert.True(t, SupportsKey(sslibsvssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH)))
+	assert.True(t, SupportsKey(sslibsvssh.NewKeyFromBytes(t, artifacts.SSHECDSAPublicSSH)))
+	assert.True(t, SupportsKey(sslibsvssh.NewKeyFromBytes(t, artifacts.SSHRSAPublicSSH)))
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(artifacts.GPGKey1Public)
+	require.Nil(t, err)
+	assert.False(t, SupportsKey(gpgKey))
+}
+
+func TestUnwrapKeyKnownAnswers(t *testing.T) {
+	// These wrapped keys were created using WrapKey, and ensure that the
+	// data keys wrapped by earlier versions of gittuf can still be unwrapped
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+
+	tests := map[string]struct {
+		privateKeyBytes []byte
+		wrappedKey      string
+	}{
+		"ed25519": {
+			privateKeyBytes: artifacts.SSHED25519Private,
+			wrappedKey:      "3c664e462b4e019db697fd19f997484086ff5f424dc2176baa31171685578d1ca039fd3f7c1a50f6ab99399920c50ad906291447add86281ceef5dff390cb4444a349c8354e6d3ce1d5bc6b6b529a3cf",
+		},
+		"ecdsa": {
+			privateKeyBytes: artifacts.SSHECDSAPrivate,
+			wrappedKey:      "043491e3f86feea2f18a2c8b28a7879808375081f1b4d812a655f24c09f8a5330ed7d79e2b34d6c91c6f4d4f8518adbf05d4f57ef2c3b4f1a6e02c2b7f52330b4066db62e89dd1ab42195c22dcb03b4fb2d429873eaa309e8d3e265d64ec380aefcb62accf305b1932196c962df889d337",
+		},
+		"rsa": {
+			privateKeyBytes: artifacts.SSHRSAPrivate,
+			wrappedKey:      "0f656a119437ee38bdb7810bbd82b40c5965441abd1af3e2e43615fa70780777aecfb0ef56886492730fdfbea0c30a9114b880ec86c1b0268ddc1e2c8e4ae6e94279da564e2f718da10056692415c6ce250c9856688e2c22794627a9c6b393ffd95238811787de74549a88c0f4963c18036d44c3aeb5d652c3d550ae79d7ac155a17630584098d25e91a30f04846ad864cafcdcd075236cf5fbd8c462fca89c6b5b00ccab1bc46dfcddaf022f493c1fcb51d4cd35f6e65127f521c760921e1202e213f0747ff3a59dc1fda64b816f2be4a60a634a45db26d9446edbcc9829093095f15f42ae5806e0cefd69c073977c795d7e04069edadda72c740f06de8f3531f8413722b510d42d73f8009b6da0852ebfbabbb7bdfa9fd3a15a0430856859af12f212bbbe22eb6935872065d0f38ec2c5b22cde6707078f0636b2b6b42a0e91cb36631c841ffde7f269c23e3d517d1c1d521d622378646a14e19b363b0e29e73e648a703e6398a3de766781821fbc4e1ca69efe73a6c357b5fc0adbf571ab5",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			privateKeyPath := filepath.Join(t.TempDir(), "key")
+			require.Nil(t, os.WriteFile(privateKeyPath, test.privateKeyBytes, 0o600))
+
+			privateKey, _, err := LoadPrivateKey(privateKeyPath)
+			require.Nil(t, err)
+
+			wrappedKey, err := hex.DecodeString(test.wrappedKey)
+			require.Nil(t, err)
+
+			unwrappedKey, err := UnwrapKey(wrappedKey, privateKey)
+			assert.Nil(t, err)
+			assert.Equal(t, dataKey, unwrappedKey)
+
+			// Any modification to the wrapped key must be detected
+			wrappedKey[len(wrappedKey)-1] ^= 1
+			_, err = UnwrapKey(wrappedKey, privateKey)
+			assert.ErrorIs(t, err, ErrDecryptionFailed)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+)
+
+const (
+	// ManifestFileName is the name of the file at the root of an encrypted
+	// tree that records the data key wrapped for each reader.
+	ManifestFileName = ".gittuf-encryption.json"
+
+	manifestVersion = 1
+)
+
+var (
+	ErrManifestNotFound        = errors.New("encryption manifest not found in tree")
+	ErrNotARecipient           = errors.New("key is not a recipient of the encrypted contents")
+	ErrTreeHasManifestFileName = errors.New("tree to encrypt already contains encryption manifest file")
+	ErrFileNotInManifest       = errors.New("file is not recorded in the encryption manifest")
+	ErrFileNotEncrypted        = errors.New("file in encrypted tree is not an encrypted blob")
+)
+
+// Manifest records the data key used to encrypt the contents of a tree,
+// wrapped for each of the keys allowed to read it. For an encrypted tree, it
+// also records the mode of each file in the original tree, as every encrypted
+// file is stored as a regular file.
+type Manifest struct {
+	Version    int               `json:"version"`
+	Recipients []*Recipient      `json:"recipients"`
+	Files      map[string]string `json:"files,omitempty"`
+}
+
+// File is a file decrypted from an encrypted tree along with its mode in the
+// original tree.
+type File struct {
+	Mode     string
+	Contents []byte
+}
+
+// Recipient records the data key wrapped for a single key.
+type Recipient struct {
+	KeyID      string `json:"keyID"`
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+// NewManifest wraps the data key for each of the specified keys.
+func NewManifest(dataKey []byte, keys []*signerverifier.SSLibKey) (*Manifest, error) {
+	manifest := &Manifest{Version: manifestVersion, Recipients: []*Recipient{}}
+	for _, key := range keys {
+		if manifest.HasRecipient(key.KeyID) {
+			continue
+		}
+
+		wrappedKey, err := WrapKey(dataKey, key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to wrap data key for '%s': %w", key.KeyID, err)
+		}
+
+		manifest.Recipients = append(manifest.Recipients, &Recipient{KeyID: key.KeyID, WrappedKey: wrappedKey})
+	}
+
+	slices.SortFunc(manifest.Recipients, func(a, b *Recipient) int {
+		return strings.Compare(a.KeyID, b.KeyID)
+	})
+
+	return manifest, nil
+}
+
+// HasRecipient returns true if the data key is wrapped for the specified key.
+func (m *Manifest) HasRecipient(keyID string) bool {
+	for _, recipient := range m.Recipients {
+		if recipient.KeyID == keyID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetDataKey unwraps the data key using the private key of the specified
+// recipient.
+func (m *Manifest) GetDataKey(privateKey crypto.PrivateKey, keyID string) ([]byte, error) {
+	for _, recipient := range m.Recipients {
+		if recipient.KeyID == keyID {
+			return UnwrapKey(recipient.WrappedKey, privateKey)
+		}
+	}
+
+	return nil, ErrNotARecipient
+}
+
+// LoadManifest loads the encryption manifest at the root of the specified
+// tree.
+func LoadManifest(repo *gitinterface.Repository, treeID gitinterface.Hash) (*Manifest, error) {
+	blobID, err := repo.GetPathIDInTree(ManifestFileName, treeID)
+	if err != nil {
+		if errors.Is(err, gitinterface.ErrTreeDoesNotHavePath) {
+			return nil, ErrManifestNotFound
+		}
+		return nil, err
+	}
+
+	manifestBytes, err := repo.ReadBlob(blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse encryption manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// VerifyTree checks that every file in the specified encrypted tree other than
+// the manifest is an encrypted blob recorded in the manifest. This does not
+// require the data key, so it cannot check that the blobs can be decrypted.
+func (m *Manifest) VerifyTree(repo *gitinterface.Repository, treeID gitinterface.Hash) error {
+	modes, err := repo.GetAllFileModesInTree(treeID)
+	if err != nil {
+		return err
+	}
+
+	for path, mode := range modes {
+		if path == ManifestFileName {
+			continue
+		}
+
+		if _, has := m.Files[path]; !has {
+			return fmt.Errorf("%w: '%s'", ErrFileNotInManifest, path)
+		}
+
+		// Encrypted files are always written as regular files
+		if mode != gitinterface.ModeRegularFile {
+			return fmt.Errorf("%w: '%s'", ErrFileNotEncrypted, path)
+		}
+	}
+
+	return nil
+}
+
+// EncryptTree writes a tree in which the contents of every file in the
+// specified tree are encrypted with a new data key, and the data key is
+// wrapped for each of the specified keys in the encryption manifest. File
+// paths and modes are not encrypted, but each file's contents are bound to its
+// path and mode so that encrypted files cannot be moved or swapped within the
+// tree. For submodules, the commit ID is encrypted.
+func EncryptTree(repo *gitinterface.Repository, treeID gitinterface.Hash, keys []*signerverifier.SSLibKey) (gitinterface.Hash, error) {
+	files, err := repo.GetAllFilesInTree(treeID)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	if _, has := files[ManifestFileName]; has {
+		return gitinterface.ZeroHash, ErrTreeHasManifestFileName
+	}
+
+	modes, err := repo.GetAllFileModesInTree(treeID)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	dataKey, err := NewDataKey()
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	manifest, err := NewManifest(dataKey, keys)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+	manifest.Files = map[string]string{}
+
+	entries := []gitinterface.TreeEntry{}
+	for path, objectID := range files {
+		mode := modes[path]
+
+		var contents []byte
+		if mode == gitinterface.ModeGitlink {
+			// The submodule's commit is not in this repository
+			contents = []byte(objectID.String())
+		} else {
+			contents, err = repo.ReadBlob(objectID)
+			if err != nil {
+				return gitinterface.ZeroHash, err
+			}
+		}
+
+		ciphertext, err := Seal(dataKey, contents, fileAdditionalData(path, mode))
+		if err != nil {
+			return gitinterface.ZeroHash, err
+		}
+
+		encryptedBlobID, err := repo.WriteBlob(ciphertext)
+		if err != nil {
+			return gitinterface.ZeroHash, err
+		}
+
+		entries = append(entries, gitinterface.NewEntryBlob(path, encryptedBlobID))
+		manifest.Files[path] = mode
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	manifestBlobID, err := repo.WriteBlob(manifestBytes)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+	entries = append(entries, gitinterface.NewEntryBlob(ManifestFileName, manifestBlobID))
+
+	return gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(entries)
+}
+
+// DecryptTree returns the decrypted contents and original mode of every file
+// in the specified encrypted tree using the private key of one of its
+// recipients.
+func DecryptTree(repo *gitinterface.Repository, treeID gitinterface.Hash, privateKey crypto.PrivateKey, keyID string) (map[string]*File, error) {
+	manifest, err := LoadManifest(repo, treeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manifest.VerifyTree(repo, treeID); err != nil {
+		return nil, err
+	}
+
+	dataKey, err := manifest.GetDataKey(privateKey, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := repo.GetAllFilesInTree(treeID)
+	if err != nil {
+		return nil, err
+	}
+
+	decryptedFiles := map[string]*File{}
+	for path, blobID := range files {
+		if path == ManifestFileName {
+			continue
+		}
+
+		mode := manifest.Files[path]
+		ciphertext, err := repo.ReadBlob(blobID)
+		if err != nil {
+			return nil, err
+		}
+
+		plaintext, err := Open(dataKey, ciphertext, fileAdditionalData(path, mode))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt '%s': %w", path, err)
+		}
+
+		decryptedFiles[path] = &File{Mode: mode, Contents: plaintext}
+	}
+
+	return decryptedFiles, nil
+}
+
+// fileAdditionalData returns the additional data used to bind a file's
+// encrypted contents to its path and mode, in the same form as the file's
+// entry in a Git tree.
+func fileAdditionalData(path, mode string) []byte {
+	return []byte(mode + " " + path)
+}
@@ -0,0 +1,201 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecryptTree(t *testing.T) {
+	repo := gitinterface.CreateTestGitRepository(t, t.TempDir(), false)
+
+	readmeID, err := repo.WriteBlob([]byte("embargoed readme"))
+	require.Nil(t, err)
+	fixID, err := repo.WriteBlob([]byte("embargoed fix"))
+	require.Nil(t, err)
+
+	treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries([]gitinterface.TreeEntry{
+		gitinterface.NewEntryBlob("README.md", readmeID),
+		gitinterface.NewEntryBlob("src/fix.go", fixID),
+	})
+	require.Nil(t, err)
+
+	readerKey := ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH)
+	otherKey := ssh.NewKeyFromBytes(t, artifacts.SSHRSAPublicSSH)
+
+	encryptedTreeID, err := EncryptTree(repo, treeID, []*signerverifier.SSLibKey{readerKey, otherKey})
+	require.Nil(t, err)
+
+	files, err := repo.GetAllFilesInTree(encryptedTreeID)
+	require.Nil(t, err)
+	assert.Len(t, files, 3)
+	assert.Contains(t, files, ManifestFileName)
+	assert.NotEqual(t, readmeID, files["README.md"])
+	assert.NotEqual(t, fixID, files["src/fix.go"])
+
+	manifest, err := LoadManifest(repo, encryptedTreeID)
+	require.Nil(t, err)
+	assert.True(t, manifest.HasRecipient(readerKey.KeyID))
+	assert.True(t, manifest.HasRecipient(otherKey.KeyID))
+
+	privateKeyPath := filepath.Join(t.TempDir(), "key")
+	require.Nil(t, os.WriteFile(privateKeyPath, artifacts.SSHED25519Private, 0o600))
+	privateKey, keyID, err := LoadPrivateKey(privateKeyPath)
+	require.Nil(t, err)
+
+	contents, err := DecryptTree(repo, encryptedTreeID, privateKey, keyID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*File{
+		"README.md":  {Mode: gitinterface.ModeRegularFile, Contents: []byte("embargoed readme")},
+		"src/fix.go": {Mode: gitinterface.ModeRegularFile, Contents: []byte("embargoed fix")},
+	}, contents)
+
+	t.Run("file modes", func(t *testing.T) {
+		scriptID, err := repo.WriteBlob([]byte("#!/bin/sh\n"))
+		require.Nil(t, err)
+		linkID, err := repo.WriteBlob([]byte("README.md"))
+		require.Nil(t, err)
+		submoduleID, err := gitinterface.NewHash("0123456789abcdef0123456789abcdef01234567")
+		require.Nil(t, err)
+
+		// The tree builder only writes regular files
+		treeInput := fmt.Sprintf("%s blob %s\tREADME.md\n%s blob %s\tbuild.sh\n%s blob %s\tREADME.link\n%s commit %s\tvendor\n",
+			gitinterface.ModeRegularFile, readmeID.String(),
+			gitinterface.ModeExecutableFile, scriptID.String(),
+			gitinterface.ModeSymbolicLink, linkID.String(),
+			gitinterface.ModeGitlink, submoduleID.String())
+		cmd := exec.Command("git", "--git-dir", repo.GetGitDir(), "mktree")
+		cmd.Stdin = strings.NewReader(treeInput)
+		stdOut, err := cmd.Output()
+		require.Nil(t, err)
+		treeID, err := gitinterface.NewHash(strings.TrimSpace(string(stdOut)))
+		require.Nil(t, err)
+
+		encryptedTreeID, err := EncryptTree(repo, treeID, []*signerverifier.SSLibKey{readerKey})
+		require.Nil(t, err)
+
+		// Every encrypted file is a regular file
+		modes, err := repo.GetAllFileModesInTree(encryptedTreeID)
+		require.Nil(t, err)
+		for path, mode := range modes {
+			assert.Equal(t, gitinterface.ModeRegularFile, mode, path)
+		}
+
+		contents, err := DecryptTree(repo, encryptedTreeID, privateKey, keyID)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]*File{
+			"README.md":   {Mode: gitinterface.ModeRegularFile, Contents: []byte("embargoed readme")},
+			"build.sh":    {Mode: gitinterface.ModeExecutableFile, Contents: []byte("#!/bin/sh\n")},
+			"README.link": {Mode: gitinterface.ModeSymbolicLink, Contents: []byte("README.md")},
+			"vendor":      {Mode: gitinterface.ModeGitlink, Contents: []byte(submoduleID.String())},
+		}, contents)
+
+		// The mode recorded in the manifest is bound to the contents
+		manifest, err := LoadManifest(repo, encryptedTreeID)
+		require.Nil(t, err)
+		manifest.Files["build.sh"] = gitinterface.ModeRegularFile
+		_, err = DecryptTree(repo, replaceTestManifest(t, repo, encryptedTreeID, manifest), privateKey, keyID)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+
+	t.Run("not a recipient", func(t *testing.T) {
+		encryptedTreeID, err := EncryptTree(repo, treeID, []*signerverifier.SSLibKey{otherKey})
+		require.Nil(t, err)
+
+		_, err = DecryptTree(repo, encryptedTreeID, privateKey, keyID)
+		assert.ErrorIs(t, err, ErrNotARecipient)
+	})
+
+	t.Run("encrypted files swapped", func(t *testing.T) {
+		swappedTreeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries([]gitinterface.TreeEntry{
+			gitinterface.NewEntryBlob(ManifestFileName, files[ManifestFileName]),
+			gitinterface.NewEntryBlob("README.md", files["src/fix.go"]),
+			gitinterface.NewEntryBlob("src/fix.go", files["README.md"]),
+		})
+		require.Nil(t, err)
+
+		_, err = DecryptTree(repo, swappedTreeID, privateKey, keyID)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+
+	t.Run("plaintext file in encrypted tree", func(t *testing.T) {
+		manifest, err := LoadManifest(repo, encryptedTreeID)
+		require.Nil(t, err)
+		assert.Nil(t, manifest.VerifyTree(repo, encryptedTreeID))
+
+		mixedTreeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries([]gitinterface.TreeEntry{
+			gitinterface.NewEntryBlob(ManifestFileName, files[ManifestFileName]),
+			gitinterface.NewEntryBlob("README.md", files["README.md"]),
+			gitinterface.NewEntryBlob("src/fix.go", files["src/fix.go"]),
+			gitinterface.NewEntryBlob("LEAK.md", readmeID),
+		})
+		require.Nil(t, err)
+
+		err = manifest.VerifyTree(repo, mixedTreeID)
+		assert.ErrorIs(t, err, ErrFileNotInManifest)
+
+		_, err = DecryptTree(repo, mixedTreeID, privateKey, keyID)
+		assert.ErrorIs(t, err, ErrFileNotInManifest)
+
+		// Encrypted files are never symbolic links
+		treeInput := fmt.Sprintf("%s blob %s\t%s\n%s blob %s\tREADME.md\n",
+			gitinterface.ModeRegularFile, files[ManifestFileName].String(), ManifestFileName,
+			gitinterface.ModeSymbolicLink, files["README.md"].String())
+		cmd := exec.Command("git", "--git-dir", repo.GetGitDir(), "mktree")
+		cmd.Stdin = strings.NewReader(treeInput)
+		stdOut, err := cmd.Output()
+		require.Nil(t, err)
+		symlinkTreeID, err := gitinterface.NewHash(strings.TrimSpace(string(stdOut)))
+		require.Nil(t, err)
+
+		err = manifest.VerifyTree(repo, symlinkTreeID)
+		assert.ErrorIs(t, err, ErrFileNotEncrypted)
+	})
+
+	t.Run("tree is not encrypted", func(t *testing.T) {
+		_, err := LoadManifest(repo, treeID)
+		assert.ErrorIs(t, err, ErrManifestNotFound)
+	})
+
+	t.Run("tree already has manifest file", func(t *testing.T) {
+		_, err := EncryptTree(repo, encryptedTreeID, []*signerverifier.SSLibKey{readerKey})
+		assert.ErrorIs(t, err, ErrTreeHasManifestFileName)
+	})
+}
+
+// replaceTestManifest returns a copy of the encrypted tree with its manifest
+// replaced by the specified manifest.
+func replaceTestManifest(t *testing.T, repo *gitinterface.Repository, treeID gitinterface.Hash, manifest *Manifest) gitinterface.Hash {
+	t.Helper()
+
+	files, err := repo.GetAllFilesInTree(treeID)
+	require.Nil(t, err)
+
+	manifestBytes, err := json.Marshal(manifest)
+	require.Nil(t, err)
+	files[ManifestFileName], err = repo.WriteBlob(manifestBytes)
+	require.Nil(t, err)
+
+	entries := []gitinterface.TreeEntry{}
+	for path, blobID := range files {
+		entries = append(entries, gitinterface.NewEntryBlob(path, blobID))
+	}
+
+	newTreeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(entries)
+	require.Nil(t, err)
+	return newTreeID
+}
@@ -0,0 +1,230 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/luasandbox"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"sigs.k8s.io/yaml"
+)
+
+var ErrInvalidDeclaration = errors.New("invalid policy declaration")
+
+// Declaration describes the desired contents of a policy: the keys, persons,
+// teams, and rules of the primary rule file, and the global rules and hooks
+// of the root of trust. It is written in YAML or JSON, and the staged policy
+// is reconciled with it using ReconcileTargetsMetadata and
+// ReconcileRootMetadata. Keys are referenced the same way as on the command
+// line, i.e., as a path to an SSH public key, "gpg:<fingerprint>", or
+// "fulcio:<identity>::<issuer>", and teams, rules, and hooks may refer to a
+// declared key using either this reference or its key ID.
+type Declaration struct {
+	Keys        []string              `json:"keys,omitempty"`
+	Persons     []*DeclaredPerson     `json:"persons,omitempty"`
+	Teams       []*DeclaredTeam       `json:"teams,omitempty"`
+	Rules       []*DeclaredRule       `json:"rules,omitempty"`
+	GlobalRules []*DeclaredGlobalRule `json:"globalRules,omitempty"`
+	Hooks       []*DeclaredHook       `json:"hooks,omitempty"`
+}
+
+// DeclaredPerson is a person trusted in the primary rule file.
+type DeclaredPerson struct {
+	ID                   string            `json:"id"`
+	Keys                 []string          `json:"keys"`
+	AssociatedIdentities map[string]string `json:"associatedIdentities,omitempty"`
+	Custom               map[string]string `json:"custom,omitempty"`
+}
+
+// DeclaredTeam is a team of keys and persons trusted in the primary rule file.
+// The threshold defaults to one.
+type DeclaredTeam struct {
+	ID         string   `json:"id"`
+	Principals []string `json:"principals"`
+	Threshold  int      `json:"threshold,omitempty"`
+}
+
+// DeclaredRule is a rule in the primary rule file. Rules take precedence in
+// the order they are declared. Patterns prefixed with
+// tuf.ExclusionPatternPrefix exclude matching namespaces from the rule. The
+// threshold defaults to one. The description, contact, and link are optional
+// details shown to users about the rule.
+type DeclaredRule struct {
+	Name               string   `json:"name"`
+	Principals         []string `json:"principals"`
+	Patterns           []string `json:"patterns"`
+	Threshold          int      `json:"threshold,omitempty"`
+	SeparationOfDuties bool     `json:"separationOfDuties,omitempty"`
+	Description        string   `json:"description,omitempty"`
+	Contact            string   `json:"contact,omitempty"`
+	Link               string   `json:"link,omitempty"`
+}
+
+// DeclaredGlobalRule is a global rule in the root of trust. The fields that
+// apply depend on its type. As with the add-global-rule command, the threshold
+// is also the number of approvals needed to override a freeze window, and it
+// defaults to one.
+type DeclaredGlobalRule struct {
+	Name                      string    `json:"name"`
+	Type                      string    `json:"type"`
+	Patterns                  []string  `json:"patterns"`
+	Threshold                 int       `json:"threshold,omitempty"`
+	DeniedPatterns            []string  `json:"deniedPatterns,omitempty"`
+	MaxBlobSize               uint64    `json:"maxBlobSize,omitempty"`
+	AllowAssociatedIdentities bool      `json:"allowAssociatedIdentities,omitempty"`
+	Start                     time.Time `json:"start,omitzero"`
+	End                       time.Time `json:"end,omitzero"`
+	Readers                   []string  `json:"readers,omitempty"`
+}
+
+// DeclaredHook is a hook in the root of trust. File is the path to the hook's
+// script. The environment defaults to lua, and the timeout to the default
+// timeout for hooks.
+type DeclaredHook struct {
+	Name        string              `json:"name"`
+	Stages      []tuf.HookStage     `json:"stages"`
+	File        string              `json:"file"`
+	Environment tuf.HookEnvironment `json:"environment,omitempty"`
+	Principals  []string            `json:"principals"`
+	Timeout     int                 `json:"timeout,omitempty"`
+}
+
+// ParseDeclaration loads a policy declaration from its YAML or JSON
+// representation, checking that every item is named uniquely and sets the
+// fields required for it. Unknown fields are rejected.
+func ParseDeclaration(contents []byte) (*Declaration, error) {
+	declaration := &Declaration{}
+	if err := yaml.UnmarshalStrict(contents, declaration); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDeclaration, err)
+	}
+
+	principalIDs := set.NewSetFromItems(declaration.Keys...)
+	if principalIDs.Len() != len(declaration.Keys) {
+		return nil, fmt.Errorf("%w: keys must be declared only once", ErrInvalidDeclaration)
+	}
+
+	for _, person := range declaration.Persons {
+		if person.ID == "" {
+			return nil, fmt.Errorf("%w: person must have an ID", ErrInvalidDeclaration)
+		}
+		if principalIDs.Has(person.ID) {
+			return nil, fmt.Errorf("%w: principal '%s' declared more than once", ErrInvalidDeclaration, person.ID)
+		}
+		principalIDs.Add(person.ID)
+
+		if len(person.Keys) == 0 {
+			return nil, fmt.Errorf("%w: person '%s' must have at least one key", ErrInvalidDeclaration, person.ID)
+		}
+	}
+
+	for _, team := range declaration.Teams {
+		if team.ID == "" {
+			return nil, fmt.Errorf("%w: team must have an ID", ErrInvalidDeclaration)
+		}
+		if principalIDs.Has(team.ID) {
+			return nil, fmt.Errorf("%w: principal '%s' declared more than once", ErrInvalidDeclaration, team.ID)
+		}
+		principalIDs.Add(team.ID)
+
+		if team.Threshold == 0 {
+			team.Threshold = 1
+		}
+	}
+
+	ruleNames := set.NewSet[string]()
+	for _, rule := range declaration.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("%w: rule must have a name", ErrInvalidDeclaration)
+		}
+		if ruleNames.Has(rule.Name) {
+			return nil, fmt.Errorf("%w: rule '%s' declared more than once", ErrInvalidDeclaration, rule.Name)
+		}
+		ruleNames.Add(rule.Name)
+
+		if len(rule.Patterns) == 0 {
+			return nil, fmt.Errorf("%w: rule '%s' must have at least one pattern", ErrInvalidDeclaration, rule.Name)
+		}
+		if rule.Threshold == 0 {
+			rule.Threshold = 1
+		}
+	}
+
+	globalRuleNames := set.NewSet[string]()
+	for _, globalRule := range declaration.GlobalRules {
+		if globalRule.Name == "" {
+			return nil, fmt.Errorf("%w: global rule must have a name", ErrInvalidDeclaration)
+		}
+		if globalRuleNames.Has(globalRule.Name) {
+			return nil, fmt.Errorf("%w: global rule '%s' declared more than once", ErrInvalidDeclaration, globalRule.Name)
+		}
+		globalRuleNames.Add(globalRule.Name)
+
+		if len(globalRule.Patterns) == 0 {
+			return nil, fmt.Errorf("%w: global rule '%s' must have at least one pattern", ErrInvalidDeclaration, globalRule.Name)
+		}
+		if globalRule.Threshold == 0 {
+			globalRule.Threshold = 1
+		}
+
+		// Check the rule's fields are valid for its type
+		if _, err := globalRule.GlobalRule(); err != nil {
+			return nil, fmt.Errorf("%w: global rule '%s': %w", ErrInvalidDeclaration, globalRule.Name, err)
+		}
+	}
+
+	hookNames := set.NewSet[string]()
+	for _, hook := range declaration.Hooks {
+		if hook.Name == "" {
+			return nil, fmt.Errorf("%w: hook must have a name", ErrInvalidDeclaration)
+		}
+		if hookNames.Has(hook.Name) {
+			return nil, fmt.Errorf("%w: hook '%s' declared more than once", ErrInvalidDeclaration, hook.Name)
+		}
+		hookNames.Add(hook.Name)
+
+		if len(hook.Stages) == 0 {
+			return nil, fmt.Errorf("%w: hook '%s' must run in at least one stage", ErrInvalidDeclaration, hook.Name)
+		}
+		if hook.File == "" {
+			return nil, fmt.Errorf("%w: hook '%s' must have a file", ErrInvalidDeclaration, hook.Name)
+		}
+		if hook.Timeout == 0 {
+			hook.Timeout = luasandbox.LuaTimeOut
+		}
+	}
+
+	return declaration, nil
+}
+
+// GlobalRule returns the global rule described by the declaration.
+func (g *DeclaredGlobalRule) GlobalRule() (tuf.GlobalRule, error) {
+	switch g.Type {
+	case tuf.GlobalRuleThresholdType:
+		if g.Threshold < 1 {
+			return nil, tuf.ErrInvalidThreshold
+		}
+		return tufv01.NewGlobalRuleThreshold(g.Name, g.Patterns, g.Threshold), nil
+	case tuf.GlobalRuleRequireSignedCommitsType:
+		return tufv01.NewGlobalRuleRequireSignedCommits(g.Name, g.Patterns)
+	case tuf.GlobalRuleRequireSignOffType:
+		return tufv01.NewGlobalRuleRequireSignOff(g.Name, g.Patterns, g.AllowAssociatedIdentities)
+	case tuf.GlobalRuleRequireLinearHistoryType:
+		return tufv01.NewGlobalRuleRequireLinearHistory(g.Name, g.Patterns)
+	case tuf.GlobalRuleRestrictFilesType:
+		return tufv01.NewGlobalRuleRestrictFiles(g.Name, g.Patterns, g.DeniedPatterns, g.MaxBlobSize)
+	case tuf.GlobalRuleFreezeWindowType:
+		return tufv01.NewGlobalRuleFreezeWindow(g.Name, g.Patterns, g.Start, g.End, g.Threshold)
+	case tuf.GlobalRuleRestrictReadType:
+		return tufv01.NewGlobalRuleRestrictRead(g.Name, g.Patterns, g.Readers)
+	case tuf.GlobalRuleBlockForcePushesType:
+		return tufv01.NewGlobalRuleBlockForcePushes(g.Name, g.Patterns)
+	default:
+		return nil, tuf.ErrUnknownGlobalRuleType
+	}
+}
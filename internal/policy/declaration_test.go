@@ -0,0 +1,126 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/luasandbox"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeclaration(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		contents := []byte(`
+keys:
+  - keys/bob.pub
+persons:
+  - id: jane.doe
+    keys: [keys/jane.pub]
+    associatedIdentities:
+      github: jane-doe
+teams:
+  - id: maintainers
+    principals: [jane.doe, keys/bob.pub]
+rules:
+  - name: protect-main
+    principals: [maintainers]
+    patterns: [git:refs/heads/main]
+  - name: protect-docs
+    principals: [jane.doe, keys/bob.pub]
+    patterns: [file:docs/*, "!file:docs/drafts/*"]
+    threshold: 2
+    separationOfDuties: true
+globalRules:
+  - name: require-approval
+    type: threshold
+    patterns: [git:refs/heads/main]
+    threshold: 2
+  - name: release-freeze
+    type: freeze-window
+    patterns: [git:refs/heads/main]
+    start: 2026-12-20T00:00:00Z
+    end: 2027-01-05T00:00:00Z
+  - name: restrict-embargo
+    type: restrict-read
+    patterns: [git:refs/heads/embargo/*]
+    readers: [jane.doe]
+hooks:
+  - name: lint
+    stages: [preCommit, prePush]
+    file: hooks/lint.lua
+    principals: [jane.doe]
+`)
+
+		declaration, err := ParseDeclaration(contents)
+		require.Nil(t, err)
+
+		assert.Equal(t, []string{"keys/bob.pub"}, declaration.Keys)
+		assert.Equal(t, []*DeclaredPerson{{ID: "jane.doe", Keys: []string{"keys/jane.pub"}, AssociatedIdentities: map[string]string{"github": "jane-doe"}}}, declaration.Persons)
+		assert.Equal(t, []*DeclaredTeam{{ID: "maintainers", Principals: []string{"jane.doe", "keys/bob.pub"}, Threshold: 1}}, declaration.Teams)
+		assert.Equal(t, []*DeclaredRule{
+			{Name: "protect-main", Principals: []string{"maintainers"}, Patterns: []string{"git:refs/heads/main"}, Threshold: 1},
+			{Name: "protect-docs", Principals: []string{"jane.doe", "keys/bob.pub"}, Patterns: []string{"file:docs/*", "!file:docs/drafts/*"}, Threshold: 2, SeparationOfDuties: true},
+		}, declaration.Rules)
+
+		require.Len(t, declaration.GlobalRules, 3)
+		globalRule, err := declaration.GlobalRules[0].GlobalRule()
+		require.Nil(t, err)
+		assert.Equal(t, tufv01.NewGlobalRuleThreshold("require-approval", []string{"git:refs/heads/main"}, 2), globalRule)
+
+		globalRule, err = declaration.GlobalRules[1].GlobalRule()
+		require.Nil(t, err)
+		freezeWindow, isFreezeWindow := globalRule.(tuf.GlobalRuleFreezeWindow)
+		require.True(t, isFreezeWindow)
+		assert.Equal(t, time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC), freezeWindow.GetStart().UTC())
+
+		globalRule, err = declaration.GlobalRules[2].GlobalRule()
+		require.Nil(t, err)
+		restrictRead, isRestrictRead := globalRule.(tuf.GlobalRuleRestrictRead)
+		require.True(t, isRestrictRead)
+		assert.Equal(t, []string{"jane.doe"}, restrictRead.GetReaderPrincipalIDs())
+
+		assert.Equal(t, []*DeclaredHook{{
+			Name:        "lint",
+			Stages:      []tuf.HookStage{tuf.HookStagePreCommit, tuf.HookStagePrePush},
+			File:        "hooks/lint.lua",
+			Environment: tuf.HookEnvironmentLua,
+			Principals:  []string{"jane.doe"},
+			Timeout:     luasandbox.LuaTimeOut,
+		}}, declaration.Hooks)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		contents := []byte(`{"rules": [{"name": "protect-main", "principals": ["jane.doe"], "patterns": ["git:refs/heads/main"]}]}`)
+
+		declaration, err := ParseDeclaration(contents)
+		require.Nil(t, err)
+		assert.Equal(t, []*DeclaredRule{{Name: "protect-main", Principals: []string{"jane.doe"}, Patterns: []string{"git:refs/heads/main"}, Threshold: 1}}, declaration.Rules)
+	})
+
+	t.Run("invalid declarations", func(t *testing.T) {
+		tests := map[string]string{
+			"unknown field":              "rule: []",
+			"duplicated principal":       "persons: [{id: jane.doe, keys: [a]}]\nteams: [{id: jane.doe, principals: [a]}]",
+			"person without keys":        "persons: [{id: jane.doe}]",
+			"duplicated rule":            "rules: [{name: a, patterns: [b]}, {name: a, patterns: [c]}]",
+			"rule without patterns":      "rules: [{name: a, principals: [b]}]",
+			"unknown global rule type":   "globalRules: [{name: a, type: b, patterns: [git:refs/heads/main]}]",
+			"invalid global rule fields": "globalRules: [{name: a, type: block-force-pushes, patterns: [file:a]}]",
+			"hook without stages":        "hooks: [{name: a, file: b}]",
+			"invalid hook stage":         "hooks: [{name: a, file: b, stages: [postCommit]}]",
+		}
+
+		for name, contents := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := ParseDeclaration([]byte(contents))
+				assert.ErrorIs(t, err, ErrInvalidDeclaration)
+			})
+		}
+	})
+}
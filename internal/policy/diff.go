@@ -0,0 +1,448 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+)
+
+const (
+	DiffActionAdded   = "added"
+	DiffActionRemoved = "removed"
+	DiffActionChanged = "changed"
+
+	DiffKindPrincipal            = "principal"
+	DiffKindRole                 = "role"
+	DiffKindRuleFile             = "rule-file"
+	DiffKindRule                 = "rule"
+	DiffKindGlobalRule           = "global-rule"
+	DiffKindHook                 = "hook"
+	DiffKindPropagationDirective = "propagation-directive"
+	DiffKindControllerRepository = "controller-repository"
+	DiffKindNetworkRepository    = "network-repository"
+
+	// DiffScopeRoot is the scope of changes to the root of trust metadata.
+	DiffScopeRoot = "root"
+)
+
+// Change records a single difference between two policy states. Scope is
+// either DiffScopeRoot or the name of the rule file the changed item is
+// declared in. For changed items, Details lists each attribute that differs.
+type Change struct {
+	Kind    string   `json:"kind"`
+	Action  string   `json:"action"`
+	Scope   string   `json:"scope"`
+	Name    string   `json:"name"`
+	Details []string `json:"details,omitempty"`
+}
+
+// attributes is a flattened, human-readable view of a policy item that is
+// compared to identify what changed about the item.
+type attributes map[string]string
+
+// DiffStates returns the semantic differences between the from and to policy
+// states. It compares the principals, roles and their thresholds, global
+// rules, hooks, propagation directives, and controller and network
+// repositories declared in the root of trust, and the principals and rules in
+// every rule file. Changes are ordered by scope, kind, and name, with the root
+// of trust first.
+func DiffStates(from, to *State) ([]*Change, error) {
+	fromRootMetadata, err := from.GetRootMetadata(false)
+	if err != nil {
+		return nil, err
+	}
+	toRootMetadata, err := to.GetRootMetadata(false)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*Change{}
+
+	changes = append(changes, diffItems(DiffKindPrincipal, DiffScopeRoot, principalsAttributes(fromRootMetadata.GetPrincipals()), principalsAttributes(toRootMetadata.GetPrincipals()))...)
+
+	fromRoles, err := rolesAttributes(fromRootMetadata)
+	if err != nil {
+		return nil, err
+	}
+	toRoles, err := rolesAttributes(toRootMetadata)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, diffItems(DiffKindRole, DiffScopeRoot, fromRoles, toRoles)...)
+
+	changes = append(changes, diffItems(DiffKindGlobalRule, DiffScopeRoot, globalRulesAttributes(fromRootMetadata.GetGlobalRules()), globalRulesAttributes(toRootMetadata.GetGlobalRules()))...)
+	changes = append(changes, diffItems(DiffKindHook, DiffScopeRoot, hooksAttributes(from.Hooks), hooksAttributes(to.Hooks))...)
+	changes = append(changes, diffItems(DiffKindPropagationDirective, DiffScopeRoot, propagationDirectivesAttributes(fromRootMetadata.GetPropagationDirectives()), propagationDirectivesAttributes(toRootMetadata.GetPropagationDirectives()))...)
+	changes = append(changes, diffItems(DiffKindControllerRepository, DiffScopeRoot, otherRepositoriesAttributes(fromRootMetadata.GetControllerRepositories()), otherRepositoriesAttributes(toRootMetadata.GetControllerRepositories()))...)
+	changes = append(changes, diffItems(DiffKindNetworkRepository, DiffScopeRoot, otherRepositoriesAttributes(fromRootMetadata.GetNetworkRepositories()), otherRepositoriesAttributes(toRootMetadata.GetNetworkRepositories()))...)
+
+	fromRuleFiles, err := loadRuleFiles(from)
+	if err != nil {
+		return nil, err
+	}
+	toRuleFiles, err := loadRuleFiles(to)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleFileNames := slices.Collect(maps.Keys(fromRuleFiles))
+	for ruleFileName := range toRuleFiles {
+		if _, has := fromRuleFiles[ruleFileName]; !has {
+			ruleFileNames = append(ruleFileNames, ruleFileName)
+		}
+	}
+	slices.SortFunc(ruleFileNames, compareRuleFileNames)
+
+	for _, ruleFileName := range ruleFileNames {
+		fromRuleFile, inFrom := fromRuleFiles[ruleFileName]
+		toRuleFile, inTo := toRuleFiles[ruleFileName]
+
+		switch {
+		case !inFrom:
+			changes = append(changes, &Change{Kind: DiffKindRuleFile, Action: DiffActionAdded, Scope: ruleFileName, Name: ruleFileName})
+		case !inTo:
+			changes = append(changes, &Change{Kind: DiffKindRuleFile, Action: DiffActionRemoved, Scope: ruleFileName, Name: ruleFileName})
+		}
+
+		var fromPrincipals, toPrincipals, fromRules, toRules map[string]attributes
+		if inFrom {
+			fromPrincipals = principalsAttributes(fromRuleFile.GetPrincipals())
+			fromRules = rulesAttributes(fromRuleFile.GetRules())
+		}
+		if inTo {
+			toPrincipals = principalsAttributes(toRuleFile.GetPrincipals())
+			toRules = rulesAttributes(toRuleFile.GetRules())
+		}
+
+		changes = append(changes, diffItems(DiffKindPrincipal, ruleFileName, fromPrincipals, toPrincipals)...)
+		changes = append(changes, diffItems(DiffKindRule, ruleFileName, fromRules, toRules)...)
+	}
+
+	return changes, nil
+}
+
+// diffItems compares two sets of items of the same kind keyed by name.
+func diffItems(kind, scope string, from, to map[string]attributes) []*Change {
+	names := slices.Collect(maps.Keys(from))
+	for name := range to {
+		if _, has := from[name]; !has {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	changes := []*Change{}
+	for _, name := range names {
+		fromAttributes, inFrom := from[name]
+		toAttributes, inTo := to[name]
+
+		switch {
+		case !inFrom:
+			changes = append(changes, &Change{Kind: kind, Action: DiffActionAdded, Scope: scope, Name: name})
+		case !inTo:
+			changes = append(changes, &Change{Kind: kind, Action: DiffActionRemoved, Scope: scope, Name: name})
+		default:
+			if details := diffAttributes(fromAttributes, toAttributes); len(details) != 0 {
+				changes = append(changes, &Change{Kind: kind, Action: DiffActionChanged, Scope: scope, Name: name, Details: details})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffAttributes returns a description of each attribute that differs
+// between from and to.
+func diffAttributes(from, to attributes) []string {
+	keys := slices.Collect(maps.Keys(from))
+	for key := range to {
+		if _, has := from[key]; !has {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	details := []string{}
+	for _, key := range keys {
+		fromValue, toValue := from[key], to[key]
+		if fromValue == toValue {
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s: '%s' -> '%s'", key, fromValue, toValue))
+	}
+
+	return details
+}
+
+// loadRuleFiles returns every rule file in the state keyed by name.
+func loadRuleFiles(state *State) (map[string]tuf.TargetsMetadata, error) {
+	ruleFiles := map[string]tuf.TargetsMetadata{}
+	if !state.HasTargetsRole(TargetsRoleName) {
+		return ruleFiles, nil
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+	if err != nil {
+		return nil, err
+	}
+	ruleFiles[TargetsRoleName] = targetsMetadata
+
+	for ruleFileName := range state.Metadata.DelegationEnvelopes {
+		targetsMetadata, err := state.GetTargetsMetadata(ruleFileName, false)
+		if err != nil {
+			return nil, err
+		}
+		ruleFiles[ruleFileName] = targetsMetadata
+	}
+
+	return ruleFiles, nil
+}
+
+// compareRuleFileNames orders the primary rule file ahead of delegated rule
+// files, which are sorted by name.
+func compareRuleFileNames(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == TargetsRoleName:
+		return -1
+	case b == TargetsRoleName:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func principalsAttributes(principals map[string]tuf.Principal) map[string]attributes {
+	items := map[string]attributes{}
+	for principalID, principal := range principals {
+		keyIDs := []string{}
+		for _, key := range principal.Keys() {
+			keyIDs = append(keyIDs, key.KeyID)
+		}
+
+		item := attributes{
+			"keys":   joinSorted(keyIDs),
+			"custom": joinMap(principal.CustomMetadata()),
+		}
+
+		switch principal := principal.(type) {
+		case *tufv02.Person:
+			item["associated identities"] = joinMap(principal.AssociatedIdentities)
+		case tuf.Team:
+			item["members"] = joinSorted(principal.GetPrincipalIDs().Contents())
+			item["threshold"] = fmt.Sprint(principal.GetThreshold())
+		}
+
+		items[principalID] = item
+	}
+
+	return items
+}
+
+// rolesAttributes returns the principals and thresholds trusted for the root
+// of trust, the primary rule file, and GitHub apps.
+func rolesAttributes(rootMetadata tuf.RootMetadata) (map[string]attributes, error) {
+	items := map[string]attributes{}
+
+	rootPrincipals, err := rootMetadata.GetRootPrincipals()
+	if err != nil {
+		return nil, err
+	}
+	rootThreshold, err := rootMetadata.GetRootThreshold()
+	if err != nil {
+		return nil, err
+	}
+	items[tuf.RootRoleName] = attributes{
+		"principals": joinPrincipalIDs(rootPrincipals),
+		"threshold":  fmt.Sprint(rootThreshold),
+	}
+
+	primaryRuleFilePrincipals, err := rootMetadata.GetPrimaryRuleFilePrincipals()
+	if err == nil {
+		primaryRuleFileThreshold, err := rootMetadata.GetPrimaryRuleFileThreshold()
+		if err != nil {
+			return nil, err
+		}
+		items[tuf.TargetsRoleName] = attributes{
+			"principals": joinPrincipalIDs(primaryRuleFilePrincipals),
+			"threshold":  fmt.Sprint(primaryRuleFileThreshold),
+		}
+	}
+
+	apps, err := rootMetadata.GetGitHubAppEntries()
+	if err != nil {
+		return nil, err
+	}
+	for appName, app := range apps {
+		items[fmt.Sprintf("github-app:%s", appName)] = attributes{
+			"principals": joinSorted(app.GetPrincipalIDs()),
+			"threshold":  fmt.Sprint(app.GetThreshold()),
+			"trusted":    fmt.Sprint(app.IsTrusted()),
+		}
+	}
+
+	return items, nil
+}
+
+// rulesAttributes returns the attributes of the rules in a rule file,
+// including their position as rule order determines precedence.
+func rulesAttributes(rules []tuf.Rule) map[string]attributes {
+	items := map[string]attributes{}
+	for index, rule := range rules {
+		if rule.ID() == tuf.AllowRuleName {
+			continue
+		}
+
+		principalIDs := []string{}
+		if rule.GetPrincipalIDs() != nil {
+			principalIDs = rule.GetPrincipalIDs().Contents()
+		}
+
+		items[rule.ID()] = attributes{
+			"position":             fmt.Sprint(index + 1),
+			"principals":           joinSorted(principalIDs),
+			"threshold":            fmt.Sprint(rule.GetThreshold()),
+			"patterns":             strings.Join(rule.GetProtectedNamespaces(), ", "),
+			"excluded patterns":    strings.Join(rule.GetExcludedNamespaces(), ", "),
+			"terminating":          fmt.Sprint(rule.IsLastTrustedInRuleFile()),
+			"separation of duties": fmt.Sprint(rule.RequiresSeparationOfDuties()),
+			"description":          rule.GetDescription(),
+			"contact":              rule.GetContact(),
+			"link":                 rule.GetLink(),
+		}
+	}
+
+	return items
+}
+
+func globalRulesAttributes(globalRules []tuf.GlobalRule) map[string]attributes {
+	items := map[string]attributes{}
+	for _, globalRule := range globalRules {
+		item := attributes{}
+
+		switch globalRule := globalRule.(type) {
+		case tuf.GlobalRuleThreshold:
+			item["type"] = tuf.GlobalRuleThresholdType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+			item["threshold"] = fmt.Sprint(globalRule.GetThreshold())
+		case tuf.GlobalRuleRequireSignedCommits:
+			item["type"] = tuf.GlobalRuleRequireSignedCommitsType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+		case tuf.GlobalRuleRequireSignOff:
+			item["type"] = tuf.GlobalRuleRequireSignOffType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+			item["allow associated identities"] = fmt.Sprint(globalRule.AllowsAssociatedIdentities())
+		case tuf.GlobalRuleRequireLinearHistory:
+			item["type"] = tuf.GlobalRuleRequireLinearHistoryType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+		case tuf.GlobalRuleRestrictFiles:
+			item["type"] = tuf.GlobalRuleRestrictFilesType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+			item["denied patterns"] = strings.Join(globalRule.GetDeniedPatterns(), ", ")
+			item["max blob size"] = fmt.Sprint(globalRule.GetMaxBlobSize())
+		case tuf.GlobalRuleFreezeWindow:
+			item["type"] = tuf.GlobalRuleFreezeWindowType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+			item["start"] = globalRule.GetStart().Format(time.RFC3339)
+			item["end"] = globalRule.GetEnd().Format(time.RFC3339)
+			item["override threshold"] = fmt.Sprint(globalRule.GetOverrideThreshold())
+		case tuf.GlobalRuleRestrictRead:
+			item["type"] = tuf.GlobalRuleRestrictReadType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+			item["readers"] = joinSorted(globalRule.GetReaderPrincipalIDs())
+		case tuf.GlobalRuleBlockForcePushes:
+			item["type"] = tuf.GlobalRuleBlockForcePushesType
+			item["namespaces"] = strings.Join(globalRule.GetProtectedNamespaces(), ", ")
+		}
+
+		items[globalRule.GetName()] = item
+	}
+
+	return items
+}
+
+// hooksAttributes returns the attributes of every hook, merging the entries
+// for a hook that runs in more than one stage.
+func hooksAttributes(hooks map[tuf.HookStage][]tuf.Hook) map[string]attributes {
+	stages := slices.Sorted(maps.Keys(hooks))
+
+	items := map[string]attributes{}
+	for _, stage := range stages {
+		for _, hook := range hooks[stage] {
+			if item, has := items[hook.ID()]; has {
+				item["stages"] += ", " + stage.String()
+				continue
+			}
+
+			items[hook.ID()] = attributes{
+				"stages":      stage.String(),
+				"principals":  joinSorted(hook.GetPrincipalIDs().Contents()),
+				"hashes":      joinMap(hook.GetHashes()),
+				"environment": hook.GetEnvironment().String(),
+				"timeout":     fmt.Sprint(hook.GetTimeout()),
+			}
+		}
+	}
+
+	return items
+}
+
+func propagationDirectivesAttributes(directives []tuf.PropagationDirective) map[string]attributes {
+	items := map[string]attributes{}
+	for _, directive := range directives {
+		items[directive.GetName()] = attributes{
+			"upstream repository":  directive.GetUpstreamRepository(),
+			"upstream reference":   directive.GetUpstreamReference(),
+			"upstream path":        directive.GetUpstreamPath(),
+			"downstream reference": directive.GetDownstreamReference(),
+			"downstream path":      directive.GetDownstreamPath(),
+		}
+	}
+
+	return items
+}
+
+func otherRepositoriesAttributes(repositories []tuf.OtherRepository) map[string]attributes {
+	items := map[string]attributes{}
+	for _, repository := range repositories {
+		items[repository.GetName()] = attributes{
+			"location":                repository.GetLocation(),
+			"initial root principals": joinPrincipalIDs(repository.GetInitialRootPrincipals()),
+		}
+	}
+
+	return items
+}
+
+func joinPrincipalIDs(principals []tuf.Principal) string {
+	principalIDs := make([]string, 0, len(principals))
+	for _, principal := range principals {
+		principalIDs = append(principalIDs, principal.ID())
+	}
+
+	return joinSorted(principalIDs)
+}
+
+func joinSorted(items []string) string {
+	items = slices.Clone(items)
+	slices.Sort(items)
+	return strings.Join(items, ", ")
+}
+
+func joinMap(items map[string]string) string {
+	entries := make([]string, 0, len(items))
+	for key, value := range items {
+		entries = append(entries, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	return joinSorted(entries)
+}
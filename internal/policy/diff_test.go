@@ -0,0 +1,156 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStates(t *testing.T) {
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+	approverKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := DiffStates(createTestStateWithPolicy(t), createTestStateWithPolicy(t))
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("rule file changes", func(t *testing.T) {
+		changes, err := DiffStates(createTestStateWithPolicy(t), createTestStateWithThresholdPolicy(t))
+		assert.Nil(t, err)
+
+		expectedChanges := []*Change{
+			{
+				Kind:   DiffKindPrincipal,
+				Action: DiffActionAdded,
+				Scope:  TargetsRoleName,
+				Name:   approverKey.KeyID,
+			},
+			{
+				Kind:   DiffKindRule,
+				Action: DiffActionChanged,
+				Scope:  TargetsRoleName,
+				Name:   "protect-main",
+				Details: []string{
+					"principals: '" + gpgKey.KeyID + "' -> '" + gpgKey.KeyID + ", " + approverKey.KeyID + "'",
+					"threshold: '1' -> '2'",
+				},
+			},
+		}
+		assert.Equal(t, expectedChanges, changes)
+	})
+
+	t.Run("delegated rule file changes", func(t *testing.T) {
+		from := createTestStateWithDelegatedPolicies(t)
+		to := createTestStateWithDelegatedPolicies(t)
+		delete(to.Metadata.DelegationEnvelopes, "1")
+
+		changes, err := DiffStates(from, to)
+		assert.Nil(t, err)
+
+		expectedChanges := []*Change{
+			{
+				Kind:   DiffKindRuleFile,
+				Action: DiffActionRemoved,
+				Scope:  "1",
+				Name:   "1",
+			},
+			{
+				Kind:   DiffKindPrincipal,
+				Action: DiffActionRemoved,
+				Scope:  "1",
+				Name:   gpgKey.KeyID,
+			},
+			{
+				Kind:   DiffKindRule,
+				Action: DiffActionRemoved,
+				Scope:  "1",
+				Name:   "3",
+			},
+			{
+				Kind:   DiffKindRule,
+				Action: DiffActionRemoved,
+				Scope:  "1",
+				Name:   "4",
+			},
+		}
+		assert.Equal(t, expectedChanges, changes)
+	})
+
+	t.Run("root changes", func(t *testing.T) {
+		from := createTestStateWithGlobalConstraintThreshold(t)
+		to := createTestStateWithGlobalConstraintThreshold(t)
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+		rootMetadata, err := to.GetRootMetadata(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.UpdateGlobalRule(tufv01.NewGlobalRuleThreshold("threshold-2-main", []string{"git:refs/heads/main", "git:refs/heads/release"}, 3)); err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.AddPrimaryRuleFilePrincipal(gpgKey); err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.UpdatePrimaryRuleFileThreshold(2); err != nil {
+			t.Fatal(err)
+		}
+
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		to.Metadata.RootEnvelope = rootEnv
+
+		changes, err := DiffStates(from, to)
+		assert.Nil(t, err)
+
+		rootKeyID := signer.MetadataKey().KeyID
+		expectedChanges := []*Change{
+			{
+				Kind:   DiffKindPrincipal,
+				Action: DiffActionAdded,
+				Scope:  DiffScopeRoot,
+				Name:   gpgKey.KeyID,
+			},
+			{
+				Kind:   DiffKindRole,
+				Action: DiffActionChanged,
+				Scope:  DiffScopeRoot,
+				Name:   TargetsRoleName,
+				Details: []string{
+					"principals: '" + rootKeyID + "' -> '" + gpgKey.KeyID + ", " + rootKeyID + "'",
+					"threshold: '1' -> '2'",
+				},
+			},
+			{
+				Kind:   DiffKindGlobalRule,
+				Action: DiffActionChanged,
+				Scope:  DiffScopeRoot,
+				Name:   "threshold-2-main",
+				Details: []string{
+					"namespaces: 'git:refs/heads/main' -> 'git:refs/heads/main, git:refs/heads/release'",
+					"threshold: '2' -> '3'",
+				},
+			},
+		}
+		assert.Equal(t, expectedChanges, changes)
+	})
+}
@@ -6,8 +6,11 @@ package policy
 import (
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
@@ -60,6 +63,85 @@ func createTestRepository(t *testing.T, stateCreator func(*testing.T) *State, op
 	return repo, state
 }
 
+// createTestRepositoryWithExpiringPolicy creates a test repository with the
+// policy from createTestStateWithPolicy, but with the rule file set to expire
+// at the specified time. The policy is applied directly rather than via Apply
+// so that the policy can have expired before the entries in the repository.
+func createTestRepositoryWithExpiringPolicy(t *testing.T, expires time.Time) (*gitinterface.Repository, *State) {
+	t.Helper()
+
+	return createTestRepositoryWithPolicyExpiry(t, func(_ tuf.RootMetadata, targetsMetadata tuf.TargetsMetadata) {
+		targetsMetadata.SetExpires(expires.UTC().Format(time.RFC3339))
+	})
+}
+
+// createTestRepositoryWithPolicyExpiry creates a test repository with the
+// policy from createTestStateWithPolicy, after setExpiry has updated the
+// metadata's expiries. The policy is applied directly rather than via Apply.
+func createTestRepositoryWithPolicyExpiry(t *testing.T, setExpiry func(tuf.RootMetadata, tuf.TargetsMetadata)) (*gitinterface.Repository, *State) {
+	t.Helper()
+
+	state := createTestStateWithPolicy(t)
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setExpiry(rootMetadata, targetsMetadata)
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Metadata.RootEnvelope = rootEnv
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Metadata.TargetsEnvelope = targetsEnv
+
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+	state.repository = repo
+
+	if err := state.Commit(repo, "Create test state", false, false); err != nil {
+		t.Fatal(err)
+	}
+	policyTip, err := repo.GetReference(PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SetReference(PolicyRef, policyTip); err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.NewReferenceEntry(PolicyRef, policyTip).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.loadedEntry = latestEntry.(rsl.ReferenceUpdaterEntry)
+
+	return repo, state
+}
+
 func createControllerAndNetworkRepositories(t *testing.T) (*gitinterface.Repository, *gitinterface.Repository) {
 	t.Helper()
 
@@ -132,22 +214,571 @@ func createControllerAndNetworkRepositories(t *testing.T) (*gitinterface.Reposit
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = networkState.Commit(networkRepository, "Initial policy\n", true, false)
+	err = networkState.Commit(networkRepository, "Initial policy\n", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Apply(testCtx, networkRepository, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return controllerRepository, networkRepository
+}
+
+func createTestStateWithOnlyRoot(t *testing.T) *State {
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes) //nolint:staticcheck
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &State{
+		Metadata: &StateMetadata{
+			RootEnvelope: rootEnv,
+		},
+	}
+}
+
+func createTestStateWithOnlyRootUnnumbered(t *testing.T) *State {
+	// This is a clone of createTestStateWithOnlyRoot but with the version of
+	// the root metadata overridden to be 0 (unnumbered). This allows us to test
+	// the behavior of transitioning from unnumbered to numbered metadata, which
+	// is relevant for repositories that had existing metadata before gittuf was
+	// introduced.
+
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes) //nolint:staticcheck
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Override the version to be 0
+	rootMetadata.(*tufv02.RootMetadata).Version = 0
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &State{
+		Metadata: &StateMetadata{
+			RootEnvelope: rootEnv,
+		},
+	}
+}
+
+func createTestStateWithPolicy(t *testing.T) *State {
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := targetsMetadata.AddRule("protect-main", []string{gpgKey.KeyID}, []string{"git:refs/heads/main"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	// Add a file protection rule. When used with common.AddNTestCommitsToSpecifiedRef, we have files with names 1, 2, 3,...n.
+	if err := targetsMetadata.AddRule("protect-files-1-and-2", []string{gpgKey.KeyID}, []string{"file:1", "file:2"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &State{
+		Metadata: &StateMetadata{
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
+		},
+	}
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+func createTestStateWithPolicyUnnumbered(t *testing.T) *State {
+	// This is a clone of createTestStateWithPolicy but with the version of the
+	// metadata overridden to be 0 (unnumbered). This allows us to test the
+	// behavior of transitioning from unnumbered to numbered metadata, which is
+	// relevant for repositories that had existing metadata before gittuf was
+	// introduced.
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Override the version to be 0
+	rootMetadata.(*tufv02.RootMetadata).Version = 0
+
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := targetsMetadata.AddRule("protect-main", []string{gpgKey.KeyID}, []string{"git:refs/heads/main"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	// Add a file protection rule. When used with common.AddNTestCommitsToSpecifiedRef, we have files with names 1, 2, 3,...n.
+	if err := targetsMetadata.AddRule("protect-files-1-and-2", []string{gpgKey.KeyID}, []string{"file:1", "file:2"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Override the version to be 0
+	targetsMetadata.(*tufv02.TargetsMetadata).Version = 0
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &State{
+		Metadata: &StateMetadata{
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
+		},
+	}
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+func createTestStateWithPolicyTargetsSignedByWrongKey(t *testing.T) *State {
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, rootSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	targetsSigner := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := targetsMetadata.AddRule("protect-main", []string{gpgKey.KeyID}, []string{"git:refs/heads/main"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	// Add a file protection rule. When used with common.AddNTestCommitsToSpecifiedRef, we have files with names 1, 2, 3,...n.
+	if err := targetsMetadata.AddRule("protect-files-1-and-2", []string{gpgKey.KeyID}, []string{"file:1", "file:2"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, targetsSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &State{
+		Metadata: &StateMetadata{
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
+		},
+	}
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+// createTestStateWithGlobalConstraintThreshold creates a policy state with no
+// explicit branch protection rules but with a two-approval constraint on
+// changes to the main branch. The two keys trusted are `rootPubKeyBytes` and
+// `gpgPubKeyBytes`.
+func createTestStateWithExclusionPolicy(t *testing.T) *State {
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := targetsMetadata.AddPrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+	// Protect everything under src except the generated files, which are
+	// protected by a separate rule
+	if err := targetsMetadata.AddRule("protect-src", []string{gpgKey.KeyID}, []string{"file:src/*", "!file:src/generated/*"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := targetsMetadata.AddRule("protect-generated", []string{key.KeyID}, []string{"file:src/generated/*"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &State{
+		Metadata: &StateMetadata{
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
+		},
+	}
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+func createTestStateWithGlobalConstraintThreshold(t *testing.T) *State {
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(tufv01.NewGlobalRuleThreshold("threshold-2-main", []string{"git:refs/heads/main"}, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &State{
+		Metadata: &StateMetadata{
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
+		},
+	}
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+// createTestStateWithGlobalConstraintBlockForcePushes creates a policy state
+// with no explicit branch protection rules but with a rule that blocks force
+// pushes to main.
+func createTestStateWithGlobalConstraintBlockForcePushes(t *testing.T) *State {
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	forcePushesGlobalRule, err := tufv01.NewGlobalRuleBlockForcePushes("block-force-pushes-main", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rootMetadata.AddGlobalRule(forcePushesGlobalRule); err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &State{
+		Metadata: &StateMetadata{
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
+		},
+	}
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+// createTestStateWithGlobalConstraintBlockForcePushesOnDefaultBranch creates a
+// policy state that declares main as the default branch in the root of trust
+// and blocks force pushes to the default branch.
+func createTestStateWithGlobalConstraintBlockForcePushesOnDefaultBranch(t *testing.T) *State {
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+	rootMetadata, err := InitializeRootMetadata(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.SetDefaultBranch("refs/heads/main"); err != nil {
+		t.Fatal(err)
+	}
+
+	forcePushesGlobalRule, err := tufv01.NewGlobalRuleBlockForcePushes("block-force-pushes-default", []string{"class:default-branch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rootMetadata.AddGlobalRule(forcePushesGlobalRule); err != nil {
+		t.Fatal(err)
+	}
+
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = Apply(testCtx, networkRepository, false)
-	if err != nil {
+
+	state := &State{
+		Metadata: &StateMetadata{
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
+		},
+	}
+
+	if err := state.preprocess(); err != nil {
 		t.Fatal(err)
 	}
 
-	return controllerRepository, networkRepository
+	return state
 }
 
-func createTestStateWithOnlyRoot(t *testing.T) *State {
+// createTestStateWithGlobalConstraintRequireSignedCommits creates a policy state
+// with no explicit branch protection rules but with a rule that requires all
+// commits on main to be signed by a principal declared in the policy.
+func createTestStateWithGlobalConstraintRequireSignedCommits(t *testing.T) *State {
 	t.Helper()
 
-	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes) //nolint:staticcheck
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
 	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
 
 	rootMetadata, err := InitializeRootMetadata(key)
@@ -155,6 +786,18 @@ func createTestStateWithOnlyRoot(t *testing.T) *State {
 		t.Fatal(err)
 	}
 
+	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	signedCommitsGlobalRule, err := tufv01.NewGlobalRuleRequireSignedCommits("require-signed-commits-main", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rootMetadata.AddGlobalRule(signedCommitsGlobalRule); err != nil {
+		t.Fatal(err)
+	}
+
 	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
 	if err != nil {
 		t.Fatal(err)
@@ -164,50 +807,44 @@ func createTestStateWithOnlyRoot(t *testing.T) *State {
 		t.Fatal(err)
 	}
 
-	return &State{
-		Metadata: &StateMetadata{
-			RootEnvelope: rootEnv,
-		},
-	}
-}
-
-func createTestStateWithOnlyRootUnnumbered(t *testing.T) *State {
-	// This is a clone of createTestStateWithOnlyRoot but with the version of
-	// the root metadata overridden to be 0 (unnumbered). This allows us to test
-	// the behavior of transitioning from unnumbered to numbered metadata, which
-	// is relevant for repositories that had existing metadata before gittuf was
-	// introduced.
-
-	t.Helper()
-
-	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes) //nolint:staticcheck
-	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
-
-	rootMetadata, err := InitializeRootMetadata(key)
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
 	if err != nil {
 		t.Fatal(err)
 	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
 
-	// Override the version to be 0
-	rootMetadata.(*tufv02.RootMetadata).Version = 0
+	targetsMetadata := InitializeTargetsMetadata()
+	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
+		t.Fatal(err)
+	}
 
-	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
 	if err != nil {
 		t.Fatal(err)
 	}
-	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	return &State{
+	state := &State{
 		Metadata: &StateMetadata{
-			RootEnvelope: rootEnv,
+			RootEnvelope:    rootEnv,
+			TargetsEnvelope: targetsEnv,
 		},
 	}
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
 }
 
-func createTestStateWithPolicy(t *testing.T) *State {
+// createTestStateWithGlobalConstraintRequireLinearHistory creates a policy state
+// with no explicit branch protection rules but with a rule that rejects merge
+// commits on main.
+func createTestStateWithGlobalConstraintRequireLinearHistory(t *testing.T) *State {
 	t.Helper()
 
 	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
@@ -222,6 +859,14 @@ func createTestStateWithPolicy(t *testing.T) *State {
 		t.Fatal(err)
 	}
 
+	linearHistoryGlobalRule, err := tufv01.NewGlobalRuleRequireLinearHistory("require-linear-history-main", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rootMetadata.AddGlobalRule(linearHistoryGlobalRule); err != nil {
+		t.Fatal(err)
+	}
+
 	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
 	if err != nil {
 		t.Fatal(err)
@@ -241,13 +886,6 @@ func createTestStateWithPolicy(t *testing.T) *State {
 	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
 		t.Fatal(err)
 	}
-	if err := targetsMetadata.AddRule("protect-main", []string{gpgKey.KeyID}, []string{"git:refs/heads/main"}, 1); err != nil {
-		t.Fatal(err)
-	}
-	// Add a file protection rule. When used with common.AddNTestCommitsToSpecifiedRef, we have files with names 1, 2, 3,...n.
-	if err := targetsMetadata.AddRule("protect-files-1-and-2", []string{gpgKey.KeyID}, []string{"file:1", "file:2"}, 1); err != nil {
-		t.Fatal(err)
-	}
 
 	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
 	if err != nil {
@@ -272,12 +910,10 @@ func createTestStateWithPolicy(t *testing.T) *State {
 	return state
 }
 
-func createTestStateWithPolicyUnnumbered(t *testing.T) *State {
-	// This is a clone of createTestStateWithPolicy but with the version of the
-	// metadata overridden to be 0 (unnumbered). This allows us to test the
-	// behavior of transitioning from unnumbered to numbered metadata, which is
-	// relevant for repositories that had existing metadata before gittuf was
-	// introduced.
+// createTestStateWithGlobalConstraintRestrictFiles creates a policy state with
+// no explicit branch protection rules but with a rule that rejects .env files
+// and blobs larger than 16 bytes on main.
+func createTestStateWithGlobalConstraintRestrictFiles(t *testing.T) *State {
 	t.Helper()
 
 	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
@@ -288,13 +924,18 @@ func createTestStateWithPolicyUnnumbered(t *testing.T) *State {
 		t.Fatal(err)
 	}
 
-	// Override the version to be 0
-	rootMetadata.(*tufv02.RootMetadata).Version = 0
-
 	if err := rootMetadata.AddPrimaryRuleFilePrincipal(key); err != nil {
 		t.Fatal(err)
 	}
 
+	restrictFilesGlobalRule, err := tufv01.NewGlobalRuleRestrictFiles("restrict-files-main", []string{"git:refs/heads/main"}, []string{"*.env"}, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rootMetadata.AddGlobalRule(restrictFilesGlobalRule); err != nil {
+		t.Fatal(err)
+	}
+
 	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
 	if err != nil {
 		t.Fatal(err)
@@ -314,16 +955,6 @@ func createTestStateWithPolicyUnnumbered(t *testing.T) *State {
 	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
 		t.Fatal(err)
 	}
-	if err := targetsMetadata.AddRule("protect-main", []string{gpgKey.KeyID}, []string{"git:refs/heads/main"}, 1); err != nil {
-		t.Fatal(err)
-	}
-	// Add a file protection rule. When used with common.AddNTestCommitsToSpecifiedRef, we have files with names 1, 2, 3,...n.
-	if err := targetsMetadata.AddRule("protect-files-1-and-2", []string{gpgKey.KeyID}, []string{"file:1", "file:2"}, 1); err != nil {
-		t.Fatal(err)
-	}
-
-	// Override the version to be 0
-	targetsMetadata.(*tufv02.TargetsMetadata).Version = 0
 
 	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
 	if err != nil {
@@ -348,9 +979,14 @@ func createTestStateWithPolicyUnnumbered(t *testing.T) *State {
 	return state
 }
 
-func createTestStateWithPolicyTargetsSignedByWrongKey(t *testing.T) *State {
-	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
-	key := tufv01.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+// createTestStateWithGlobalConstraintRestrictRead creates a policy state with
+// no explicit branch protection rules but with a rule that requires the
+// contents of main to be encrypted for a reader using the targets2 key.
+func createTestStateWithGlobalConstraintRestrictRead(t *testing.T) *State {
+	t.Helper()
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	key := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
 
 	rootMetadata, err := InitializeRootMetadata(key)
 	if err != nil {
@@ -361,11 +997,21 @@ func createTestStateWithPolicyTargetsSignedByWrongKey(t *testing.T) *State {
 		t.Fatal(err)
 	}
 
+	readerKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets2PubKeyBytes))
+
+	restrictReadGlobalRule, err := tufv01.NewGlobalRuleRestrictRead("restrict-read-main", []string{"git:refs/heads/main"}, []string{readerKey.KeyID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rootMetadata.AddGlobalRule(restrictReadGlobalRule); err != nil {
+		t.Fatal(err)
+	}
+
 	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
 	if err != nil {
 		t.Fatal(err)
 	}
-	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, rootSigner)
+	rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -376,17 +1022,11 @@ func createTestStateWithPolicyTargetsSignedByWrongKey(t *testing.T) *State {
 	}
 	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
 
-	targetsSigner := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
-
 	targetsMetadata := InitializeTargetsMetadata()
 	if err := targetsMetadata.AddPrincipal(gpgKey); err != nil {
 		t.Fatal(err)
 	}
-	if err := targetsMetadata.AddRule("protect-main", []string{gpgKey.KeyID}, []string{"git:refs/heads/main"}, 1); err != nil {
-		t.Fatal(err)
-	}
-	// Add a file protection rule. When used with common.AddNTestCommitsToSpecifiedRef, we have files with names 1, 2, 3,...n.
-	if err := targetsMetadata.AddRule("protect-files-1-and-2", []string{gpgKey.KeyID}, []string{"file:1", "file:2"}, 1); err != nil {
+	if err := targetsMetadata.AddPrincipal(readerKey); err != nil {
 		t.Fatal(err)
 	}
 
@@ -394,7 +1034,7 @@ func createTestStateWithPolicyTargetsSignedByWrongKey(t *testing.T) *State {
 	if err != nil {
 		t.Fatal(err)
 	}
-	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, targetsSigner)
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -413,11 +1053,10 @@ func createTestStateWithPolicyTargetsSignedByWrongKey(t *testing.T) *State {
 	return state
 }
 
-// createTestStateWithGlobalConstraintThreshold creates a policy state with no
-// explicit branch protection rules but with a two-approval constraint on
-// changes to the main branch. The two keys trusted are `rootPubKeyBytes` and
-// `gpgPubKeyBytes`.
-func createTestStateWithGlobalConstraintThreshold(t *testing.T) *State {
+// createTestStateWithGlobalConstraintFreezeWindow creates a policy state with
+// no explicit branch protection rules but with a rule that freezes main on the
+// day of the test clock unless two principals approve the change.
+func createTestStateWithGlobalConstraintFreezeWindow(t *testing.T) *State {
 	t.Helper()
 
 	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
@@ -432,7 +1071,12 @@ func createTestStateWithGlobalConstraintThreshold(t *testing.T) *State {
 		t.Fatal(err)
 	}
 
-	if err := rootMetadata.AddGlobalRule(tufv01.NewGlobalRuleThreshold("threshold-2-main", []string{"git:refs/heads/main"}, 2)); err != nil {
+	freezeStart := time.Date(1995, time.October, 26, 0, 0, 0, 0, time.UTC)
+	freezeWindowGlobalRule, err := tufv01.NewGlobalRuleFreezeWindow("freeze-main", []string{"git:refs/heads/main"}, freezeStart, freezeStart.Add(24*time.Hour), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rootMetadata.AddGlobalRule(freezeWindowGlobalRule); err != nil {
 		t.Fatal(err)
 	}
 
@@ -479,10 +1123,10 @@ func createTestStateWithGlobalConstraintThreshold(t *testing.T) *State {
 	return state
 }
 
-// createTestStateWithGlobalConstraintBlockForcePushes creates a policy state
-// with no explicit branch protection rules but with a rule that blocks force
-// pushes to main.
-func createTestStateWithGlobalConstraintBlockForcePushes(t *testing.T) *State {
+// createTestStateWithGlobalConstraintRequireSignOff creates a policy state
+// with no explicit branch protection rules but with a rule that requires all
+// commits on main to be signed off by their authors.
+func createTestStateWithGlobalConstraintRequireSignOff(t *testing.T) *State {
 	t.Helper()
 
 	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
@@ -497,11 +1141,11 @@ func createTestStateWithGlobalConstraintBlockForcePushes(t *testing.T) *State {
 		t.Fatal(err)
 	}
 
-	forcePushesGlobalRule, err := tufv01.NewGlobalRuleBlockForcePushes("block-force-pushes-main", []string{"git:refs/heads/main"})
+	signOffGlobalRule, err := tufv01.NewGlobalRuleRequireSignOff("require-sign-off-main", []string{"git:refs/heads/main"}, false)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if err := rootMetadata.AddGlobalRule(forcePushesGlobalRule); err != nil {
+	if err := rootMetadata.AddGlobalRule(signOffGlobalRule); err != nil {
 		t.Fatal(err)
 	}
 
@@ -768,6 +1412,44 @@ func createTestStateWithThresholdPolicy(t *testing.T) *State {
 	return state
 }
 
+// createTestStateWithSecretsPolicy sets up a test policy where the secrets ref
+// is protected by a rule that only trusts targets1PubKeyBytes.
+func createTestStateWithSecretsPolicy(t *testing.T) *State {
+	t.Helper()
+
+	state := createTestStateWithPolicy(t)
+
+	approverKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := targetsMetadata.AddPrincipal(approverKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := targetsMetadata.AddRule("protect-secrets", []string{approverKey.KeyID}, []string{"git:refs/gittuf/secrets"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Metadata.TargetsEnvelope = targetsEnv
+
+	return state
+}
+
 // createTestStateWithThresholdPolicyAndGitHubAppTrust sets up a test policy
 // with threshold rules. It uses v0.2 (and higher) policy metadata to support
 // GitHub apps.
@@ -973,6 +1655,70 @@ func createTestStateWithThresholdPolicyAndGitHubAppTrustForMixedAttestations(t *
 	return state
 }
 
+// createTestStateWithSeparationOfDutiesPolicy sets up a test policy in which
+// the main branch is protected by a rule that requires separation of duties.
+//
+// Usage notes:
+//   - The two authorized persons are "jane.doe" and "john.doe"
+//   - jane.doe's signing key is targets1PubKeyBytes, and jane.doe's associated
+//     identity is the email address of the author of test commits
+//   - john.doe's signing key is targets2PubKeyBytes
+//   - The rule's threshold is 1
+func createTestStateWithSeparationOfDutiesPolicy(t *testing.T) *State {
+	t.Helper()
+
+	state := createTestStateWithPolicyUsingPersons(t)
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	author := &tufv02.Person{
+		PersonID:             "jane.doe",
+		PublicKeys:           map[string]*tufv02.Key{authorKey.KeyID: authorKey},
+		AssociatedIdentities: map[string]string{"email": "jane.doe@example.com"},
+	}
+	if err := targetsMetadata.AddPrincipal(author); err != nil {
+		t.Fatal(err)
+	}
+
+	approverKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets2PubKeyBytes))
+	approver := &tufv02.Person{
+		PersonID:   "john.doe",
+		PublicKeys: map[string]*tufv02.Key{approverKey.KeyID: approverKey},
+	}
+	if err := targetsMetadata.AddPrincipal(approver); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := targetsMetadata.UpdateRule("protect-main", []string{author.ID(), approver.ID()}, []string{"git:refs/heads/main"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := targetsMetadata.EnableSeparationOfDuties("protect-main"); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Metadata.TargetsEnvelope = targetsEnv
+
+	if err := state.preprocess(); err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
 func createTestStateWithTagPolicy(t *testing.T) *State {
 	t.Helper()
 
@@ -1094,6 +1840,38 @@ func createTestStateWithTagPolicyForUnauthorizedTest(t *testing.T) *State {
 	return state
 }
 
+// createTestMergeCommit creates an unsigned commit with the specified parents
+// and sets refName to it.
+func createTestMergeCommit(t *testing.T, repo *gitinterface.Repository, refName string, parentIDs ...gitinterface.Hash) gitinterface.Hash {
+	t.Helper()
+
+	treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"--git-dir", repo.GetGitDir(), "commit-tree", treeID.String(), "-m", "Merge commit"}
+	for _, parentID := range parentIDs {
+		args = append(args, "-p", parentID.String())
+	}
+
+	stdOut, err := exec.Command("git", args...).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := gitinterface.NewHash(strings.TrimSpace(string(stdOut)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.SetReference(refName, commitID); err != nil {
+		t.Fatal(err)
+	}
+
+	return commitID
+}
+
 func setupSSHKeysForSigning(t *testing.T, privateBytes, publicBytes []byte) *ssh.Signer {
 	t.Helper()
 
@@ -0,0 +1,332 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/danwakefield/fnmatch"
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+)
+
+const (
+	LintCheckShadowedRule           = "shadowed-rule"
+	LintCheckUnsatisfiableThreshold = "unsatisfiable-threshold"
+	LintCheckUnusedPrincipal        = "unused-principal"
+	LintCheckUnreachableRuleFile    = "unreachable-rule-file"
+	LintCheckOverlappingGlobalRules = "overlapping-global-rules"
+)
+
+// LintFinding records a problem identified in the policy by Lint. Name
+// identifies the rule, principal, rule file, or global rule the finding is
+// about, and RuleFile the rule file it is declared in, if any.
+type LintFinding struct {
+	Check    string `json:"check"`
+	RuleFile string `json:"ruleFile,omitempty"`
+	Name     string `json:"name"`
+	Message  string `json:"message"`
+}
+
+// Lint analyzes the policy for rules that can never apply or be met. It flags
+// rules that are shadowed by an earlier terminating rule in the same rule file,
+// rules whose threshold exceeds the number of distinct principals they trust,
+// principals declared in a rule file that none of its rules or teams, nor any
+// restrict read global rule, references, rule files that cannot be reached from the primary
+// rule file, and global rules of the same type whose namespaces overlap.
+// Restrict files and freeze window global rules are not checked for overlaps
+// as overlapping rules of these types are expected to differ in the files or
+// times they apply to. Restrict read global rules are not checked either as
+// overlapping rules combine their readers.
+func (s *State) Lint() ([]*LintFinding, error) {
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := lintGlobalRules(rootMetadata.GetGlobalRules())
+
+	if !s.HasTargetsRole(TargetsRoleName) {
+		return findings, nil
+	}
+
+	ruleFileNames := make([]string, 0, len(s.Metadata.DelegationEnvelopes))
+	for ruleFileName := range s.Metadata.DelegationEnvelopes {
+		ruleFileNames = append(ruleFileNames, ruleFileName)
+	}
+	slices.Sort(ruleFileNames)
+	ruleFileNames = append([]string{TargetsRoleName}, ruleFileNames...)
+
+	ruleFiles := map[string]tuf.TargetsMetadata{}
+	allPrincipals := map[string]tuf.Principal{}
+	readerPrincipalIDs := set.NewSet[string]()
+	for _, globalRule := range rootMetadata.GetGlobalRules() {
+		if restrictReadRule, isRestrictRead := globalRule.(tuf.GlobalRuleRestrictRead); isRestrictRead {
+			readerPrincipalIDs.Extend(set.NewSetFromItems(restrictReadRule.GetReaderPrincipalIDs()...))
+		}
+	}
+	for _, ruleFileName := range ruleFileNames {
+		targetsMetadata, err := s.GetTargetsMetadata(ruleFileName, false)
+		if err != nil {
+			return nil, err
+		}
+		ruleFiles[ruleFileName] = targetsMetadata
+
+		for principalID, principal := range targetsMetadata.GetPrincipals() {
+			allPrincipals[principalID] = principal
+		}
+	}
+
+	for _, ruleFileName := range ruleFileNames {
+		targetsMetadata := ruleFiles[ruleFileName]
+		rules := targetsMetadata.GetRules()
+
+		for index, rule := range rules {
+			if rule.ID() == tuf.AllowRuleName {
+				continue
+			}
+
+			if shadowingRule := s.findShadowingRule(rules[:index], rule); shadowingRule != nil {
+				findings = append(findings, &LintFinding{
+					Check:    LintCheckShadowedRule,
+					RuleFile: ruleFileName,
+					Name:     rule.ID(),
+					Message:  fmt.Sprintf("rule is shadowed by earlier terminating rule '%s' and never applies", shadowingRule.ID()),
+				})
+			}
+
+			if distinctPrincipals := countDistinctPrincipals(rule, allPrincipals); rule.GetThreshold() > distinctPrincipals {
+				findings = append(findings, &LintFinding{
+					Check:    LintCheckUnsatisfiableThreshold,
+					RuleFile: ruleFileName,
+					Name:     rule.ID(),
+					Message:  fmt.Sprintf("threshold %d exceeds the %d distinct principal(s) trusted by the rule", rule.GetThreshold(), distinctPrincipals),
+				})
+			}
+		}
+
+		// Principals are declared in the rule file that uses them, so only
+		// this rule file's rules and teams, and the readers of restrict read
+		// global rules, can reference them
+		referencedPrincipalIDs := set.NewSet[string]()
+		referencedPrincipalIDs.Extend(readerPrincipalIDs)
+		for _, rule := range rules {
+			if rule.GetPrincipalIDs() != nil {
+				referencedPrincipalIDs.Extend(rule.GetPrincipalIDs())
+			}
+		}
+
+		principalIDs := []string{}
+		for principalID, principal := range targetsMetadata.GetPrincipals() {
+			principalIDs = append(principalIDs, principalID)
+			if team, isTeam := principal.(tuf.Team); isTeam && team.GetPrincipalIDs() != nil {
+				referencedPrincipalIDs.Extend(team.GetPrincipalIDs())
+			}
+		}
+		slices.Sort(principalIDs)
+		for _, principalID := range principalIDs {
+			if !referencedPrincipalIDs.Has(principalID) {
+				findings = append(findings, &LintFinding{
+					Check:    LintCheckUnusedPrincipal,
+					RuleFile: ruleFileName,
+					Name:     principalID,
+					Message:  "principal is not referenced by any rule or team",
+				})
+			}
+		}
+	}
+
+	reachableRuleFiles := set.NewSetFromItems(TargetsRoleName)
+	ruleFilesToSearch := []string{TargetsRoleName}
+	for len(ruleFilesToSearch) > 0 {
+		ruleFileName := ruleFilesToSearch[0]
+		ruleFilesToSearch = ruleFilesToSearch[1:]
+
+		for _, rule := range ruleFiles[ruleFileName].GetRules() {
+			if _, has := ruleFiles[rule.ID()]; has && !reachableRuleFiles.Has(rule.ID()) {
+				reachableRuleFiles.Add(rule.ID())
+				ruleFilesToSearch = append(ruleFilesToSearch, rule.ID())
+			}
+		}
+	}
+
+	for _, ruleFileName := range ruleFileNames {
+		if !reachableRuleFiles.Has(ruleFileName) {
+			findings = append(findings, &LintFinding{
+				Check:   LintCheckUnreachableRuleFile,
+				Name:    ruleFileName,
+				Message: "rule file is not delegated to by any reachable rule",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// findShadowingRule returns the first of the earlierRules that is terminating
+// and matches every namespace protected by rule. Terminating rules only stop
+// the processing of subsequent rules if they delegate to a rule file.
+func (s *State) findShadowingRule(earlierRules []tuf.Rule, rule tuf.Rule) tuf.Rule {
+	for _, earlierRule := range earlierRules {
+		if !earlierRule.IsLastTrustedInRuleFile() || !s.HasTargetsRole(earlierRule.ID()) {
+			continue
+		}
+
+		if namespacesCovered(earlierRule, rule.GetProtectedNamespaces()) {
+			return earlierRule
+		}
+	}
+
+	return nil
+}
+
+// namespacesCovered returns true if every namespace pattern is matched by one
+// of the rule's patterns and cannot be matched by its exclusions. Regular
+// expression and ref class patterns can't be compared with other patterns, so
+// they're only considered to cover identical patterns.
+func namespacesCovered(rule tuf.Rule, namespaces []string) bool {
+	for _, namespace := range namespaces {
+		covered := false
+		for _, pattern := range rule.GetProtectedNamespaces() {
+			if pattern == namespace || (tuf.IsGlobPattern(pattern) && tuf.IsGlobPattern(namespace) && fnmatch.Match(pattern, namespace, 0)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+
+		for _, excludedPattern := range rule.GetExcludedNamespaces() {
+			if !tuf.IsGlobPattern(excludedPattern) || !tuf.IsGlobPattern(namespace) {
+				// The exclusion may match some of the namespace
+				return false
+			}
+			if patternsOverlap(excludedPattern, namespace) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// countDistinctPrincipals returns the number of principals trusted by the rule
+// that can independently count towards its threshold. Principals that are not
+// declared cannot sign, and a key that belongs to a person also trusted by the
+// rule is counted with the person.
+func countDistinctPrincipals(rule tuf.Rule, principals map[string]tuf.Principal) int {
+	principalIDs := rule.GetPrincipalIDs()
+	if principalIDs == nil {
+		return 0
+	}
+
+	personKeyIDs := set.NewSet[string]()
+	for _, principalID := range principalIDs.Contents() {
+		if person, isPerson := principals[principalID].(*tufv02.Person); isPerson {
+			for keyID := range person.PublicKeys {
+				personKeyIDs.Add(keyID)
+			}
+		}
+	}
+
+	count := 0
+	for _, principalID := range principalIDs.Contents() {
+		principal, has := principals[principalID]
+		if !has {
+			continue
+		}
+		if _, isKey := principal.(*tufv02.Key); isKey && personKeyIDs.Has(principalID) {
+			continue
+		}
+		count++
+	}
+
+	return count
+}
+
+// lintGlobalRules flags pairs of global rules of the same type whose
+// namespaces overlap. Overlapping threshold rules are reported as conflicting
+// if their thresholds differ, and as redundant otherwise.
+func lintGlobalRules(globalRules []tuf.GlobalRule) []*LintFinding {
+	findings := []*LintFinding{}
+
+	for index, globalRule := range globalRules {
+		ruleType, namespaces := lintableGlobalRuleTypeAndNamespaces(globalRule)
+		if ruleType == "" {
+			continue
+		}
+
+		for _, earlierGlobalRule := range globalRules[:index] {
+			earlierRuleType, earlierNamespaces := lintableGlobalRuleTypeAndNamespaces(earlierGlobalRule)
+			if earlierRuleType != ruleType || !anyPatternsOverlap(earlierNamespaces, namespaces) {
+				continue
+			}
+
+			message := fmt.Sprintf("namespaces overlap with %s global rule '%s'", ruleType, earlierGlobalRule.GetName())
+			if thresholdRule, isThreshold := globalRule.(tuf.GlobalRuleThreshold); isThreshold {
+				earlierThreshold := earlierGlobalRule.(tuf.GlobalRuleThreshold).GetThreshold() //nolint:errcheck
+				if earlierThreshold != thresholdRule.GetThreshold() {
+					message = fmt.Sprintf("threshold %d conflicts with threshold %d of global rule '%s' for overlapping namespaces", thresholdRule.GetThreshold(), earlierThreshold, earlierGlobalRule.GetName())
+				}
+			}
+
+			findings = append(findings, &LintFinding{
+				Check:   LintCheckOverlappingGlobalRules,
+				Name:    globalRule.GetName(),
+				Message: message,
+			})
+		}
+	}
+
+	return findings
+}
+
+// lintableGlobalRuleTypeAndNamespaces returns the type and namespaces of the
+// global rule if it is checked for overlaps by lintGlobalRules.
+func lintableGlobalRuleTypeAndNamespaces(globalRule tuf.GlobalRule) (string, []string) {
+	switch globalRule := globalRule.(type) {
+	case tuf.GlobalRuleThreshold:
+		return tuf.GlobalRuleThresholdType, globalRule.GetProtectedNamespaces()
+	case tuf.GlobalRuleRequireSignedCommits:
+		return tuf.GlobalRuleRequireSignedCommitsType, globalRule.GetProtectedNamespaces()
+	case tuf.GlobalRuleRequireSignOff:
+		return tuf.GlobalRuleRequireSignOffType, globalRule.GetProtectedNamespaces()
+	case tuf.GlobalRuleRequireLinearHistory:
+		return tuf.GlobalRuleRequireLinearHistoryType, globalRule.GetProtectedNamespaces()
+	case tuf.GlobalRuleRestrictFiles, tuf.GlobalRuleFreezeWindow, tuf.GlobalRuleRestrictRead:
+		return "", nil
+	case tuf.GlobalRuleBlockForcePushes:
+		return tuf.GlobalRuleBlockForcePushesType, globalRule.GetProtectedNamespaces()
+	}
+
+	return "", nil
+}
+
+// anyPatternsOverlap returns true if a pattern in patternsA and one in
+// patternsB may match the same namespace.
+func anyPatternsOverlap(patternsA, patternsB []string) bool {
+	for _, patternA := range patternsA {
+		for _, patternB := range patternsB {
+			if patternsOverlap(patternA, patternB) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// patternsOverlap approximates whether two patterns may match the same
+// namespace by checking if either pattern matches the other. Regular expression
+// and ref class patterns are only considered to overlap identical patterns.
+func patternsOverlap(patternA, patternB string) bool {
+	if !tuf.IsGlobPattern(patternA) || !tuf.IsGlobPattern(patternB) {
+		return patternA == patternB
+	}
+
+	return fnmatch.Match(patternA, patternB, 0) || fnmatch.Match(patternB, patternA, 0)
+}
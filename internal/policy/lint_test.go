@@ -0,0 +1,247 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateLint(t *testing.T) {
+	t.Run("no findings", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+
+		findings, err := state.Lint()
+		assert.Nil(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("rule file findings", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+		unusedKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootKeyID := signer.MetadataKey().KeyID
+
+		if err := targetsMetadata.AddPrincipal(unusedKey); err != nil {
+			t.Fatal(err)
+		}
+		// rule 1 delegates to a rule file, so as a terminating rule it shadows
+		// any subsequent rule it covers
+		targetsMetadata.(*tufv02.TargetsMetadata).Delegations.Roles[0].Terminating = true
+		if err := targetsMetadata.AddRule("shadowed", []string{rootKeyID}, []string{"file:1/subpath1/*"}, 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := targetsMetadata.AddRule("unsatisfiable", []string{rootKeyID}, []string{"file:3/*"}, 1); err != nil {
+			t.Fatal(err)
+		}
+		// AddRule rejects such thresholds, but rule files may be modified
+		// directly
+		rules := targetsMetadata.(*tufv02.TargetsMetadata).Delegations.Roles
+		rules[len(rules)-2].Threshold = 2
+
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.Metadata.TargetsEnvelope = targetsEnv
+
+		orphanMetadata := InitializeTargetsMetadata()
+		orphanEnv, err := dsse.CreateEnvelope(orphanMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		orphanEnv, err = dsse.SignEnvelope(context.Background(), orphanEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.Metadata.DelegationEnvelopes["orphan"] = orphanEnv
+
+		findings, err := state.Lint()
+		assert.Nil(t, err)
+
+		expectedFindings := []*LintFinding{
+			{
+				Check:    LintCheckShadowedRule,
+				RuleFile: TargetsRoleName,
+				Name:     "shadowed",
+				Message:  "rule is shadowed by earlier terminating rule '1' and never applies",
+			},
+			{
+				Check:    LintCheckUnsatisfiableThreshold,
+				RuleFile: TargetsRoleName,
+				Name:     "unsatisfiable",
+				Message:  "threshold 2 exceeds the 1 distinct principal(s) trusted by the rule",
+			},
+			{
+				Check:    LintCheckUnusedPrincipal,
+				RuleFile: TargetsRoleName,
+				Name:     unusedKey.KeyID,
+				Message:  "principal is not referenced by any rule or team",
+			},
+			{
+				Check:   LintCheckUnreachableRuleFile,
+				Name:    "orphan",
+				Message: "rule file is not delegated to by any reachable rule",
+			},
+		}
+		assert.Equal(t, expectedFindings, findings)
+	})
+
+	t.Run("principal referenced only in another rule file", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+		rootKey := tufv01.NewKeyFromSSLibKey(signer.MetadataKey())
+
+		// The root key is trusted by the rules in the primary rule file, but
+		// not by any rule in rule file 1
+		delegationMetadata, err := state.GetTargetsMetadata("1", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := delegationMetadata.AddPrincipal(rootKey); err != nil {
+			t.Fatal(err)
+		}
+
+		delegationEnv, err := dsse.CreateEnvelope(delegationMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		delegationEnv, err = dsse.SignEnvelope(context.Background(), delegationEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.Metadata.DelegationEnvelopes["1"] = delegationEnv
+
+		findings, err := state.Lint()
+		assert.Nil(t, err)
+
+		expectedFindings := []*LintFinding{
+			{
+				Check:    LintCheckUnusedPrincipal,
+				RuleFile: "1",
+				Name:     rootKey.KeyID,
+				Message:  "principal is not referenced by any rule or team",
+			},
+		}
+		assert.Equal(t, expectedFindings, findings)
+	})
+
+	t.Run("person and their key are counted once", func(t *testing.T) {
+		gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+		person := &tufv02.Person{
+			PersonID:   "jane.doe",
+			PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey},
+		}
+		principals := map[string]tuf.Principal{
+			person.PersonID: person,
+			gpgKey.KeyID:    gpgKey,
+		}
+
+		rule := &tufv02.Delegation{
+			Name:  "rule",
+			Paths: []string{"git:refs/heads/main"},
+			Role: tufv02.Role{
+				PrincipalIDs: set.NewSetFromItems(person.PersonID, gpgKey.KeyID),
+				Threshold:    2,
+			},
+		}
+
+		assert.Equal(t, 1, countDistinctPrincipals(rule, principals))
+	})
+
+	t.Run("global rule findings", func(t *testing.T) {
+		state := createTestStateWithGlobalConstraintThreshold(t)
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+		gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+		rootMetadata, err := state.GetRootMetadata(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := rootMetadata.AddGlobalRule(tufv01.NewGlobalRuleThreshold("threshold-3-branches", []string{"git:refs/heads/*"}, 3)); err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.AddGlobalRule(tufv01.NewGlobalRuleThreshold("threshold-2-tags", []string{"git:refs/tags/*"}, 2)); err != nil {
+			t.Fatal(err)
+		}
+		blockForcePushesMain, err := tufv01.NewGlobalRuleBlockForcePushes("block-force-pushes-main", []string{"git:refs/heads/main"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.AddGlobalRule(blockForcePushesMain); err != nil {
+			t.Fatal(err)
+		}
+		blockForcePushesAll, err := tufv01.NewGlobalRuleBlockForcePushes("block-force-pushes-all", []string{"git:*"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.AddGlobalRule(blockForcePushesAll); err != nil {
+			t.Fatal(err)
+		}
+
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.Metadata.RootEnvelope = rootEnv
+
+		findings, err := state.Lint()
+		assert.Nil(t, err)
+
+		expectedFindings := []*LintFinding{
+			{
+				Check:   LintCheckOverlappingGlobalRules,
+				Name:    "threshold-3-branches",
+				Message: "threshold 3 conflicts with threshold 2 of global rule 'threshold-2-main' for overlapping namespaces",
+			},
+			{
+				Check:   LintCheckOverlappingGlobalRules,
+				Name:    "block-force-pushes-all",
+				Message: "namespaces overlap with " + tuf.GlobalRuleBlockForcePushesType + " global rule 'block-force-pushes-main'",
+			},
+			{
+				// The policy has no rules that use the gpg key
+				Check:    LintCheckUnusedPrincipal,
+				RuleFile: TargetsRoleName,
+				Name:     gpgKey.KeyID,
+				Message:  "principal is not referenced by any rule or team",
+			},
+		}
+		assert.Equal(t, expectedFindings, findings)
+	})
+}
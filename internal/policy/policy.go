@@ -9,18 +9,24 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/encryption"
 	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
 	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/gittuf/gittuf/internal/tuf/migrations"
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
 	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 )
 
 const (
@@ -43,6 +49,8 @@ const (
 
 	gitReferenceRuleScheme = "git"
 	fileRuleScheme         = "file"
+
+	signOffTrailerKey = "Signed-off-by"
 )
 
 var (
@@ -53,6 +61,10 @@ var (
 	ErrNotAncestor                   = errors.New("cannot apply changes since policy is not an ancestor of the policy staging")
 	ErrControllerMetadataNotFound    = errors.New("requested controller repository metadata not found")
 	ErrControllerMetadataNotVerified = errors.New("unable to verify controller repository metadata")
+	ErrMetadataExpired               = errors.New("policy metadata has expired")
+	ErrReferenceNotReadRestricted    = errors.New("reference is not read restricted by any global rule")
+	ErrReaderHasNoEncryptionKey      = errors.New("reader has no key that contents can be encrypted for")
+	ErrDefaultBranchUnknown          = errors.New("unable to determine the repository's default branch from its symbolic HEAD, declare it in the root of trust using 'gittuf trust set-default-branch'")
 )
 
 // State contains the full set of metadata and root keys present in a policy
@@ -72,6 +84,11 @@ type State struct {
 	allPrincipals  map[string]tuf.Principal
 	hasFileRule    bool
 	globalRules    map[string][]tuf.GlobalRule
+
+	usesDefaultBranch   bool
+	defaultBranch       string
+	defaultBranchErr    error
+	defaultBranchLoaded bool
 }
 
 type StateMetadata struct {
@@ -482,6 +499,11 @@ func (s *State) findVerifiersForPathIfProtected(path string) ([]*SignatureVerifi
 		targetsMetadata.GetRules(),
 	}
 
+	matchOptions, err := s.getMatchOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	seenRoles := map[string]bool{TargetsRoleName: true}
 
 	var currentDelegationGroup []tuf.Rule
@@ -501,16 +523,8 @@ func (s *State) findVerifiersForPathIfProtected(path string) ([]*SignatureVerifi
 			delegation := currentDelegationGroup[0]
 			currentDelegationGroup = currentDelegationGroup[1:]
 
-			if delegation.Matches(path) {
-				verifier := &SignatureVerifier{
-					repository: s.repository,
-					name:       delegation.ID(),
-					principals: make([]tuf.Principal, 0, delegation.GetPrincipalIDs().Len()),
-					threshold:  delegation.GetThreshold(),
-				}
-				for _, principalID := range delegation.GetPrincipalIDs().Contents() {
-					verifier.principals = append(verifier.principals, allPrincipals[principalID])
-				}
+			if delegation.Matches(path, matchOptions...) {
+				verifier := newSignatureVerifierForRule(s.repository, delegation, allPrincipals)
 				verifiers = append(verifiers, verifier)
 
 				if _, seen := seenRoles[delegation.ID()]; seen {
@@ -548,16 +562,151 @@ func (s *State) GetAllPrincipals() map[string]tuf.Principal {
 	return s.allPrincipals
 }
 
+// GetReaderKeysForReference returns the keys of the principals that are
+// allowed to read the specified reference by the restrict read global rules
+// that apply to it. Teams are expanded to the keys of their members. Only keys
+// that contents can be encrypted for are returned, and every reader must have
+// at least one such key. If no restrict read global rule applies to the
+// reference, it returns ErrReferenceNotReadRestricted.
+func (s *State) GetReaderKeysForReference(refName string) ([]*signerverifier.SSLibKey, error) {
+	target := fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName)
+
+	matchOptions, err := s.getMatchOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	restricted := false
+	keys := []*signerverifier.SSLibKey{}
+	seenKeyIDs := set.NewSet[string]()
+	for _, globalRules := range s.globalRules {
+		for _, globalRule := range globalRules {
+			rule, isRestrictRead := globalRule.(tuf.GlobalRuleRestrictRead)
+			if !isRestrictRead || !rule.Matches(target, matchOptions...) {
+				continue
+			}
+			restricted = true
+
+			readerKeys, err := s.getReaderKeysForGlobalRule(rule)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, readerID := range slices.Sorted(maps.Keys(readerKeys)) {
+				for _, key := range readerKeys[readerID] {
+					if seenKeyIDs.Has(key.KeyID) {
+						continue
+					}
+					seenKeyIDs.Add(key.KeyID)
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+
+	if !restricted {
+		return nil, ErrReferenceNotReadRestricted
+	}
+
+	return keys, nil
+}
+
+// getReaderKeysForGlobalRule returns the keys that contents can be encrypted
+// for of each reader authorized by the restrict read global rule, keyed by
+// reader.
+func (s *State) getReaderKeysForGlobalRule(rule tuf.GlobalRuleRestrictRead) (map[string][]*signerverifier.SSLibKey, error) {
+	readerKeys, err := s.getEncryptionKeysByReader(rule.GetReaderPrincipalIDs())
+	if err != nil {
+		return nil, fmt.Errorf("unable to identify readers of global rule '%s': %w", rule.GetName(), err)
+	}
+
+	return readerKeys, nil
+}
+
+// getEncryptionKeysByReader returns the keys that contents can be encrypted
+// for of each of the specified principals, keyed by principal ID. Teams are
+// replaced by their members. Keys of other types, such as GPG and Sigstore
+// keys, are skipped, and ErrReaderHasNoEncryptionKey is returned for a
+// principal that has no key contents can be encrypted for.
+func (s *State) getEncryptionKeysByReader(principalIDs []string) (map[string][]*signerverifier.SSLibKey, error) {
+	readers := map[string]tuf.Principal{}
+	for _, principalID := range principalIDs {
+		principal, has := s.allPrincipals[principalID]
+		if !has {
+			return nil, fmt.Errorf("%w: '%s'", tuf.ErrPrincipalNotFound, principalID)
+		}
+
+		team, isTeam := principal.(tuf.Team)
+		if !isTeam || team.GetPrincipalIDs() == nil {
+			readers[principalID] = principal
+			continue
+		}
+
+		for _, memberID := range team.GetPrincipalIDs().Contents() {
+			member, has := s.allPrincipals[memberID]
+			if !has {
+				return nil, fmt.Errorf("%w: member '%s' of team '%s'", tuf.ErrPrincipalNotFound, memberID, principalID)
+			}
+			readers[memberID] = member
+		}
+	}
+
+	readerKeys := map[string][]*signerverifier.SSLibKey{}
+	for _, readerID := range slices.Sorted(maps.Keys(readers)) {
+		keys := []*signerverifier.SSLibKey{}
+		for _, key := range readers[readerID].Keys() {
+			if !encryption.SupportsKey(key) {
+				slog.Debug(fmt.Sprintf("Key '%s' of reader '%s' does not support encryption, skipping...", key.KeyID, readerID))
+				continue
+			}
+			keys = append(keys, key)
+		}
+
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%w: '%s'", ErrReaderHasNoEncryptionKey, readerID)
+		}
+		readerKeys[readerID] = keys
+	}
+
+	return readerKeys, nil
+}
+
+// GetEncryptionKeysForPrincipalIDs returns the keys that contents can be
+// encrypted for of the specified principals. The keys of each member of a team
+// are included in place of the team. Keys of other types, such as GPG and
+// Sigstore keys, are skipped, and ErrReaderHasNoEncryptionKey is returned if a
+// principal has no key contents can be encrypted for.
+func (s *State) GetEncryptionKeysForPrincipalIDs(principalIDs []string) ([]*signerverifier.SSLibKey, error) {
+	readerKeys, err := s.getEncryptionKeysByReader(principalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*signerverifier.SSLibKey{}
+	for _, readerID := range slices.Sorted(maps.Keys(readerKeys)) {
+		keys = append(keys, readerKeys[readerID]...)
+	}
+
+	return keys, nil
+}
+
 // Verify verifies the contents of the State for internal consistency.
 // Specifically, it checks that the root keys in the root role match the ones
 // stored on disk in the state. Further, it also verifies the signatures of the
 // top level Targets role and all reachable delegated Targets roles. Any
 // unreachable role returns an error.
 func (s *State) Verify(ctx context.Context) error {
+	return s.verify(ctx, nil)
+}
+
+// verify implements Verify, disregarding signatures using the keys in
+// revokedKeyIDs.
+func (s *State) verify(ctx context.Context, revokedKeyIDs *set.Set[string]) error {
 	rootVerifier, err := s.getRootVerifier()
 	if err != nil {
 		return err
 	}
+	rootVerifier = rootVerifier.withRevokedKeys(revokedKeyIDs)
 
 	if _, err := rootVerifier.Verify(ctx, gitinterface.ZeroHash, s.Metadata.RootEnvelope); err != nil {
 		return err
@@ -589,6 +738,7 @@ func (s *State) Verify(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		targetsVerifier = targetsVerifier.withRevokedKeys(revokedKeyIDs)
 
 		if _, err := targetsVerifier.Verify(ctx, gitinterface.ZeroHash, s.Metadata.TargetsEnvelope); err != nil {
 			return err
@@ -619,18 +769,7 @@ func (s *State) Verify(ctx context.Context) error {
 
 				env := s.Metadata.DelegationEnvelopes[delegation.ID()]
 
-				principals := []tuf.Principal{}
-				for _, principalID := range delegation.GetPrincipalIDs().Contents() {
-					principals = append(principals, delegationKeys[principalID])
-				}
-
-				verifier := &SignatureVerifier{
-					repository: s.repository,
-					name:       delegation.ID(),
-					principals: principals,
-					threshold:  delegation.GetThreshold(),
-				}
-
+				verifier := newSignatureVerifierForRule(s.repository, delegation, delegationKeys).withRevokedKeys(revokedKeyIDs)
 				if _, err := verifier.Verify(ctx, gitinterface.ZeroHash, env); err != nil {
 					return err
 				}
@@ -654,6 +793,19 @@ func (s *State) Verify(ctx context.Context) error {
 		}
 	}
 
+	// Check expiry of metadata at the time the policy was recorded in the
+	// RSL, so that verifying historic policy states is reproducible
+	verificationTime := time.Now()
+	if s.loadedEntry != nil {
+		verificationTime, err = s.repository.GetCommitTime(s.loadedEntry.GetID())
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.VerifyExpiry(verificationTime); err != nil {
+		return err
+	}
+
 	if s.loadedEntry == nil {
 		slog.Debug("Policy not loaded from RSL, skipping verification of controller metadata...")
 		return nil
@@ -851,10 +1003,34 @@ func Apply(ctx context.Context, repo *gitinterface.Repository, signRSLEntry bool
 	if err != nil {
 		return fmt.Errorf("failed to load current state: %w", err)
 	}
-	if err := state.Verify(ctx); err != nil {
+
+	// Revocations declared in the staged policy take effect for the new
+	// policy's RSL entry, so revoked keys must not have signed it
+	keyRevocations, err := state.GetKeyRevocations()
+	if err != nil {
+		return err
+	}
+	revokedKeyIDs, err := getRevokedKeyIDsForNewEntry(repo, keyRevocations)
+	if err != nil {
+		return err
+	}
+	if err := state.verify(ctx, revokedKeyIDs); err != nil {
 		return fmt.Errorf("staged policy is invalid: %w", err)
 	}
 
+	if entryFound {
+		// The staged root of trust must also be signed by the applied root
+		// of trust's threshold, as verification checks each policy using the
+		// one before it
+		appliedState, err := LoadState(ctx, repo, policyEntry)
+		if err != nil {
+			return fmt.Errorf("failed to load applied policy: %w", err)
+		}
+		if err := appliedState.verifyNewState(ctx, state, revokedKeyIDs); err != nil {
+			return fmt.Errorf("staged policy is not trusted by applied policy: %w", err)
+		}
+	}
+
 	// Update the reference for the base to point to the new commit
 	if err := repo.SetReference(PolicyRef, policyStagingTip); err != nil {
 		return fmt.Errorf("failed to set new policy reference: %w", err)
@@ -1072,6 +1248,97 @@ func ReconcileStaging(repo *gitinterface.Repository, signCommit bool) error {
 	return newStagingState.Commit(repo, "Rebase policy staging\n", true, signCommit)
 }
 
+// GetMetadataExpiries returns the expiry time of the root of trust and every
+// rule file in the policy state, keyed by role name. Metadata that does not
+// declare an expiry is omitted.
+func (s *State) GetMetadataExpiries() (map[string]time.Time, error) {
+	expiries := map[string]time.Time{}
+
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return nil, err
+	}
+	if err := addMetadataExpiry(expiries, RootRoleName, rootMetadata.GetExpires()); err != nil {
+		return nil, err
+	}
+
+	if s.Metadata.TargetsEnvelope == nil {
+		return expiries, nil
+	}
+
+	roleNames := []string{TargetsRoleName}
+	for roleName := range s.Metadata.DelegationEnvelopes {
+		roleNames = append(roleNames, roleName)
+	}
+
+	for _, roleName := range roleNames {
+		targetsMetadata, err := s.GetTargetsMetadata(roleName, false)
+		if err != nil {
+			return nil, err
+		}
+		if err := addMetadataExpiry(expiries, roleName, targetsMetadata.GetExpires()); err != nil {
+			return nil, err
+		}
+	}
+
+	return expiries, nil
+}
+
+// VerifyExpiry checks that neither the root of trust nor any rule file in the
+// policy state had expired at the specified time. Expiry is enforced for new
+// roots of trust unless they opt out. Roots of trust created before expiry was
+// enforced do not declare it, and continue to verify until their expiry is
+// set.
+func (s *State) VerifyExpiry(at time.Time) error {
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return err
+	}
+	if !rootMetadata.IsExpiryEnforced() {
+		slog.Debug("Root of trust does not enforce expiry, skipping expiry verification...")
+		return nil
+	}
+
+	expiries, err := s.GetMetadataExpiries()
+	if err != nil {
+		return err
+	}
+
+	roleNames := []string{}
+	for roleName := range expiries {
+		roleNames = append(roleNames, roleName)
+	}
+	slices.Sort(roleNames)
+
+	for _, roleName := range roleNames {
+		if expires := expiries[roleName]; !at.Before(expires) {
+			return fmt.Errorf("%w: metadata for '%s' expired at '%s'", ErrMetadataExpired, roleName, expires.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// defaultExpiry returns the expiry of newly created metadata, one year from
+// now.
+func defaultExpiry() string {
+	return time.Now().AddDate(1, 0, 0).Format(time.RFC3339)
+}
+
+func addMetadataExpiry(expiries map[string]time.Time, roleName, expires string) error {
+	if expires == "" {
+		return nil
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expires)
+	if err != nil {
+		return fmt.Errorf("unable to parse expiry of metadata for '%s': %w", roleName, err)
+	}
+
+	expiries[roleName] = expiry
+	return nil
+}
+
 func (s *State) GetRootKeys() ([]tuf.Principal, error) {
 	rootMetadata, err := s.GetRootMetadata(false) // don't migrate: this may be for a write and we don't want to write tufv02 metadata yet
 	if err != nil {
@@ -1081,6 +1348,26 @@ func (s *State) GetRootKeys() ([]tuf.Principal, error) {
 	return rootMetadata.GetRootPrincipals()
 }
 
+// GetKeyRevocations returns the IDs of keys revoked in the root of trust mapped
+// to the RSL entries after which they are revoked.
+func (s *State) GetKeyRevocations() (map[string]gitinterface.Hash, error) {
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return nil, err
+	}
+
+	revocations := map[string]gitinterface.Hash{}
+	for keyID, entryID := range rootMetadata.GetKeyRevocations() {
+		effectiveEntryID, err := gitinterface.NewHash(entryID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSL entry for revocation of key '%s': %w", keyID, err)
+		}
+		revocations[keyID] = effectiveEntryID
+	}
+
+	return revocations, nil
+}
+
 // GetRootMetadata returns the deserialized payload of the State's RootEnvelope.
 // The `migrate` parameter determines if the schema must be converted to a newer
 // version.
@@ -1117,6 +1404,71 @@ func (s *State) HasRuleName(name string) bool {
 	return s.ruleNames.Has(name)
 }
 
+// getMatchOptions returns the options used to match rule patterns against
+// namespaces. The repository's default branch is resolved the first time it's
+// needed, so that ref class patterns can refer to it. It returns an error if
+// the policy has patterns that refer to the default branch and it cannot be
+// determined.
+func (s *State) getMatchOptions() ([]matchopts.Option, error) {
+	if !s.defaultBranchLoaded {
+		s.defaultBranchLoaded = true
+		s.defaultBranch, s.defaultBranchErr = s.resolveDefaultBranch()
+	}
+
+	if s.defaultBranchErr != nil {
+		return nil, s.defaultBranchErr
+	}
+
+	return []matchopts.Option{matchopts.WithDefaultBranch(s.defaultBranch)}, nil
+}
+
+// resolveDefaultBranch identifies the repository's default branch as the
+// branch its symbolic HEAD points to. As HEAD can vary across clones, a branch
+// declared in the root metadata takes precedence, so that the default branch
+// can be pinned for every clone.
+func (s *State) resolveDefaultBranch() (string, error) {
+	if !s.usesDefaultBranch {
+		return "", nil
+	}
+
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return "", err
+	}
+
+	if defaultBranch := rootMetadata.GetDefaultBranch(); defaultBranch != "" {
+		slog.Debug(fmt.Sprintf("Using default branch '%s' declared in root of trust...", defaultBranch))
+		return defaultBranch, nil
+	}
+
+	if s.repository == nil {
+		return "", ErrDefaultBranchUnknown
+	}
+
+	defaultBranch, err := s.repository.GetSymbolicReferenceTarget("HEAD")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDefaultBranchUnknown, err)
+	}
+	if !strings.HasPrefix(defaultBranch, gitinterface.BranchRefPrefix) {
+		return "", ErrDefaultBranchUnknown
+	}
+
+	slog.Debug(fmt.Sprintf("Using default branch '%s' from HEAD...", defaultBranch))
+	return defaultBranch, nil
+}
+
+// rulesReferToDefaultBranch returns true if any pattern of the rules depends
+// on the repository's default branch.
+func rulesReferToDefaultBranch(rules []tuf.Rule) bool {
+	return slices.ContainsFunc(rules, tuf.RuleRefersToDefaultBranch)
+}
+
+// globalRulesReferToDefaultBranch returns true if any pattern of the global
+// rules depends on the repository's default branch.
+func globalRulesReferToDefaultBranch(globalRules []tuf.GlobalRule) bool {
+	return slices.ContainsFunc(globalRules, tuf.GlobalRuleRefersToDefaultBranch)
+}
+
 // preprocess handles several "one time" tasks when the state is first loaded.
 // This includes things like loading the set of rule names present in the state,
 // checking if it has file rules, etc.
@@ -1159,6 +1511,7 @@ func (s *State) preprocess() error {
 		s.globalRules = map[string][]tuf.GlobalRule{
 			"": rootMetadata.GetGlobalRules(),
 		}
+		s.usesDefaultBranch = s.usesDefaultBranch || globalRulesReferToDefaultBranch(globalRules)
 	}
 
 	if s.allPrincipals == nil {
@@ -1189,6 +1542,8 @@ func (s *State) preprocess() error {
 		s.allPrincipals[principalID] = principal
 	}
 
+	s.usesDefaultBranch = s.usesDefaultBranch || rulesReferToDefaultBranch(targetsMetadata.GetRules())
+
 	for _, rule := range targetsMetadata.GetRules() {
 		if rule.ID() == tuf.AllowRuleName {
 			continue
@@ -1203,7 +1558,7 @@ func (s *State) preprocess() error {
 		if !s.hasFileRule {
 			patterns := rule.GetProtectedNamespaces()
 			for _, pattern := range patterns {
-				if strings.HasPrefix(pattern, fileRuleScheme) {
+				if tuf.IsFilePattern(pattern) {
 					s.hasFileRule = true
 					break
 				}
@@ -1222,6 +1577,8 @@ func (s *State) preprocess() error {
 				s.allPrincipals[principalID] = principal
 			}
 
+			s.usesDefaultBranch = s.usesDefaultBranch || rulesReferToDefaultBranch(delegatedMetadata.GetRules())
+
 			for _, rule := range delegatedMetadata.GetRules() {
 				if rule.ID() == tuf.AllowRuleName {
 					continue
@@ -1236,7 +1593,7 @@ func (s *State) preprocess() error {
 				if !s.hasFileRule {
 					patterns := rule.GetProtectedNamespaces()
 					for _, pattern := range patterns {
-						if strings.HasPrefix(pattern, fileRuleScheme) {
+						if tuf.IsFilePattern(pattern) {
 							s.hasFileRule = true
 							break
 						}
@@ -1259,6 +1616,7 @@ func (s *State) preprocess() error {
 			}
 
 			s.globalRules[controllerName] = globalRules
+			s.usesDefaultBranch = s.usesDefaultBranch || globalRulesReferToDefaultBranch(globalRules)
 		}
 	}
 
@@ -1311,6 +1669,78 @@ func (s *State) getTargetsVerifier() (*SignatureVerifier, error) {
 	}, nil
 }
 
+// getDelegationVerifier returns the verifier for the rule file delegated to
+// as roleName by reaching it from the primary rule file.
+func (s *State) getDelegationVerifier(roleName string) (*SignatureVerifier, error) {
+	targetsMetadata, err := s.GetTargetsMetadata(TargetsRoleName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	delegationsQueue := targetsMetadata.GetRules()
+	delegationKeys := targetsMetadata.GetPrincipals()
+	for len(delegationsQueue) > 1 {
+		// The last entry in the queue is always the allow rule
+		delegation := delegationsQueue[0]
+		delegationsQueue = delegationsQueue[1:]
+
+		if delegation.ID() == roleName {
+			return newSignatureVerifierForRule(s.repository, delegation, delegationKeys), nil
+		}
+
+		if s.HasTargetsRole(delegation.ID()) {
+			delegatedMetadata, err := s.GetTargetsMetadata(delegation.ID(), false)
+			if err != nil {
+				return nil, err
+			}
+
+			delegationsQueue = append(delegatedMetadata.GetRules(), delegationsQueue...)
+			for keyID, key := range delegatedMetadata.GetPrincipals() {
+				delegationKeys[keyID] = key
+			}
+		}
+	}
+
+	return nil, ErrDanglingDelegationMetadata
+}
+
+// VerifyMetadataSignature checks that signature is a valid signature over the
+// metadata for roleName, which is either RootRoleName or the name of a rule
+// file, made using a key trusted to sign that metadata.
+func (s *State) VerifyMetadataSignature(ctx context.Context, roleName string, signature sslibdsse.Signature) error {
+	var (
+		verifier *SignatureVerifier
+		env      *sslibdsse.Envelope
+		err      error
+	)
+	switch {
+	case roleName == RootRoleName:
+		verifier, err = s.getRootVerifier()
+		env = s.Metadata.RootEnvelope
+	case !s.HasTargetsRole(roleName):
+		return ErrMetadataNotFound
+	case roleName == TargetsRoleName:
+		verifier, err = s.getTargetsVerifier()
+		env = s.Metadata.TargetsEnvelope
+	default:
+		verifier, err = s.getDelegationVerifier(roleName)
+		env = s.Metadata.DelegationEnvelopes[roleName]
+	}
+	if err != nil {
+		return err
+	}
+
+	// A single signature must be verified by one of the trusted principals,
+	// irrespective of the number of signatures required for the metadata
+	verifier.threshold = 1
+	_, err = verifier.Verify(ctx, gitinterface.ZeroHash, &sslibdsse.Envelope{
+		PayloadType: env.PayloadType,
+		Payload:     env.Payload,
+		Signatures:  []sslibdsse.Signature{signature},
+	})
+	return err
+}
+
 // loadStateForEntry returns the State for a specified RSL reference entry for
 // the policy namespace. This helper is focused on reading the Git object store
 // and loading the policy contents. Typically, LoadCurrentState of LoadState
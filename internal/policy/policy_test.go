@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/common/set"
 	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
@@ -18,6 +19,7 @@ import (
 	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -526,6 +528,25 @@ func TestStateVerify(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("with expired root", func(t *testing.T) {
+		t.Parallel()
+		state := createTestStateWithOnlyRoot(t)
+
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+		rootMetadata, err := state.GetRootMetadata(false)
+		require.Nil(t, err)
+		rootMetadata.SetExpires(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		require.Nil(t, err)
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+		require.Nil(t, err)
+		state.Metadata.RootEnvelope = rootEnv
+
+		err = state.Verify(testCtx)
+		assert.ErrorIs(t, err, ErrMetadataExpired)
+	})
+
 	t.Run("successful verification with multiple repositories", func(t *testing.T) {
 		controllerRepositoryLocation := t.TempDir()
 		networkRepositoryLocation := t.TempDir()
@@ -943,6 +964,44 @@ func TestStateFindVerifiersForPath(t *testing.T) {
 		}
 	})
 
+	t.Run("with exclusion patterns", func(t *testing.T) {
+		t.Parallel()
+		state := createTestStateWithExclusionPolicy(t)
+
+		rootKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, rootPubKeyBytes))
+		gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		require.Nil(t, err)
+		gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+		tests := map[string]struct {
+			path      string
+			verifiers []*SignatureVerifier
+		}{
+			"verifiers for source file": {
+				path: "file:src/main.go",
+				verifiers: []*SignatureVerifier{{
+					name:       "protect-src",
+					principals: []tuf.Principal{gpgKey},
+					threshold:  1,
+				}},
+			},
+			"verifiers for excluded generated file": {
+				path: "file:src/generated/types.go",
+				verifiers: []*SignatureVerifier{{
+					name:       "protect-generated",
+					principals: []tuf.Principal{rootKey},
+					threshold:  1,
+				}},
+			},
+		}
+
+		for name, test := range tests {
+			verifiers, err := state.FindVerifiersForPath(test.path)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+			assert.Equal(t, test.verifiers, verifiers, fmt.Sprintf("policy verifiers for path '%s' don't match expected verifiers in test '%s'", test.path, name))
+		}
+	})
+
 	t.Run("without policy", func(t *testing.T) {
 		t.Parallel()
 		state := createTestStateWithOnlyRoot(t)
@@ -986,6 +1045,86 @@ func TestStateGetAllPrincipals(t *testing.T) {
 	assert.Contains(t, principals, gpgKey.ID())
 }
 
+func TestStateGetReaderKeysForReference(t *testing.T) {
+	t.Parallel()
+	state := createTestStateWithGlobalConstraintRestrictRead(t)
+
+	readerKey := ssh.NewKeyFromBytes(t, targets2PubKeyBytes)
+
+	keys, err := state.GetReaderKeysForReference("refs/heads/main")
+	assert.Nil(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, readerKey.KeyID, keys[0].KeyID)
+
+	_, err = state.GetReaderKeysForReference("refs/heads/feature")
+	assert.ErrorIs(t, err, ErrReferenceNotReadRestricted)
+}
+
+func TestStateGetEncryptionKeysByReader(t *testing.T) {
+	t.Parallel()
+	state := createTestStateWithGlobalConstraintRestrictRead(t)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	require.Nil(t, err)
+	gpgKey := tufv02.NewKeyFromSSLibKey(gpgKeyR)
+	sshKey := tufv02.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets2PubKeyBytes))
+
+	state.allPrincipals["alice"] = &tufv02.Person{
+		PersonID:   "alice",
+		PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey, sshKey.KeyID: sshKey},
+	}
+	state.allPrincipals["bob"] = &tufv02.Person{
+		PersonID:   "bob",
+		PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey},
+	}
+	state.allPrincipals["readers"] = &tufv02.Team{
+		TeamID:       "readers",
+		PrincipalIDs: set.NewSetFromItems("alice"),
+		Threshold:    1,
+	}
+
+	// The GPG key cannot be encrypted for and is skipped
+	readerKeys, err := state.getEncryptionKeysByReader([]string{"readers"})
+	assert.Nil(t, err)
+	require.Len(t, readerKeys, 1)
+	require.Len(t, readerKeys["alice"], 1)
+	assert.Equal(t, sshKey.KeyID, readerKeys["alice"][0].KeyID)
+
+	_, err = state.getEncryptionKeysByReader([]string{"alice", "bob"})
+	assert.ErrorIs(t, err, ErrReaderHasNoEncryptionKey)
+	assert.ErrorContains(t, err, "'bob'")
+
+	_, err = state.getEncryptionKeysByReader([]string{"missing"})
+	assert.ErrorIs(t, err, tuf.ErrPrincipalNotFound)
+}
+
+func TestStateGetEncryptionKeysForPrincipalIDs(t *testing.T) {
+	t.Parallel()
+	state := createTestStateWithGlobalConstraintRestrictRead(t)
+
+	rootKey := ssh.NewKeyFromBytes(t, rootPubKeyBytes)
+	readerKey := ssh.NewKeyFromBytes(t, targets2PubKeyBytes)
+
+	keys, err := state.GetEncryptionKeysForPrincipalIDs([]string{rootKey.KeyID, readerKey.KeyID})
+	assert.Nil(t, err)
+	require.Len(t, keys, 2)
+	assert.ElementsMatch(t, []string{rootKey.KeyID, readerKey.KeyID}, []string{keys[0].KeyID, keys[1].KeyID})
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	require.Nil(t, err)
+	gpgKey := tufv02.NewKeyFromSSLibKey(gpgKeyR)
+	state.allPrincipals["bob"] = &tufv02.Person{
+		PersonID:   "bob",
+		PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey},
+	}
+
+	_, err = state.GetEncryptionKeysForPrincipalIDs([]string{readerKey.KeyID, "bob"})
+	assert.ErrorIs(t, err, ErrReaderHasNoEncryptionKey)
+
+	_, err = state.GetEncryptionKeysForPrincipalIDs([]string{"missing"})
+	assert.ErrorIs(t, err, tuf.ErrPrincipalNotFound)
+}
+
 func TestStateHasRuleName(t *testing.T) {
 	t.Parallel()
 	state := createTestStateWithPolicy(t)
@@ -995,6 +1134,87 @@ func TestStateHasRuleName(t *testing.T) {
 	assert.False(t, state.HasRuleName("missing-rule"))
 }
 
+func TestStateGetMetadataExpiries(t *testing.T) {
+	t.Parallel()
+	state := createTestStateWithDelegatedPolicies(t)
+
+	expiries, err := state.GetMetadataExpiries()
+	require.Nil(t, err)
+
+	assert.Contains(t, expiries, RootRoleName)
+	assert.Contains(t, expiries, TargetsRoleName)
+	assert.Contains(t, expiries, "1")
+	for _, expiry := range expiries {
+		assert.True(t, expiry.After(time.Now()))
+	}
+}
+
+func TestStateVerifyExpiry(t *testing.T) {
+	t.Parallel()
+	state := createTestStateWithPolicy(t)
+
+	signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	// Expiry is enforced for new roots of trust
+	err := state.VerifyExpiry(time.Now())
+	assert.Nil(t, err)
+
+	// Metadata is initialized to expire in a year
+	err = state.VerifyExpiry(time.Now().AddDate(2, 0, 0))
+	assert.ErrorIs(t, err, ErrMetadataExpired)
+	assert.ErrorContains(t, err, "metadata for 'root' expired")
+
+	// Only the rule file has expired
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+	require.Nil(t, err)
+	targetsMetadata.SetExpires(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	require.Nil(t, err)
+	targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+	require.Nil(t, err)
+	state.Metadata.TargetsEnvelope = targetsEnv
+
+	err = state.VerifyExpiry(time.Now())
+	assert.ErrorIs(t, err, ErrMetadataExpired)
+	assert.ErrorContains(t, err, "metadata for 'targets' expired")
+
+	// Expiry is not enforced if the root of trust opts out
+	rootMetadata, err := state.GetRootMetadata(false)
+	require.Nil(t, err)
+	require.Nil(t, rootMetadata.SetExpiryEnforced(false))
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	require.Nil(t, err)
+	rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+	require.Nil(t, err)
+	state.Metadata.RootEnvelope = rootEnv
+
+	err = state.VerifyExpiry(time.Now().AddDate(2, 0, 0))
+	assert.Nil(t, err)
+
+	t.Run("tufv01 root of trust", func(t *testing.T) {
+		t.Parallel()
+
+		// Root of trust metadata from before expiry was enforced cannot
+		// declare it, so its expiry is not enforced until it is migrated
+		rootMetadata := tufv01.NewRootMetadata()
+		rootMetadata.SetExpires(time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339))
+		assert.ErrorIs(t, rootMetadata.SetExpiryEnforced(true), tuf.ErrInvalidOperationForMetadataVersion)
+
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		require.Nil(t, err)
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+		require.Nil(t, err)
+		state := &State{
+			Metadata: &StateMetadata{
+				RootEnvelope: rootEnv,
+			},
+		}
+
+		err = state.VerifyExpiry(time.Now())
+		assert.Nil(t, err)
+	})
+}
+
 func TestApply(t *testing.T) {
 	t.Run("regular apply", func(t *testing.T) {
 		repo, state := createTestRepository(t, createTestStateWithOnlyRoot)
@@ -0,0 +1,294 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// ReconcileTargetsMetadata updates the primary rule file so that its
+// principals and rules match the declaration, returning the changes made.
+// principals are the keys, persons, and teams resolved from the declaration by
+// the caller. Only the principals and rules that differ are added, updated, or
+// removed, and the rules are reordered if their order differs from the
+// declared order, which is reported as a change to the rule file.
+func ReconcileTargetsMetadata(targetsMetadata tuf.TargetsMetadata, declaration *Declaration, principals []tuf.Principal) ([]*Change, error) {
+	declaredPrincipals := map[string]tuf.Principal{}
+	for _, principal := range principals {
+		declaredPrincipals[principal.ID()] = principal
+	}
+
+	existingPrincipals := targetsMetadata.GetPrincipals()
+	existingTeamIDs := set.NewSet[string]()
+	for principalID, principal := range existingPrincipals {
+		if _, isTeam := principal.(tuf.Team); isTeam {
+			existingTeamIDs.Add(principalID)
+		}
+	}
+	principalChanges := diffItems(DiffKindPrincipal, TargetsRoleName, principalsAttributes(existingPrincipals), principalsAttributes(declaredPrincipals))
+
+	existingRules := rulesAttributes(targetsMetadata.GetRules())
+	for _, item := range existingRules {
+		// Rule order is reconciled separately, and the terminating flag cannot
+		// be declared
+		delete(item, "position")
+		delete(item, "terminating")
+	}
+	ruleChanges := diffItems(DiffKindRule, TargetsRoleName, existingRules, declaredRulesAttributes(declaration.Rules))
+
+	// Teams are added after and removed before their members
+	for _, teams := range []bool{false, true} {
+		for _, change := range principalChanges {
+			principal, isDeclared := declaredPrincipals[change.Name]
+			if !isDeclared {
+				continue
+			}
+			if _, isTeam := principal.(tuf.Team); isTeam != teams {
+				continue
+			}
+
+			var err error
+			switch change.Action {
+			case DiffActionAdded:
+				err = targetsMetadata.AddPrincipal(principal)
+			case DiffActionChanged:
+				err = targetsMetadata.UpdatePrincipal(principal)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ruleActions := map[string]string{}
+	for _, change := range ruleChanges {
+		if change.Action == DiffActionRemoved {
+			if err := targetsMetadata.RemoveRule(change.Name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		ruleActions[change.Name] = change.Action
+	}
+
+	// Added rules are created in the order they are declared so that they need
+	// not be reordered
+	for _, rule := range declaration.Rules {
+		var err error
+		switch ruleActions[rule.Name] {
+		case DiffActionAdded:
+			err = targetsMetadata.AddRule(rule.Name, rule.Principals, rule.Patterns, rule.Threshold)
+		case DiffActionChanged:
+			err = targetsMetadata.UpdateRule(rule.Name, rule.Principals, rule.Patterns, rule.Threshold)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if rule.SeparationOfDuties {
+			err = targetsMetadata.EnableSeparationOfDuties(rule.Name)
+		} else {
+			err = targetsMetadata.DisableSeparationOfDuties(rule.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Rule details cannot be set in tufv01 metadata, so they're only set
+		// if they're declared or must be cleared
+		existingRule := existingRules[rule.Name]
+		if rule.Description != "" || rule.Contact != "" || rule.Link != "" || existingRule["description"] != "" || existingRule["contact"] != "" || existingRule["link"] != "" {
+			if err := targetsMetadata.SetRuleDetails(rule.Name, rule.Description, rule.Contact, rule.Link); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, teams := range []bool{true, false} {
+		for _, change := range principalChanges {
+			if change.Action != DiffActionRemoved || existingTeamIDs.Has(change.Name) != teams {
+				continue
+			}
+
+			if err := targetsMetadata.RemovePrincipal(change.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	changes := slices.Concat(principalChanges, ruleChanges)
+
+	currentRuleNames := []string{}
+	for _, rule := range targetsMetadata.GetRules() {
+		if rule.ID() != tuf.AllowRuleName {
+			currentRuleNames = append(currentRuleNames, rule.ID())
+		}
+	}
+	declaredRuleNames := make([]string, 0, len(declaration.Rules))
+	for _, rule := range declaration.Rules {
+		declaredRuleNames = append(declaredRuleNames, rule.Name)
+	}
+
+	if !slices.Equal(currentRuleNames, declaredRuleNames) {
+		if err := targetsMetadata.ReorderRules(declaredRuleNames); err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, &Change{
+			Kind:    DiffKindRuleFile,
+			Action:  DiffActionChanged,
+			Scope:   TargetsRoleName,
+			Name:    TargetsRoleName,
+			Details: []string{fmt.Sprintf("rule order: '%s' -> '%s'", strings.Join(currentRuleNames, ", "), strings.Join(declaredRuleNames, ", "))},
+		})
+	}
+
+	return changes, nil
+}
+
+// ReconcileRootMetadata updates the root of trust so that its global rules and
+// hooks match the declaration, returning the changes made. hookHashes maps the
+// name of each declared hook to the hashes of its file. A global rule whose
+// type changes is replaced, as is a hook that is changed in any way.
+func ReconcileRootMetadata(rootMetadata tuf.RootMetadata, declaration *Declaration, hookHashes map[string]map[string]string) ([]*Change, error) {
+	declaredGlobalRules := map[string]tuf.GlobalRule{}
+	for _, declaredGlobalRule := range declaration.GlobalRules {
+		globalRule, err := declaredGlobalRule.GlobalRule()
+		if err != nil {
+			return nil, err
+		}
+		declaredGlobalRules[globalRule.GetName()] = globalRule
+	}
+
+	existingGlobalRules := globalRulesAttributes(rootMetadata.GetGlobalRules())
+	declaredGlobalRulesAttributes := globalRulesAttributes(slices.Collect(maps.Values(declaredGlobalRules)))
+	globalRuleChanges := diffItems(DiffKindGlobalRule, DiffScopeRoot, existingGlobalRules, declaredGlobalRulesAttributes)
+	for _, change := range globalRuleChanges {
+		globalRule := declaredGlobalRules[change.Name]
+
+		var err error
+		switch change.Action {
+		case DiffActionAdded:
+			err = rootMetadata.AddGlobalRule(globalRule)
+		case DiffActionRemoved:
+			err = rootMetadata.DeleteGlobalRule(change.Name)
+		case DiffActionChanged:
+			if existingGlobalRules[change.Name]["type"] != declaredGlobalRulesAttributes[change.Name]["type"] {
+				if err := rootMetadata.DeleteGlobalRule(change.Name); err != nil {
+					return nil, err
+				}
+				err = rootMetadata.AddGlobalRule(globalRule)
+			} else {
+				err = rootMetadata.UpdateGlobalRule(globalRule)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existingHooks := map[tuf.HookStage][]tuf.Hook{}
+	existingHookStages := map[string][]tuf.HookStage{}
+	for _, stage := range []tuf.HookStage{tuf.HookStagePreCommit, tuf.HookStagePrePush} {
+		hooks, err := rootMetadata.GetHooks(stage)
+		if err != nil {
+			if errors.Is(err, tuf.ErrNoHooksDefined) {
+				continue
+			}
+			return nil, err
+		}
+
+		existingHooks[stage] = hooks
+		for _, hook := range hooks {
+			existingHookStages[hook.ID()] = append(existingHookStages[hook.ID()], stage)
+		}
+	}
+
+	declaredHooks := map[string]*DeclaredHook{}
+	for _, hook := range declaration.Hooks {
+		declaredHooks[hook.Name] = hook
+	}
+
+	hookChanges := diffItems(DiffKindHook, DiffScopeRoot, hooksAttributes(existingHooks), declaredHooksAttributes(declaration.Hooks, hookHashes))
+	for _, change := range hookChanges {
+		if change.Action != DiffActionAdded {
+			if err := rootMetadata.RemoveHook(existingHookStages[change.Name], change.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		if change.Action != DiffActionRemoved {
+			hook := declaredHooks[change.Name]
+			if _, err := rootMetadata.AddHook(hook.Stages, hook.Name, hook.Principals, hookHashes[hook.Name], hook.Environment, hook.Timeout); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return append(globalRuleChanges, hookChanges...), nil
+}
+
+// declaredRulesAttributes returns the attributes of the declared rules in the
+// same form as rulesAttributes, without their position.
+func declaredRulesAttributes(rules []*DeclaredRule) map[string]attributes {
+	items := map[string]attributes{}
+	for _, rule := range rules {
+		patterns, excludedPatterns := []string{}, []string{}
+		for _, pattern := range rule.Patterns {
+			if excludedPattern, isExcluded := strings.CutPrefix(pattern, tuf.ExclusionPatternPrefix); isExcluded {
+				excludedPatterns = append(excludedPatterns, excludedPattern)
+				continue
+			}
+			patterns = append(patterns, pattern)
+		}
+
+		items[rule.Name] = attributes{
+			"principals":           joinSorted(set.NewSetFromItems(rule.Principals...).Contents()),
+			"threshold":            fmt.Sprint(rule.Threshold),
+			"patterns":             strings.Join(patterns, ", "),
+			"excluded patterns":    strings.Join(excludedPatterns, ", "),
+			"separation of duties": fmt.Sprint(rule.SeparationOfDuties),
+			"description":          rule.Description,
+			"contact":              rule.Contact,
+			"link":                 rule.Link,
+		}
+	}
+
+	return items
+}
+
+// declaredHooksAttributes returns the attributes of the declared hooks in the
+// same form as hooksAttributes.
+func declaredHooksAttributes(hooks []*DeclaredHook, hookHashes map[string]map[string]string) map[string]attributes {
+	items := map[string]attributes{}
+	for _, hook := range hooks {
+		stages := slices.Clone(hook.Stages)
+		slices.Sort(stages)
+		stages = slices.Compact(stages)
+
+		stageNames := make([]string, 0, len(stages))
+		for _, stage := range stages {
+			stageNames = append(stageNames, stage.String())
+		}
+
+		items[hook.Name] = attributes{
+			"stages":      strings.Join(stageNames, ", "),
+			"principals":  joinSorted(set.NewSetFromItems(hook.Principals...).Contents()),
+			"hashes":      joinMap(hookHashes[hook.Name]),
+			"environment": hook.Environment.String(),
+			"timeout":     fmt.Sprint(hook.Timeout),
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,268 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileTargetsMetadata(t *testing.T) {
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+	approverKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+	t.Run("no changes", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+		require.Nil(t, err)
+
+		declaration := &Declaration{
+			Rules: []*DeclaredRule{
+				{Name: "protect-main", Principals: []string{gpgKey.KeyID}, Patterns: []string{"git:refs/heads/main"}, Threshold: 1},
+				{Name: "protect-files-1-and-2", Principals: []string{gpgKey.KeyID}, Patterns: []string{"file:1", "file:2"}, Threshold: 1},
+			},
+		}
+
+		changes, err := ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey})
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("principals and rules changed", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+		require.Nil(t, err)
+
+		declaration := &Declaration{
+			Rules: []*DeclaredRule{
+				{Name: "protect-release", Principals: []string{approverKey.KeyID}, Patterns: []string{"git:refs/heads/release/*", "!git:refs/heads/release/old"}, Threshold: 1},
+				{Name: "protect-main", Principals: []string{gpgKey.KeyID, approverKey.KeyID}, Patterns: []string{"git:refs/heads/main"}, Threshold: 2, SeparationOfDuties: true},
+			},
+		}
+
+		expectedChanges := []*Change{
+			{Kind: DiffKindPrincipal, Action: DiffActionAdded, Scope: TargetsRoleName, Name: approverKey.KeyID},
+			{Kind: DiffKindRule, Action: DiffActionRemoved, Scope: TargetsRoleName, Name: "protect-files-1-and-2"},
+			{
+				Kind:   DiffKindRule,
+				Action: DiffActionChanged,
+				Scope:  TargetsRoleName,
+				Name:   "protect-main",
+				Details: []string{
+					"principals: '" + gpgKey.KeyID + "' -> '" + joinSorted([]string{gpgKey.KeyID, approverKey.KeyID}) + "'",
+					"separation of duties: 'false' -> 'true'",
+					"threshold: '1' -> '2'",
+				},
+			},
+			{Kind: DiffKindRule, Action: DiffActionAdded, Scope: TargetsRoleName, Name: "protect-release"},
+			{
+				Kind:    DiffKindRuleFile,
+				Action:  DiffActionChanged,
+				Scope:   TargetsRoleName,
+				Name:    TargetsRoleName,
+				Details: []string{"rule order: 'protect-main, protect-release' -> 'protect-release, protect-main'"},
+			},
+		}
+
+		changes, err := ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey, approverKey})
+		assert.Nil(t, err)
+		assert.Equal(t, expectedChanges, changes)
+
+		rules := targetsMetadata.GetRules()
+		require.Len(t, rules, 3)
+		assert.Equal(t, "protect-release", rules[0].ID())
+		assert.Equal(t, []string{"git:refs/heads/release/old"}, rules[0].GetExcludedNamespaces())
+		assert.Equal(t, "protect-main", rules[1].ID())
+		assert.Equal(t, 2, rules[1].GetThreshold())
+		assert.True(t, rules[1].RequiresSeparationOfDuties())
+		assert.Equal(t, tuf.AllowRuleName, rules[2].ID())
+
+		// Reconciling again is a no-op
+		changes, err = ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey, approverKey})
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("rule details", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, true)
+		require.Nil(t, err)
+
+		declaration := &Declaration{
+			Rules: []*DeclaredRule{
+				{Name: "protect-main", Principals: []string{gpgKey.KeyID}, Patterns: []string{"git:refs/heads/main"}, Threshold: 1, Description: "why", Contact: "security@example.com", Link: "https://example.com/policy"},
+				{Name: "protect-files-1-and-2", Principals: []string{gpgKey.KeyID}, Patterns: []string{"file:1", "file:2"}, Threshold: 1},
+			},
+		}
+
+		expectedChanges := []*Change{
+			{
+				Kind:   DiffKindRule,
+				Action: DiffActionChanged,
+				Scope:  TargetsRoleName,
+				Name:   "protect-main",
+				Details: []string{
+					"contact: '' -> 'security@example.com'",
+					"description: '' -> 'why'",
+					"link: '' -> 'https://example.com/policy'",
+				},
+			},
+		}
+
+		changes, err := ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey})
+		assert.Nil(t, err)
+		assert.Equal(t, expectedChanges, changes)
+
+		rules := targetsMetadata.GetRules()
+		assert.Equal(t, "why", rules[0].GetDescription())
+		assert.Equal(t, "security@example.com", rules[0].GetContact())
+		assert.Equal(t, "https://example.com/policy", rules[0].GetLink())
+
+		// Reconciling again is a no-op
+		changes, err = ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey})
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+
+		// Details that are no longer declared are cleared
+		declaration.Rules[0].Description, declaration.Rules[0].Contact, declaration.Rules[0].Link = "", "", ""
+		changes, err = ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey})
+		assert.Nil(t, err)
+		assert.Len(t, changes, 1)
+		assert.Empty(t, targetsMetadata.GetRules()[0].GetDescription())
+
+		changes, err = ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey})
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("teams added and removed with members", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+		require.Nil(t, err)
+
+		team := &tufv02.Team{
+			TeamID:       "maintainers",
+			PrincipalIDs: set.NewSetFromItems(gpgKey.KeyID, approverKey.KeyID),
+			Threshold:    2,
+		}
+		declaration := &Declaration{
+			Rules: []*DeclaredRule{
+				{Name: "protect-main", Principals: []string{team.TeamID}, Patterns: []string{"git:refs/heads/main"}, Threshold: 1},
+			},
+		}
+
+		changes, err := ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{team, gpgKey, approverKey})
+		assert.Nil(t, err)
+		assert.Len(t, changes, 4)
+		assert.Contains(t, targetsMetadata.GetPrincipals(), team.TeamID)
+
+		declaration = &Declaration{
+			Rules: []*DeclaredRule{
+				{Name: "protect-main", Principals: []string{approverKey.KeyID}, Patterns: []string{"git:refs/heads/main"}, Threshold: 1},
+			},
+		}
+
+		changes, err = ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{approverKey})
+		assert.Nil(t, err)
+		assert.Equal(t, []*Change{
+			{Kind: DiffKindPrincipal, Action: DiffActionRemoved, Scope: TargetsRoleName, Name: gpgKey.KeyID},
+			{Kind: DiffKindPrincipal, Action: DiffActionRemoved, Scope: TargetsRoleName, Name: team.TeamID},
+			{Kind: DiffKindRule, Action: DiffActionChanged, Scope: TargetsRoleName, Name: "protect-main", Details: []string{"principals: 'maintainers' -> '" + approverKey.KeyID + "'"}},
+		}, changes)
+		assert.Equal(t, map[string]tuf.Principal{approverKey.KeyID: approverKey}, targetsMetadata.GetPrincipals())
+	})
+
+	t.Run("unknown principal", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+		require.Nil(t, err)
+
+		declaration := &Declaration{
+			Rules: []*DeclaredRule{
+				{Name: "protect-main", Principals: []string{"jane.doe"}, Patterns: []string{"git:refs/heads/main"}, Threshold: 1},
+			},
+		}
+
+		_, err = ReconcileTargetsMetadata(targetsMetadata, declaration, []tuf.Principal{gpgKey})
+		assert.ErrorIs(t, err, tuf.ErrPrincipalNotFound)
+	})
+}
+
+func TestReconcileRootMetadata(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+	rootMetadata, err := state.GetRootMetadata(false)
+	require.Nil(t, err)
+
+	hookHashes := map[string]map[string]string{
+		"lint": {"sha256": "abcd"},
+	}
+
+	declaration := &Declaration{
+		GlobalRules: []*DeclaredGlobalRule{
+			{Name: "require-approval", Type: tuf.GlobalRuleThresholdType, Patterns: []string{"git:refs/heads/main"}, Threshold: 2},
+			{Name: "protect-history", Type: tuf.GlobalRuleBlockForcePushesType, Patterns: []string{"git:refs/heads/main"}},
+		},
+		Hooks: []*DeclaredHook{
+			{Name: "lint", Stages: []tuf.HookStage{tuf.HookStagePreCommit}, File: "lint.lua", Principals: []string{"jane.doe"}, Timeout: 10},
+		},
+	}
+
+	changes, err := ReconcileRootMetadata(rootMetadata, declaration, hookHashes)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Change{
+		{Kind: DiffKindGlobalRule, Action: DiffActionAdded, Scope: DiffScopeRoot, Name: "protect-history"},
+		{Kind: DiffKindGlobalRule, Action: DiffActionAdded, Scope: DiffScopeRoot, Name: "require-approval"},
+		{Kind: DiffKindHook, Action: DiffActionAdded, Scope: DiffScopeRoot, Name: "lint"},
+	}, changes)
+	assert.Len(t, rootMetadata.GetGlobalRules(), 2)
+
+	changes, err = ReconcileRootMetadata(rootMetadata, declaration, hookHashes)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+
+	// Change a global rule's type and a hook's stages
+	declaration.GlobalRules[1].Type = tuf.GlobalRuleRequireLinearHistoryType
+	declaration.Hooks[0].Stages = []tuf.HookStage{tuf.HookStagePrePush, tuf.HookStagePreCommit}
+
+	changes, err = ReconcileRootMetadata(rootMetadata, declaration, hookHashes)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Change{
+		{Kind: DiffKindGlobalRule, Action: DiffActionChanged, Scope: DiffScopeRoot, Name: "protect-history", Details: []string{"type: 'block-force-pushes' -> 'require-linear-history'"}},
+		{Kind: DiffKindHook, Action: DiffActionChanged, Scope: DiffScopeRoot, Name: "lint", Details: []string{"stages: 'preCommit' -> 'preCommit, prePush'"}},
+	}, changes)
+
+	hasLinearHistoryRule := false
+	for _, globalRule := range rootMetadata.GetGlobalRules() {
+		if _, isLinearHistory := globalRule.(tuf.GlobalRuleRequireLinearHistory); isLinearHistory && globalRule.GetName() == "protect-history" {
+			hasLinearHistoryRule = true
+		}
+	}
+	assert.True(t, hasLinearHistoryRule)
+
+	hooks, err := rootMetadata.GetHooks(tuf.HookStagePrePush)
+	require.Nil(t, err)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "lint", hooks[0].ID())
+
+	changes, err = ReconcileRootMetadata(rootMetadata, &Declaration{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Change{
+		{Kind: DiffKindGlobalRule, Action: DiffActionRemoved, Scope: DiffScopeRoot, Name: "protect-history"},
+		{Kind: DiffKindGlobalRule, Action: DiffActionRemoved, Scope: DiffScopeRoot, Name: "require-approval"},
+		{Kind: DiffKindHook, Action: DiffActionRemoved, Scope: DiffScopeRoot, Name: "lint"},
+	}, changes)
+	assert.Empty(t, rootMetadata.GetGlobalRules())
+}
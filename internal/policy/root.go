@@ -4,18 +4,20 @@
 package policy
 
 import (
-	"time"
-
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 )
 
 // InitializeRootMetadata initializes a new instance of tuf.RootMetadata with
 // default values and a given key. The default values are version set to 1,
-// expiry date set to one year from now, and the provided key is added.
+// expiry date set to one year from now and enforced, and the provided key is
+// added.
 func InitializeRootMetadata(key tuf.Principal) (tuf.RootMetadata, error) {
 	rootMetadata := tufv02.NewRootMetadata()
-	rootMetadata.SetExpires(time.Now().AddDate(1, 0, 0).Format(time.RFC3339))
+	rootMetadata.SetExpires(defaultExpiry())
+	if err := rootMetadata.SetExpiryEnforced(true); err != nil {
+		return nil, err
+	}
 
 	if err := rootMetadata.AddRootPrincipal(key); err != nil {
 		return nil, err
@@ -17,6 +17,7 @@ func TestInitializeRootMetadata(t *testing.T) {
 
 	rootMetadata, err := InitializeRootMetadata(key)
 	assert.Nil(t, err)
+	assert.True(t, rootMetadata.IsExpiryEnforced())
 
 	allPrincipals := rootMetadata.GetPrincipals()
 	assert.Equal(t, key, allPrincipals[key.KeyID])
@@ -4,10 +4,14 @@
 package policy
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/mail"
+	"slices"
 	"strings"
 
 	"github.com/gittuf/gittuf/internal/common/set"
@@ -19,6 +23,7 @@ import (
 	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
 
@@ -27,7 +32,47 @@ type SignatureVerifier struct {
 	name               string
 	principals         []tuf.Principal
 	threshold          int
-	verifyExhaustively bool // verifyExhaustively checks all possible signatures and returns all matched principals, even if threshold is already met
+	verifyExhaustively bool                       // verifyExhaustively checks all possible signatures and returns all matched principals, even if threshold is already met
+	teamMembers        map[string][]tuf.Principal // teamMembers maps the IDs of team principals trusted by the verifier to their members
+	separationOfDuties bool                       // separationOfDuties indicates the principal who signed the Git object must not count towards the threshold
+	revokedKeyIDs      *set.Set[string]           // revokedKeyIDs contains the IDs of keys that must not be used to verify signatures
+}
+
+// newSignatureVerifierForRule returns a SignatureVerifier for the specified
+// rule. The rule's principals are resolved using allPrincipals, and the
+// members of any teams authorized by the rule are recorded so that they can
+// be used to meet the team's threshold.
+func newSignatureVerifierForRule(repo *gitinterface.Repository, rule tuf.Rule, allPrincipals map[string]tuf.Principal) *SignatureVerifier {
+	verifier := &SignatureVerifier{
+		repository:         repo,
+		name:               rule.ID(),
+		principals:         make([]tuf.Principal, 0, rule.GetPrincipalIDs().Len()),
+		threshold:          rule.GetThreshold(),
+		separationOfDuties: rule.RequiresSeparationOfDuties(),
+	}
+
+	for _, principalID := range rule.GetPrincipalIDs().Contents() {
+		principal := allPrincipals[principalID]
+		verifier.principals = append(verifier.principals, principal)
+
+		team, isTeam := principal.(tuf.Team)
+		if !isTeam {
+			continue
+		}
+
+		if verifier.teamMembers == nil {
+			verifier.teamMembers = map[string][]tuf.Principal{}
+		}
+		members := make([]tuf.Principal, 0, team.GetPrincipalIDs().Len())
+		for _, memberID := range team.GetPrincipalIDs().Contents() {
+			if member, has := allPrincipals[memberID]; has {
+				members = append(members, member)
+			}
+		}
+		verifier.teamMembers[team.ID()] = members
+	}
+
+	return verifier
 }
 
 func (v *SignatureVerifier) Name() string {
@@ -47,6 +92,357 @@ func (v *SignatureVerifier) TrustedPrincipalIDs() *set.Set[string] {
 	return principalIDs
 }
 
+// candidatePrincipals returns the principals whose signatures are checked by
+// the verifier. Teams are replaced by their members, as a team has no keys of
+// its own.
+func (v *SignatureVerifier) candidatePrincipals() []tuf.Principal {
+	if len(v.teamMembers) == 0 {
+		return v.principals
+	}
+
+	seenPrincipalIDs := set.NewSet[string]()
+	candidates := []tuf.Principal{}
+	addCandidate := func(principal tuf.Principal) {
+		if seenPrincipalIDs.Has(principal.ID()) {
+			return
+		}
+		seenPrincipalIDs.Add(principal.ID())
+		candidates = append(candidates, principal)
+	}
+
+	for _, principal := range v.principals {
+		members, isTeam := v.teamMembers[principal.ID()]
+		if !isTeam {
+			addCandidate(principal)
+			continue
+		}
+
+		for _, member := range members {
+			addCandidate(member)
+		}
+	}
+
+	return candidates
+}
+
+// countedPrincipalIDs returns the IDs of the principals trusted by the verifier
+// that count towards its threshold using the principals in usedPrincipalIDs
+// and the members in hatPrincipalIDs who approved wearing a team's hat. Each
+// person is counted at most once: a person trusted directly by the verifier is
+// not also counted towards the threshold of a team they belong to, and a member
+// of several teams trusted by the verifier counts towards only one of them. An
+// approval made in a team's hat counts only towards that team.
+func (v *SignatureVerifier) countedPrincipalIDs(usedPrincipalIDs *set.Set[string], hatPrincipalIDs map[string]*set.Set[string]) *set.Set[string] {
+	trustedPrincipalIDs := v.TrustedPrincipalIDs()
+	countedPrincipalIDs := trustedPrincipalIDs.Intersection(usedPrincipalIDs)
+
+	// Members who are trusted directly have already been counted
+	memberIDs := usedPrincipalIDs.Minus(trustedPrincipalIDs)
+
+	teams := []tuf.Team{}
+	candidateMemberIDs := map[string]*set.Set[string]{}
+	for _, principal := range v.principals {
+		team, isTeam := principal.(tuf.Team)
+		if !isTeam || countedPrincipalIDs.Has(team.ID()) {
+			continue
+		}
+
+		teamMemberIDs := memberIDs
+		if hatMemberIDs, has := hatPrincipalIDs[team.ID()]; has {
+			// Members trusted directly by the verifier are counted on their
+			// own and cannot also help meet the team's threshold
+			teamMemberIDs = set.NewSet[string]()
+			teamMemberIDs.Extend(memberIDs)
+			teamMemberIDs.Extend(hatMemberIDs.Minus(trustedPrincipalIDs))
+		}
+
+		teams = append(teams, team)
+		candidateMemberIDs[team.ID()] = team.GetPrincipalIDs().Intersection(teamMemberIDs)
+	}
+
+	for _, teamID := range assignMembersToTeams(teams, candidateMemberIDs) {
+		slog.Debug(fmt.Sprintf("Threshold of team '%s' met, counting '%s' towards threshold...", teamID, teamID))
+		countedPrincipalIDs.Add(teamID)
+	}
+
+	return countedPrincipalIDs
+}
+
+// assignMembersToTeams matches the members in candidateMemberIDs to the
+// specified teams, such that each member is assigned to at most one team, and
+// returns the IDs of the teams whose thresholds are met by the matching. Teams
+// are considered in order of increasing threshold, and a team is kept only if
+// its threshold can be met by reassigning members along augmenting paths
+// without leaving any team kept earlier unmet. This takes polynomial time in
+// the number of teams and members. Finding the largest set of teams whose
+// thresholds can be met at once is intractable in general, so for contrived
+// overlapping teams, fewer teams may be counted than is possible.
+func assignMembersToTeams(teams []tuf.Team, candidateMemberIDs map[string]*set.Set[string]) []string {
+	teams = slices.Clone(teams)
+	slices.SortStableFunc(teams, func(a, b tuf.Team) int {
+		return cmp.Compare(a.GetThreshold(), b.GetThreshold())
+	})
+
+	sortedMemberIDs := make(map[string][]string, len(teams))
+	for _, team := range teams {
+		memberIDs := candidateMemberIDs[team.ID()].Contents()
+		slices.Sort(memberIDs)
+		sortedMemberIDs[team.ID()] = memberIDs
+	}
+
+	// assignedTeamIDs maps each assigned member to the team it counts
+	// towards
+	assignedTeamIDs := map[string]string{}
+
+	// assign finds an augmenting path that assigns one more member to the
+	// team, moving members assigned to other teams to alternative members
+	// of those teams as needed
+	var assign func(teamID string, visitedMemberIDs *set.Set[string]) bool
+	assign = func(teamID string, visitedMemberIDs *set.Set[string]) bool {
+		for _, memberID := range sortedMemberIDs[teamID] {
+			if visitedMemberIDs.Has(memberID) {
+				continue
+			}
+			visitedMemberIDs.Add(memberID)
+
+			currentTeamID, isAssigned := assignedTeamIDs[memberID]
+			if !isAssigned || assign(currentTeamID, visitedMemberIDs) {
+				assignedTeamIDs[memberID] = teamID
+				return true
+			}
+		}
+
+		return false
+	}
+
+	metTeamIDs := []string{}
+	for _, team := range teams {
+		if len(sortedMemberIDs[team.ID()]) < team.GetThreshold() {
+			continue
+		}
+
+		priorAssignedTeamIDs := maps.Clone(assignedTeamIDs)
+		thresholdMet := true
+		for range team.GetThreshold() {
+			if !assign(team.ID(), set.NewSet[string]()) {
+				thresholdMet = false
+				break
+			}
+		}
+
+		if !thresholdMet {
+			// Undo the partial assignment so the members remain available
+			// to other teams
+			assignedTeamIDs = priorAssignedTeamIDs
+			continue
+		}
+
+		metTeamIDs = append(metTeamIDs, team.ID())
+	}
+
+	return metTeamIDs
+}
+
+// getHatPrincipalIDs returns the members of each team trusted by the verifier
+// who approved the change wearing the team's hat, keyed by team ID. Approvals
+// made in a hat only count towards that team's threshold.
+func (v *SignatureVerifier) getHatPrincipalIDs(ctx context.Context, hats *hatApprovals, appNames []string) (map[string]*set.Set[string], error) {
+	hatPrincipalIDs := map[string]*set.Set[string]{}
+	for _, principal := range v.principals {
+		team, isTeam := principal.(tuf.Team)
+		if !isTeam {
+			continue
+		}
+
+		members := v.teamMembers[team.ID()]
+		teamHatPrincipalIDs := set.NewSet[string]()
+
+		if env, has := hats.authorizations[team.ID()]; has {
+			slog.Debug(fmt.Sprintf("Verifying reference authorization signed in hat '%s'...", team.ID()))
+			hatVerifier := &SignatureVerifier{
+				repository:         v.repository,
+				name:               team.ID(),
+				principals:         members,
+				threshold:          1,
+				verifyExhaustively: true,
+				revokedKeyIDs:      v.revokedKeyIDs,
+			}
+			acceptedPrincipalIDs, err := hatVerifier.Verify(ctx, nil, env)
+			if err != nil && !errors.Is(err, ErrInvalidVerifier) {
+				return nil, err
+			}
+			teamHatPrincipalIDs.Extend(acceptedPrincipalIDs)
+		}
+
+		if approverIDs, has := hats.approverIDs[team.ID()]; has {
+			for _, approverID := range approverIDs.Contents() {
+				for _, member := range members {
+					if hasAssociatedIdentity(member, appNames, approverID) {
+						slog.Debug(fmt.Sprintf("Principal '%s' approved in hat '%s' with associated identity '%s'...", member.ID(), team.ID(), approverID))
+						teamHatPrincipalIDs.Add(member.ID())
+						break
+					}
+				}
+			}
+		}
+
+		if teamHatPrincipalIDs.Len() != 0 {
+			hatPrincipalIDs[team.ID()] = teamHatPrincipalIDs
+		}
+	}
+
+	return hatPrincipalIDs, nil
+}
+
+// getCommitAuthorPrincipalIDs returns the IDs of the principals trusted by the
+// verifier who authored the specified Git object: the principal whose key
+// signed it, and the persons who declare its author email as one of their
+// associated identities that are email addresses. As the author email can be
+// set to anything, the signature identifies the author even if the email
+// belongs to someone else.
+// Git objects that are not commits have no author email, so only their signer
+// is returned.
+func (v *SignatureVerifier) getCommitAuthorPrincipalIDs(ctx context.Context, gitID gitinterface.Hash) (*set.Set[string], error) {
+	authorIDs := set.NewSet[string]()
+
+	signer, _, err := v.verifyGitObjectSignature(ctx, gitID)
+	if err != nil {
+		return nil, err
+	}
+	if signer != nil {
+		authorIDs.Add(signer.ID())
+	}
+
+	objectType, err := v.repository.GetObjectType(gitID)
+	if err != nil {
+		return nil, err
+	}
+	if objectType != gitinterface.CommitObjectType {
+		return authorIDs, nil
+	}
+
+	authorEmail, err := v.repository.GetCommitAuthorEmail(gitID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, principal := range v.candidatePrincipals() {
+		person, isV02 := principal.(*tufv02.Person)
+		if !isV02 {
+			continue
+		}
+
+		for _, identity := range person.AssociatedIdentities {
+			if isEmailAddress(identity) && strings.EqualFold(identity, authorEmail) {
+				authorIDs.Add(person.ID())
+				break
+			}
+		}
+	}
+
+	return authorIDs, nil
+}
+
+// isEmailAddress returns true if the associated identity is a bare email
+// address. Identities for other providers, such as usernames, may coincide
+// with an arbitrary author email and must not be compared with it.
+func isEmailAddress(identity string) bool {
+	address, err := mail.ParseAddress(identity)
+	return err == nil && address.Address == identity
+}
+
+// withoutPrincipal returns a copy of the verifier that does not trust the
+// specified principal, either directly or as a member of a team. Teams that can
+// no longer meet their thresholds are retained, but cannot be satisfied.
+func (v *SignatureVerifier) withoutPrincipal(principalID string) *SignatureVerifier {
+	verifier := &SignatureVerifier{
+		repository:         v.repository,
+		name:               v.name,
+		principals:         make([]tuf.Principal, 0, len(v.principals)),
+		threshold:          v.threshold,
+		verifyExhaustively: v.verifyExhaustively,
+		separationOfDuties: v.separationOfDuties,
+		revokedKeyIDs:      v.revokedKeyIDs,
+	}
+
+	for _, principal := range v.principals {
+		if principal.ID() != principalID {
+			verifier.principals = append(verifier.principals, principal)
+		}
+	}
+
+	if v.teamMembers != nil {
+		verifier.teamMembers = make(map[string][]tuf.Principal, len(v.teamMembers))
+		for teamID, members := range v.teamMembers {
+			remainingMembers := make([]tuf.Principal, 0, len(members))
+			for _, member := range members {
+				if member.ID() != principalID {
+					remainingMembers = append(remainingMembers, member)
+				}
+			}
+			verifier.teamMembers[teamID] = remainingMembers
+		}
+	}
+
+	return verifier
+}
+
+// withRevokedKeys returns a copy of the verifier that does not use the keys
+// identified by keyIDs to verify signatures.
+func (v *SignatureVerifier) withRevokedKeys(keyIDs *set.Set[string]) *SignatureVerifier {
+	verifier := *v
+	verifier.revokedKeyIDs = keyIDs
+	return &verifier
+}
+
+// isRevoked returns true if the key identified by keyID must not be used to
+// verify signatures.
+func (v *SignatureVerifier) isRevoked(keyID string) bool {
+	return v.revokedKeyIDs != nil && v.revokedKeyIDs.Has(keyID)
+}
+
+// verifyGitObjectSignature returns the principal trusted by the verifier whose
+// key was used to sign the specified Git object along with the ID of the key.
+// If the Git object is not signed by any of the verifier's principals, a nil
+// principal is returned.
+func (v *SignatureVerifier) verifyGitObjectSignature(ctx context.Context, gitObjectID gitinterface.Hash) (tuf.Principal, string, error) {
+	slog.Debug(fmt.Sprintf("Verifying signature of Git object with ID '%s'...", gitObjectID.String()))
+	for _, principal := range v.candidatePrincipals() {
+		// there are multiple keys we must try
+		keys := principal.Keys()
+
+		for _, key := range keys {
+			if v.isRevoked(key.KeyID) {
+				slog.Debug(fmt.Sprintf("Public key '%s' belonging to principal '%s' is revoked, skipping...", key.KeyID, principal.ID()))
+				continue
+			}
+
+			err := v.repository.VerifySignature(ctx, gitObjectID, key)
+			if err == nil {
+				// Signature verification succeeded
+				slog.Debug(fmt.Sprintf("Public key '%s' belonging to principal '%s' successfully used to verify signature of Git object '%s'...", key.KeyID, principal.ID(), gitObjectID.String()))
+				return principal, key.KeyID, nil
+			}
+			if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
+				// TODO: this should be removed once we have unified signing
+				// methods across metadata and git signatures
+				continue
+			}
+			if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
+				return nil, "", err
+			}
+		}
+	}
+
+	return nil, "", nil
+}
+
+// thresholdMet returns true if the principals in usedPrincipalIDs, along with
+// any teams they satisfy, meet the verifier's threshold.
+func (v *SignatureVerifier) thresholdMet(usedPrincipalIDs *set.Set[string]) bool {
+	return v.countedPrincipalIDs(usedPrincipalIDs, nil).Len() >= v.threshold
+}
+
 // Verify is used to check for a threshold of signatures using the verifier. The
 // threshold of signatures may be met using a combination of at most one Git
 // signature and signatures embedded in a DSSE envelope. Verify does not inspect
@@ -72,43 +468,21 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 
 	// First, verify the gitObject's signature if one is presented
 	if gitObjectID != nil && !gitObjectID.IsZero() {
-		slog.Debug(fmt.Sprintf("Verifying signature of Git object with ID '%s'...", gitObjectID.String()))
-		for _, principal := range v.principals {
-			// there are multiple keys we must try
-			keys := principal.Keys()
-
-			for _, key := range keys {
-				err := v.repository.VerifySignature(ctx, gitObjectID, key)
-				if err == nil {
-					// Signature verification succeeded
-					slog.Debug(fmt.Sprintf("Public key '%s' belonging to principal '%s' successfully used to verify signature of Git object '%s', counting '%s' towards threshold...", key.KeyID, principal.ID(), gitObjectID.String(), principal.ID()))
-					usedPrincipalIDs.Add(principal.ID())
-					usedKeyIDs.Add(key.KeyID)
-					gitObjectVerified = true
-
-					// No need to try the other keys for this principal, break
-					break
-				}
-				if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
-					// TODO: this should be removed once we have unified signing
-					// methods across metadata and git signatures
-					continue
-				}
-				if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
-					return nil, err
-				}
-			}
-
-			if gitObjectVerified {
-				// No need to try other principals, break
-				break
-			}
+		principal, keyID, err := v.verifyGitObjectSignature(ctx, gitObjectID)
+		if err != nil {
+			return nil, err
+		}
+		if principal != nil {
+			slog.Debug(fmt.Sprintf("Counting '%s' towards threshold...", principal.ID()))
+			usedPrincipalIDs.Add(principal.ID())
+			usedKeyIDs.Add(keyID)
+			gitObjectVerified = true
 		}
 	}
 
-	// If we don't have to verify exhaustively and threshold is 1 and the Git
-	// signature is verified, we can return
-	if !v.verifyExhaustively && v.threshold == 1 && gitObjectVerified {
+	// If we don't have to verify exhaustively and the Git signature is
+	// verified and sufficient to meet the threshold, we can return
+	if !v.verifyExhaustively && gitObjectVerified && v.thresholdMet(usedPrincipalIDs) {
 		return usedPrincipalIDs, nil
 	}
 
@@ -120,7 +494,7 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 		// We have to verify the envelope independently for each principal
 		// trusted in the verifier as a principal may have multiple keys
 		// associated with them.
-		for _, principal := range v.principals {
+		for _, principal := range v.candidatePrincipals() {
 			if usedPrincipalIDs.Has(principal.ID()) {
 				// Do not verify using this principal as they were verified for
 				// the Git signature
@@ -139,6 +513,11 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 					continue
 				}
 
+				if v.isRevoked(key.KeyID) {
+					slog.Debug(fmt.Sprintf("Key with ID '%s' is revoked, skipping...", key.KeyID))
+					continue
+				}
+
 				var (
 					dsseVerifier sslibdsse.Verifier
 					err          error
@@ -206,7 +585,7 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 		}
 	}
 
-	if v.verifyExhaustively || usedPrincipalIDs.Len() >= v.Threshold() {
+	if v.verifyExhaustively || v.thresholdMet(usedPrincipalIDs) {
 		// TODO: double check that this is okay!
 		return usedPrincipalIDs, nil
 	}
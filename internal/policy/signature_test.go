@@ -6,13 +6,16 @@ package policy
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
 	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/stretchr/testify/assert"
 )
@@ -76,6 +79,7 @@ func TestSignatureVerifier(t *testing.T) {
 		threshold   int
 		gitObjectID gitinterface.Hash
 		attestation *sslibdsse.Envelope
+		revokedKeys []string
 
 		expectedError error
 	}{
@@ -115,6 +119,28 @@ func TestSignatureVerifier(t *testing.T) {
 			gitObjectID: commitID,
 			attestation: attestationWithTwoSigs,
 		},
+		"commit, no attestation, revoked key, threshold 1": {
+			principals:    []tuf.Principal{gpgKey},
+			threshold:     1,
+			gitObjectID:   commitID,
+			revokedKeys:   []string{gpgKey.KeyID},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"commit, attestation, revoked attestation key, threshold 2": {
+			principals:    []tuf.Principal{gpgKey, rootPubKey},
+			threshold:     2,
+			gitObjectID:   commitID,
+			attestation:   attestation,
+			revokedKeys:   []string{rootPubKey.KeyID},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"commit, attestation, revoked key unused, threshold 2": {
+			principals:  []tuf.Principal{gpgKey, rootPubKey},
+			threshold:   2,
+			gitObjectID: commitID,
+			attestation: attestation,
+			revokedKeys: []string{targetsPubKey.KeyID},
+		},
 		"tag, no attestation, valid key, threshold 1": {
 			principals:  []tuf.Principal{gpgKey},
 			threshold:   1,
@@ -160,6 +186,9 @@ func TestSignatureVerifier(t *testing.T) {
 			principals: test.principals,
 			threshold:  test.threshold,
 		}
+		if len(test.revokedKeys) != 0 {
+			verifier = verifier.withRevokedKeys(set.NewSetFromItems(test.revokedKeys...))
+		}
 
 		_, err := verifier.Verify(testCtx, test.gitObjectID, test.attestation)
 		if test.expectedError == nil {
@@ -169,3 +198,221 @@ func TestSignatureVerifier(t *testing.T) {
 		}
 	}
 }
+
+func TestSignatureVerifierWithTeams(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv02.NewKeyFromSSLibKey(gpgKeyR)
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	rootPubKey := tufv02.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+
+	targetsSigner := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+	targetsPubKey := tufv02.NewKeyFromSSLibKey(targetsSigner.MetadataKey())
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/main", 1, gpgKeyBytes)
+	commitID := commitIDs[0]
+
+	attestation, err := dsse.CreateEnvelope(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestation, err = dsse.SignEnvelope(testCtx, attestation, rootSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attestationWithTwoSigs, err := dsse.CreateEnvelope(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestationWithTwoSigs, err = dsse.SignEnvelope(testCtx, attestationWithTwoSigs, rootSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestationWithTwoSigs, err = dsse.SignEnvelope(testCtx, attestationWithTwoSigs, targetsSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsAttestation, err := dsse.CreateEnvelope(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsAttestation, err = dsse.SignEnvelope(testCtx, targetsAttestation, targetsSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgRootTeam := &tufv02.Team{
+		TeamID:       "gpg-root-team",
+		PrincipalIDs: set.NewSetFromItems(gpgKey.KeyID, rootPubKey.KeyID),
+		Threshold:    2,
+	}
+	allKeysTeam := &tufv02.Team{
+		TeamID:       "all-keys-team",
+		PrincipalIDs: set.NewSetFromItems(gpgKey.KeyID, rootPubKey.KeyID, targetsPubKey.KeyID),
+		Threshold:    3,
+	}
+	rootTargetsTeam := &tufv02.Team{
+		TeamID:       "root-targets-team",
+		PrincipalIDs: set.NewSetFromItems(rootPubKey.KeyID, targetsPubKey.KeyID),
+		Threshold:    1,
+	}
+	rootTeam := &tufv02.Team{
+		TeamID:       "root-team",
+		PrincipalIDs: set.NewSetFromItems(rootPubKey.KeyID),
+		Threshold:    1,
+	}
+
+	allPrincipals := map[string]tuf.Principal{
+		gpgKey.KeyID:           gpgKey,
+		rootPubKey.KeyID:       rootPubKey,
+		targetsPubKey.KeyID:    targetsPubKey,
+		gpgRootTeam.TeamID:     gpgRootTeam,
+		allKeysTeam.TeamID:     allKeysTeam,
+		rootTargetsTeam.TeamID: rootTargetsTeam,
+		rootTeam.TeamID:        rootTeam,
+	}
+
+	tests := map[string]struct {
+		principalIDs []string
+		threshold    int
+		attestation  *sslibdsse.Envelope
+
+		expectedError error
+	}{
+		"team, commit signature alone does not meet team threshold": {
+			principalIDs:  []string{gpgRootTeam.TeamID},
+			threshold:     1,
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"team, commit signature and attestation meet team threshold": {
+			principalIDs: []string{gpgRootTeam.TeamID},
+			threshold:    1,
+			attestation:  attestation,
+		},
+		"team and key, threshold 2": {
+			principalIDs: []string{gpgRootTeam.TeamID, targetsPubKey.KeyID},
+			threshold:    2,
+			attestation:  attestationWithTwoSigs,
+		},
+		"team and key, threshold 2, team threshold unmet": {
+			principalIDs:  []string{gpgRootTeam.TeamID, targetsPubKey.KeyID},
+			threshold:     2,
+			attestation:   targetsAttestation,
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"team with higher threshold unmet": {
+			principalIDs:  []string{allKeysTeam.TeamID},
+			threshold:     1,
+			attestation:   attestation,
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"team with higher threshold met": {
+			principalIDs: []string{allKeysTeam.TeamID},
+			threshold:    1,
+			attestation:  attestationWithTwoSigs,
+		},
+		"key and team containing key, threshold 2, only key signed": {
+			principalIDs:  []string{rootPubKey.KeyID, rootTargetsTeam.TeamID},
+			threshold:     2,
+			attestation:   attestation,
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"key and team containing key, threshold 2, another member signed": {
+			principalIDs: []string{rootPubKey.KeyID, rootTargetsTeam.TeamID},
+			threshold:    2,
+			attestation:  attestationWithTwoSigs,
+		},
+		"overlapping teams, threshold 2, only shared member signed": {
+			principalIDs:  []string{rootTargetsTeam.TeamID, rootTeam.TeamID},
+			threshold:     2,
+			attestation:   attestation,
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"overlapping teams, threshold 2, shared member and another member signed": {
+			principalIDs: []string{rootTargetsTeam.TeamID, rootTeam.TeamID},
+			threshold:    2,
+			attestation:  attestationWithTwoSigs,
+		},
+	}
+
+	for name, test := range tests {
+		rule := &tufv02.Delegation{
+			Name:  "test-rule",
+			Paths: []string{"git:refs/heads/main"},
+			Role: tufv02.Role{
+				PrincipalIDs: set.NewSetFromItems(test.principalIDs...),
+				Threshold:    test.threshold,
+			},
+		}
+		verifier := newSignatureVerifierForRule(repo, rule, allPrincipals)
+
+		_, err := verifier.Verify(testCtx, commitID, test.attestation)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("incorrect error received in test '%s'", name))
+		}
+	}
+}
+
+func TestAssignMembersToTeams(t *testing.T) {
+	t.Run("members are reassigned to meet overlapping teams", func(t *testing.T) {
+		teams := []tuf.Team{
+			&tufv02.Team{TeamID: "team-a", PrincipalIDs: set.NewSetFromItems("alice", "bob"), Threshold: 1},
+			&tufv02.Team{TeamID: "team-b", PrincipalIDs: set.NewSetFromItems("alice"), Threshold: 1},
+		}
+		candidateMemberIDs := map[string]*set.Set[string]{
+			"team-a": set.NewSetFromItems("alice", "bob"),
+			"team-b": set.NewSetFromItems("alice"),
+		}
+
+		metTeamIDs := assignMembersToTeams(teams, candidateMemberIDs)
+		assert.ElementsMatch(t, []string{"team-a", "team-b"}, metTeamIDs)
+	})
+
+	t.Run("members are not counted towards more than one team", func(t *testing.T) {
+		teams := []tuf.Team{
+			&tufv02.Team{TeamID: "team-a", PrincipalIDs: set.NewSetFromItems("alice", "bob"), Threshold: 2},
+			&tufv02.Team{TeamID: "team-b", PrincipalIDs: set.NewSetFromItems("alice", "bob"), Threshold: 1},
+		}
+		candidateMemberIDs := map[string]*set.Set[string]{
+			"team-a": set.NewSetFromItems("alice", "bob"),
+			"team-b": set.NewSetFromItems("alice", "bob"),
+		}
+
+		metTeamIDs := assignMembersToTeams(teams, candidateMemberIDs)
+		assert.Equal(t, []string{"team-b"}, metTeamIDs)
+	})
+
+	t.Run("large overlapping teams", func(t *testing.T) {
+		// Each team can individually meet its threshold, but only two can
+		// be met at once; enumerating combinations of members would not
+		// finish in reasonable time
+		memberIDs := []string{}
+		for i := range 60 {
+			memberIDs = append(memberIDs, fmt.Sprintf("member-%d", i))
+		}
+
+		teams := []tuf.Team{}
+		candidateMemberIDs := map[string]*set.Set[string]{}
+		for i := range 20 {
+			teamID := fmt.Sprintf("team-%d", i)
+			teams = append(teams, &tufv02.Team{TeamID: teamID, PrincipalIDs: set.NewSetFromItems(memberIDs...), Threshold: 30})
+			candidateMemberIDs[teamID] = set.NewSetFromItems(memberIDs...)
+		}
+
+		start := time.Now()
+		metTeamIDs := assignMembersToTeams(teams, candidateMemberIDs)
+		assert.Len(t, metTeamIDs, 2)
+		assert.Less(t, time.Since(start), 10*time.Second)
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+// SimulatedFailure records an RSL entry that fails verification when replayed
+// against a proposed policy.
+type SimulatedFailure struct {
+	EntryID gitinterface.Hash
+	RefName string
+	Err     error
+}
+
+// SimulatePolicy verifies the reference entries recorded in the RSL from
+// sinceEntry to the latest entry using the proposed policy state instead of
+// the policy that was in effect when each entry was recorded. It returns the
+// entries that would fail verification, allowing the impact of a policy change
+// to be assessed before it is applied. The attestations in effect for each
+// entry and the key revocations in the proposed policy are used. As with
+// VerifyRef, an entry fails if the proposed policy had expired when the entry
+// was recorded and the root of trust enforces expiry. Entries for the
+// policy ref, propagation entries, and entries already skipped by an
+// annotation are not verified. Nothing is written to the repository.
+func SimulatePolicy(ctx context.Context, repo *gitinterface.Repository, proposedPolicy *State, sinceEntry rsl.Entry) ([]*SimulatedFailure, error) {
+	var currentAttestations *attestations.Attestations
+
+	slog.Debug(fmt.Sprintf("Loading attestations applicable at entry '%s'...", sinceEntry.GetID().String()))
+	initialAttestationsEntry, err := newSearcher(repo).FindAttestationsEntryFor(sinceEntry)
+	if err == nil {
+		currentAttestations, err = attestations.LoadAttestationsForEntry(repo, initialAttestationsEntry)
+		if err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, attestations.ErrAttestationsNotFound) {
+		return nil, err
+	}
+
+	keyRevocations, err := proposedPolicy.GetKeyRevocations()
+	if err != nil {
+		return nil, err
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Identifying all entries in range...")
+	entries, annotations, err := rsl.GetReferenceUpdaterEntriesInRange(repo, sinceEntry.GetID(), latestEntry.GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	failures := []*SimulatedFailure{}
+	for _, entry := range entries {
+		entry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry {
+			continue
+		}
+
+		if entry.RefName == attestations.Ref {
+			// Subsequent entries are verified using these attestations
+			currentAttestations, err = attestations.LoadAttestationsForEntry(repo, entry)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if entry.RefName == PolicyRef {
+			continue
+		}
+
+		if entry.SkippedBy(annotations[entry.GetID().String()]) {
+			slog.Debug(fmt.Sprintf("Entry '%s' has been revoked, skipping...", entry.GetID().String()))
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying entry '%s' using proposed policy...", entry.GetID().String()))
+		revokedKeyIDs, err := getRevokedKeyIDsForEntry(repo, keyRevocations, entry)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyEntryWithinPolicyExpiry(ctx, repo, proposedPolicy, currentAttestations, entry, withRevokedKeyIDs(revokedKeyIDs)); err != nil {
+			slog.Debug(fmt.Sprintf("Violation found: %s", err.Error()))
+			failures = append(failures, &SimulatedFailure{
+				EntryID: entry.GetID(),
+				RefName: entry.RefName,
+				Err:     err,
+			})
+		}
+	}
+
+	return failures, nil
+}
@@ -0,0 +1,95 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatePolicy(t *testing.T) {
+	repo, currentState := createTestRepository(t, createTestStateWithPolicy)
+	sinceEntry := currentState.loadedEntry
+
+	mainRefName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, mainRefName, 1, gpgKeyBytes)
+	mainEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(mainRefName, commitIDs[0]), gpgKeyBytes)
+
+	featureRefName := "refs/heads/feature"
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(featureRefName, commitIDs[0]), gpgKeyBytes)
+
+	t.Run("current policy", func(t *testing.T) {
+		failures, err := SimulatePolicy(testCtx, repo, currentState, sinceEntry)
+		require.Nil(t, err)
+		assert.Empty(t, failures)
+	})
+
+	t.Run("stricter policy", func(t *testing.T) {
+		proposedState := createTestStateWithThresholdPolicy(t)
+		proposedState.repository = repo
+
+		failures, err := SimulatePolicy(testCtx, repo, proposedState, sinceEntry)
+		require.Nil(t, err)
+		require.Len(t, failures, 1)
+		assert.Equal(t, mainEntryID, failures[0].EntryID)
+		assert.Equal(t, mainRefName, failures[0].RefName)
+		assert.ErrorIs(t, failures[0].Err, ErrVerificationFailed)
+	})
+
+	t.Run("since latest entry", func(t *testing.T) {
+		proposedState := createTestStateWithThresholdPolicy(t)
+		proposedState.repository = repo
+
+		latestEntry, err := rsl.GetLatestEntry(repo)
+		require.Nil(t, err)
+
+		failures, err := SimulatePolicy(testCtx, repo, proposedState, latestEntry)
+		require.Nil(t, err)
+		assert.Empty(t, failures)
+	})
+
+	t.Run("expired policy", func(t *testing.T) {
+		// The test clock is fixed at 1995-10-26T09:00:00Z, so the proposed
+		// policy had expired when the entries were recorded
+		_, proposedState := createTestRepositoryWithExpiringPolicy(t, time.Date(1995, time.October, 1, 0, 0, 0, 0, time.UTC))
+		proposedState.repository = repo
+
+		failures, err := SimulatePolicy(testCtx, repo, proposedState, sinceEntry)
+		require.Nil(t, err)
+		require.Len(t, failures, 2)
+		for _, failure := range failures {
+			assert.ErrorIs(t, failure.Err, ErrVerificationFailed)
+			assert.ErrorIs(t, failure.Err, ErrMetadataExpired)
+		}
+	})
+
+	t.Run("policy protecting secrets ref", func(t *testing.T) {
+		repo, currentState := createTestRepository(t, createTestStateWithPolicy)
+		sinceEntry := currentState.loadedEntry
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, secrets.Ref, 1, gpgKeyBytes)
+		secretsEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(secrets.Ref, commitIDs[0]), gpgKeyBytes)
+
+		failures, err := SimulatePolicy(testCtx, repo, currentState, sinceEntry)
+		require.Nil(t, err)
+		assert.Empty(t, failures)
+
+		proposedState := createTestStateWithSecretsPolicy(t)
+		proposedState.repository = repo
+
+		failures, err = SimulatePolicy(testCtx, repo, proposedState, sinceEntry)
+		require.Nil(t, err)
+		require.Len(t, failures, 1)
+		assert.Equal(t, secretsEntryID, failures[0].EntryID)
+		assert.Equal(t, secrets.Ref, failures[0].RefName)
+		assert.ErrorIs(t, failures[0].Err, ErrVerificationFailed)
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"slices"
+
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+// SignatureStatus records which of the principals trusted to sign a metadata
+// envelope have signed it, and whether their signatures meet the threshold
+// needed for the metadata to be verified.
+type SignatureStatus struct {
+	Name                 string
+	Threshold            int
+	SignedPrincipalIDs   []string
+	UnsignedPrincipalIDs []string
+	ThresholdMet         bool
+}
+
+// GetRootSignatureStatus returns the signature status of the root of trust,
+// using the root principals and threshold it declares.
+func (s *State) GetRootSignatureStatus(ctx context.Context) (*SignatureStatus, error) {
+	verifier, err := s.getRootVerifier()
+	if err != nil {
+		return nil, err
+	}
+	verifier.name = RootRoleName
+
+	return verifier.signatureStatus(ctx, s.Metadata.RootEnvelope)
+}
+
+// GetNewRootSignatureStatus returns the signature status of the root of trust
+// in newPolicy, using the root principals and threshold declared in this
+// policy. The threshold must be met for newPolicy to be applied after it.
+func (s *State) GetNewRootSignatureStatus(ctx context.Context, newPolicy *State) (*SignatureStatus, error) {
+	verifier, err := s.getRootVerifier()
+	if err != nil {
+		return nil, err
+	}
+	verifier.name = RootRoleName
+
+	return verifier.signatureStatus(ctx, newPolicy.Metadata.RootEnvelope)
+}
+
+// GetRuleFileSignatureStatus returns the signature status of the primary rule
+// file and each reachable delegated rule file, in the order they are reached
+// during verification. Each rule file is checked using the principals and
+// threshold of the rule that delegates to it.
+func (s *State) GetRuleFileSignatureStatus(ctx context.Context) ([]*SignatureStatus, error) {
+	if s.Metadata.TargetsEnvelope == nil {
+		return nil, ErrMetadataNotFound
+	}
+
+	targetsVerifier, err := s.getTargetsVerifier()
+	if err != nil {
+		return nil, err
+	}
+	targetsVerifier.name = TargetsRoleName
+
+	targetsStatus, err := targetsVerifier.signatureStatus(ctx, s.Metadata.TargetsEnvelope)
+	if err != nil {
+		return nil, err
+	}
+	statuses := []*SignatureStatus{targetsStatus}
+
+	targetsMetadata, err := s.GetTargetsMetadata(TargetsRoleName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	delegationsQueue := targetsMetadata.GetRules()
+	delegationKeys := targetsMetadata.GetPrincipals()
+	for len(delegationsQueue) > 1 {
+		// The last entry in the queue is always the allow rule
+		delegation := delegationsQueue[0]
+		delegationsQueue = delegationsQueue[1:]
+
+		if !s.HasTargetsRole(delegation.ID()) {
+			continue
+		}
+
+		verifier := newSignatureVerifierForRule(s.repository, delegation, delegationKeys)
+		status, err := verifier.signatureStatus(ctx, s.Metadata.DelegationEnvelopes[delegation.ID()])
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+
+		delegatedMetadata, err := s.GetTargetsMetadata(delegation.ID(), false)
+		if err != nil {
+			return nil, err
+		}
+
+		delegationsQueue = append(delegatedMetadata.GetRules(), delegationsQueue...)
+		for principalID, principal := range delegatedMetadata.GetPrincipals() {
+			delegationKeys[principalID] = principal
+		}
+	}
+
+	return statuses, nil
+}
+
+// signatureStatus checks every signature on the envelope using the verifier,
+// recording all the trusted principals who have signed it rather than
+// stopping once the threshold is met.
+func (v *SignatureVerifier) signatureStatus(ctx context.Context, env *sslibdsse.Envelope) (*SignatureStatus, error) {
+	verifier := *v
+	verifier.verifyExhaustively = true
+
+	usedPrincipalIDs, err := verifier.Verify(ctx, gitinterface.ZeroHash, env)
+	if err != nil {
+		return nil, err
+	}
+	countedPrincipalIDs := verifier.countedPrincipalIDs(usedPrincipalIDs, nil)
+	thresholdMet := countedPrincipalIDs.Len() >= verifier.threshold
+	usedPrincipalIDs.Extend(countedPrincipalIDs)
+
+	unsignedPrincipalIDs := verifier.TrustedPrincipalIDs().Minus(usedPrincipalIDs).Contents()
+	slices.Sort(unsignedPrincipalIDs)
+
+	signedPrincipalIDs := usedPrincipalIDs.Contents()
+	slices.Sort(signedPrincipalIDs)
+
+	return &SignatureStatus{
+		Name:                 verifier.name,
+		Threshold:            verifier.threshold,
+		SignedPrincipalIDs:   signedPrincipalIDs,
+		UnsignedPrincipalIDs: unsignedPrincipalIDs,
+		ThresholdMet:         thresholdMet,
+	}, nil
+}
@@ -0,0 +1,122 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRootSignatureStatus(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+	rootKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, rootPubKeyBytes))
+
+	status, err := state.GetRootSignatureStatus(testCtx)
+	require.Nil(t, err)
+
+	assert.Equal(t, &SignatureStatus{
+		Name:                 RootRoleName,
+		Threshold:            1,
+		SignedPrincipalIDs:   []string{rootKey.KeyID},
+		UnsignedPrincipalIDs: []string{},
+		ThresholdMet:         true,
+	}, status)
+}
+
+func TestGetNewRootSignatureStatus(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+	rootKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, rootPubKeyBytes))
+
+	// The new root of trust is signed by a key the current root of trust
+	// does not trust
+	newState := createTestStateWithPolicy(t)
+	rootMetadata, err := newState.GetRootMetadata(false)
+	require.Nil(t, err)
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	require.Nil(t, err)
+	rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes))
+	require.Nil(t, err)
+	newState.Metadata.RootEnvelope = rootEnv
+
+	status, err := state.GetNewRootSignatureStatus(testCtx, newState)
+	require.Nil(t, err)
+
+	assert.Equal(t, &SignatureStatus{
+		Name:                 RootRoleName,
+		Threshold:            1,
+		SignedPrincipalIDs:   []string{},
+		UnsignedPrincipalIDs: []string{rootKey.KeyID},
+		ThresholdMet:         false,
+	}, status)
+}
+
+func TestGetRuleFileSignatureStatus(t *testing.T) {
+	rootKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, rootPubKeyBytes))
+
+	t.Run("signed", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		statuses, err := state.GetRuleFileSignatureStatus(testCtx)
+		require.Nil(t, err)
+
+		assert.Equal(t, []*SignatureStatus{{
+			Name:                 TargetsRoleName,
+			Threshold:            1,
+			SignedPrincipalIDs:   []string{rootKey.KeyID},
+			UnsignedPrincipalIDs: []string{},
+			ThresholdMet:         true,
+		}}, statuses)
+	})
+
+	t.Run("signed by untrusted key", func(t *testing.T) {
+		state := createTestStateWithPolicyTargetsSignedByWrongKey(t)
+
+		statuses, err := state.GetRuleFileSignatureStatus(testCtx)
+		require.Nil(t, err)
+
+		assert.Equal(t, []*SignatureStatus{{
+			Name:                 TargetsRoleName,
+			Threshold:            1,
+			SignedPrincipalIDs:   []string{},
+			UnsignedPrincipalIDs: []string{rootKey.KeyID},
+			ThresholdMet:         false,
+		}}, statuses)
+	})
+
+	t.Run("delegated rule files", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+
+		statuses, err := state.GetRuleFileSignatureStatus(testCtx)
+		require.Nil(t, err)
+
+		assert.Equal(t, []*SignatureStatus{
+			{
+				Name:                 TargetsRoleName,
+				Threshold:            1,
+				SignedPrincipalIDs:   []string{rootKey.KeyID},
+				UnsignedPrincipalIDs: []string{},
+				ThresholdMet:         true,
+			},
+			{
+				Name:                 "1",
+				Threshold:            1,
+				SignedPrincipalIDs:   []string{rootKey.KeyID},
+				UnsignedPrincipalIDs: []string{},
+				ThresholdMet:         true,
+			},
+		}, statuses)
+	})
+
+	t.Run("no rule file", func(t *testing.T) {
+		state := createTestStateWithOnlyRoot(t)
+
+		_, err := state.GetRuleFileSignatureStatus(testCtx)
+		assert.ErrorIs(t, err, ErrMetadataNotFound)
+	})
+}
@@ -4,8 +4,6 @@
 package policy
 
 import (
-	"time"
-
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 )
@@ -14,6 +12,6 @@ import (
 func InitializeTargetsMetadata() tuf.TargetsMetadata {
 	targetsMetadata := tufv02.NewTargetsMetadata()
 
-	targetsMetadata.SetExpires(time.Now().AddDate(1, 0, 0).Format(time.RFC3339))
+	targetsMetadata.SetExpires(defaultExpiry())
 	return targetsMetadata
 }
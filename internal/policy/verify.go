@@ -10,8 +10,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/mail"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/attestations/authorizations"
@@ -19,6 +23,7 @@ import (
 	githubv01 "github.com/gittuf/gittuf/internal/attestations/github/v01"
 	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/encryption"
 	"github.com/gittuf/gittuf/internal/policy/options/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
 	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
@@ -26,6 +31,7 @@ import (
 	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	ita "github.com/in-toto/attestation/go/v1"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 )
 
 var (
@@ -39,6 +45,14 @@ var (
 	ErrNetworkRepositoryDoesNotDeclareRequiredController = errors.New("network repository does not declare required controller repository")
 	ErrNetworkRepositoryHasStaleControllerMetadata       = errors.New("network repository has not fetched latest controller metadata")
 	ErrMetadataRollbackDetected                          = errors.New("gittuf policy metadata rollback detected")
+	ErrCommitNotSigned                                   = errors.New("commit is not signed by any principal declared in policy")
+	ErrCommitNotSignedOff                                = errors.New("commit does not have a sign-off matching its author")
+	ErrMergeCommitNotAllowed                             = errors.New("merge commits are not allowed on references that require linear history")
+	ErrFileNotAllowed                                    = errors.New("file matches a pattern denied by global rule")
+	ErrBlobTooLarge                                      = errors.New("file exceeds the maximum blob size allowed by global rule")
+	ErrFreezeWindowActive                                = errors.New("reference is frozen and the freeze override threshold is not met")
+	ErrSeparationOfDutiesNotMet                          = errors.New("threshold not met without the approval of the principals who signed or authored the change")
+	ErrReadRestrictedContentNotEncrypted                 = errors.New("contents of read restricted reference are not encrypted for all readers")
 )
 
 // PolicyVerifier implements various gittuf verification workflows.
@@ -72,6 +86,10 @@ func NewPolicyVerifier(repo *gitinterface.Repository) *PolicyVerifier {
 // using the latest policy. The expected Git ID for the ref in the latest RSL
 // entry is returned if the policy verification is successful.
 func (v *PolicyVerifier) VerifyRef(ctx context.Context, target string) (gitinterface.Hash, error) {
+	if err := v.verifyLatestPolicyNotExpired(ctx); err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
 	// Find latest entry for target
 	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
 	latestEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(target))
@@ -86,6 +104,10 @@ func (v *PolicyVerifier) VerifyRef(ctx context.Context, target string) (gitinter
 // entry. The expected Git ID for the ref in the latest RSL entry is returned if
 // the policy verification is successful.
 func (v *PolicyVerifier) VerifyRefFull(ctx context.Context, target string) (gitinterface.Hash, error) {
+	if err := v.verifyLatestPolicyNotExpired(ctx); err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
 	// Trace RSL back to the start
 	slog.Debug(fmt.Sprintf("Identifying first RSL entry for '%s'...", target))
 	var (
@@ -129,6 +151,10 @@ func (v *PolicyVerifier) VerifyRefFull(ctx context.Context, target string) (giti
 // RSL entry. The expected Git ID for the ref in the latest RSL entry is
 // returned if the policy verification is successful.
 func (v *PolicyVerifier) VerifyRefFromEntry(ctx context.Context, target string, entryID gitinterface.Hash) (gitinterface.Hash, error) {
+	if err := v.verifyLatestPolicyNotExpired(ctx); err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
 	// Load starting point entry
 	slog.Debug("Identifying starting RSL entry...")
 	fromEntryT, err := rsl.GetEntry(v.repo, entryID)
@@ -249,6 +275,11 @@ func (v *PolicyVerifier) verifyMergeable(ctx context.Context, targetRef string,
 	if err != nil {
 		return false, err
 	}
+	// The change will be checked against the latest policy, which must not
+	// have expired by now
+	if err := state.VerifyExpiry(time.Now()); err != nil {
+		return false, err
+	}
 	currentPolicy = state
 
 	// Load latest attestations
@@ -266,14 +297,31 @@ func (v *PolicyVerifier) verifyMergeable(ctx context.Context, targetRef string,
 		return false, err
 	}
 
-	authorizationAttestation, approverIDs, err := getApproverAttestationAndKeyIDsForIndex(ctx, v.repo, currentPolicy, currentAttestations, targetRef, fromID, mergeTreeID, false)
+	// The change will be recorded after every existing RSL entry, so all
+	// revocations in the latest policy are in effect
+	keyRevocations, err := currentPolicy.GetKeyRevocations()
 	if err != nil {
 		return false, err
 	}
+	revokedKeyIDs := set.NewSet[string]()
+	for keyID := range keyRevocations {
+		revokedKeyIDs.Add(keyID)
+	}
 
-	_, rslEntrySignatureNeededForThreshold, err := verifyGitObjectAndAttestations(ctx, currentPolicy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, targetRef), gitinterface.ZeroHash, authorizationAttestation, withApproverPrincipalIDs(approverIDs), withVerifyMergeable())
+	authorizationAttestation, approverIDs, hats, err := getApproverAttestationAndKeyIDsForIndex(ctx, v.repo, currentPolicy, currentAttestations, targetRef, fromID, mergeTreeID, false, revokedKeyIDs)
 	if err != nil {
-		return false, fmt.Errorf("not enough approvals to meet Git namespace policies, %w", ErrVerificationFailed)
+		return false, err
+	}
+
+	// Identify the commits the change introduces
+	commitIDs, err := v.repo.GetCommitsBetweenRange(featureID, fromID)
+	if err != nil {
+		return false, err
+	}
+
+	_, rslEntrySignatureNeededForThreshold, err := verifyGitObjectAndAttestations(ctx, currentPolicy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, targetRef), gitinterface.ZeroHash, authorizationAttestation, withApproverPrincipalIDs(approverIDs), withHatApprovals(hats), withVerifyMergeable(), withRevokedKeyIDs(revokedKeyIDs), withCommitIDs(commitIDs))
+	if err != nil {
+		return false, fmt.Errorf("verifying Git namespace policies failed, %w: %w", ErrVerificationFailed, err)
 	}
 
 	if !currentPolicy.hasFileRule {
@@ -281,10 +329,6 @@ func (v *PolicyVerifier) verifyMergeable(ctx context.Context, targetRef string,
 	}
 
 	// Verify modified files
-	commitIDs, err := v.repo.GetCommitsBetweenRange(featureID, fromID)
-	if err != nil {
-		return false, err
-	}
 
 	for _, commitID := range commitIDs {
 		paths, err := v.repo.GetFilePathsChangedByCommit(commitID)
@@ -301,9 +345,9 @@ func (v *PolicyVerifier) verifyMergeable(ctx context.Context, targetRef string,
 			// usual. Also, we don't use verifyMergeable=true here. File
 			// verification rules are not met using the signature on the RSL
 			// entry, so we don't count threshold-1 here.
-			verifiedUsing, _, err = verifyGitObjectAndAttestations(ctx, currentPolicy, fmt.Sprintf("%s:%s", fileRuleScheme, path), commitID, authorizationAttestation, withApproverPrincipalIDs(approverIDs), withTrustedVerifier(verifiedUsing))
+			verifiedUsing, _, err = verifyGitObjectAndAttestations(ctx, currentPolicy, fmt.Sprintf("%s:%s", fileRuleScheme, path), commitID, authorizationAttestation, withApproverPrincipalIDs(approverIDs), withHatApprovals(hats), withTrustedVerifier(verifiedUsing), withRevokedKeyIDs(revokedKeyIDs))
 			if err != nil {
-				return false, fmt.Errorf("verifying file namespace policies failed, %w", ErrVerificationFailed)
+				return false, fmt.Errorf("verifying file namespace policies failed, %w: %w", ErrVerificationFailed, err)
 			}
 		}
 	}
@@ -495,6 +539,14 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 	}
 	// require currentAttestations != nil || (entry.Ref != attestations.Ref for entry in 0..firstEntry)
 
+	// Keys may be revoked retroactively, so we use the revocations declared in
+	// the latest policy
+	slog.Debug("Loading key revocations from latest policy...")
+	keyRevocations, err := v.loadLatestKeyRevocations(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Enumerate RSL entries between firstEntry and lastEntry, ignoring irrelevant ones
 	slog.Debug("Identifying all entries in range...")
 	entries, annotations, err := rsl.GetReferenceUpdaterEntriesInRangeForRef(v.repo, firstEntry.GetID(), lastEntry.GetID(), target)
@@ -538,13 +590,25 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 					}
 					// require newPolicy != nil
 
+					// Revoked keys must not be used to sign
+					// policy metadata either
+					revokedKeyIDs, err := getRevokedKeyIDsForEntry(v.repo, keyRevocations, entry)
+					if err != nil {
+						return err
+					}
+
+					slog.Debug("Verifying new policy's metadata...")
+					if err := newPolicy.verify(ctx, revokedKeyIDs); err != nil {
+						return err
+					}
+
 					if currentPolicy != nil {
 						// currentPolicy can be nil when
 						// verifying from the beginning of the
 						// RSL entry and we only have staging
 						// refs
 						slog.Debug("Verifying new policy using current policy...")
-						if err := currentPolicy.VerifyNewState(ctx, newPolicy); err != nil {
+						if err := currentPolicy.verifyNewState(ctx, newPolicy, revokedKeyIDs); err != nil {
 							return err
 						}
 						slog.Debug("Updating current policy...")
@@ -581,7 +645,11 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 				if currentPolicy == nil {
 					return ErrPolicyNotFound
 				}
-				if err := verifyEntry(ctx, v.repo, currentPolicy, currentAttestations, entry); err != nil {
+				revokedKeyIDs, err := getRevokedKeyIDsForEntry(v.repo, keyRevocations, entry)
+				if err != nil {
+					return err
+				}
+				if err := verifyEntryWithinPolicyExpiry(ctx, v.repo, currentPolicy, currentAttestations, entry, withRevokedKeyIDs(revokedKeyIDs)); err != nil {
 					slog.Debug(fmt.Sprintf("Violation found: %s", err.Error()))
 					slog.Debug("Checking if entry has been revoked...")
 					// If the invalid entry is never marked as skipped, we return err
@@ -812,10 +880,17 @@ func (s *StateMetadata) VerifyNewStateMetadata(_ context.Context, newStateMetada
 // VerifyNewState ensures that when a new policy is encountered, its root role
 // is signed by keys trusted in the current policy.
 func (s *State) VerifyNewState(ctx context.Context, newPolicy *State) error {
+	return s.verifyNewState(ctx, newPolicy, nil)
+}
+
+// verifyNewState implements VerifyNewState, disregarding signatures using the
+// keys in revokedKeyIDs.
+func (s *State) verifyNewState(ctx context.Context, newPolicy *State, revokedKeyIDs *set.Set[string]) error {
 	rootVerifier, err := s.getRootVerifier()
 	if err != nil {
 		return err
 	}
+	rootVerifier = rootVerifier.withRevokedKeys(revokedKeyIDs)
 
 	if _, err := rootVerifier.Verify(ctx, gitinterface.ZeroHash, newPolicy.Metadata.RootEnvelope); err != nil {
 		return err
@@ -842,33 +917,148 @@ func (s *State) VerifyNewState(ctx context.Context, newPolicy *State) error {
 	return nil
 }
 
+// verifyLatestPolicyNotExpired checks that the latest policy has not expired by
+// now, even if it was valid when it was applied. Entries are verified using the
+// policy in effect when they were recorded, so this check ensures a repository
+// whose policy has lapsed fails verification until the policy is renewed. If no
+// policy has been applied yet, there is nothing to check.
+func (v *PolicyVerifier) verifyLatestPolicyNotExpired(ctx context.Context) error {
+	latestPolicyEntry, err := v.searcher.FindLatestPolicyEntry()
+	if err != nil {
+		if errors.Is(err, ErrPolicyNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	latestPolicy, err := LoadState(ctx, v.repo, latestPolicyEntry)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Checking if latest policy has expired...")
+	return latestPolicy.VerifyExpiry(time.Now())
+}
+
+// loadLatestKeyRevocations returns the key revocations declared in the latest
+// policy. If no policy has been applied yet, no keys are revoked.
+func (v *PolicyVerifier) loadLatestKeyRevocations(ctx context.Context) (map[string]gitinterface.Hash, error) {
+	latestPolicyEntry, err := v.searcher.FindLatestPolicyEntry()
+	if err != nil {
+		if errors.Is(err, ErrPolicyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	latestPolicy, err := LoadState(ctx, v.repo, latestPolicyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return latestPolicy.GetKeyRevocations()
+}
+
+// getRevokedKeyIDsForEntry returns the IDs of keys whose revocation is in
+// effect for the specified entry, i.e., keys revoked as of an RSL entry that
+// precedes the specified entry.
+func getRevokedKeyIDsForEntry(repo *gitinterface.Repository, keyRevocations map[string]gitinterface.Hash, entry rsl.Entry) (*set.Set[string], error) {
+	revokedKeyIDs := set.NewSet[string]()
+	for keyID, effectiveEntryID := range keyRevocations {
+		if entry.GetID().Equal(effectiveEntryID) {
+			// Signatures on the entry the revocation is effective after
+			// remain valid
+			continue
+		}
+
+		isAfter, err := repo.KnowsCommit(entry.GetID(), effectiveEntryID)
+		if err != nil {
+			return nil, err
+		}
+		if isAfter {
+			slog.Debug(fmt.Sprintf("Key '%s' was revoked after entry '%s', disregarding signatures using it...", keyID, effectiveEntryID.String()))
+			revokedKeyIDs.Add(keyID)
+		}
+	}
+
+	return revokedKeyIDs, nil
+}
+
+// getRevokedKeyIDsForNewEntry returns the IDs of keys whose revocation will be
+// in effect for the next entry recorded in the RSL, i.e., keys revoked as of an
+// RSL entry that has already been recorded.
+func getRevokedKeyIDsForNewEntry(repo *gitinterface.Repository, keyRevocations map[string]gitinterface.Hash) (*set.Set[string], error) {
+	revokedKeyIDs := set.NewSet[string]()
+	if len(keyRevocations) == 0 {
+		return revokedKeyIDs, nil
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for keyID, effectiveEntryID := range keyRevocations {
+		isRecorded, err := repo.KnowsCommit(latestEntry.GetID(), effectiveEntryID)
+		if err != nil {
+			return nil, err
+		}
+		if isRecorded {
+			revokedKeyIDs.Add(keyID)
+		}
+	}
+
+	return revokedKeyIDs, nil
+}
+
+// verifyEntryWithinPolicyExpiry checks that the policy had not expired when the
+// entry was recorded in the RSL before verifying the entry using the policy.
+func verifyEntryWithinPolicyExpiry(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry, opts ...verifyGitObjectAndAttestationsOption) error {
+	entryTime, err := repo.GetCommitTime(entry.GetID())
+	if err != nil {
+		return err
+	}
+
+	if err := policy.VerifyExpiry(entryTime); err != nil {
+		return fmt.Errorf("verifying entry '%s' failed, %w: %w", entry.GetID().String(), ErrVerificationFailed, err)
+	}
+
+	return verifyEntry(ctx, repo, policy, attestationsState, entry, opts...)
+}
+
 // verifyEntry is a helper to verify an entry's signature using the specified
 // policy. The specified policy is used for the RSL entry itself. However, for
 // commit signatures, verifyEntry checks when the commit was first introduced
 // via the RSL across all refs. Then, it uses the policy applicable at the
 // commit's first entry into the repository. If the commit is brand new to the
-// repository, the specified policy is used.
-func verifyEntry(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) error {
+// repository, the specified policy is used. Any specified options are applied
+// when verifying the entry's Git objects and attestations.
+func verifyEntry(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry, opts ...verifyGitObjectAndAttestationsOption) error {
 	if entry.RefName == PolicyRef || entry.RefName == attestations.Ref {
 		return nil
 	}
 
 	if strings.HasPrefix(entry.RefName, gitinterface.TagRefPrefix) {
 		slog.Debug("Entry is for a Git tag, using tag verification workflow...")
-		return verifyTagEntry(ctx, repo, policy, attestationsState, entry)
+		return verifyTagEntry(ctx, repo, policy, attestationsState, entry, opts...)
+	}
+
+	options := &verifyGitObjectAndAttestationsOptions{}
+	for _, fn := range opts {
+		fn(options)
 	}
 
 	// Load the applicable reference authorization and approvals from trusted
 	// code review systems
 	slog.Debug("Searching for applicable reference authorizations and code reviews...")
-	authorizationAttestation, approverKeyIDs, err := getApproverAttestationAndKeyIDs(ctx, repo, policy, attestationsState, entry)
+	authorizationAttestation, approverKeyIDs, hats, err := getApproverAttestationAndKeyIDs(ctx, repo, policy, attestationsState, entry, options.revokedKeyIDs)
 	if err != nil {
 		return err
 	}
 
 	// Verify Git namespace policies using the RSL entry and attestations
-	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.ID, authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs)); err != nil {
-		return fmt.Errorf("verifying Git namespace policies failed, %w", ErrVerificationFailed)
+	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.ID, authorizationAttestation, append([]verifyGitObjectAndAttestationsOption{withApproverPrincipalIDs(approverKeyIDs), withHatApprovals(hats)}, opts...)...); err != nil {
+		return fmt.Errorf("verifying Git namespace policies failed, %w: %w", ErrVerificationFailed, err)
 	}
 
 	// Check if policy has file rules at all for efficiency
@@ -898,9 +1088,9 @@ func verifyEntry(ctx context.Context, repo *gitinterface.Repository, policy *Sta
 			// If not found, we don't make any assumptions about it being a
 			// failure in case of name mismatches. So, the signature check
 			// proceeds as usual.
-			verifiedUsing, _, err = verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", fileRuleScheme, path), commitID, authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs), withTrustedVerifier(verifiedUsing))
+			verifiedUsing, _, err = verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", fileRuleScheme, path), commitID, authorizationAttestation, append([]verifyGitObjectAndAttestationsOption{withApproverPrincipalIDs(approverKeyIDs), withHatApprovals(hats), withTrustedVerifier(verifiedUsing)}, opts...)...)
 			if err != nil {
-				return fmt.Errorf("verifying file namespace policies failed, %w", ErrVerificationFailed)
+				return fmt.Errorf("verifying file namespace policies failed, %w: %w", ErrVerificationFailed, err)
 			}
 		}
 	}
@@ -908,7 +1098,7 @@ func verifyEntry(ctx context.Context, repo *gitinterface.Repository, policy *Sta
 	return nil
 }
 
-func verifyTagEntry(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) error {
+func verifyTagEntry(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry, opts ...verifyGitObjectAndAttestationsOption) error {
 	entryTagRef, err := repo.GetReference(entry.RefName)
 	if err != nil {
 		return err
@@ -923,21 +1113,26 @@ func verifyTagEntry(ctx context.Context, repo *gitinterface.Repository, policy *
 		return fmt.Errorf("verifying RSL entry failed, tag reference set to unexpected target")
 	}
 
-	authorizationAttestation, approverKeyIDs, err := getApproverAttestationAndKeyIDs(ctx, repo, policy, attestationsState, entry)
+	options := &verifyGitObjectAndAttestationsOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	authorizationAttestation, approverKeyIDs, hats, err := getApproverAttestationAndKeyIDs(ctx, repo, policy, attestationsState, entry, options.revokedKeyIDs)
 	if err != nil {
 		return err
 	}
 
-	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.GetID(), authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs), withTagObjectID(entry.TargetID)); err != nil {
+	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.GetID(), authorizationAttestation, append([]verifyGitObjectAndAttestationsOption{withApproverPrincipalIDs(approverKeyIDs), withHatApprovals(hats), withTagObjectID(entry.TargetID)}, opts...)...); err != nil {
 		return fmt.Errorf("verifying tag entry failed, %w: %w", ErrVerificationFailed, err)
 	}
 
 	return nil
 }
 
-func getApproverAttestationAndKeyIDs(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) (*sslibdsse.Envelope, *set.Set[string], error) {
+func getApproverAttestationAndKeyIDs(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry, revokedKeyIDs *set.Set[string]) (*sslibdsse.Envelope, *set.Set[string], *hatApprovals, error) {
 	if attestationsState == nil {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 
 	firstEntry := false
@@ -945,7 +1140,7 @@ func getApproverAttestationAndKeyIDs(ctx context.Context, repo *gitinterface.Rep
 	priorRefEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(repo, rsl.ForReference(entry.RefName), rsl.BeforeEntryID(entry.ID))
 	if err != nil {
 		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
 		firstEntry = true
@@ -971,25 +1166,31 @@ func getApproverAttestationAndKeyIDs(ctx context.Context, repo *gitinterface.Rep
 		toID, err = repo.GetCommitTreeID(entry.TargetID)
 	}
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return getApproverAttestationAndKeyIDsForIndex(ctx, repo, policy, attestationsState, entry.RefName, fromID, toID, isTag)
+	return getApproverAttestationAndKeyIDsForIndex(ctx, repo, policy, attestationsState, entry.RefName, fromID, toID, isTag, revokedKeyIDs)
 }
 
-func getApproverAttestationAndKeyIDsForIndex(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, targetRef string, fromID, toID gitinterface.Hash, isTag bool) (*sslibdsse.Envelope, *set.Set[string], error) {
+func getApproverAttestationAndKeyIDsForIndex(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, targetRef string, fromID, toID gitinterface.Hash, isTag bool, revokedKeyIDs *set.Set[string]) (*sslibdsse.Envelope, *set.Set[string], *hatApprovals, error) {
 	if attestationsState == nil {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 
 	slog.Debug(fmt.Sprintf("Finding reference authorization attestations for '%s' from '%s' to '%s'...", targetRef, fromID.String(), toID.String()))
 	authorizationAttestation, err := attestationsState.GetReferenceAuthorizationFor(repo, targetRef, fromID.String(), toID.String())
 	if err != nil {
 		if !errors.Is(err, authorizations.ErrAuthorizationNotFound) {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
+	hats := &hatApprovals{approverIDs: map[string]*set.Set[string]{}}
+	hats.authorizations, err = attestationsState.GetHattedReferenceAuthorizationsFor(repo, targetRef, fromID.String(), toID.String())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	approverIdentities := set.NewSet[string]()
 
 	// When we add other code review systems, we can move this into a
@@ -1009,7 +1210,7 @@ func getApproverAttestationAndKeyIDsForIndex(ctx context.Context, repo *gitinter
 			githubApprovalAttestation, err := attestationsState.GetGitHubPullRequestApprovalAttestationFor(repo, appName, targetRef, fromID.String(), toID.String())
 			if err != nil {
 				if !errors.Is(err, github.ErrPullRequestApprovalAttestationNotFound) {
-					return nil, nil, err
+					return nil, nil, nil, err
 				}
 			}
 
@@ -1022,19 +1223,20 @@ func getApproverAttestationAndKeyIDsForIndex(ctx context.Context, repo *gitinter
 			if githubApprovalAttestation != nil {
 				slog.Debug("GitHub pull request approval found, verifying attestation signature...")
 				approvalVerifier := &SignatureVerifier{
-					repository: policy.repository,
-					name:       appName,
-					principals: appPrincipals,
-					threshold:  appEntry.GetThreshold(),
+					repository:    policy.repository,
+					name:          appName,
+					principals:    appPrincipals,
+					threshold:     appEntry.GetThreshold(),
+					revokedKeyIDs: revokedKeyIDs,
 				}
 				_, err := approvalVerifier.Verify(ctx, nil, githubApprovalAttestation)
 				if err != nil {
-					return nil, nil, fmt.Errorf("%w: failed to verify GitHub app approval attestation, signed by untrusted key", ErrVerificationFailed)
+					return nil, nil, nil, fmt.Errorf("%w: failed to verify GitHub app approval attestation, signed by untrusted key", ErrVerificationFailed)
 				}
 
 				payloadBytes, err := githubApprovalAttestation.DecodeB64Payload()
 				if err != nil {
-					return nil, nil, err
+					return nil, nil, nil, err
 				}
 
 				// TODO: support multiple versions
@@ -1046,17 +1248,28 @@ func getApproverAttestationAndKeyIDsForIndex(ctx context.Context, repo *gitinter
 				}
 				stmt := new(tmpStatement)
 				if err := json.Unmarshal(payloadBytes, stmt); err != nil {
-					return nil, nil, err
+					return nil, nil, nil, err
 				}
 
+				approverHats := stmt.Predicate.GetApproverHats()
 				for _, approver := range stmt.Predicate.GetApprovers() {
-					approverIdentities.Add(approver)
+					hat, hasHat := approverHats[approver]
+					if !hasHat {
+						approverIdentities.Add(approver)
+						continue
+					}
+
+					// Approvals in a hat only count towards that hat
+					if _, has := hats.approverIDs[hat]; !has {
+						hats.approverIDs[hat] = set.NewSet[string]()
+					}
+					hats.approverIDs[hat].Add(approver)
 				}
 			}
 		}
 	}
 
-	return authorizationAttestation, approverIdentities, nil
+	return authorizationAttestation, approverIdentities, hats, nil
 }
 
 // getCommits identifies the commits introduced to the entry's ref since the
@@ -1085,9 +1298,12 @@ func getCommits(repo *gitinterface.Repository, entry *rsl.ReferenceEntry) ([]git
 // verifyGitObjectAndAttestations.
 type verifyGitObjectAndAttestationsOptions struct {
 	approverPrincipalIDs *set.Set[string]
+	hats                 *hatApprovals
 	verifyMergeable      bool
 	trustedVerifier      string
 	tagObjectID          gitinterface.Hash
+	revokedKeyIDs        *set.Set[string]
+	commitIDs            []gitinterface.Hash
 }
 
 type verifyGitObjectAndAttestationsOption func(o *verifyGitObjectAndAttestationsOptions)
@@ -1101,6 +1317,15 @@ func withApproverPrincipalIDs(approverPrincipalIDs *set.Set[string]) verifyGitOb
 	}
 }
 
+// withHatApprovals allows for optionally passing in approvals made in specific
+// hats, which are only counted towards the thresholds of the corresponding
+// teams.
+func withHatApprovals(hats *hatApprovals) verifyGitObjectAndAttestationsOption {
+	return func(o *verifyGitObjectAndAttestationsOptions) {
+		o.hats = hats
+	}
+}
+
 // withVerifyMergeable indicates that the verification must check if a change
 // can be merged.
 func withVerifyMergeable() verifyGitObjectAndAttestationsOption {
@@ -1127,6 +1352,23 @@ func withTagObjectID(objID gitinterface.Hash) verifyGitObjectAndAttestationsOpti
 	}
 }
 
+// withCommitIDs is used to specify the commits introduced by a change that
+// has no RSL entry yet, such as when verifying if a change is mergeable.
+// Otherwise, the commits are identified using the RSL entry being verified.
+func withCommitIDs(commitIDs []gitinterface.Hash) verifyGitObjectAndAttestationsOption {
+	return func(o *verifyGitObjectAndAttestationsOptions) {
+		o.commitIDs = commitIDs
+	}
+}
+
+// withRevokedKeyIDs is used to specify the IDs of keys that have been revoked
+// and must not be used to verify signatures.
+func withRevokedKeyIDs(keyIDs *set.Set[string]) verifyGitObjectAndAttestationsOption {
+	return func(o *verifyGitObjectAndAttestationsOptions) {
+		o.revokedKeyIDs = keyIDs
+	}
+}
+
 func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target string, gitID gitinterface.Hash, authorizationAttestation *sslibdsse.Envelope, opts ...verifyGitObjectAndAttestationsOption) (string, bool, error) {
 	options := &verifyGitObjectAndAttestationsOptions{tagObjectID: gitinterface.ZeroHash}
 	for _, fn := range opts {
@@ -1143,6 +1385,16 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 		return "", false, nil
 	}
 
+	if options.revokedKeyIDs != nil && options.revokedKeyIDs.Len() != 0 {
+		// The verifiers may be cached in the policy, so we use copies that
+		// disregard revoked keys
+		verifiersWithRevocations := make([]*SignatureVerifier, 0, len(verifiers))
+		for _, verifier := range verifiers {
+			verifiersWithRevocations = append(verifiersWithRevocations, verifier.withRevokedKeys(options.revokedKeyIDs))
+		}
+		verifiers = verifiersWithRevocations
+	}
+
 	if options.trustedVerifier != "" {
 		for _, verifier := range verifiers {
 			if verifier.Name() == options.trustedVerifier {
@@ -1157,7 +1409,20 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 			appNames = append(appNames, appName)
 		}
 	}
-	verifiedUsing, acceptedPrincipalIDs, rslSignatureNeededForThreshold, err := verifyGitObjectAndAttestationsUsingVerifiers(ctx, verifiers, gitID, authorizationAttestation, appNames, options.approverPrincipalIDs, options.verifyMergeable)
+	var authoredCommitIDs []gitinterface.Hash
+	for _, verifier := range verifiers {
+		if verifier.separationOfDuties {
+			// The authors of the change's commits cannot count towards
+			// the thresholds of such rules
+			authoredCommitIDs, err = getCommitsForTarget(policy, target, gitID, options)
+			if err != nil {
+				return "", false, err
+			}
+			break
+		}
+	}
+
+	verifiedUsing, acceptedPrincipalIDs, rslSignatureNeededForThreshold, err := verifyGitObjectAndAttestationsUsingVerifiers(ctx, verifiers, gitID, authoredCommitIDs, authorizationAttestation, appNames, options.approverPrincipalIDs, options.hats, options.verifyMergeable)
 	if err != nil {
 		return "", false, err
 	}
@@ -1195,6 +1460,11 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 		verifiedPrincipalIDs = acceptedPrincipalIDs.Len()
 	}
 
+	matchOptions, err := policy.getMatchOptions()
+	if err != nil {
+		return "", false, err
+	}
+
 	for controllerName, globalRules := range policy.globalRules {
 		if controllerName == "" { // this is the special case
 			slog.Debug("Checking global rules declared in current repository...")
@@ -1206,7 +1476,7 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 			slog.Debug(fmt.Sprintf("Checking if global rule '%s' applies...", rule.GetName()))
 			switch rule := rule.(type) {
 			case tuf.GlobalRuleThreshold:
-				if !rule.Matches(target) {
+				if !rule.Matches(target, matchOptions...) {
 					break
 				}
 
@@ -1229,9 +1499,161 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 
 				slog.Debug(fmt.Sprintf("Successfully verified global rule '%s'", rule.GetName()))
 
+			case tuf.GlobalRuleFreezeWindow:
+				if !rule.Matches(target, matchOptions...) {
+					break
+				}
+
+				// The global rule applies to the namespace under verification
+				slog.Debug(fmt.Sprintf("Verifying freeze window global rule '%s'...", rule.GetName()))
+
+				var changeTime time.Time
+				if options.verifyMergeable {
+					// A proposed change has no RSL entry yet, so we check
+					// if it can be merged right now
+					changeTime = time.Now()
+				} else {
+					// We use the time the RSL entry was recorded so that
+					// verification is reproducible
+					changeTime, err = policy.repository.GetCommitTime(gitID)
+					if err != nil {
+						return "", false, err
+					}
+				}
+
+				if !rule.IsActiveAt(changeTime) {
+					slog.Debug(fmt.Sprintf("Freeze window global rule '%s' is not active at '%s'", rule.GetName(), changeTime.UTC().Format(time.RFC3339)))
+					break
+				}
+
+				requiredThreshold := rule.GetOverrideThreshold()
+				if rslSignatureNeededForThreshold && options.verifyMergeable {
+					// Since we're verifying if it's mergeable and we already know
+					// that the RSL signature is needed to meet threshold, we can
+					// reduce the override threshold as well
+					slog.Debug("Reducing required override threshold by 1 (verifying if change is mergeable and RSL signature is required)...")
+					requiredThreshold--
+				}
+				if verifiedPrincipalIDs < requiredThreshold {
+					slog.Debug(fmt.Sprintf("Global rule '%s' not met, required override threshold '%d', only have '%d'", rule.GetName(), rule.GetOverrideThreshold(), verifiedPrincipalIDs))
+					return "", false, fmt.Errorf("%w: global rule '%s' freezes '%s' from '%s' to '%s', override threshold '%d' not met", ErrFreezeWindowActive, rule.GetName(), target, rule.GetStart().Format(time.RFC3339), rule.GetEnd().Format(time.RFC3339), rule.GetOverrideThreshold())
+				}
+
+				slog.Debug(fmt.Sprintf("Successfully verified global rule '%s'", rule.GetName()))
+
+			case tuf.GlobalRuleRequireSignedCommits:
+				if !rule.Matches(target, matchOptions...) {
+					break
+				}
+
+				// The global rule applies to the namespace under verification
+				slog.Debug(fmt.Sprintf("Verifying require signed commits global rule '%s'...", rule.GetName()))
+
+				// The commits of a proposed change are not recorded in the RSL
+				// yet, so they are specified by the caller
+				commitIDs, err := getCommitsForTarget(policy, target, gitID, options)
+				if err != nil {
+					return "", false, err
+				}
+
+				if err := verifyCommitsSignedByAnyPrincipal(ctx, policy, commitIDs, options.revokedKeyIDs); err != nil {
+					slog.Debug(fmt.Sprintf("Global rule '%s' not met: %v", rule.GetName(), err))
+					return "", false, err
+				}
+
+				slog.Debug(fmt.Sprintf("Successfully verified global rule '%s'", rule.GetName()))
+
+			case tuf.GlobalRuleRequireSignOff:
+				if !rule.Matches(target, matchOptions...) {
+					break
+				}
+
+				// The global rule applies to the namespace under verification
+				slog.Debug(fmt.Sprintf("Verifying require sign-off global rule '%s'...", rule.GetName()))
+
+				// The commits of a proposed change are not recorded in the RSL
+				// yet, so they are specified by the caller
+				commitIDs, err := getCommitsForTarget(policy, target, gitID, options)
+				if err != nil {
+					return "", false, err
+				}
+
+				if err := verifyCommitsSignedOff(policy, commitIDs, rule.AllowsAssociatedIdentities()); err != nil {
+					slog.Debug(fmt.Sprintf("Global rule '%s' not met: %v", rule.GetName(), err))
+					return "", false, err
+				}
+
+				slog.Debug(fmt.Sprintf("Successfully verified global rule '%s'", rule.GetName()))
+
+			case tuf.GlobalRuleRequireLinearHistory:
+				if !rule.Matches(target, matchOptions...) {
+					break
+				}
+
+				// The global rule applies to the namespace under verification
+				slog.Debug(fmt.Sprintf("Verifying require linear history global rule '%s'...", rule.GetName()))
+
+				// The commits of a proposed change are not recorded in the RSL
+				// yet, so they are specified by the caller
+				commitIDs, err := getCommitsForTarget(policy, target, gitID, options)
+				if err != nil {
+					return "", false, err
+				}
+
+				if err := verifyCommitsHaveLinearHistory(policy, commitIDs); err != nil {
+					slog.Debug(fmt.Sprintf("Global rule '%s' not met: %v", rule.GetName(), err))
+					return "", false, err
+				}
+
+				slog.Debug(fmt.Sprintf("Successfully verified global rule '%s'", rule.GetName()))
+
+			case tuf.GlobalRuleRestrictFiles:
+				if !rule.Matches(target, matchOptions...) {
+					break
+				}
+
+				// The global rule applies to the namespace under verification
+				slog.Debug(fmt.Sprintf("Verifying restrict files global rule '%s'...", rule.GetName()))
+
+				// The commits of a proposed change are not recorded in the RSL
+				// yet, so they are specified by the caller
+				commitIDs, err := getCommitsForTarget(policy, target, gitID, options)
+				if err != nil {
+					return "", false, err
+				}
+
+				if err := verifyCommitsRestrictFiles(policy, commitIDs, rule); err != nil {
+					slog.Debug(fmt.Sprintf("Global rule '%s' not met: %v", rule.GetName(), err))
+					return "", false, err
+				}
+
+				slog.Debug(fmt.Sprintf("Successfully verified global rule '%s'", rule.GetName()))
+
+			case tuf.GlobalRuleRestrictRead:
+				if !rule.Matches(target, matchOptions...) {
+					break
+				}
+
+				// The global rule applies to the namespace under verification
+				slog.Debug(fmt.Sprintf("Verifying restrict read global rule '%s'...", rule.GetName()))
+
+				// The commits of a proposed change are not recorded in the RSL
+				// yet, so they are specified by the caller
+				commitIDs, err := getCommitsForTarget(policy, target, gitID, options)
+				if err != nil {
+					return "", false, err
+				}
+
+				if err := verifyCommitsEncryptedForReaders(policy, commitIDs, rule); err != nil {
+					slog.Debug(fmt.Sprintf("Global rule '%s' not met: %v", rule.GetName(), err))
+					return "", false, err
+				}
+
+				slog.Debug(fmt.Sprintf("Successfully verified global rule '%s'", rule.GetName()))
+
 			case tuf.GlobalRuleBlockForcePushes:
 				// TODO: we use policy.repository, not ideal...
-				if !rule.Matches(target) {
+				if !rule.Matches(target, matchOptions...) {
 					break
 				}
 
@@ -1295,7 +1717,281 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 	return verifiedUsing, rslSignatureNeededForThreshold, nil
 }
 
-func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers []*SignatureVerifier, gitID gitinterface.Hash, authorizationAttestation *sslibdsse.Envelope, appNames []string, approverIDs *set.Set[string], verifyMergeable bool) (string, *set.Set[string], bool, error) {
+// getCommitsForTarget returns the commits introduced by the change being
+// verified for the target. For file rules, the Git object is the commit itself.
+// For Git namespace rules, the commits are those specified using withCommitIDs
+// or, otherwise, those introduced by the RSL entry.
+func getCommitsForTarget(policy *State, target string, gitID gitinterface.Hash, options *verifyGitObjectAndAttestationsOptions) ([]gitinterface.Hash, error) {
+	if options.commitIDs != nil {
+		return options.commitIDs, nil
+	}
+
+	if gitID == nil || gitID.IsZero() {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(target, fileRuleScheme+":") {
+		return []gitinterface.Hash{gitID}, nil
+	}
+
+	return getCommitsForGlobalRule(policy, gitID)
+}
+
+// getCommitsForGlobalRule returns the commits introduced by the RSL reference
+// entry identified by gitID. Global rules that inspect commits only accept
+// git:<> patterns, so gitID must be for an RSL reference entry. No commits are
+// returned for entries that update tags.
+func getCommitsForGlobalRule(policy *State, gitID gitinterface.Hash) ([]gitinterface.Hash, error) {
+	currentEntry, err := rsl.GetEntry(policy.repository, gitID)
+	if err != nil {
+		slog.Debug(fmt.Sprintf("unable to load RSL entry for '%s': %v", gitID.String(), err))
+		return nil, err
+	}
+
+	currentEntryRef, isReferenceEntry := currentEntry.(*rsl.ReferenceEntry)
+	if !isReferenceEntry {
+		slog.Debug(fmt.Sprintf("Expected '%s' to be RSL reference entry, aborting verification of global rule...", gitID.String()))
+		return nil, rsl.ErrInvalidRSLEntry
+	}
+
+	if strings.HasPrefix(currentEntryRef.RefName, gitinterface.TagRefPrefix) {
+		slog.Debug(fmt.Sprintf("Entry '%s' is for tag '%s', no commits to check...", currentEntryRef.GetID().String(), currentEntryRef.RefName))
+		return nil, nil
+	}
+
+	return getCommits(policy.repository, currentEntryRef)
+}
+
+// verifyCommitsSignedOff checks that each of the specified commits has a
+// Signed-off-by trailer matching the commit's author. If
+// allowAssociatedIdentities is set, a trailer may also match an email address
+// declared as an associated identity of the person in the policy whose
+// associated identities include the author's email address.
+func verifyCommitsSignedOff(policy *State, commitIDs []gitinterface.Hash, allowAssociatedIdentities bool) error {
+	for _, commitID := range commitIDs {
+		authorEmail, err := policy.repository.GetCommitAuthorEmail(commitID)
+		if err != nil {
+			return err
+		}
+
+		acceptedEmails := set.NewSetFromItems(strings.ToLower(authorEmail))
+		if allowAssociatedIdentities {
+			for _, person := range policy.getPersonsForEmail(authorEmail) {
+				for _, identity := range person.AssociatedIdentities {
+					acceptedEmails.Add(strings.ToLower(identity))
+				}
+			}
+		}
+
+		signOffs, err := policy.repository.GetCommitTrailerValues(commitID, signOffTrailerKey)
+		if err != nil {
+			return err
+		}
+
+		signedOff := false
+		for _, signOff := range signOffs {
+			address, err := mail.ParseAddress(signOff)
+			if err != nil {
+				slog.Debug(fmt.Sprintf("Unable to parse sign-off '%s' in commit '%s', skipping...", signOff, commitID.String()))
+				continue
+			}
+
+			if acceptedEmails.Has(strings.ToLower(address.Address)) {
+				signedOff = true
+				break
+			}
+		}
+
+		if !signedOff {
+			return fmt.Errorf("%w: '%s'", ErrCommitNotSignedOff, commitID.String())
+		}
+	}
+
+	return nil
+}
+
+// getPersonsForEmail returns the persons in the policy who declare the
+// specified email address as one of their associated identities. Email
+// addresses are compared case-insensitively.
+func (s *State) getPersonsForEmail(email string) []*tufv02.Person {
+	persons := []*tufv02.Person{}
+	for _, principal := range s.allPrincipals {
+		person, isV02 := principal.(*tufv02.Person)
+		if !isV02 {
+			continue
+		}
+
+		for _, identity := range person.AssociatedIdentities {
+			if strings.EqualFold(identity, email) {
+				persons = append(persons, person)
+				break
+			}
+		}
+	}
+
+	return persons
+}
+
+// verifyCommitsHaveLinearHistory checks that none of the specified commits is a
+// merge commit, i.e., has more than one parent.
+func verifyCommitsHaveLinearHistory(policy *State, commitIDs []gitinterface.Hash) error {
+	for _, commitID := range commitIDs {
+		parentIDs, err := policy.repository.GetCommitParentIDs(commitID)
+		if err != nil {
+			return err
+		}
+
+		if len(parentIDs) > 1 {
+			return fmt.Errorf("%w: commit '%s' has %d parents", ErrMergeCommitNotAllowed, commitID.String(), len(parentIDs))
+		}
+	}
+
+	return nil
+}
+
+// verifyCommitsRestrictFiles checks that none of the specified commits
+// introduces a file matching a pattern denied by the rule or a blob larger than
+// the rule's maximum blob size. Files removed by a commit are not checked.
+func verifyCommitsRestrictFiles(policy *State, commitIDs []gitinterface.Hash, rule tuf.GlobalRuleRestrictFiles) error {
+	maxBlobSize := rule.GetMaxBlobSize()
+
+	for _, commitID := range commitIDs {
+		paths, err := policy.repository.GetFilePathsChangedByCommit(commitID)
+		if err != nil {
+			return err
+		}
+
+		treeID, err := policy.repository.GetCommitTreeID(commitID)
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			if path == "" {
+				continue
+			}
+
+			blobID, err := policy.repository.GetPathIDInTree(path, treeID)
+			if err != nil {
+				if errors.Is(err, gitinterface.ErrTreeDoesNotHavePath) {
+					// The path was removed by this commit
+					continue
+				}
+
+				return err
+			}
+
+			if pattern := rule.MatchesDeniedPattern(path); pattern != "" {
+				return fmt.Errorf("%w: commit '%s' introduces '%s' matching '%s'", ErrFileNotAllowed, commitID.String(), path, pattern)
+			}
+
+			if maxBlobSize == 0 {
+				continue
+			}
+
+			blobSize, err := policy.repository.GetObjectSize(blobID)
+			if err != nil {
+				return err
+			}
+
+			if blobSize > maxBlobSize {
+				return fmt.Errorf("%w: commit '%s' introduces '%s' of size %d bytes, maximum is %d bytes", ErrBlobTooLarge, commitID.String(), path, blobSize, maxBlobSize)
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyCommitsEncryptedForReaders checks that the tree of each of the
+// specified commits is encrypted, that every file in the tree is an encrypted
+// blob recorded in the manifest, and that its data key is wrapped for at least
+// one key of each reader authorized by the rule. The encrypted contents
+// themselves cannot be inspected without a reader's private key.
+func verifyCommitsEncryptedForReaders(policy *State, commitIDs []gitinterface.Hash, rule tuf.GlobalRuleRestrictRead) error {
+	readerKeys, err := policy.getReaderKeysForGlobalRule(rule)
+	if err != nil {
+		return err
+	}
+
+	for _, commitID := range commitIDs {
+		treeID, err := policy.repository.GetCommitTreeID(commitID)
+		if err != nil {
+			return err
+		}
+
+		manifest, err := encryption.LoadManifest(policy.repository, treeID)
+		if err != nil {
+			if errors.Is(err, encryption.ErrManifestNotFound) {
+				return fmt.Errorf("%w: commit '%s' is not encrypted", ErrReadRestrictedContentNotEncrypted, commitID.String())
+			}
+
+			return err
+		}
+
+		if err := manifest.VerifyTree(policy.repository, treeID); err != nil {
+			return fmt.Errorf("%w: commit '%s': %w", ErrReadRestrictedContentNotEncrypted, commitID.String(), err)
+		}
+
+		// Each reader must be able to decrypt the contents using one of
+		// their keys
+		for _, readerID := range slices.Sorted(maps.Keys(readerKeys)) {
+			isRecipient := slices.ContainsFunc(readerKeys[readerID], func(key *signerverifier.SSLibKey) bool {
+				return manifest.HasRecipient(key.KeyID)
+			})
+			if !isRecipient {
+				return fmt.Errorf("%w: commit '%s' is not encrypted for reader '%s'", ErrReadRestrictedContentNotEncrypted, commitID.String(), readerID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyCommitsSignedByAnyPrincipal checks that each of the specified commits
+// is signed by at least one of the principals declared in the policy using a
+// key that is not revoked.
+func verifyCommitsSignedByAnyPrincipal(ctx context.Context, policy *State, commitIDs []gitinterface.Hash, revokedKeyIDs *set.Set[string]) error {
+	verifier := &SignatureVerifier{
+		repository:    policy.repository,
+		name:          tuf.GlobalRuleRequireSignedCommitsType,
+		principals:    []tuf.Principal{},
+		threshold:     1,
+		revokedKeyIDs: revokedKeyIDs,
+	}
+	for _, principal := range policy.allPrincipals {
+		if _, isTeam := principal.(tuf.Team); isTeam {
+			// Teams have no keys of their own, their members are
+			// principals in the policy
+			continue
+		}
+		verifier.principals = append(verifier.principals, principal)
+	}
+
+	for _, commitID := range commitIDs {
+		if len(verifier.principals) == 0 {
+			return fmt.Errorf("%w: '%s'", ErrCommitNotSigned, commitID.String())
+		}
+
+		if _, err := verifier.Verify(ctx, commitID, nil); err != nil {
+			if errors.Is(err, ErrVerifierConditionsUnmet) {
+				return fmt.Errorf("%w: '%s'", ErrCommitNotSigned, commitID.String())
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyGitObjectAndAttestationsUsingVerifiers checks if any of the verifiers
+// is satisfied by the signature on the Git object, the authorization
+// attestation, and the approvals. For verifiers that require separation of
+// duties, the principal who signed the Git object and the authors of the
+// specified commits, identified by their signatures and author emails, do not
+// count towards the threshold.
+func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers []*SignatureVerifier, gitID gitinterface.Hash, authoredCommitIDs []gitinterface.Hash, authorizationAttestation *sslibdsse.Envelope, appNames []string, approverIDs *set.Set[string], hats *hatApprovals, verifyMergeable bool) (string, *set.Set[string], bool, error) {
 	if len(verifiers) == 0 {
 		return "", nil, false, ErrNoVerifiers
 	}
@@ -1304,11 +2000,47 @@ func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers
 		verifiedUsing                       string
 		acceptedPrincipalIDs                *set.Set[string]
 		rslEntrySignatureNeededForThreshold bool
+		disregardedApprovals                []string
 	)
 	for _, verifier := range verifiers {
-		trustedPrincipalIDs := verifier.TrustedPrincipalIDs()
+		verifierGitID := gitID
+		if verifier.separationOfDuties {
+			// The principals who signed or authored the change cannot count
+			// towards the threshold, so we disregard their signature and any
+			// other approvals they made
+			authorIDs := set.NewSet[string]()
+			for _, commitID := range authoredCommitIDs {
+				commitAuthorIDs, err := verifier.getCommitAuthorPrincipalIDs(ctx, commitID)
+				if err != nil {
+					return "", nil, false, err
+				}
+				authorIDs.Extend(commitAuthorIDs)
+			}
+
+			if gitID != nil && !gitID.IsZero() {
+				signer, _, err := verifier.verifyGitObjectSignature(ctx, gitID)
+				if err != nil {
+					return "", nil, false, err
+				}
+
+				if signer != nil {
+					authorIDs.Add(signer.ID())
+					verifierGitID = nil
+				}
+			}
 
-		usedPrincipalIDs, err := verifier.Verify(ctx, gitID, authorizationAttestation)
+			for _, authorID := range authorIDs.Contents() {
+				slog.Debug(fmt.Sprintf("Rule '%s' requires separation of duties, disregarding approvals by '%s' who signed or authored the change...", verifier.Name(), authorID))
+				disregardedApprovals = append(disregardedApprovals, fmt.Sprintf("approvals by '%s' for rule '%s'", authorID, verifier.Name()))
+				verifier = verifier.withoutPrincipal(authorID)
+			}
+
+			if authorIDs.Len() != 0 && len(verifier.principals) == 0 {
+				continue
+			}
+		}
+
+		usedPrincipalIDs, err := verifier.Verify(ctx, verifierGitID, authorizationAttestation)
 		if err == nil {
 			// We meet requirements just from the authorization attestation's sigs
 			verifiedUsing = verifier.Name()
@@ -1327,7 +2059,7 @@ func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers
 			for _, approverID := range approverIDs.Contents() {
 				// For each approver ID from the app attestation, we try to see
 				// if it matches a principal in the current verifiers.
-				for _, principal := range verifier.principals {
+				for _, principal := range verifier.candidatePrincipals() {
 					slog.Debug(fmt.Sprintf("Checking if approver identity '%s' matches '%s'...", approverID, principal.ID()))
 					if usedPrincipalIDs.Has(principal.ID()) {
 						// This principal has already been counted towards the
@@ -1336,32 +2068,29 @@ func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers
 						continue
 					}
 
-					// We can only match against a principal if it has a notion
-					// of associated identities
-					// Right now, this is just tufv02.Person
-					matchedAssociatedIdentity := false
-					if principal, isV02 := principal.(*tufv02.Person); isV02 {
-						for _, appName := range appNames {
-							if associatedIdentity, has := principal.AssociatedIdentities[appName]; has && associatedIdentity == approverID {
-								// The approver ID from the issuer (appName) matches
-								// the principal's associated identity for the same
-								// issuer!
-								slog.Debug(fmt.Sprintf("Principal '%s' has associated identity '%s', counting principal towards threshold...", principal.ID(), approverID))
-								usedPrincipalIDs.Add(principal.ID())
-								matchedAssociatedIdentity = true
-								break
-							}
-						}
-					}
-					if matchedAssociatedIdentity {
+					if hasAssociatedIdentity(principal, appNames, approverID) {
+						slog.Debug(fmt.Sprintf("Principal '%s' has associated identity '%s', counting principal towards threshold...", principal.ID(), approverID))
+						usedPrincipalIDs.Add(principal.ID())
 						break
 					}
 				}
 			}
 		}
 
-		// Get a list of used principals that are also trusted by the verifier
-		trustedUsedPrincipalIDs := trustedPrincipalIDs.Intersection(usedPrincipalIDs)
+		var hatPrincipalIDs map[string]*set.Set[string]
+		if hats != nil {
+			// Find the members who approved wearing a team's hat, these only
+			// count towards that team's threshold
+			hatPrincipalIDs, err = verifier.getHatPrincipalIDs(ctx, hats, appNames)
+			if err != nil {
+				return "", nil, false, err
+			}
+		}
+
+		// Get a list of used principals that are also trusted by the
+		// verifier, along with teams whose thresholds are met by the members
+		// used so far
+		trustedUsedPrincipalIDs := verifier.countedPrincipalIDs(usedPrincipalIDs, hatPrincipalIDs)
 		if trustedUsedPrincipalIDs.Len() >= verifier.Threshold() {
 			// With approvals, we now meet threshold!
 			slog.Debug(fmt.Sprintf("Counted '%d' principals towards threshold '%d' for '%s', threshold met!", trustedUsedPrincipalIDs.Len(), verifier.Threshold(), verifier.Name()))
@@ -1370,8 +2099,10 @@ func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers
 			break
 		}
 
-		// If verifyMergeable is true, we only need to meet threshold - 1
-		if verifyMergeable && verifier.Threshold() > 1 {
+		// If verifyMergeable is true, we only need to meet threshold - 1,
+		// unless the rule requires separation of duties as the person merging
+		// may have authored the change
+		if verifyMergeable && verifier.Threshold() > 1 && !verifier.separationOfDuties {
 			if trustedUsedPrincipalIDs.Len() >= verifier.Threshold()-1 {
 				slog.Debug(fmt.Sprintf("Counted '%d' principals towards threshold '%d' for '%s', policies can be met if the merge is by authorized person!", trustedUsedPrincipalIDs.Len(), verifier.Threshold(), verifier.Name()))
 				verifiedUsing = verifier.Name()
@@ -1386,5 +2117,43 @@ func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers
 		return verifiedUsing, acceptedPrincipalIDs, rslEntrySignatureNeededForThreshold, nil
 	}
 
+	if len(disregardedApprovals) != 0 {
+		return "", nil, false, fmt.Errorf("%w: %s disregarded as they signed or authored the change", ErrSeparationOfDutiesNotMet, strings.Join(disregardedApprovals, ", "))
+	}
+
 	return "", nil, false, ErrVerifierConditionsUnmet
 }
+
+// hatApprovals records approvals made in a specific hat, i.e., on behalf of a
+// specific team in the policy. They are counted only towards the threshold of
+// the corresponding team.
+type hatApprovals struct {
+	// authorizations maps each hat to the reference authorization signed by
+	// principals wearing the hat.
+	authorizations map[string]*sslibdsse.Envelope
+
+	// approverIDs maps each hat to the identities that approved the change
+	// wearing the hat on a code review tool.
+	approverIDs map[string]*set.Set[string]
+}
+
+// hasAssociatedIdentity returns true if the principal has the approverID as an
+// associated identity for one of the specified apps. We can only match against
+// a principal if it has a notion of associated identities. Right now, this is
+// just tufv02.Person.
+func hasAssociatedIdentity(principal tuf.Principal, appNames []string, approverID string) bool {
+	person, isV02 := principal.(*tufv02.Person)
+	if !isV02 {
+		return false
+	}
+
+	for _, appName := range appNames {
+		if associatedIdentity, has := person.AssociatedIdentities[appName]; has && associatedIdentity == approverID {
+			// The approver ID from the issuer (appName) matches the
+			// principal's associated identity for the same issuer!
+			return true
+		}
+	}
+
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	authorizationsv01 "github.com/gittuf/gittuf/internal/attestations/authorizations/v01"
@@ -18,13 +19,18 @@ import (
 	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/encryption"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
 	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
+	ita "github.com/in-toto/attestation/go/v1"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,6 +54,75 @@ func TestVerifyRef(t *testing.T) {
 	assert.Equal(t, commitIDs[0], currentTip)
 }
 
+func TestVerifyRefWithSeparationOfDuties(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// pushChangeAuthorizedBy adds a commit authored by jane.doe that is
+	// authorized by the specified signer, and records it in the RSL using an
+	// entry made by someone else that is signed using a key the rule does not
+	// trust.
+	pushChangeAuthorizedBy := func(t *testing.T, repo *gitinterface.Repository, signer *ssh.Signer) {
+		t.Helper()
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refAuthorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(refAuthorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add reference authorization", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := repo.SetGitConfig("user.email", "release-bot@example.com"); err != nil {
+			t.Fatal(err)
+		}
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+	}
+
+	t.Run("author is the only approver", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithSeparationOfDutiesPolicy)
+
+		pushChangeAuthorizedBy(t, repo, setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.VerifyRef(testCtx, refName)
+		assert.ErrorIs(t, err, ErrSeparationOfDutiesNotMet)
+	})
+
+	t.Run("change approved by someone other than the author", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithSeparationOfDutiesPolicy)
+
+		pushChangeAuthorizedBy(t, repo, setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.VerifyRef(testCtx, refName)
+		assert.Nil(t, err)
+	})
+}
+
 func TestVerifyRefFull(t *testing.T) {
 	// FIXME: currently this test is identical to the one for VerifyRef.
 	// This is because it's not trivial to create a bunch of test policy / RSL
@@ -115,6 +190,118 @@ func TestVerifyRefFromEntry(t *testing.T) {
 }
 
 func TestVerifyRelativeForRefUsingPersons(t *testing.T) {
+	t.Run("root signed by revoked key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+		rootKeyID, err := rootSigner.KeyID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		newRootSigner := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+		newRootKey := tufv01.NewKeyFromSSLibKey(newRootSigner.MetadataKey())
+
+		updateRoot := func(update func(tuf.RootMetadata), signer sslibdsse.SignerVerifier) {
+			t.Helper()
+
+			rootMetadata, err := state.GetRootMetadata(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			update(rootMetadata)
+			rootMetadata.IncrementVersion()
+			rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state.Metadata.RootEnvelope = rootEnv
+		}
+
+		// Trust a second root key
+		updateRoot(func(rootMetadata tuf.RootMetadata) {
+			if err := rootMetadata.AddRootPrincipal(newRootKey); err != nil {
+				t.Fatal(err)
+			}
+			if err := rootMetadata.AddPrimaryRuleFilePrincipal(newRootKey); err != nil {
+				t.Fatal(err)
+			}
+		}, rootSigner)
+		if err := state.Commit(repo, "Add root key", true, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+		firstEntry.ID = firstEntryID
+
+		// Revoke the original root key as of the first entry, re-signing
+		// all metadata it signed using the second root key
+		updateRoot(func(rootMetadata tuf.RootMetadata) {
+			if err := rootMetadata.RevokeKey(rootKeyID, firstEntryID.String()); err != nil {
+				t.Fatal(err)
+			}
+		}, newRootSigner)
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata.IncrementVersion()
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, newRootSigner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.Metadata.TargetsEnvelope = targetsEnv
+		if err := state.Commit(repo, "Revoke key", true, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Sign a new root using the revoked key
+		updateRoot(func(tuf.RootMetadata) {}, rootSigner)
+		if err := state.Commit(repo, "Sign root using revoked key", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = Apply(testCtx, repo, false)
+		assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+
+		// Record the new root without using Apply
+		policyStagingTip, err := repo.GetReference(PolicyStagingRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.SetReference(PolicyRef, policyStagingTip); err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewReferenceEntry(PolicyRef, policyStagingTip).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+	})
+
 	t.Run("no recovery", func(t *testing.T) {
 		repo, _ := createTestRepository(t, createTestStateWithPolicyUsingPersons)
 		refName := "refs/heads/main"
@@ -758,6 +945,60 @@ func TestVerifyRelativeForRefUsingPersons(t *testing.T) {
 	})
 }
 
+func TestGetApproverAttestationAndKeyIDsForIndex(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrust)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"john.doe"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// This signer for the GitHub app is trusted in the root setup by the
+	// policy state creator helper
+	signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+	env, err := dsse.CreateEnvelope(githubAppApproval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err = dsse.SignEnvelope(testCtx, env, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("approval signed by trusted key", func(t *testing.T) {
+		_, approverIDs, _, err := getApproverAttestationAndKeyIDsForIndex(testCtx, repo, state, currentAttestations, refName, gitinterface.ZeroHash, commitTreeID, false, nil)
+		assert.Nil(t, err)
+		assert.True(t, approverIDs.Has("john.doe"))
+	})
+
+	t.Run("approval signed by revoked key", func(t *testing.T) {
+		appKeyID, err := signer.KeyID()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, _, err = getApproverAttestationAndKeyIDsForIndex(testCtx, repo, state, currentAttestations, refName, gitinterface.ZeroHash, commitTreeID, false, set.NewSetFromItems(appKeyID))
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+}
+
 func TestVerifyMergeable(t *testing.T) {
 	refName := "refs/heads/main"
 	featureRefName := "refs/heads/feature"
@@ -2028,96 +2269,308 @@ func TestVerifyMergeableForCommit(t *testing.T) {
 		assert.Nil(t, err)
 		assert.False(t, rslSignatureRequired)
 	})
-}
-
-func TestVerifyNetwork(t *testing.T) {
-	t.Run("repository is up-to-date", func(t *testing.T) {
-		controllerRepository, networkRepository := createControllerAndNetworkRepositories(t)
+	t.Run("require signed commits rule, unsigned commit not mergeable", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignedCommits)
 
-		networkState, err := LoadCurrentState(testCtx, networkRepository, PolicyRef)
-		require.Nil(t, err)
-		networkRootMetadata, err := networkState.GetRootMetadata(false)
-		require.Nil(t, err)
+		// We need to change the directory for this test because we `checkout`
+		// for older Git versions, modifying the worktree. This chdir ensures
+		// that the temporary directory is used as the worktree.
+		pwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(filepath.Join(repo.GetGitDir(), "..")); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(pwd) //nolint:errcheck
 
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
-		require.Nil(t, err)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 1, gpgKeyBytes)
 
-		verifier := NewPolicyVerifier(controllerRepository)
-		err = verifier.VerifyNetwork(testCtx)
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, commitIDs[0])
 		assert.Nil(t, err)
-	})
 
-	t.Run("propagation not performed", func(t *testing.T) {
-		controllerRepository, _ := createControllerAndNetworkRepositories(t)
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unsignedCommitID, err := repo.Commit(treeID, featureRefName, "Unsigned commit\n", false)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		verifier := NewPolicyVerifier(controllerRepository)
-		err := verifier.VerifyNetwork(testCtx)
-		assert.ErrorIs(t, err, gitinterface.ErrTreeDoesNotHavePath)
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, unsignedCommitID)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrCommitNotSigned)
+		assert.ErrorContains(t, err, unsignedCommitID.String())
 	})
 
-	t.Run("network repository does not declare controller repository", func(t *testing.T) {
-		// This test does not use the helper, since we intentionally skip the
-		// step where the network repository adds the controller repository
-		controllerRepositoryLocation := t.TempDir()
-		networkRepositoryLocation := t.TempDir()
-
-		controllerRepository := gitinterface.CreateTestGitRepository(t, controllerRepositoryLocation, true)
-		controllerState := createTestStateWithGlobalConstraintThreshold(t)
-		controllerState.repository = controllerRepository
+	t.Run("require sign-off rule, commit without sign-off not mergeable", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignOff)
 
-		networkRepository := gitinterface.CreateTestGitRepository(t, networkRepositoryLocation, false)
-		networkState := createTestStateWithOnlyRoot(t)
-		networkState.repository = networkRepository
+		// We need to change the directory for this test because we `checkout`
+		// for older Git versions, modifying the worktree. This chdir ensures
+		// that the temporary directory is used as the worktree.
+		pwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(filepath.Join(repo.GetGitDir(), "..")); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(pwd) //nolint:errcheck
 
-		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signedOffCommitID, err := repo.CommitUsingSpecificKey(treeID, featureRefName, "Signed off commit\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n", gpgKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		controllerRootMetadata, err := controllerState.GetRootMetadata(false)
-		require.Nil(t, err)
-		controllerRootMetadata.SetRepositoryLocation(controllerRepositoryLocation)
-		err = controllerRootMetadata.EnableController()
-		require.Nil(t, err)
-		err = controllerRootMetadata.AddNetworkRepository("test", networkRepositoryLocation, []tuf.Principal{tufv01.NewKeyFromSSLibKey(signer.MetadataKey())})
-		require.Nil(t, err)
-		controllerRootEnv, err := dsse.CreateEnvelope(controllerRootMetadata)
-		require.Nil(t, err)
-		controllerRootEnv, err = dsse.SignEnvelope(testCtx, controllerRootEnv, signer)
-		require.Nil(t, err)
-		controllerState.Metadata.RootEnvelope = controllerRootEnv
-		err = controllerState.preprocess()
-		require.Nil(t, err)
-		err = controllerState.Commit(controllerRepository, "Initial policy\n", true, false)
-		require.Nil(t, err)
-		err = Apply(testCtx, controllerRepository, false)
-		require.Nil(t, err)
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, signedOffCommitID)
+		assert.Nil(t, err)
 
-		networkRootMetadata, err := networkState.GetRootMetadata(false)
-		require.Nil(t, err)
-		networkRootEnv, err := dsse.CreateEnvelope(networkRootMetadata)
-		require.Nil(t, err)
-		networkRootEnv, err = dsse.SignEnvelope(testCtx, networkRootEnv, signer)
-		require.Nil(t, err)
-		networkState.Metadata.RootEnvelope = networkRootEnv
-		err = networkState.preprocess()
-		require.Nil(t, err)
-		err = networkState.Commit(networkRepository, "Initial policy\n", true, false)
-		require.Nil(t, err)
-		err = Apply(testCtx, networkRepository, false)
-		require.Nil(t, err)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 1, gpgKeyBytes)
 
-		verifier := NewPolicyVerifier(controllerRepository)
-		err = verifier.VerifyNetwork(testCtx)
-		assert.ErrorIs(t, err, ErrNetworkRepositoryDoesNotDeclareRequiredController)
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, commitIDs[0])
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrCommitNotSignedOff)
+		assert.ErrorContains(t, err, commitIDs[0].String())
 	})
 
-	t.Run("repository state is stale", func(t *testing.T) {
-		controllerRepository, networkRepository := createControllerAndNetworkRepositories(t)
+	t.Run("require linear history rule, merge commit not mergeable", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithGlobalConstraintRequireLinearHistory)
 
-		controllerState, err := LoadCurrentState(testCtx, controllerRepository, PolicyRef)
-		require.Nil(t, err)
-		controllerRootMetadata, err := controllerState.GetRootMetadata(false)
-		require.Nil(t, err)
+		// We need to change the directory for this test because we `checkout`
+		// for older Git versions, modifying the worktree. This chdir ensures
+		// that the temporary directory is used as the worktree.
+		pwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(filepath.Join(repo.GetGitDir(), "..")); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(pwd) //nolint:errcheck
 
-		networkState, err := LoadCurrentState(testCtx, networkRepository, PolicyRef)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 1, gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, commitIDs[0])
+		assert.Nil(t, err)
+
+		// Merge in unrelated history
+		unrelatedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/unrelated", 1, gpgKeyBytes)
+		mergeCommitID := createTestMergeCommit(t, repo, featureRefName, commitIDs[0], unrelatedCommitIDs[0])
+
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, mergeCommitID)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrMergeCommitNotAllowed)
+		assert.ErrorContains(t, err, mergeCommitID.String())
+	})
+
+	t.Run("restrict files rule, denied file not mergeable", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithGlobalConstraintRestrictFiles)
+
+		// We need to change the directory for this test because we `checkout`
+		// for older Git versions, modifying the worktree. This chdir ensures
+		// that the temporary directory is used as the worktree.
+		pwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(filepath.Join(repo.GetGitDir(), "..")); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(pwd) //nolint:errcheck
+
+		commitWithFiles := func(message string, files map[string][]byte) gitinterface.Hash {
+			t.Helper()
+
+			entries := []gitinterface.TreeEntry{}
+			for name, contents := range files {
+				blobID, err := repo.WriteBlob(contents)
+				if err != nil {
+					t.Fatal(err)
+				}
+				entries = append(entries, gitinterface.NewEntryBlob(name, blobID))
+			}
+
+			treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			commitID, err := repo.Commit(treeID, featureRefName, message, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return commitID
+		}
+
+		commitID := commitWithFiles("Add README\n", map[string][]byte{"README.md": []byte("hello")})
+
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, commitID)
+		assert.Nil(t, err)
+
+		deniedCommitID := commitWithFiles("Add env file\n", map[string][]byte{"README.md": []byte("hello"), "config/.env": []byte("KEY=1")})
+
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, deniedCommitID)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrFileNotAllowed)
+		assert.ErrorContains(t, err, "config/.env")
+
+		largeCommitID := commitWithFiles("Add large file\n", map[string][]byte{"README.md": []byte("hello"), "large.bin": []byte("this file is larger than sixteen bytes")})
+
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, largeCommitID)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrBlobTooLarge)
+		assert.ErrorContains(t, err, "large.bin")
+	})
+
+	t.Run("restrict read rule, plaintext commit not mergeable", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithGlobalConstraintRestrictRead)
+
+		// We need to change the directory for this test because we `checkout`
+		// for older Git versions, modifying the worktree. This chdir ensures
+		// that the temporary directory is used as the worktree.
+		pwd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(filepath.Join(repo.GetGitDir(), "..")); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(pwd) //nolint:errcheck
+
+		blobID, err := repo.WriteBlob([]byte("embargoed fix"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries([]gitinterface.TreeEntry{gitinterface.NewEntryBlob("fix.go", blobID)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		readerKey := ssh.NewKeyFromBytes(t, targets2PubKeyBytes)
+		encryptedTreeID, err := encryption.EncryptTree(repo, treeID, []*signerverifier.SSLibKey{readerKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+		encryptedCommitID, err := repo.Commit(encryptedTreeID, featureRefName, "Encrypt fix\n", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, encryptedCommitID)
+		assert.Nil(t, err)
+
+		plaintextCommitID, err := repo.Commit(treeID, featureRefName, "Add fix\n", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, plaintextCommitID)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrReadRestrictedContentNotEncrypted)
+		assert.ErrorContains(t, err, plaintextCommitID.String())
+	})
+}
+
+func TestVerifyNetwork(t *testing.T) {
+	t.Run("repository is up-to-date", func(t *testing.T) {
+		controllerRepository, networkRepository := createControllerAndNetworkRepositories(t)
+
+		networkState, err := LoadCurrentState(testCtx, networkRepository, PolicyRef)
+		require.Nil(t, err)
+		networkRootMetadata, err := networkState.GetRootMetadata(false)
+		require.Nil(t, err)
+
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		require.Nil(t, err)
+
+		verifier := NewPolicyVerifier(controllerRepository)
+		err = verifier.VerifyNetwork(testCtx)
+		assert.Nil(t, err)
+	})
+
+	t.Run("propagation not performed", func(t *testing.T) {
+		controllerRepository, _ := createControllerAndNetworkRepositories(t)
+
+		verifier := NewPolicyVerifier(controllerRepository)
+		err := verifier.VerifyNetwork(testCtx)
+		assert.ErrorIs(t, err, gitinterface.ErrTreeDoesNotHavePath)
+	})
+
+	t.Run("network repository does not declare controller repository", func(t *testing.T) {
+		// This test does not use the helper, since we intentionally skip the
+		// step where the network repository adds the controller repository
+		controllerRepositoryLocation := t.TempDir()
+		networkRepositoryLocation := t.TempDir()
+
+		controllerRepository := gitinterface.CreateTestGitRepository(t, controllerRepositoryLocation, true)
+		controllerState := createTestStateWithGlobalConstraintThreshold(t)
+		controllerState.repository = controllerRepository
+
+		networkRepository := gitinterface.CreateTestGitRepository(t, networkRepositoryLocation, false)
+		networkState := createTestStateWithOnlyRoot(t)
+		networkState.repository = networkRepository
+
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+		controllerRootMetadata, err := controllerState.GetRootMetadata(false)
+		require.Nil(t, err)
+		controllerRootMetadata.SetRepositoryLocation(controllerRepositoryLocation)
+		err = controllerRootMetadata.EnableController()
+		require.Nil(t, err)
+		err = controllerRootMetadata.AddNetworkRepository("test", networkRepositoryLocation, []tuf.Principal{tufv01.NewKeyFromSSLibKey(signer.MetadataKey())})
+		require.Nil(t, err)
+		controllerRootEnv, err := dsse.CreateEnvelope(controllerRootMetadata)
+		require.Nil(t, err)
+		controllerRootEnv, err = dsse.SignEnvelope(testCtx, controllerRootEnv, signer)
+		require.Nil(t, err)
+		controllerState.Metadata.RootEnvelope = controllerRootEnv
+		err = controllerState.preprocess()
+		require.Nil(t, err)
+		err = controllerState.Commit(controllerRepository, "Initial policy\n", true, false)
+		require.Nil(t, err)
+		err = Apply(testCtx, controllerRepository, false)
+		require.Nil(t, err)
+
+		networkRootMetadata, err := networkState.GetRootMetadata(false)
+		require.Nil(t, err)
+		networkRootEnv, err := dsse.CreateEnvelope(networkRootMetadata)
+		require.Nil(t, err)
+		networkRootEnv, err = dsse.SignEnvelope(testCtx, networkRootEnv, signer)
+		require.Nil(t, err)
+		networkState.Metadata.RootEnvelope = networkRootEnv
+		err = networkState.preprocess()
+		require.Nil(t, err)
+		err = networkState.Commit(networkRepository, "Initial policy\n", true, false)
+		require.Nil(t, err)
+		err = Apply(testCtx, networkRepository, false)
+		require.Nil(t, err)
+
+		verifier := NewPolicyVerifier(controllerRepository)
+		err = verifier.VerifyNetwork(testCtx)
+		assert.ErrorIs(t, err, ErrNetworkRepositoryDoesNotDeclareRequiredController)
+	})
+
+	t.Run("repository state is stale", func(t *testing.T) {
+		controllerRepository, networkRepository := createControllerAndNetworkRepositories(t)
+
+		controllerState, err := LoadCurrentState(testCtx, controllerRepository, PolicyRef)
+		require.Nil(t, err)
+		controllerRootMetadata, err := controllerState.GetRootMetadata(false)
+		require.Nil(t, err)
+
+		networkState, err := LoadCurrentState(testCtx, networkRepository, PolicyRef)
 		require.Nil(t, err)
 		networkRootMetadata, err := networkState.GetRootMetadata(false)
 		require.Nil(t, err)
@@ -2150,8 +2603,9 @@ func TestVerifyNetwork(t *testing.T) {
 }
 
 func TestVerifyRelativeForRef(t *testing.T) {
-	t.Run("no recovery", func(t *testing.T) {
-		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	t.Run("policy expired at time of entry", func(t *testing.T) {
+		// The test clock is fixed at 1995-10-26T09:00:00Z
+		repo, _ := createTestRepositoryWithExpiringPolicy(t, time.Date(1995, time.October, 1, 0, 0, 0, 0, time.UTC))
 		refName := "refs/heads/main"
 
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
@@ -2166,68 +2620,101 @@ func TestVerifyRelativeForRef(t *testing.T) {
 
 		verifier := NewPolicyVerifier(repo)
 		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.Nil(t, err)
-
-		err = verifier.VerifyRelativeForRef(testCtx, entry, firstEntry, refName)
-		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+		assert.ErrorIs(t, err, ErrMetadataExpired)
 	})
 
-	t.Run("no recovery, first entry is the very first entry", func(t *testing.T) {
-		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	t.Run("policy expired after time of entry", func(t *testing.T) {
+		// The policy has expired now, but was valid when the entries were
+		// created
+		repo, _ := createTestRepositoryWithExpiringPolicy(t, time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC))
 		refName := "refs/heads/main"
 
-		firstEntry, _, err := rsl.GetFirstEntry(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
-
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+		firstEntry.ID = firstEntryID
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
 		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
 		verifier := NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
 		assert.Nil(t, err)
 
-		err = verifier.VerifyRelativeForRef(testCtx, entry, firstEntry, refName)
-		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+		// The expired policy can still be loaded, so that it can be renewed
+		_, err = LoadCurrentState(testCtx, repo, PolicyRef)
+		assert.Nil(t, err)
+
+		_, err = verifier.VerifyRef(testCtx, refName)
+		assert.ErrorIs(t, err, ErrMetadataExpired)
 	})
 
-	t.Run("no recovery, first entry is the very first entry but policy is not applied", func(t *testing.T) {
-		repo, _ := createTestRepository(t, createTestStateWithPolicy)
-		currentRSLTip, err := repo.GetReference(rsl.Ref)
-		if err != nil {
-			t.Fatal(err)
-		}
-		currentRSLTipParentIDs, err := repo.GetCommitParentIDs(currentRSLTip)
-		if err != nil {
-			t.Fatal(err)
-		}
-		if err := repo.SetReference(rsl.Ref, currentRSLTipParentIDs[0]); err != nil {
-			// Set to parent -> this is policy staging
-			t.Fatal(err)
-		}
+	t.Run("expired rule file fails by default", func(t *testing.T) {
+		// The root of trust is created by InitializeRootMetadata, with no
+		// extra setup to enforce expiry
+		repo, _ := createTestRepositoryWithExpiringPolicy(t, time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC))
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.VerifyRef(testCtx, refName)
+		assert.ErrorIs(t, err, ErrMetadataExpired)
+		assert.ErrorContains(t, err, "metadata for 'targets' expired")
+	})
 
+	t.Run("policy without expiry", func(t *testing.T) {
+		// Policies created before expiries were enforced may not declare one
+		repo, _ := createTestRepositoryWithPolicyExpiry(t, func(rootMetadata tuf.RootMetadata, targetsMetadata tuf.TargetsMetadata) {
+			rootMetadata.SetExpires("")
+			targetsMetadata.SetExpires("")
+		})
 		refName := "refs/heads/main"
 
-		firstEntry, _, err := rsl.GetFirstEntry(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		_, err := LoadCurrentState(testCtx, repo, PolicyRef)
+		assert.Nil(t, err)
+
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyRef(testCtx, refName)
+		assert.Nil(t, err)
+	})
+
+	t.Run("expired policy without expiry enforced", func(t *testing.T) {
+		// Roots of trust that opt out of expiry continue to verify after
+		// their expiry
+		repo, _ := createTestRepositoryWithPolicyExpiry(t, func(rootMetadata tuf.RootMetadata, targetsMetadata tuf.TargetsMetadata) {
+			if err := rootMetadata.SetExpiryEnforced(false); err != nil {
+				t.Fatal(err)
+			}
+			expires := time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+			rootMetadata.SetExpires(expires)
+			targetsMetadata.SetExpires(expires)
+		})
+		refName := "refs/heads/main"
 
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
 		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
+		_, err := LoadCurrentState(testCtx, repo, PolicyRef)
+		assert.Nil(t, err)
+
 		verifier := NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.ErrorIs(t, err, ErrPolicyNotFound)
+		_, err = verifier.VerifyRef(testCtx, refName)
+		assert.Nil(t, err)
 	})
 
-	t.Run("with recovery, commit-same, recovered by authorized user", func(t *testing.T) {
-		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	t.Run("key revoked after entry", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
 		refName := "refs/heads/main"
 
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
@@ -2244,28 +2731,164 @@ func TestVerifyRelativeForRef(t *testing.T) {
 		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
 		assert.Nil(t, err)
 
-		validCommitID := commitIDs[0] // track this for later
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgUnauthorizedKeyBytes)
-		entry = rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
-		entry.ID = entryID
-
-		// It's in an invalid state right now, error out
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
-
-		// Fix using the known-good commit
-		if err := repo.SetReference(refName, validCommitID); err != nil {
+		// Revoke the key used to sign both entries as of the first entry
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
 			t.Fatal(err)
 		}
-		// Create a skip annotation for the invalid entry
-		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
-		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
-		annotation.ID = annotationID
-		// Create a new entry moving branch back to valid commit
-		entry = rsl.NewReferenceEntry(refName, validCommitID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		rootMetadata, err := state.GetRootMetadata(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.RevokeKey(gpgKey.KeyID, firstEntryID.String()); err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata.IncrementVersion()
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.Metadata.RootEnvelope = rootEnv
+		if err := state.Commit(repo, "Revoke key", true, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(testCtx, repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// The first entry was signed before the revocation took effect
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, firstEntry, refName)
+		assert.Nil(t, err)
+
+		// The second entry was signed after the revocation took effect
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		err = verifier.VerifyRelativeForRef(testCtx, entry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("no recovery", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+		firstEntry.ID = firstEntryID
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		err = verifier.VerifyRelativeForRef(testCtx, entry, firstEntry, refName)
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+	})
+
+	t.Run("no recovery, first entry is the very first entry", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		firstEntry, _, err := rsl.GetFirstEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		err = verifier.VerifyRelativeForRef(testCtx, entry, firstEntry, refName)
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+	})
+
+	t.Run("no recovery, first entry is the very first entry but policy is not applied", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		currentRSLTip, err := repo.GetReference(rsl.Ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		currentRSLTipParentIDs, err := repo.GetCommitParentIDs(currentRSLTip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.SetReference(rsl.Ref, currentRSLTipParentIDs[0]); err != nil {
+			// Set to parent -> this is policy staging
+			t.Fatal(err)
+		}
+
+		refName := "refs/heads/main"
+
+		firstEntry, _, err := rsl.GetFirstEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrPolicyNotFound)
+	})
+
+	t.Run("with recovery, commit-same, recovered by authorized user", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+		firstEntry.ID = firstEntryID
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		validCommitID := commitIDs[0] // track this for later
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		// It's in an invalid state right now, error out
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		// Fix using the known-good commit
+		if err := repo.SetReference(refName, validCommitID); err != nil {
+			t.Fatal(err)
+		}
+		// Create a skip annotation for the invalid entry
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
+		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		annotation.ID = annotationID
+		// Create a new entry moving branch back to valid commit
+		entry = rsl.NewReferenceEntry(refName, validCommitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
 		// No error anymore
@@ -2699,102 +3322,661 @@ func TestVerifyRelativeForRef(t *testing.T) {
 		firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
 		firstEntry.ID = firstEntryID
 
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		validCommitID := commitIDs[0] // track this for later
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		// It's in an invalid state right now, error out
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		// Fix using the known-good commit's tree
+		validTreeID, err := repo.GetCommitTreeID(validCommitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		newCommitID, err := repo.CommitUsingSpecificKey(validTreeID, refName, "Revert invalid commit\n", gpgKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create a skip annotation for the invalid entry
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
+		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		annotation.ID = annotationID
+		// Create a new entry moving branch back to valid commit
+		entry = rsl.NewReferenceEntry(refName, newCommitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// No error anymore
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		// Skip the recovery entry as well
+		annotation = rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
+		annotationID = common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		annotation.ID = annotationID
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("with annotation but no fix entry", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+		firstEntry.ID = firstEntryID
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		// It's in an invalid state right now, error out
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		// Create a skip annotation for the invalid entry
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
+		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		annotation.ID = annotationID
+
+		// No fix entry, error out
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("with recovery when recovery is not needed", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithThresholdPolicy)
+		refName := "refs/heads/main"
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		authorization, err := authorizationsv02.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+		env, err := dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		firstValidCommitOnRef := commitIDs[0]
+
+		firstEntry, _, err := rsl.GetFirstEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// At this point, the verifier should pass; the change does not violate policy
+		verifier := NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		// Do this again so we have two successive valid changes
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+
+		commitTreeID, err = repo.GetCommitTreeID(commitIDs[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		authorization, err = authorizationsv02.NewReferenceAuthorizationForCommit(refName, firstValidCommitOnRef.String(), commitTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		env, err = dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, firstValidCommitOnRef.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// At this point, the verifier should pass; the change does not violate policy
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+
+		// Pretend the second valid change is actually invalid, "recover from
+		// it" but without the threshold validation
+		if err := repo.SetReference(refName, firstValidCommitOnRef); err != nil {
+			t.Fatal(err)
+		}
+		// Create a skip annotation for the invalid entry
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
+		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		annotation.ID = annotationID
+
+		// Create a new entry moving branch back to earlier commit
+		entry = rsl.NewReferenceEntry(refName, firstValidCommitOnRef)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// Verification results in error because recovery was not needed and the
+		// rollback is invalid
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("freeze window global rule", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithGlobalConstraintFreezeWindow)
+		refName := "refs/heads/main"
+
+		firstEntry, _, err := rsl.GetFirstEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		verifier := NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrFreezeWindowActive)
+	})
+}
+
+func TestVerifyEntry(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("successful verification", func(t *testing.T) {
+		for _, objectFormat := range []gitinterface.ObjectFormat{gitinterface.ObjectFormatSHA1, gitinterface.ObjectFormatSHA256} {
+			t.Run(string(objectFormat), func(t *testing.T) {
+				repo, state := createTestRepository(t, createTestStateWithPolicy, gitinterface.WithObjectFormat(objectFormat))
+
+				commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+				entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+				entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+				entry.ID = entryID
+
+				err := verifyEntry(testCtx, repo, state, nil, entry)
+				assert.Nil(t, err)
+			})
+		}
+	})
+
+	t.Run("successful verification using persons", func(t *testing.T) {
+		t.Setenv(dev.DevModeKey, "1")
+
+		repo, state := createTestRepository(t, createTestStateWithPolicyUsingPersons)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("successful verification with higher threshold using v0.1 reference authorization", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicy)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create authorization for this change
+		// We're explicitly using the old type here to ensure policy
+		// verification still works
+		authorization, err := authorizationsv01.NewReferenceAuthorization(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+		env, err := dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("successful verification with higher threshold using latest reference authorization", func(t *testing.T) {
+		for _, objectFormat := range []gitinterface.ObjectFormat{gitinterface.ObjectFormatSHA1, gitinterface.ObjectFormatSHA256} {
+			t.Run(string(objectFormat), func(t *testing.T) {
+				repo, state := createTestRepository(t, createTestStateWithThresholdPolicy, gitinterface.WithObjectFormat(objectFormat))
+
+				currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+				commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Create authorization for this change
+				// This uses the latest reference authorization version. The
+				// from revision is the repository's zero hash, matching what
+				// gittuf writes when attesting a reference's first entry.
+				fromID := repo.ZeroHash().String()
+				authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, fromID, commitTreeID.String())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+				env, err := dsse.CreateEnvelope(authorization)
+				if err != nil {
+					t.Fatal(err)
+				}
+				env, err = dsse.SignEnvelope(testCtx, env, signer)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, fromID, commitTreeID.String()); err != nil {
+					t.Fatal(err)
+				}
+				if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+					t.Fatal(err)
+				}
+
+				currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+				entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+				entry.ID = entryID
+
+				err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+				assert.Nil(t, err)
+			})
+		}
+	})
+
+	t.Run("successful verification with higher threshold but using GitHub approval", func(t *testing.T) {
+		t.Setenv(dev.DevModeKey, "1")
+
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrust)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// This is using the jane.doe signer
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create authorization for this change using john.doe trusted as approver
+		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"john.doe"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// This signer for the GitHub app is trusted in the root setup by the
+		// policy state creator helper
+		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+		env, err := dsse.CreateEnvelope(githubAppApproval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unsuccessful verification with higher threshold but using GitHub approval due to invalid app key", func(t *testing.T) {
+		t.Setenv(dev.DevModeKey, "1")
+
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrust)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// This is using the jane.doe signer
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Create authorization for this change using john.doe trusted as approver
+		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"john.doe"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// This signer for the GitHub app is NOT trusted in the root setup by
+		// the policy state creator helper
+		signer := setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
+
+		env, err := dsse.CreateEnvelope(githubAppApproval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("successful verification with higher threshold but using GitHub approval and reference authorization v0.2", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrustForMixedAttestations)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// This is the jane.doe principal
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Approved by jill.doe
+		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"jill.doe"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// This signer for the GitHub app is trusted in the root setup by the
+		// policy state creator helper
+		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+		env, err := dsse.CreateEnvelope(githubAppApproval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Add reference authorization for john.doe
+		signer = setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
+
+		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		env, err = dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
 		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
-		verifier := NewPolicyVerifier(repo)
-		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
 		assert.Nil(t, err)
+	})
 
-		validCommitID := commitIDs[0] // track this for later
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgUnauthorizedKeyBytes)
-		entry = rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
-		entry.ID = entryID
-
-		// It's in an invalid state right now, error out
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
+	t.Run("unsuccessful verification with higher threshold but using GitHub approval from untrusted key and reference authorization v0.2", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrustForMixedAttestations)
 
-		// Fix using the known-good commit's tree
-		validTreeID, err := repo.GetCommitTreeID(validCommitID)
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		newCommitID, err := repo.CommitUsingSpecificKey(validTreeID, refName, "Revert invalid commit\n", gpgKeyBytes)
+		// This is the jane.doe principal
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// Create a skip annotation for the invalid entry
-		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
-		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
-		annotation.ID = annotationID
-		// Create a new entry moving branch back to valid commit
-		entry = rsl.NewReferenceEntry(refName, newCommitID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		// Approved by jill.doe
+		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"jill.doe"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		// No error anymore
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.Nil(t, err)
+		// This signer for the GitHub app is NOT trusted in the root setup by
+		// the policy state creator helper
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
 
-		// Skip the recovery entry as well
-		annotation = rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
-		annotationID = common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
-		annotation.ID = annotationID
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
-	})
+		env, err := dsse.CreateEnvelope(githubAppApproval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	t.Run("with annotation but no fix entry", func(t *testing.T) {
-		repo, _ := createTestRepository(t, createTestStateWithPolicy)
-		refName := "refs/heads/main"
+		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
+			t.Fatal(err)
+		}
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
-		firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
-		firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
-		firstEntry.ID = firstEntryID
+		// Add reference authorization for john.doe
+		signer = setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
 
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
-		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		verifier := NewPolicyVerifier(repo)
-		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.Nil(t, err)
+		env, err = dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgUnauthorizedKeyBytes)
-		entry = rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
-		entry.ID = entryID
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+			t.Fatal(err)
+		}
 
-		// It's in an invalid state right now, error out
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		// Create a skip annotation for the invalid entry
-		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
-		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
-		annotation.ID = annotationID
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		// No fix entry, error out
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
 		assert.ErrorIs(t, err, ErrVerificationFailed)
 	})
 
-	t.Run("with recovery when recovery is not needed", func(t *testing.T) {
-		repo, _ := createTestRepository(t, createTestStateWithThresholdPolicy)
-		refName := "refs/heads/main"
+	t.Run("unsuccessful verification with higher threshold but using GitHub approval", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrust)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
@@ -2808,14 +3990,17 @@ func TestVerifyRelativeForRef(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		authorization, err := authorizationsv02.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		// Create approval for jill.doe -> NOT TRUSTED in this policy
+		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"jill.doe"}, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
 
+		// This signer for the GitHub app is trusted in the root setup by the
+		// policy state creator helper
 		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
 
-		env, err := dsse.CreateEnvelope(authorization)
+		env, err := dsse.CreateEnvelope(githubAppApproval)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2824,10 +4009,10 @@ func TestVerifyRelativeForRef(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
 			t.Fatal(err)
 		}
-		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
 			t.Fatal(err)
 		}
 
@@ -2840,32 +4025,36 @@ func TestVerifyRelativeForRef(t *testing.T) {
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
-		firstValidCommitOnRef := commitIDs[0]
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
 
-		firstEntry, _, err := rsl.GetFirstEntry(repo)
+	t.Run("unsuccessful verification with higher threshold when a person signs reference authorization and uses GitHub approval", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrustForMixedAttestations)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// At this point, the verifier should pass; the change does not violate policy
-		verifier := NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.Nil(t, err)
-
-		// Do this again so we have two successive valid changes
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
 
-		commitTreeID, err = repo.GetCommitTreeID(commitIDs[1])
+		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		authorization, err = authorizationsv02.NewReferenceAuthorizationForCommit(refName, firstValidCommitOnRef.String(), commitTreeID.String())
+		// Create approval for john.doe
+		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"john.doe"}, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		env, err = dsse.CreateEnvelope(authorization)
+		// This signer for the GitHub app is trusted in the root setup by the
+		// policy state creator helper
+		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+
+		env, err := dsse.CreateEnvelope(githubAppApproval)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2874,80 +4063,60 @@ func TestVerifyRelativeForRef(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, firstValidCommitOnRef.String(), commitTreeID.String()); err != nil {
+		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
 			t.Fatal(err)
 		}
-		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
 			t.Fatal(err)
 		}
 
-		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
-
-		// At this point, the verifier should pass; the change does not violate policy
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.Nil(t, err)
-
-		// Pretend the second valid change is actually invalid, "recover from
-		// it" but without the threshold validation
-		if err := repo.SetReference(refName, firstValidCommitOnRef); err != nil {
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
 			t.Fatal(err)
 		}
-		// Create a skip annotation for the invalid entry
-		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, true, "invalid entry")
-		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
-		annotation.ID = annotationID
-
-		// Create a new entry moving branch back to earlier commit
-		entry = rsl.NewReferenceEntry(refName, firstValidCommitOnRef)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
-
-		// Verification results in error because recovery was not needed and the
-		// rollback is invalid
-		verifier = NewPolicyVerifier(repo)
-		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
-	})
-}
-
-func TestVerifyEntry(t *testing.T) {
-	refName := "refs/heads/main"
 
-	t.Run("successful verification", func(t *testing.T) {
-		for _, objectFormat := range []gitinterface.ObjectFormat{gitinterface.ObjectFormatSHA1, gitinterface.ObjectFormatSHA256} {
-			t.Run(string(objectFormat), func(t *testing.T) {
-				repo, state := createTestRepository(t, createTestStateWithPolicy, gitinterface.WithObjectFormat(objectFormat))
+		// Add reference authorization for john.doe
+		signer = setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
 
-				commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
-				entry := rsl.NewReferenceEntry(refName, commitIDs[0])
-				entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-				entry.ID = entryID
+		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
 
-				err := verifyEntry(testCtx, repo, state, nil, entry)
-				assert.Nil(t, err)
-			})
+		env, err = dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
 		}
-	})
 
-	t.Run("successful verification using persons", func(t *testing.T) {
-		t.Setenv(dev.DevModeKey, "1")
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+			t.Fatal(err)
+		}
 
-		repo, state := createTestRepository(t, createTestStateWithPolicyUsingPersons)
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
 		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
-		err := verifyEntry(testCtx, repo, state, nil, entry)
-		assert.Nil(t, err)
+		// We have an RSL signature from jane.doe, a GitHub approval from
+		// john.doe and a reference authorization from john.doe
+		// Insufficient to meet threshold 3
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
 	})
 
-	t.Run("successful verification with higher threshold using v0.1 reference authorization", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithThresholdPolicy)
+	t.Run("successful verification with global threshold constraint", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintThreshold)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
@@ -2962,14 +4131,13 @@ func TestVerifyEntry(t *testing.T) {
 		}
 
 		// Create authorization for this change
-		// We're explicitly using the old type here to ensure policy
-		// verification still works
-		authorization, err := authorizationsv01.NewReferenceAuthorization(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		// This uses the latest reference authorization version
+		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes) // this is trusted in the global constraint state creator
 
 		env, err := dsse.CreateEnvelope(authorization)
 		if err != nil {
@@ -3000,77 +4168,14 @@ func TestVerifyEntry(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
-	t.Run("successful verification with higher threshold using latest reference authorization", func(t *testing.T) {
-		for _, objectFormat := range []gitinterface.ObjectFormat{gitinterface.ObjectFormatSHA1, gitinterface.ObjectFormatSHA256} {
-			t.Run(string(objectFormat), func(t *testing.T) {
-				repo, state := createTestRepository(t, createTestStateWithThresholdPolicy, gitinterface.WithObjectFormat(objectFormat))
-
-				currentAttestations, err := attestations.LoadCurrentAttestations(repo)
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
-
-				commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				// Create authorization for this change
-				// This uses the latest reference authorization version. The
-				// from revision is the repository's zero hash, matching what
-				// gittuf writes when attesting a reference's first entry.
-				fromID := repo.ZeroHash().String()
-				authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, fromID, commitTreeID.String())
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
-
-				env, err := dsse.CreateEnvelope(authorization)
-				if err != nil {
-					t.Fatal(err)
-				}
-				env, err = dsse.SignEnvelope(testCtx, env, signer)
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, fromID, commitTreeID.String()); err != nil {
-					t.Fatal(err)
-				}
-				if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
-					t.Fatal(err)
-				}
-
-				currentAttestations, err = attestations.LoadCurrentAttestations(repo)
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				entry := rsl.NewReferenceEntry(refName, commitIDs[0])
-				entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-				entry.ID = entryID
-
-				err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-				assert.Nil(t, err)
-			})
-		}
-	})
-
-	t.Run("successful verification with higher threshold but using GitHub approval", func(t *testing.T) {
-		t.Setenv(dev.DevModeKey, "1")
-
-		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrust)
+	t.Run("unsuccessful verification with global threshold constraint", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintThreshold)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This is using the jane.doe signer
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
 
 		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
@@ -3078,17 +4183,16 @@ func TestVerifyEntry(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		// Create authorization for this change using john.doe trusted as approver
-		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"john.doe"}, nil)
+		// Create authorization for this change
+		// This uses the latest reference authorization version
+		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This signer for the GitHub app is trusted in the root setup by the
-		// policy state creator helper
-		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes) // this is NOT trusted in the global constraint state creator
 
-		env, err := dsse.CreateEnvelope(githubAppApproval)
+		env, err := dsse.CreateEnvelope(authorization)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3097,10 +4201,10 @@ func TestVerifyEntry(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
 			t.Fatal(err)
 		}
-		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
+		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
 			t.Fatal(err)
 		}
 
@@ -3114,419 +4218,605 @@ func TestVerifyEntry(t *testing.T) {
 		entry.ID = entryID
 
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.Nil(t, err)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
 	})
 
-	t.Run("unsuccessful verification with higher threshold but using GitHub approval due to invalid app key", func(t *testing.T) {
-		t.Setenv(dev.DevModeKey, "1")
+	t.Run("verify block force pushes rule for protected ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintBlockForcePushes)
 
-		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrust)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This is using the jane.doe signer
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		// Only one entry, this is fine
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
 
-		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
-		if err != nil {
+		// Add more entries
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// Still fine
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		// Rewrite history altogether
+		// Delete ref
+		if err := repo.SetReference(refName, gitinterface.ZeroHash); err != nil {
 			t.Fatal(err)
 		}
 
-		// Create authorization for this change using john.doe trusted as approver
-		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"john.doe"}, nil)
+		// Switch up the key for good measure
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, rootKeyBytes)
+
+		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, rootKeyBytes)
+		entry.ID = entryID
+
+		// Not fine
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("verify block force pushes rule for unprotected ref", func(t *testing.T) {
+		refName := "refs/heads/feature"
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintBlockForcePushes)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This signer for the GitHub app is NOT trusted in the root setup by
-		// the policy state creator helper
-		signer := setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
+		// Only one entry, this is fine
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
 
-		env, err := dsse.CreateEnvelope(githubAppApproval)
+		// Add more entries
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// Still fine
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		// Rewrite history altogether
+		// Delete ref
+		if err := repo.SetReference(refName, gitinterface.ZeroHash); err != nil {
+			t.Fatal(err)
+		}
+
+		// Switch up the key for good measure
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, rootKeyBytes)
+
+		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, rootKeyBytes)
+		entry.ID = entryID
+
+		// Still fine; this ref is not protected
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("verify block force pushes rule for default branch with feature branch checked out", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintBlockForcePushesOnDefaultBranch)
+
+		// The default branch is declared in the root of trust, so it does not
+		// depend on what is checked out
+		if err := repo.SetSymbolicReference("HEAD", "refs/heads/feature"); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		// Rewrite history of main
+		if err := repo.SetReference(refName, gitinterface.ZeroHash); err != nil {
+			t.Fatal(err)
+		}
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		// The checked out branch is not protected
+		featureRefName := "refs/heads/feature"
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 2, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(featureRefName, commitIDs[1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		if err := repo.SetReference(featureRefName, gitinterface.ZeroHash); err != nil {
+			t.Fatal(err)
+		}
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 2, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(featureRefName, commitIDs[1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("verify require signed commits rule for protected ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignedCommits)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		// All commits signed by a principal in the policy
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		// Commits signed by a different principal than the RSL entry's signer
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, rootKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		// Unsigned commit followed by a signed commit
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(nil)
+		if err != nil {
 			t.Fatal(err)
 		}
-		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
+		unsignedCommitID, err := repo.Commit(treeID, refName, "Unsigned commit\n", false)
+		if err != nil {
 			t.Fatal(err)
 		}
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrCommitNotSigned)
+		assert.ErrorContains(t, err, unsignedCommitID.String())
+	})
+
+	t.Run("verify require signed commits rule with commit signed by unknown key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignedCommits)
 
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+
 		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrCommitNotSigned)
+		assert.ErrorContains(t, err, commitIDs[0].String())
 	})
 
-	t.Run("successful verification with higher threshold but using GitHub approval and reference authorization v0.2", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrustForMixedAttestations)
+	t.Run("verify require signed commits rule with commit signed by revoked key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignedCommits)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This is the jane.doe principal
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, rootKeyBytes)
 
-		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
-		if err != nil {
-			t.Fatal(err)
-		}
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		// Approved by jill.doe
-		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"jill.doe"}, nil)
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		rootKey := ssh.NewKeyFromBytes(t, rootPubKeyBytes)
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry, withRevokedKeyIDs(set.NewSetFromItems(rootKey.KeyID)))
+		assert.ErrorIs(t, err, ErrCommitNotSigned)
+		assert.ErrorContains(t, err, commitIDs[0].String())
+	})
+
+	t.Run("verify require signed commits rule for unprotected ref", func(t *testing.T) {
+		refName := "refs/heads/feature"
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignedCommits)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This signer for the GitHub app is trusted in the root setup by the
-		// policy state creator helper
-		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
-
-		env, err := dsse.CreateEnvelope(githubAppApproval)
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(nil)
 		if err != nil {
 			t.Fatal(err)
 		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		commitID, err := repo.Commit(treeID, refName, "Unsigned commit\n", false)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
-			t.Fatal(err)
-		}
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		// Add reference authorization for john.doe
-		signer = setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
 
-		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
-		if err != nil {
-			t.Fatal(err)
-		}
+	t.Run("verify require sign-off rule for protected ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignOff)
 
-		env, err = dsse.CreateEnvelope(authorization)
-		if err != nil {
-			t.Fatal(err)
-		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(nil)
+		if err != nil {
 			t.Fatal(err)
 		}
 
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		commitID, err := repo.CommitUsingSpecificKey(treeID, refName, "Signed off commit\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n", gpgKeyBytes)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry := rsl.NewReferenceEntry(refName, commitID)
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
 		assert.Nil(t, err)
+
+		// Commit without a sign-off
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrCommitNotSignedOff)
+		assert.ErrorContains(t, err, commitIDs[0].String())
 	})
 
-	t.Run("unsuccessful verification with higher threshold but using GitHub approval from untrusted key and reference authorization v0.2", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrustForMixedAttestations)
+	t.Run("verify require sign-off rule for unprotected ref", func(t *testing.T) {
+		refName := "refs/heads/feature"
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignOff)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This is the jane.doe principal
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
-		if err != nil {
-			t.Fatal(err)
-		}
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
 
-		// Approved by jill.doe
-		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"jill.doe"}, nil)
+	t.Run("verify require linear history rule for protected ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireLinearHistory)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// This signer for the GitHub app is NOT trusted in the root setup by
-		// the policy state creator helper
-		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
 
-		env, err := dsse.CreateEnvelope(githubAppApproval)
-		if err != nil {
-			t.Fatal(err)
-		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
-		if err != nil {
-			t.Fatal(err)
-		}
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
-			t.Fatal(err)
-		}
+		// Linear history, this is fine
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
 
-		// Add reference authorization for john.doe
-		signer = setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
+		// Merge in unrelated history
+		unrelatedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/unrelated", 1, gpgKeyBytes)
+		mergeCommitID := createTestMergeCommit(t, repo, refName, commitIDs[1], unrelatedCommitIDs[0])
 
-		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
-		if err != nil {
-			t.Fatal(err)
-		}
+		entry = rsl.NewReferenceEntry(refName, mergeCommitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		env, err = dsse.CreateEnvelope(authorization)
-		if err != nil {
-			t.Fatal(err)
-		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
-		if err != nil {
-			t.Fatal(err)
-		}
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrMergeCommitNotAllowed)
+		assert.ErrorContains(t, err, mergeCommitID.String())
+	})
 
-		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
-			t.Fatal(err)
-		}
+	t.Run("verify require linear history rule for unprotected ref", func(t *testing.T) {
+		refName := "refs/heads/feature"
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireLinearHistory)
 
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		unrelatedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/unrelated", 1, gpgKeyBytes)
+		mergeCommitID := createTestMergeCommit(t, repo, refName, commitIDs[0], unrelatedCommitIDs[0])
+
+		entry := rsl.NewReferenceEntry(refName, mergeCommitID)
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.Nil(t, err)
 	})
 
-	t.Run("unsuccessful verification with higher threshold but using GitHub approval", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrust)
+	t.Run("verify restrict files rule for protected ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRestrictFiles)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		commitWithFiles := func(message string, files map[string][]byte) gitinterface.Hash {
+			t.Helper()
 
-		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
-		if err != nil {
-			t.Fatal(err)
-		}
+			entries := []gitinterface.TreeEntry{}
+			for name, contents := range files {
+				blobID, err := repo.WriteBlob(contents)
+				if err != nil {
+					t.Fatal(err)
+				}
+				entries = append(entries, gitinterface.NewEntryBlob(name, blobID))
+			}
 
-		// Create approval for jill.doe -> NOT TRUSTED in this policy
-		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"jill.doe"}, nil)
-		if err != nil {
-			t.Fatal(err)
-		}
+			treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(entries)
+			if err != nil {
+				t.Fatal(err)
+			}
 
-		// This signer for the GitHub app is trusted in the root setup by the
-		// policy state creator helper
-		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+			commitID, err := repo.Commit(treeID, refName, message, false)
+			if err != nil {
+				t.Fatal(err)
+			}
 
-		env, err := dsse.CreateEnvelope(githubAppApproval)
-		if err != nil {
-			t.Fatal(err)
-		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
-		if err != nil {
-			t.Fatal(err)
+			return commitID
 		}
 
-		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
-			t.Fatal(err)
-		}
+		// Small file that isn't denied, this is fine
+		commitID := commitWithFiles("Add README\n", map[string][]byte{"README.md": []byte("hello")})
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
 
-		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		// Denied file in a subdirectory
+		deniedCommitID := commitWithFiles("Add env file\n", map[string][]byte{"README.md": []byte("hello"), "config/.env": []byte("KEY=1")})
+		entry = rsl.NewReferenceEntry(refName, deniedCommitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
 		assert.ErrorIs(t, err, ErrVerificationFailed)
-	})
+		assert.ErrorIs(t, err, ErrFileNotAllowed)
+		assert.ErrorContains(t, err, deniedCommitID.String())
+		assert.ErrorContains(t, err, "config/.env")
 
-	t.Run("unsuccessful verification with higher threshold when a person signs reference authorization and uses GitHub approval", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithThresholdPolicyAndGitHubAppTrustForMixedAttestations)
+		// Removing the denied file is fine
+		commitID = commitWithFiles("Remove env file\n", map[string][]byte{"README.md": []byte("hello")})
+		entry = rsl.NewReferenceEntry(refName, commitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		// Blob larger than the maximum size
+		largeCommitID := commitWithFiles("Add large file\n", map[string][]byte{"README.md": []byte("hello"), "large.bin": []byte("this file is larger than sixteen bytes")})
+		entry = rsl.NewReferenceEntry(refName, largeCommitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrBlobTooLarge)
+		assert.ErrorContains(t, err, largeCommitID.String())
+		assert.ErrorContains(t, err, "large.bin")
+	})
+
+	t.Run("verify restrict files rule for unprotected ref", func(t *testing.T) {
+		refName := "refs/heads/feature"
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRestrictFiles)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// Create approval for john.doe
-		githubAppApproval, err := attestations.NewGitHubPullRequestApprovalAttestation(refName, gitinterface.ZeroHash.String(), commitTreeID.String(), []string{"john.doe"}, nil)
+		blobID, err := repo.WriteBlob([]byte("KEY=1"))
 		if err != nil {
 			t.Fatal(err)
 		}
-
-		// This signer for the GitHub app is trusted in the root setup by the
-		// policy state creator helper
-		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
-
-		env, err := dsse.CreateEnvelope(githubAppApproval)
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries([]gitinterface.TreeEntry{gitinterface.NewEntryBlob(".env", blobID)})
 		if err != nil {
 			t.Fatal(err)
 		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		commitID, err := repo.Commit(treeID, refName, "Add env file\n", false)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetGitHubPullRequestApprovalAttestation(repo, env, "https://github.com", 1, tuf.GitHubAppRoleName, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add GitHub pull request approval", true, false); err != nil {
-			t.Fatal(err)
-		}
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
 
-		// Add reference authorization for john.doe
-		signer = setupSSHKeysForSigning(t, targets2KeyBytes, targets2PubKeyBytes)
+	t.Run("verify restrict read rule for protected ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRestrictRead)
 
-		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		env, err = dsse.CreateEnvelope(authorization)
+		blobID, err := repo.WriteBlob([]byte("embargoed fix"))
 		if err != nil {
 			t.Fatal(err)
 		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries([]gitinterface.TreeEntry{gitinterface.NewEntryBlob("fix.go", blobID)})
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
-			t.Fatal(err)
-		}
-		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
-			t.Fatal(err)
-		}
-
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		// Plaintext contents are rejected
+		plaintextCommitID, err := repo.Commit(treeID, refName, "Add fix\n", false)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry := rsl.NewReferenceEntry(refName, plaintextCommitID)
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
-		// We have an RSL signature from jane.doe, a GitHub approval from
-		// john.doe and a reference authorization from john.doe
-		// Insufficient to meet threshold 3
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
 		assert.ErrorIs(t, err, ErrVerificationFailed)
-	})
-
-	t.Run("successful verification with global threshold constraint", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintThreshold)
+		assert.ErrorIs(t, err, ErrReadRestrictedContentNotEncrypted)
+		assert.ErrorContains(t, err, plaintextCommitID.String())
 
-		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		// Contents encrypted for someone other than the reader are rejected
+		otherKey := ssh.NewKeyFromBytes(t, targets1PubKeyBytes)
+		encryptedTreeID, err := encryption.EncryptTree(repo, treeID, []*signerverifier.SSLibKey{otherKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wrongReaderCommitID, err := repo.Commit(encryptedTreeID, refName, "Encrypt fix for wrong reader\n", false)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, wrongReaderCommitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		commitTreeID, err := repo.GetCommitTreeID(commitIDs[0])
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrReadRestrictedContentNotEncrypted)
+		assert.ErrorContains(t, err, wrongReaderCommitID.String())
+
+		// Contents encrypted for the reader are fine
+		readerKey := ssh.NewKeyFromBytes(t, targets2PubKeyBytes)
+		encryptedTreeID, err = encryption.EncryptTree(repo, treeID, []*signerverifier.SSLibKey{readerKey})
 		if err != nil {
 			t.Fatal(err)
 		}
-
-		// Create authorization for this change
-		// This uses the latest reference authorization version
-		authorization, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), commitTreeID.String())
+		commitID, err := repo.Commit(encryptedTreeID, refName, "Encrypt fix\n", false)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes) // this is trusted in the global constraint state creator
+		entry = rsl.NewReferenceEntry(refName, commitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
 
-		env, err := dsse.CreateEnvelope(authorization)
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		// Encrypted contents with a plaintext file mixed in are rejected
+		encryptedFiles, err := repo.GetAllFilesInTree(encryptedTreeID)
 		if err != nil {
 			t.Fatal(err)
 		}
-		env, err = dsse.SignEnvelope(testCtx, env, signer)
-		if err != nil {
-			t.Fatal(err)
+		mixedEntries := []gitinterface.TreeEntry{gitinterface.NewEntryBlob("leak.go", blobID)}
+		for path, encryptedBlobID := range encryptedFiles {
+			mixedEntries = append(mixedEntries, gitinterface.NewEntryBlob(path, encryptedBlobID))
 		}
-
-		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, gitinterface.ZeroHash.String(), commitTreeID.String()); err != nil {
+		mixedTreeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(mixedEntries)
+		if err != nil {
 			t.Fatal(err)
 		}
-		if err := currentAttestations.Commit(repo, "Add authorization", true, false); err != nil {
+		mixedCommitID, err := repo.Commit(mixedTreeID, refName, "Add plaintext file\n", false)
+		if err != nil {
 			t.Fatal(err)
 		}
 
-		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		entry = rsl.NewReferenceEntry(refName, mixedCommitID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrReadRestrictedContentNotEncrypted)
+		assert.ErrorIs(t, err, encryption.ErrFileNotInManifest)
+		assert.ErrorContains(t, err, "leak.go")
+	})
+
+	t.Run("verify freeze window rule for protected ref", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintFreezeWindow)
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
 		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
+		// The entry is recorded during the freeze with only one approval
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.Nil(t, err)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorIs(t, err, ErrFreezeWindowActive)
+		assert.ErrorContains(t, err, "freeze-main")
 	})
 
-	t.Run("unsuccessful verification with global threshold constraint", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintThreshold)
+	t.Run("verify freeze window rule with override threshold met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintFreezeWindow)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
@@ -3547,7 +4837,7 @@ func TestVerifyEntry(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		signer := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes) // this is NOT trusted in the global constraint state creator
+		signer := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes) // this is trusted in the freeze window state creator
 
 		env, err := dsse.CreateEnvelope(authorization)
 		if err != nil {
@@ -3574,99 +4864,25 @@ func TestVerifyEntry(t *testing.T) {
 		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
-		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
-	})
-
-	t.Run("verify block force pushes rule for protected ref", func(t *testing.T) {
-		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintBlockForcePushes)
-
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
-
-		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
-
-		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		// Only one entry, this is fine
-		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.Nil(t, err)
-
-		// Add more entries
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
-		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
-
-		// Still fine
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
 		assert.Nil(t, err)
-
-		// Rewrite history altogether
-		// Delete ref
-		if err := repo.SetReference(refName, gitinterface.ZeroHash); err != nil {
-			t.Fatal(err)
-		}
-
-		// Switch up the key for good measure
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, rootKeyBytes)
-
-		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, rootKeyBytes)
-		entry.ID = entryID
-
-		// Not fine
-		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.ErrorIs(t, err, ErrVerificationFailed)
 	})
 
-	t.Run("verify block force pushes rule for unprotected ref", func(t *testing.T) {
+	t.Run("verify freeze window rule for unprotected ref", func(t *testing.T) {
 		refName := "refs/heads/feature"
-		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintBlockForcePushes)
-
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
-
-		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintFreezeWindow)
 
 		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		// Only one entry, this is fine
-		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.Nil(t, err)
-
-		// Add more entries
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
-		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
-
-		// Still fine
-		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
-		assert.Nil(t, err)
-
-		// Rewrite history altogether
-		// Delete ref
-		if err := repo.SetReference(refName, gitinterface.ZeroHash); err != nil {
-			t.Fatal(err)
-		}
-
-		// Switch up the key for good measure
-		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, rootKeyBytes)
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
 
-		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, rootKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 		entry.ID = entryID
 
-		// Still fine; this ref is not protected
 		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
 		assert.Nil(t, err)
 	})
@@ -4154,3 +5370,539 @@ func getPropagationDirectivesForNetworkRepository(t *testing.T, rootMetadata tuf
 
 	return directives
 }
+
+func TestVerifyGitObjectAndAttestationsUsingVerifiersWithHats(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv02.NewKeyFromSSLibKey(gpgKeyR)
+
+	aliceSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	aliceKey := tufv02.NewKeyFromSSLibKey(aliceSigner.MetadataKey())
+
+	bobSigner := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+	bobKey := tufv02.NewKeyFromSSLibKey(bobSigner.MetadataKey())
+
+	alice := &tufv02.Person{
+		PersonID:             "alice",
+		PublicKeys:           map[string]*tufv02.Key{aliceKey.KeyID: aliceKey},
+		AssociatedIdentities: map[string]string{tuf.GitHubAppRoleName: "alice-github"},
+	}
+	bob := &tufv02.Person{
+		PersonID:   "bob",
+		PublicKeys: map[string]*tufv02.Key{bobKey.KeyID: bobKey},
+	}
+	carol := &tufv02.Person{
+		PersonID:   "carol",
+		PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey},
+	}
+	maintainers := &tufv02.Team{
+		TeamID:       "maintainers",
+		PrincipalIDs: set.NewSetFromItems(alice.PersonID, carol.PersonID),
+		Threshold:    1,
+	}
+	security := &tufv02.Team{
+		TeamID:       "security",
+		PrincipalIDs: set.NewSetFromItems(alice.PersonID, bob.PersonID),
+		Threshold:    1,
+	}
+	allPrincipals := map[string]tuf.Principal{
+		alice.PersonID:     alice,
+		bob.PersonID:       bob,
+		carol.PersonID:     carol,
+		maintainers.TeamID: maintainers,
+		security.TeamID:    security,
+	}
+
+	rule := &tufv02.Delegation{
+		Name:  "protect-main",
+		Paths: []string{"git:refs/heads/main"},
+		Role: tufv02.Role{
+			PrincipalIDs: set.NewSetFromItems(maintainers.TeamID, security.TeamID),
+			Threshold:    2,
+		},
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	commitID := commitIDs[0]
+	treeID, err := repo.GetCommitTreeID(commitID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createAuthorization := func(t *testing.T, hat string, signer *ssh.Signer) *sslibdsse.Envelope {
+		t.Helper()
+
+		var (
+			statement *ita.Statement
+			err       error
+		)
+		if hat == "" {
+			statement, err = attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), treeID.String())
+		} else {
+			statement, err = attestations.NewReferenceAuthorizationForCommitWithHat(refName, gitinterface.ZeroHash.String(), treeID.String(), hat)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		env, err := dsse.CreateEnvelope(statement)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return env
+	}
+
+	tests := map[string]struct {
+		gitID                    gitinterface.Hash
+		authorizationAttestation *sslibdsse.Envelope
+		hats                     *hatApprovals
+		expectedError            error
+	}{
+		"unhatted authorization counts for only one team": {
+			gitID:                    gitinterface.ZeroHash,
+			authorizationAttestation: createAuthorization(t, "", aliceSigner),
+			expectedError:            ErrVerifierConditionsUnmet,
+		},
+		"hatted authorization counts only for its team": {
+			gitID: gitinterface.ZeroHash,
+			hats: &hatApprovals{
+				authorizations: map[string]*sslibdsse.Envelope{security.TeamID: createAuthorization(t, security.TeamID, aliceSigner)},
+			},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"hatted authorization and commit signature by another team's member": {
+			gitID: commitID,
+			hats: &hatApprovals{
+				authorizations: map[string]*sslibdsse.Envelope{security.TeamID: createAuthorization(t, security.TeamID, aliceSigner)},
+			},
+		},
+		"hatted authorization and hatted code review approval by the same person": {
+			gitID: gitinterface.ZeroHash,
+			hats: &hatApprovals{
+				authorizations: map[string]*sslibdsse.Envelope{security.TeamID: createAuthorization(t, security.TeamID, aliceSigner)},
+				approverIDs:    map[string]*set.Set[string]{maintainers.TeamID: set.NewSetFromItems("alice-github")},
+			},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"hatted authorization and code review approval in the same hat": {
+			gitID: gitinterface.ZeroHash,
+			hats: &hatApprovals{
+				authorizations: map[string]*sslibdsse.Envelope{security.TeamID: createAuthorization(t, security.TeamID, aliceSigner)},
+				approverIDs:    map[string]*set.Set[string]{security.TeamID: set.NewSetFromItems("alice-github")},
+			},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"hatted authorization signed by non-member of team": {
+			gitID: gitinterface.ZeroHash,
+			hats: &hatApprovals{
+				authorizations: map[string]*sslibdsse.Envelope{security.TeamID: createAuthorization(t, security.TeamID, aliceSigner), maintainers.TeamID: createAuthorization(t, maintainers.TeamID, bobSigner)},
+			},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+	}
+
+	for name, test := range tests {
+		verifiers := []*SignatureVerifier{newSignatureVerifierForRule(repo, rule, allPrincipals)}
+
+		_, _, _, err := verifyGitObjectAndAttestationsUsingVerifiers(testCtx, verifiers, test.gitID, nil, test.authorizationAttestation, []string{tuf.GitHubAppRoleName}, nil, test.hats, false)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error in test '%s'", name))
+		}
+	}
+}
+
+func TestVerifyGitObjectAndAttestationsUsingVerifiersWithSeparationOfDuties(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv02.NewKeyFromSSLibKey(gpgKeyR)
+
+	aliceSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	aliceKey := tufv02.NewKeyFromSSLibKey(aliceSigner.MetadataKey())
+
+	bobSigner := setupSSHKeysForSigning(t, targets1KeyBytes, targets1PubKeyBytes)
+	bobKey := tufv02.NewKeyFromSSLibKey(bobSigner.MetadataKey())
+
+	alice := &tufv02.Person{
+		PersonID:   "alice",
+		PublicKeys: map[string]*tufv02.Key{aliceKey.KeyID: aliceKey},
+	}
+	bob := &tufv02.Person{
+		PersonID:   "bob",
+		PublicKeys: map[string]*tufv02.Key{bobKey.KeyID: bobKey},
+	}
+	carol := &tufv02.Person{
+		PersonID:             "carol",
+		PublicKeys:           map[string]*tufv02.Key{gpgKey.KeyID: gpgKey},
+		AssociatedIdentities: map[string]string{tuf.GitHubAppRoleName: "carol-github"},
+	}
+	// dave's username could be mistaken for an author email
+	dave := &tufv02.Person{
+		PersonID:             "dave",
+		AssociatedIdentities: map[string]string{tuf.GitHubAppRoleName: "dave-github"},
+	}
+	// jane authors the commit, but does not sign it
+	jane := &tufv02.Person{
+		PersonID: "jane",
+		AssociatedIdentities: map[string]string{
+			"email":               "Jane.Doe@example.com",
+			tuf.GitHubAppRoleName: "jane-github",
+		},
+	}
+	maintainers := &tufv02.Team{
+		TeamID:       "maintainers",
+		PrincipalIDs: set.NewSetFromItems(bob.PersonID, carol.PersonID),
+		Threshold:    1,
+	}
+	allPrincipals := map[string]tuf.Principal{
+		alice.PersonID:     alice,
+		bob.PersonID:       bob,
+		carol.PersonID:     carol,
+		jane.PersonID:      jane,
+		dave.PersonID:      dave,
+		maintainers.TeamID: maintainers,
+	}
+
+	refName := "refs/heads/main"
+	// The commit is authored by jane, and the RSL entry that pushes it is
+	// made by someone else and signed by carol
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	treeID, err := repo.GetCommitTreeID(commitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SetGitConfig("user.email", "release-bot@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+	entry.ID = entryID
+
+	// As in verifyGitObjectAndAttestations, the authors of the commits
+	// introduced by the RSL entry are excluded
+	entryCommitIDs, err := getCommits(repo, entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// This commit is signed by bob, but its author email is not declared by
+	// any principal
+	bobCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/feature", 1, targets1KeyBytes)
+
+	// This commit's author email matches dave's username, which is not an
+	// email address
+	if err := repo.SetGitConfig("user.email", "dave-github"); err != nil {
+		t.Fatal(err)
+	}
+	usernameCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/username", 1, gpgKeyBytes)
+
+	createAuthorization := func(t *testing.T, signers ...*ssh.Signer) *sslibdsse.Envelope {
+		t.Helper()
+
+		statement, err := attestations.NewReferenceAuthorizationForCommit(refName, gitinterface.ZeroHash.String(), treeID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		env, err := dsse.CreateEnvelope(statement)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, signer := range signers {
+			env, err = dsse.SignEnvelope(testCtx, env, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return env
+	}
+
+	tests := map[string]struct {
+		principalIDs             []string
+		separationOfDuties       bool
+		gitID                    gitinterface.Hash
+		authoredCommitIDs        []gitinterface.Hash
+		authorizationAttestation *sslibdsse.Envelope
+		approverIDs              *set.Set[string]
+		verifyMergeable          bool
+		expectedError            error
+	}{
+		"author and another approver, separation of duties not required": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID, carol.PersonID},
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+		},
+		"author and another approver": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID, carol.PersonID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+			expectedError:            ErrSeparationOfDutiesNotMet,
+		},
+		"author's code review approval is disregarded": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID, carol.PersonID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+			approverIDs:              set.NewSetFromItems("carol-github"),
+			expectedError:            ErrSeparationOfDutiesNotMet,
+		},
+		"author and two other approvers": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID, carol.PersonID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner, bobSigner),
+		},
+		"author's membership in team is disregarded": {
+			principalIDs:             []string{alice.PersonID, maintainers.TeamID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+			expectedError:            ErrSeparationOfDutiesNotMet,
+		},
+		"author's team met by another member": {
+			principalIDs:             []string{alice.PersonID, maintainers.TeamID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner, bobSigner),
+		},
+		"commit author's code review approval is disregarded": {
+			principalIDs:             []string{alice.PersonID, jane.PersonID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+			approverIDs:              set.NewSetFromItems("jane-github"),
+			expectedError:            ErrSeparationOfDutiesNotMet,
+		},
+		"commit author's code review approval counts without separation of duties": {
+			principalIDs:             []string{alice.PersonID, jane.PersonID},
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+			approverIDs:              set.NewSetFromItems("jane-github"),
+		},
+		"commit author and two other approvers": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID, jane.PersonID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authorizationAttestation: createAuthorization(t, aliceSigner, bobSigner),
+			approverIDs:              set.NewSetFromItems("jane-github"),
+		},
+		"commit signer's approval is disregarded regardless of author email": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authoredCommitIDs:        bobCommitIDs,
+			authorizationAttestation: createAuthorization(t, aliceSigner, bobSigner),
+			expectedError:            ErrSeparationOfDutiesNotMet,
+		},
+		"commit signer's approval counts without separation of duties": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID},
+			gitID:                    entryID,
+			authoredCommitIDs:        bobCommitIDs,
+			authorizationAttestation: createAuthorization(t, aliceSigner, bobSigner),
+		},
+		"author email is not compared with usernames": {
+			principalIDs:             []string{alice.PersonID, dave.PersonID},
+			separationOfDuties:       true,
+			gitID:                    entryID,
+			authoredCommitIDs:        usernameCommitIDs,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+			approverIDs:              set.NewSetFromItems("dave-github"),
+		},
+		"approvers without signed Git object": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID, carol.PersonID},
+			separationOfDuties:       true,
+			gitID:                    gitinterface.ZeroHash,
+			authorizationAttestation: createAuthorization(t, aliceSigner, bobSigner),
+		},
+		"mergeable with one approval does not assume merger is not the author": {
+			principalIDs:             []string{alice.PersonID, bob.PersonID, carol.PersonID},
+			separationOfDuties:       true,
+			gitID:                    gitinterface.ZeroHash,
+			authorizationAttestation: createAuthorization(t, aliceSigner),
+			verifyMergeable:          true,
+			expectedError:            ErrVerifierConditionsUnmet,
+		},
+	}
+
+	for name, test := range tests {
+		rule := &tufv02.Delegation{
+			Name:               "protect-main",
+			Paths:              []string{"git:refs/heads/main"},
+			SeparationOfDuties: test.separationOfDuties,
+			Role: tufv02.Role{
+				PrincipalIDs: set.NewSetFromItems(test.principalIDs...),
+				Threshold:    2,
+			},
+		}
+		verifiers := []*SignatureVerifier{newSignatureVerifierForRule(repo, rule, allPrincipals)}
+
+		authoredCommitIDs := test.authoredCommitIDs
+		if authoredCommitIDs == nil && !test.gitID.IsZero() {
+			authoredCommitIDs = entryCommitIDs
+		}
+
+		_, _, _, err := verifyGitObjectAndAttestationsUsingVerifiers(testCtx, verifiers, test.gitID, authoredCommitIDs, test.authorizationAttestation, []string{tuf.GitHubAppRoleName}, test.approverIDs, nil, test.verifyMergeable)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error in test '%s'", name))
+		}
+	}
+}
+
+func TestVerifyCommitsSignedOff(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRequireSignOff)
+
+	state.allPrincipals["jane.doe"] = &tufv02.Person{
+		PersonID: "jane.doe",
+		AssociatedIdentities: map[string]string{
+			"email": "Jane.Doe@example.com",
+			"work":  "jane@corp.example.com",
+		},
+	}
+	state.allPrincipals["john.doe"] = &tufv02.Person{
+		PersonID: "john.doe",
+		AssociatedIdentities: map[string]string{
+			"email": "John.Doe@example.com",
+		},
+	}
+
+	treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The test repository's author is Jane Doe <jane.doe@example.com>
+	tests := map[string]struct {
+		message                   string
+		allowAssociatedIdentities bool
+		expectedError             error
+	}{
+		"sign-off matches author": {
+			message: "Test commit\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n",
+		},
+		"sign-off matches author with different case": {
+			message: "Test commit\n\nSigned-off-by: Jane Doe <Jane.Doe@example.com>\n",
+		},
+		"one of multiple sign-offs matches author": {
+			message: "Test commit\n\nSigned-off-by: John Doe <john.doe@example.com>\nSigned-off-by: Jane Doe <jane.doe@example.com>\n",
+		},
+		"no sign-off": {
+			message:       "Test commit\n",
+			expectedError: ErrCommitNotSignedOff,
+		},
+		"sign-off does not match author": {
+			message:       "Test commit\n\nSigned-off-by: John Doe <john.doe@example.com>\n",
+			expectedError: ErrCommitNotSignedOff,
+		},
+		"malformed sign-off": {
+			message:       "Test commit\n\nSigned-off-by: jane.doe\n",
+			expectedError: ErrCommitNotSignedOff,
+		},
+		"sign-off matches associated identity of author": {
+			message:                   "Test commit\n\nSigned-off-by: Jane Doe <jane@corp.example.com>\n",
+			allowAssociatedIdentities: true,
+		},
+		"sign-off matches associated identity of author when not allowed": {
+			message:       "Test commit\n\nSigned-off-by: Jane Doe <jane@corp.example.com>\n",
+			expectedError: ErrCommitNotSignedOff,
+		},
+		"sign-off matches associated identity of another person": {
+			message:                   "Test commit\n\nSigned-off-by: John Doe <john.doe@example.com>\n",
+			allowAssociatedIdentities: true,
+			expectedError:             ErrCommitNotSignedOff,
+		},
+		"sign-off does not match author or associated identity": {
+			message:                   "Test commit\n\nSigned-off-by: Jill Doe <jill.doe@example.com>\n",
+			allowAssociatedIdentities: true,
+			expectedError:             ErrCommitNotSignedOff,
+		},
+	}
+
+	for name, test := range tests {
+		commitID, err := repo.Commit(treeID, "refs/heads/main", test.message, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = verifyCommitsSignedOff(state, []gitinterface.Hash{commitID}, test.allowAssociatedIdentities)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error '%v' in test '%s'", err, name))
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error '%v', expected '%v' in test '%s'", err, test.expectedError, name))
+		}
+	}
+}
+
+func TestVerifyCommitsEncryptedForReaders(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithGlobalConstraintRestrictRead)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv02.NewKeyFromSSLibKey(gpgKeyR)
+	sshKey := ssh.NewKeyFromBytes(t, targets2PubKeyBytes)
+
+	// A reader with a GPG key in addition to an SSH key only needs the
+	// contents to be encrypted for the SSH key
+	state.allPrincipals["alice"] = &tufv02.Person{
+		PersonID:   "alice",
+		PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey, sshKey.KeyID: tufv02.NewKeyFromSSLibKey(sshKey)},
+	}
+	state.allPrincipals["bob"] = &tufv02.Person{
+		PersonID:   "bob",
+		PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey},
+	}
+
+	blobID, err := repo.WriteBlob([]byte("embargoed fix"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries([]gitinterface.TreeEntry{gitinterface.NewEntryBlob("fix.go", blobID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedTreeID, err := encryption.EncryptTree(repo, treeID, []*signerverifier.SSLibKey{sshKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := repo.Commit(encryptedTreeID, "refs/heads/main", "Encrypt fix\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := tufv01.NewGlobalRuleRestrictRead("restrict-read-main", []string{"git:refs/heads/main"}, []string{"alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = verifyCommitsEncryptedForReaders(state, []gitinterface.Hash{commitID}, rule)
+	assert.Nil(t, err)
+
+	// A reader without a key that contents can be encrypted for is reported
+	rule, err = tufv01.NewGlobalRuleRestrictRead("restrict-read-main", []string{"git:refs/heads/main"}, []string{"alice", "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = verifyCommitsEncryptedForReaders(state, []gitinterface.Hash{commitID}, rule)
+	assert.ErrorIs(t, err, ErrReaderHasNoEncryptionKey)
+}
@@ -0,0 +1,80 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// PathAuthorization describes a rule or global threshold rule that a change to
+// a path must satisfy. For global rules, Principals is empty as approvals from
+// any principal declared in the policy count towards the threshold, and
+// ControllerName identifies the controller repository that declared the rule,
+// if any.
+type PathAuthorization struct {
+	Name               string
+	IsGlobalRule       bool
+	ControllerName     string
+	Threshold          int
+	SeparationOfDuties bool
+	Principals         []tuf.Principal
+	TeamMembers        map[string][]tuf.Principal
+}
+
+// FindAuthorizationsForPath returns the rules that apply to the path, as found
+// by FindVerifiersForPath, followed by the global threshold rules that match
+// it. The path must be prefixed with the namespace's scheme, e.g.,
+// "git:refs/heads/main" or "file:src/main.go".
+func (s *State) FindAuthorizationsForPath(path string) ([]*PathAuthorization, error) {
+	authorizations := []*PathAuthorization{}
+
+	verifiers, err := s.FindVerifiersForPath(path)
+	if err != nil && !errors.Is(err, ErrMetadataNotFound) {
+		return nil, err
+	}
+	for _, verifier := range verifiers {
+		if verifier.verifyExhaustively {
+			// The exhaustive verifier only exists to count approvals for
+			// global rules, which are reported separately
+			continue
+		}
+
+		authorizations = append(authorizations, &PathAuthorization{
+			Name:               verifier.name,
+			Threshold:          verifier.threshold,
+			SeparationOfDuties: verifier.separationOfDuties,
+			Principals:         verifier.principals,
+			TeamMembers:        verifier.teamMembers,
+		})
+	}
+
+	matchOptions, err := s.getMatchOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Global rules declared by this repository are keyed by the empty string
+	// and sort ahead of those declared by controller repositories
+	for _, controllerName := range slices.Sorted(maps.Keys(s.globalRules)) {
+		for _, globalRule := range s.globalRules[controllerName] {
+			thresholdRule, isThreshold := globalRule.(tuf.GlobalRuleThreshold)
+			if !isThreshold || !thresholdRule.Matches(path, matchOptions...) {
+				continue
+			}
+
+			authorizations = append(authorizations, &PathAuthorization{
+				Name:           thresholdRule.GetName(),
+				IsGlobalRule:   true,
+				ControllerName: controllerName,
+				Threshold:      thresholdRule.GetThreshold(),
+			})
+		}
+	}
+
+	return authorizations, nil
+}
@@ -0,0 +1,136 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	tufv02 "github.com/gittuf/gittuf/internal/tuf/v02"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateFindAuthorizationsForPath(t *testing.T) {
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	t.Run("rules", func(t *testing.T) {
+		state := createTestStateWithPolicyUsingPersons(t)
+
+		authorizations, err := state.FindAuthorizationsForPath("git:refs/heads/main")
+		assert.Nil(t, err)
+
+		expectedAuthorizations := []*PathAuthorization{
+			{
+				Name:      "protect-main",
+				Threshold: 1,
+				Principals: []tuf.Principal{
+					&tufv02.Person{
+						PersonID:   "jane.doe@example.com",
+						PublicKeys: map[string]*tufv02.Key{gpgKey.KeyID: gpgKey},
+					},
+				},
+			},
+		}
+		assert.Equal(t, expectedAuthorizations, authorizations)
+	})
+
+	t.Run("delegated rules", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+
+		authorizations, err := state.FindAuthorizationsForPath("file:1/subpath1/a")
+		assert.Nil(t, err)
+
+		names := []string{}
+		for _, authorization := range authorizations {
+			names = append(names, authorization.Name)
+		}
+		assert.Equal(t, []string{"1", "3"}, names)
+	})
+
+	t.Run("global threshold rule", func(t *testing.T) {
+		state := createTestStateWithGlobalConstraintThreshold(t)
+
+		authorizations, err := state.FindAuthorizationsForPath("git:refs/heads/main")
+		assert.Nil(t, err)
+
+		expectedAuthorizations := []*PathAuthorization{
+			{
+				Name:         "threshold-2-main",
+				IsGlobalRule: true,
+				Threshold:    2,
+			},
+		}
+		assert.Equal(t, expectedAuthorizations, authorizations)
+
+		authorizations, err = state.FindAuthorizationsForPath("git:refs/heads/feature")
+		assert.Nil(t, err)
+		assert.Empty(t, authorizations)
+	})
+
+	t.Run("global threshold rule for default branch", func(t *testing.T) {
+		state := createTestStateWithGlobalConstraintThreshold(t)
+
+		state.globalRules[""] = []tuf.GlobalRule{tufv01.NewGlobalRuleThreshold("threshold-2-default", []string{"class:default-branch"}, 2)}
+		state.usesDefaultBranch = true
+
+		// The default branch is neither declared in the root of trust nor
+		// can it be resolved from a repository's HEAD
+		_, err := state.FindAuthorizationsForPath("git:refs/heads/trunk")
+		assert.ErrorIs(t, err, ErrDefaultBranchUnknown)
+
+		// The default branch follows the repository's symbolic HEAD
+		repo := gitinterface.CreateTestGitRepository(t, t.TempDir(), false)
+		if err := repo.SetSymbolicReference("HEAD", "refs/heads/main"); err != nil {
+			t.Fatal(err)
+		}
+		state.repository = repo
+		state.defaultBranchLoaded = false
+
+		authorizations, err := state.FindAuthorizationsForPath("git:refs/heads/main")
+		assert.Nil(t, err)
+		expectedAuthorizations := []*PathAuthorization{
+			{
+				Name:         "threshold-2-default",
+				IsGlobalRule: true,
+				Threshold:    2,
+			},
+		}
+		assert.Equal(t, expectedAuthorizations, authorizations)
+
+		authorizations, err = state.FindAuthorizationsForPath("git:refs/heads/trunk")
+		assert.Nil(t, err)
+		assert.Empty(t, authorizations)
+
+		// A default branch declared in the root of trust takes precedence
+		rootMetadata, err := state.GetRootMetadata(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rootMetadata.SetDefaultBranch("refs/heads/trunk"); err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.Metadata.RootEnvelope = rootEnv
+		state.defaultBranchLoaded = false
+
+		authorizations, err = state.FindAuthorizationsForPath("git:refs/heads/trunk")
+		assert.Nil(t, err)
+		assert.Equal(t, expectedAuthorizations, authorizations)
+
+		authorizations, err = state.FindAuthorizationsForPath("git:refs/heads/main")
+		assert.Nil(t, err)
+		assert.Empty(t, authorizations)
+	})
+}
@@ -0,0 +1,274 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/encryption"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+)
+
+const (
+	Ref = "refs/gittuf/secrets"
+
+	defaultCommitMessage = "Update secrets"
+
+	secretVersion = 1
+)
+
+var (
+	ErrSecretNotFound    = errors.New("secret not found")
+	ErrInvalidSecretName = errors.New("secret name may only contain letters, digits, '.', '_', and '-'")
+	ErrSecretHasNoReader = errors.New("secret must have at least one reader")
+	ErrSecretStale       = errors.New("secret is not encrypted for the current readers and keys in the policy and must be rotated")
+)
+
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+// Secret is a value encrypted for the keys of a set of principals in the
+// policy.
+type Secret struct {
+	// Version is the version of the secret's format.
+	Version int `json:"version"`
+
+	// Readers contains the IDs of the principals the secret is encrypted for.
+	Readers []string `json:"readers"`
+
+	// Manifest records the data key used to encrypt the secret, wrapped for
+	// each key of the readers.
+	Manifest *encryption.Manifest `json:"manifest"`
+
+	// Ciphertext is the encrypted value of the secret.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// NewSecret encrypts the value with a new data key for the specified keys of
+// the readers. The ciphertext is bound to the name of the secret, so the secret
+// cannot be stored under a different name.
+func NewSecret(name string, value []byte, readers []string, keys []*signerverifier.SSLibKey) (*Secret, error) {
+	if len(readers) == 0 || len(keys) == 0 {
+		return nil, ErrSecretHasNoReader
+	}
+
+	dataKey, err := encryption.NewDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := encryption.NewManifest(dataKey, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	readers = slices.Clone(readers)
+	slices.Sort(readers)
+
+	secret := &Secret{Version: secretVersion, Readers: slices.Compact(readers), Manifest: manifest}
+	secret.Ciphertext, err = encryption.Seal(dataKey, value, secret.additionalData(name))
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// Open returns the value of the secret with the specified name using the
+// private key of one of its recipients.
+func (s *Secret) Open(name string, privateKey crypto.PrivateKey, keyID string) ([]byte, error) {
+	dataKey, err := s.Manifest.GetDataKey(privateKey, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryption.Open(dataKey, s.Ciphertext, s.additionalData(name))
+}
+
+// additionalData returns the additional data used to bind the secret's
+// ciphertext to its name and format version.
+func (s *Secret) additionalData(name string) []byte {
+	return fmt.Appendf(nil, "gittuf secret v%d %s", s.Version, name)
+}
+
+// NeedsRotation returns true if the secret is not encrypted for exactly the
+// specified keys, such as when a reader's keys have changed in the policy.
+func (s *Secret) NeedsRotation(keys []*signerverifier.SSLibKey) bool {
+	keyIDs := []string{}
+	for _, key := range keys {
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+	slices.Sort(keyIDs)
+	keyIDs = slices.Compact(keyIDs)
+
+	if len(keyIDs) != len(s.Manifest.Recipients) {
+		return true
+	}
+
+	for _, keyID := range keyIDs {
+		if !s.Manifest.HasRecipient(keyID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Secrets tracks all the secrets in a gittuf repository.
+type Secrets struct {
+	// secrets maps the name of each secret to its contents.
+	secrets map[string]*Secret
+}
+
+// LoadCurrentSecrets inspects the repository's secrets namespace and loads the
+// current secrets.
+func LoadCurrentSecrets(repo *gitinterface.Repository) (*Secrets, error) {
+	entry, _, err := rsl.GetLatestReferenceUpdaterEntry(repo, rsl.ForReference(Ref))
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+
+		return &Secrets{secrets: map[string]*Secret{}}, nil
+	}
+
+	return LoadSecretsForEntry(repo, entry)
+}
+
+// LoadSecretsForEntry loads the repository's secrets for a particular RSL entry
+// for the secrets namespace.
+func LoadSecretsForEntry(repo *gitinterface.Repository, entry rsl.ReferenceUpdaterEntry) (*Secrets, error) {
+	if entry.GetRefName() != Ref {
+		return nil, rsl.ErrRSLEntryDoesNotMatchRef
+	}
+
+	secretsRootTreeID, err := repo.GetCommitTreeID(entry.GetTargetID())
+	if err != nil {
+		return nil, err
+	}
+
+	treeContents, err := repo.GetAllFilesInTree(secretsRootTreeID)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := &Secrets{secrets: map[string]*Secret{}}
+	for name, blobID := range treeContents {
+		secretBytes, err := repo.ReadBlob(blobID)
+		if err != nil {
+			return nil, err
+		}
+
+		secret := &Secret{}
+		if err := json.Unmarshal(secretBytes, secret); err != nil {
+			return nil, fmt.Errorf("unable to parse secret '%s': %w", name, err)
+		}
+
+		secrets.secrets[name] = secret
+	}
+
+	return secrets, nil
+}
+
+// Names returns the sorted names of all the secrets.
+func (s *Secrets) Names() []string {
+	names := []string{}
+	for name := range s.secrets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Get returns the secret with the specified name.
+func (s *Secrets) Get(name string) (*Secret, error) {
+	secret, has := s.secrets[name]
+	if !has {
+		return nil, fmt.Errorf("%w: '%s'", ErrSecretNotFound, name)
+	}
+
+	return secret, nil
+}
+
+// Set adds or replaces the secret with the specified name.
+func (s *Secrets) Set(name string, secret *Secret) error {
+	if !secretNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidSecretName, name)
+	}
+
+	s.secrets[name] = secret
+	return nil
+}
+
+// Remove removes the secret with the specified name.
+func (s *Secrets) Remove(name string) error {
+	if _, has := s.secrets[name]; !has {
+		return fmt.Errorf("%w: '%s'", ErrSecretNotFound, name)
+	}
+
+	delete(s.secrets, name)
+	return nil
+}
+
+// Commit writes the state of the secrets to the repository, creating a new
+// commit with the changes made. An RSL entry is also recorded for the namespace
+// if createRSLEntry is true.
+func (s *Secrets) Commit(repo *gitinterface.Repository, commitMessage string, createRSLEntry, signCommit bool) error {
+	if len(commitMessage) == 0 {
+		commitMessage = defaultCommitMessage
+	}
+
+	allSecrets := []gitinterface.TreeEntry{}
+	for name, secret := range s.secrets {
+		secretBytes, err := json.Marshal(secret)
+		if err != nil {
+			return err
+		}
+
+		blobID, err := repo.WriteBlob(secretBytes)
+		if err != nil {
+			return err
+		}
+
+		allSecrets = append(allSecrets, gitinterface.NewEntryBlob(name, blobID))
+	}
+
+	secretsTreeID, err := gitinterface.NewTreeBuilder(repo).WriteTreeFromEntries(allSecrets)
+	if err != nil {
+		return err
+	}
+
+	priorCommitID, err := repo.GetReference(Ref)
+	if err != nil {
+		if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return err
+		}
+	}
+
+	newCommitID, err := repo.Commit(secretsTreeID, Ref, commitMessage, signCommit)
+	if err != nil {
+		return err
+	}
+
+	if createRSLEntry {
+		// We must reset to original secrets commit if err != nil from here
+		// onwards.
+		if err := rsl.NewReferenceEntry(Ref, newCommitID).Commit(repo, signCommit); err != nil {
+			if !priorCommitID.IsZero() {
+				return repo.ResetDueToError(err, Ref, priorCommitID)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
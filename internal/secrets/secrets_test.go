@@ -0,0 +1,126 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/encryption"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	readerKey := ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH)
+	otherKey := ssh.NewKeyFromBytes(t, artifacts.SSHRSAPublicSSH)
+
+	privateKeyPath := filepath.Join(t.TempDir(), "key")
+	require.Nil(t, os.WriteFile(privateKeyPath, artifacts.SSHED25519Private, 0o600))
+	privateKey, keyID, err := encryption.LoadPrivateKey(privateKeyPath)
+	require.Nil(t, err)
+
+	secret, err := NewSecret("deploy-token", []byte("deploy-token"), []string{"bob", "alice", "bob"}, []*signerverifier.SSLibKey{readerKey, otherKey})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, secret.Readers)
+	assert.NotContains(t, string(secret.Ciphertext), "deploy-token")
+
+	value, err := secret.Open("deploy-token", privateKey, keyID)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("deploy-token"), value)
+
+	// The secret cannot be used under a different name
+	_, err = secret.Open("ci-token", privateKey, keyID)
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed)
+
+	// The version of the secret's format is also bound to the ciphertext
+	tamperedSecret := *secret
+	tamperedSecret.Version++
+	_, err = tamperedSecret.Open("deploy-token", privateKey, keyID)
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed)
+
+	assert.False(t, secret.NeedsRotation([]*signerverifier.SSLibKey{otherKey, readerKey}))
+	assert.True(t, secret.NeedsRotation([]*signerverifier.SSLibKey{readerKey}))
+
+	t.Run("not a recipient", func(t *testing.T) {
+		secret, err := NewSecret("deploy-token", []byte("deploy-token"), []string{"alice"}, []*signerverifier.SSLibKey{otherKey})
+		require.Nil(t, err)
+
+		_, err = secret.Open("deploy-token", privateKey, keyID)
+		assert.ErrorIs(t, err, encryption.ErrNotARecipient)
+	})
+
+	t.Run("no readers", func(t *testing.T) {
+		_, err := NewSecret("deploy-token", []byte("deploy-token"), []string{}, []*signerverifier.SSLibKey{})
+		assert.ErrorIs(t, err, ErrSecretHasNoReader)
+	})
+}
+
+func TestLoadCurrentSecrets(t *testing.T) {
+	readerKey := ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH)
+
+	t.Run("no RSL entry", func(t *testing.T) {
+		repo := gitinterface.CreateTestGitRepository(t, t.TempDir(), false)
+
+		secrets, err := LoadCurrentSecrets(repo)
+		assert.Nil(t, err)
+		assert.Empty(t, secrets.Names())
+	})
+
+	t.Run("with RSL entry and with a secret", func(t *testing.T) {
+		repo := gitinterface.CreateTestGitRepository(t, t.TempDir(), false)
+
+		secret, err := NewSecret("deploy-token", []byte("deploy-token"), []string{"alice"}, []*signerverifier.SSLibKey{readerKey})
+		require.Nil(t, err)
+
+		secrets, err := LoadCurrentSecrets(repo)
+		require.Nil(t, err)
+		require.Nil(t, secrets.Set("deploy-token", secret))
+		require.Nil(t, secrets.Commit(repo, "Test commit", true, false))
+
+		secrets, err = LoadCurrentSecrets(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"deploy-token"}, secrets.Names())
+
+		loadedSecret, err := secrets.Get("deploy-token")
+		assert.Nil(t, err)
+		assert.Equal(t, secret, loadedSecret)
+
+		require.Nil(t, secrets.Remove("deploy-token"))
+		require.Nil(t, secrets.Commit(repo, "", true, false))
+
+		secrets, err = LoadCurrentSecrets(repo)
+		assert.Nil(t, err)
+		assert.Empty(t, secrets.Names())
+	})
+}
+
+func TestSecretsGetSetRemove(t *testing.T) {
+	secrets := &Secrets{secrets: map[string]*Secret{}}
+
+	_, err := secrets.Get("ci-token")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	err = secrets.Set("ci-token", &Secret{})
+	assert.Nil(t, err)
+
+	_, err = secrets.Get("ci-token")
+	assert.Nil(t, err)
+
+	for _, name := range []string{"", ".", "..", "ci/token", "ci token"} {
+		err = secrets.Set(name, &Secret{})
+		assert.ErrorIs(t, err, ErrInvalidSecretName, name)
+	}
+
+	err = secrets.Remove("ci-token")
+	assert.Nil(t, err)
+
+	err = secrets.Remove("ci-token")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
@@ -0,0 +1,18 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package match
+
+type Options struct {
+	DefaultBranch string
+}
+
+type Option func(o *Options)
+
+// WithDefaultBranch sets the fully qualified name of the repository's default
+// branch, used to match ref class patterns that refer to it.
+func WithDefaultBranch(refName string) Option {
+	return func(o *Options) {
+		o.DefaultBranch = refName
+	}
+}
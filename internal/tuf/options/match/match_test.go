@@ -0,0 +1,20 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDefaultBranch(t *testing.T) {
+	options := &Options{}
+
+	option := WithDefaultBranch("refs/heads/main")
+
+	option(options)
+
+	assert.Equal(t, "refs/heads/main", options.DefaultBranch)
+}
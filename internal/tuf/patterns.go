@@ -0,0 +1,188 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tuf
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/danwakefield/fnmatch"
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
+)
+
+const (
+	gitReferenceNamespacePrefix = "git:"
+	fileNamespacePrefix         = "file:"
+	branchNamespacePrefix       = gitReferenceNamespacePrefix + "refs/heads/"
+	tagNamespacePrefix          = gitReferenceNamespacePrefix + "refs/tags/"
+)
+
+// compiledRegexPatterns caches the regular expressions of patterns that have
+// been matched, as the same rules are checked for every namespace verified.
+var compiledRegexPatterns sync.Map
+
+// ValidatePattern checks that a rule pattern is well formed. Glob patterns are
+// always valid, regular expression patterns must compile and every namespace
+// they match must start with a literal "git:" or "file:", and ref class
+// patterns must name a known class.
+func ValidatePattern(pattern string) error {
+	if expression, isRegex := strings.CutPrefix(pattern, RegexPatternPrefix); isRegex {
+		prefix, err := regexLiteralPrefix(expression)
+		if err != nil {
+			return fmt.Errorf("%w: '%s': %w", ErrInvalidPattern, pattern, err)
+		}
+		if !strings.HasPrefix(prefix, gitReferenceNamespacePrefix) && !strings.HasPrefix(prefix, fileNamespacePrefix) {
+			return fmt.Errorf("%w: '%s': regular expression must only match namespaces starting with '%s' or '%s'", ErrInvalidPattern, pattern, gitReferenceNamespacePrefix, fileNamespacePrefix)
+		}
+		return nil
+	}
+
+	if class, isRefClass := strings.CutPrefix(pattern, RefClassPatternPrefix); isRefClass {
+		switch class {
+		case RefClassBranches, RefClassTags, RefClassDefaultBranch, RefClassBranchesExceptDefault:
+			return nil
+		default:
+			return fmt.Errorf("%w: unknown ref class '%s'", ErrInvalidPattern, class)
+		}
+	}
+
+	return nil
+}
+
+// ValidateGlobalRulePatterns checks that every namespace protected by the
+// global rule is a well formed pattern.
+func ValidateGlobalRulePatterns(globalRule GlobalRule) error {
+	rule, ok := globalRule.(interface{ GetProtectedNamespaces() []string })
+	if !ok {
+		return nil
+	}
+
+	for _, pattern := range rule.GetProtectedNamespaces() {
+		if err := ValidatePattern(pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RefersToDefaultBranch returns true if the pattern is a ref class whose
+// matches depend on the repository's default branch.
+func RefersToDefaultBranch(pattern string) bool {
+	class, isRefClass := strings.CutPrefix(pattern, RefClassPatternPrefix)
+	return isRefClass && (class == RefClassDefaultBranch || class == RefClassBranchesExceptDefault)
+}
+
+// RuleRefersToDefaultBranch returns true if any protected or excluded pattern
+// of the rule depends on the repository's default branch.
+func RuleRefersToDefaultBranch(rule Rule) bool {
+	return slices.ContainsFunc(rule.GetProtectedNamespaces(), RefersToDefaultBranch) || slices.ContainsFunc(rule.GetExcludedNamespaces(), RefersToDefaultBranch)
+}
+
+// GlobalRuleRefersToDefaultBranch returns true if any pattern of the global
+// rule depends on the repository's default branch.
+func GlobalRuleRefersToDefaultBranch(globalRule GlobalRule) bool {
+	rule, ok := globalRule.(interface{ GetProtectedNamespaces() []string })
+	return ok && slices.ContainsFunc(rule.GetProtectedNamespaces(), RefersToDefaultBranch)
+}
+
+// IsGlobPattern returns true if the pattern uses the default glob syntax rather
+// than a regular expression or ref class.
+func IsGlobPattern(pattern string) bool {
+	return !strings.HasPrefix(pattern, RegexPatternPrefix) && !strings.HasPrefix(pattern, RefClassPatternPrefix)
+}
+
+// IsGitReferencePattern returns true if the pattern can only match Git
+// references.
+func IsGitReferencePattern(pattern string) bool {
+	if strings.HasPrefix(pattern, RefClassPatternPrefix) {
+		return true
+	}
+
+	if expression, isRegex := strings.CutPrefix(pattern, RegexPatternPrefix); isRegex {
+		prefix, err := regexLiteralPrefix(expression)
+		return err == nil && strings.HasPrefix(prefix, gitReferenceNamespacePrefix)
+	}
+
+	return strings.HasPrefix(pattern, gitReferenceNamespacePrefix)
+}
+
+// IsFilePattern returns true if the pattern can match files, i.e., if it is
+// not restricted to Git references.
+func IsFilePattern(pattern string) bool {
+	return !IsGitReferencePattern(pattern)
+}
+
+// MatchPattern checks if a rule pattern matches the namespace. Regular
+// expressions use RE2 syntax, so matching takes time linear in the length of
+// the namespace. Ref classes that refer to the default branch need it to be
+// set using matchopts.WithDefaultBranch. If it isn't known, the default branch
+// class matches nothing and every branch is matched by the class of branches
+// other than the default branch. Invalid patterns match nothing.
+func MatchPattern(pattern, namespace string, opts ...matchopts.Option) bool {
+	if expression, isRegex := strings.CutPrefix(pattern, RegexPatternPrefix); isRegex {
+		regex, err := compileRegexPattern(expression)
+		if err != nil {
+			return false
+		}
+		return regex.MatchString(namespace)
+	}
+
+	if class, isRefClass := strings.CutPrefix(pattern, RefClassPatternPrefix); isRefClass {
+		options := &matchopts.Options{}
+		for _, fn := range opts {
+			fn(options)
+		}
+		defaultBranch := ""
+		if options.DefaultBranch != "" {
+			defaultBranch = gitReferenceNamespacePrefix + options.DefaultBranch
+		}
+
+		switch class {
+		case RefClassBranches:
+			return strings.HasPrefix(namespace, branchNamespacePrefix)
+		case RefClassTags:
+			return strings.HasPrefix(namespace, tagNamespacePrefix)
+		case RefClassDefaultBranch:
+			return defaultBranch != "" && namespace == defaultBranch
+		case RefClassBranchesExceptDefault:
+			return strings.HasPrefix(namespace, branchNamespacePrefix) && namespace != defaultBranch
+		default:
+			return false
+		}
+	}
+
+	return fnmatch.Match(pattern, namespace, 0)
+}
+
+// regexLiteralPrefix returns the literal prefix every namespace matched by the
+// regular expression must start with.
+func regexLiteralPrefix(expression string) (string, error) {
+	regex, err := compileRegexPattern(expression)
+	if err != nil {
+		return "", err
+	}
+
+	prefix, _ := regex.LiteralPrefix()
+	return prefix, nil
+}
+
+// compileRegexPattern compiles the expression of a regular expression pattern,
+// anchoring it so that it must match the entire namespace.
+func compileRegexPattern(expression string) (*regexp.Regexp, error) {
+	if regex, has := compiledRegexPatterns.Load(expression); has {
+		return regex.(*regexp.Regexp), nil
+	}
+
+	regex, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", expression))
+	if err != nil {
+		return nil, err
+	}
+
+	compiledRegexPatterns.Store(expression, regex)
+	return regex, nil
+}
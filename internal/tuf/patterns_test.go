@@ -0,0 +1,187 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tuf
+
+import (
+	"fmt"
+	"testing"
+
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePattern(t *testing.T) {
+	tests := map[string]struct {
+		pattern     string
+		expectedErr error
+	}{
+		"glob pattern": {
+			pattern: "git:refs/heads/*",
+		},
+		"regex pattern": {
+			pattern: "re:git:refs/heads/release-[0-9]+",
+		},
+		"invalid regex pattern": {
+			pattern:     "re:git:refs/heads/(release",
+			expectedErr: ErrInvalidPattern,
+		},
+		"regex pattern with backreference": {
+			pattern:     `re:git:refs/heads/(a)\1`,
+			expectedErr: ErrInvalidPattern,
+		},
+		"regex pattern with group": {
+			pattern: "re:(?:file:secrets/.*)",
+		},
+		"regex pattern matching any namespace": {
+			pattern:     "re:.*",
+			expectedErr: ErrInvalidPattern,
+		},
+		"regex pattern matching both namespaces": {
+			pattern:     "re:git:refs/heads/main|file:secrets/.*",
+			expectedErr: ErrInvalidPattern,
+		},
+		"ref class pattern": {
+			pattern: "class:branches-except-default",
+		},
+		"unknown ref class pattern": {
+			pattern:     "class:remotes",
+			expectedErr: ErrInvalidPattern,
+		},
+	}
+
+	for name, test := range tests {
+		err := ValidatePattern(test.pattern)
+		if test.expectedErr == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.ErrorIs(t, err, test.expectedErr, fmt.Sprintf("unexpected error in test '%s'", name))
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := map[string]struct {
+		pattern       string
+		namespace     string
+		defaultBranch string
+		expected      bool
+	}{
+		"glob pattern, matches": {
+			pattern:   "git:refs/heads/*",
+			namespace: "git:refs/heads/main",
+			expected:  true,
+		},
+		"regex pattern, matches": {
+			pattern:   "re:git:refs/heads/release-[0-9]+",
+			namespace: "git:refs/heads/release-10",
+			expected:  true,
+		},
+		"regex pattern, does not match": {
+			pattern:   "re:git:refs/heads/release-[0-9]+",
+			namespace: "git:refs/heads/release-10-rc",
+			expected:  false,
+		},
+		"regex pattern is anchored, does not match": {
+			pattern:   "re:refs/heads/main",
+			namespace: "git:refs/heads/main",
+			expected:  false,
+		},
+		"invalid regex pattern, does not match": {
+			pattern:   "re:git:refs/heads/(main",
+			namespace: "git:refs/heads/(main",
+			expected:  false,
+		},
+		"branches class, matches": {
+			pattern:   "class:branches",
+			namespace: "git:refs/heads/feature/foo",
+			expected:  true,
+		},
+		"branches class with tag, does not match": {
+			pattern:   "class:branches",
+			namespace: "git:refs/tags/v1",
+			expected:  false,
+		},
+		"tags class, matches": {
+			pattern:   "class:tags",
+			namespace: "git:refs/tags/v1",
+			expected:  true,
+		},
+		"tags class with file, does not match": {
+			pattern:   "class:tags",
+			namespace: "file:refs/tags/v1",
+			expected:  false,
+		},
+		"default branch class, matches": {
+			pattern:       "class:default-branch",
+			namespace:     "git:refs/heads/main",
+			defaultBranch: "refs/heads/main",
+			expected:      true,
+		},
+		"default branch class with other branch, does not match": {
+			pattern:       "class:default-branch",
+			namespace:     "git:refs/heads/dev",
+			defaultBranch: "refs/heads/main",
+			expected:      false,
+		},
+		"default branch class without default branch, does not match": {
+			pattern:   "class:default-branch",
+			namespace: "git:refs/heads/main",
+			expected:  false,
+		},
+		"branches except default class, matches": {
+			pattern:       "class:branches-except-default",
+			namespace:     "git:refs/heads/dev",
+			defaultBranch: "refs/heads/main",
+			expected:      true,
+		},
+		"branches except default class with default branch, does not match": {
+			pattern:       "class:branches-except-default",
+			namespace:     "git:refs/heads/main",
+			defaultBranch: "refs/heads/main",
+			expected:      false,
+		},
+		"branches except default class without default branch, matches": {
+			pattern:   "class:branches-except-default",
+			namespace: "git:refs/heads/main",
+			expected:  true,
+		},
+		"unknown class, does not match": {
+			pattern:   "class:remotes",
+			namespace: "git:refs/remotes/origin/main",
+			expected:  false,
+		},
+	}
+
+	for name, test := range tests {
+		got := MatchPattern(test.pattern, test.namespace, matchopts.WithDefaultBranch(test.defaultBranch))
+		assert.Equal(t, test.expected, got, fmt.Sprintf("unexpected result in test '%s'", name))
+	}
+}
+
+func TestIsGitReferencePattern(t *testing.T) {
+	assert.True(t, IsGitReferencePattern("git:refs/heads/*"))
+	assert.True(t, IsGitReferencePattern("re:git:refs/heads/.*"))
+	assert.True(t, IsGitReferencePattern("class:tags"))
+	assert.False(t, IsGitReferencePattern("file:*"))
+	assert.False(t, IsGitReferencePattern("re:file:.*"))
+	assert.False(t, IsGitReferencePattern("re:.*"))
+	assert.True(t, IsGitReferencePattern("re:(?:git:refs/heads/.*)"))
+}
+
+func TestIsFilePattern(t *testing.T) {
+	assert.True(t, IsFilePattern("file:*"))
+	assert.True(t, IsFilePattern("re:(?:file:secrets/.*)"))
+	assert.True(t, IsFilePattern("re:.*"))
+	assert.True(t, IsFilePattern("*"))
+	assert.False(t, IsFilePattern("git:refs/heads/*"))
+	assert.False(t, IsFilePattern("class:branches"))
+}
+
+func TestRefersToDefaultBranch(t *testing.T) {
+	assert.True(t, RefersToDefaultBranch("class:default-branch"))
+	assert.True(t, RefersToDefaultBranch("class:branches-except-default"))
+	assert.False(t, RefersToDefaultBranch("class:branches"))
+	assert.False(t, RefersToDefaultBranch("git:refs/heads/main"))
+	assert.False(t, RefersToDefaultBranch("re:class:default-branch"))
+}
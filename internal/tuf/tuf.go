@@ -6,8 +6,10 @@ package tuf
 import (
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/common/set"
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 )
@@ -28,9 +30,34 @@ const (
 	GittufPrefix           = "gittuf-"
 	GittufControllerPrefix = "gittuf-controller"
 
-	GlobalRuleThresholdType        = "threshold"
-	GlobalRuleBlockForcePushesType = "block-force-pushes"
-	RemoveGlobalRuleType           = "remove"
+	// ExclusionPatternPrefix marks a rule pattern as an exclusion: namespaces
+	// matching it are not protected by the rule even if they match one of the
+	// rule's other patterns.
+	ExclusionPatternPrefix = "!"
+
+	// RegexPatternPrefix marks a rule pattern as a regular expression that
+	// must match the entire namespace, e.g.,
+	// "re:git:refs/heads/release/v[0-9]+\.[0-9]+".
+	RegexPatternPrefix = "re:"
+
+	// RefClassPatternPrefix marks a rule pattern as one of the built-in
+	// classes of Git references, e.g., "class:tags".
+	RefClassPatternPrefix = "class:"
+
+	RefClassBranches              = "branches"
+	RefClassTags                  = "tags"
+	RefClassDefaultBranch         = "default-branch"
+	RefClassBranchesExceptDefault = "branches-except-default"
+
+	GlobalRuleThresholdType            = "threshold"
+	GlobalRuleBlockForcePushesType     = "block-force-pushes"
+	GlobalRuleRequireSignedCommitsType = "require-signed-commits"
+	GlobalRuleRequireSignOffType       = "require-sign-off"
+	GlobalRuleRequireLinearHistoryType = "require-linear-history"
+	GlobalRuleRestrictFilesType        = "restrict-files"
+	GlobalRuleFreezeWindowType         = "freeze-window"
+	GlobalRuleRestrictReadType         = "restrict-read"
+	RemoveGlobalRuleType               = "remove"
 
 	HookStagePreCommitString = "preCommit"
 	HookStagePrePushString   = "prePush"
@@ -44,37 +71,51 @@ const (
 )
 
 var (
-	ErrInvalidRootMetadata                             = errors.New("invalid root metadata")
-	ErrUnknownRootMetadataVersion                      = errors.New("unknown schema version for root metadata")
-	ErrUnknownTargetsMetadataVersion                   = errors.New("unknown schema version for rule file metadata")
-	ErrInvalidOperationForMetadataVersion              = errors.New("invalid operation for metadata version")
-	ErrPrimaryRuleFileInformationNotFoundInRoot        = errors.New("root metadata does not contain primary rule file information")
-	ErrGitHubAppInformationNotFoundInRoot              = errors.New("the special GitHub app role is not defined, but GitHub app approvals is set to trusted")
-	ErrDuplicatedRuleName                              = errors.New("two rules with same name found in policy")
-	ErrDuplicateControllerRepository                   = errors.New("controller repository already exists")
-	ErrDuplicateNetworkRepository                      = errors.New("network repository already exists")
-	ErrInvalidPrincipalID                              = errors.New("principal ID is invalid")
-	ErrInvalidPrincipalType                            = errors.New("invalid principal type (do you have the right gittuf version?)")
-	ErrPrincipalNotFound                               = errors.New("principal not found")
-	ErrPrincipalStillInUse                             = errors.New("principal is still in use")
-	ErrRuleNotFound                                    = errors.New("cannot find rule entry")
-	ErrMissingRules                                    = errors.New("some rules are missing")
-	ErrCannotManipulateRulesWithGittufPrefix           = errors.New("cannot add or change rules whose names have the 'gittuf-' prefix")
-	ErrCannotMeetThreshold                             = errors.New("insufficient keys to meet threshold")
-	ErrInvalidThreshold                                = errors.New("threshold must be a positive integer")
-	ErrUnknownGlobalRuleType                           = errors.New("unknown global rule type")
-	ErrGlobalRuleBlockForcePushesOnlyAppliesToGitPaths = errors.New("all patterns for block force pushes global rule must be for Git references")
-	ErrGlobalRuleNotFound                              = errors.New("global rule not found")
-	ErrGlobalRuleAlreadyExists                         = errors.New("global rule already exists")
-	ErrCannotUpdateGlobalRuleType                      = errors.New("cannot change type of global rule")
-	ErrPropagationDirectiveNotFound                    = errors.New("specified propagation directive not found")
-	ErrPropagationDirectiveAlreadyExists               = errors.New("specified propagation directive already exists")
-	ErrNotAControllerRepository                        = errors.New("current repository is not marked as a controller repository")
-	ErrDuplicatedHookName                              = errors.New("two hooks with same name found in policy")
-	ErrInvalidHookStage                                = errors.New("invalid stage for hook")
-	ErrInvalidHookEnvironment                          = errors.New("invalid environment for hook")
-	ErrHookNotFound                                    = errors.New("cannot find hook entry")
-	ErrNoHooksDefined                                  = errors.New("no hooks defined")
+	ErrInvalidRootMetadata                                 = errors.New("invalid root metadata")
+	ErrUnknownRootMetadataVersion                          = errors.New("unknown schema version for root metadata")
+	ErrUnknownTargetsMetadataVersion                       = errors.New("unknown schema version for rule file metadata")
+	ErrInvalidOperationForMetadataVersion                  = errors.New("invalid operation for metadata version")
+	ErrPrimaryRuleFileInformationNotFoundInRoot            = errors.New("root metadata does not contain primary rule file information")
+	ErrGitHubAppInformationNotFoundInRoot                  = errors.New("the special GitHub app role is not defined, but GitHub app approvals is set to trusted")
+	ErrDuplicatedRuleName                                  = errors.New("two rules with same name found in policy")
+	ErrDuplicateControllerRepository                       = errors.New("controller repository already exists")
+	ErrDuplicateNetworkRepository                          = errors.New("network repository already exists")
+	ErrInvalidPrincipalID                                  = errors.New("principal ID is invalid")
+	ErrInvalidPrincipalType                                = errors.New("invalid principal type (do you have the right gittuf version?)")
+	ErrPrincipalNotFound                                   = errors.New("principal not found")
+	ErrPrincipalStillInUse                                 = errors.New("principal is still in use")
+	ErrInvalidTeamMember                                   = errors.New("team members must be keys or persons declared in the same rule file")
+	ErrRuleNotFound                                        = errors.New("cannot find rule entry")
+	ErrMissingRules                                        = errors.New("some rules are missing")
+	ErrCannotManipulateRulesWithGittufPrefix               = errors.New("cannot add or change rules whose names have the 'gittuf-' prefix")
+	ErrCannotMeetThreshold                                 = errors.New("insufficient keys to meet threshold")
+	ErrInvalidThreshold                                    = errors.New("threshold must be a positive integer")
+	ErrRuleHasOnlyExclusionPatterns                        = errors.New("rule must have at least one pattern that is not an exclusion")
+	ErrInvalidPattern                                      = errors.New("invalid rule pattern")
+	ErrUnknownGlobalRuleType                               = errors.New("unknown global rule type")
+	ErrGlobalRuleBlockForcePushesOnlyAppliesToGitPaths     = errors.New("all patterns for block force pushes global rule must be for Git references")
+	ErrGlobalRuleRequireSignedCommitsOnlyAppliesToGitPaths = errors.New("all patterns for require signed commits global rule must be for Git references")
+	ErrGlobalRuleRequireSignOffOnlyAppliesToGitPaths       = errors.New("all patterns for require sign-off global rule must be for Git references")
+	ErrGlobalRuleRequireLinearHistoryOnlyAppliesToGitPaths = errors.New("all patterns for require linear history global rule must be for Git references")
+	ErrGlobalRuleRestrictFilesOnlyAppliesToGitPaths        = errors.New("all patterns for restrict files global rule must be for Git references")
+	ErrGlobalRuleRestrictFilesHasNoConstraints             = errors.New("restrict files global rule must deny at least one file pattern or set a maximum blob size")
+	ErrGlobalRuleFreezeWindowOnlyAppliesToGitPaths         = errors.New("all patterns for freeze window global rule must be for Git references")
+	ErrGlobalRuleFreezeWindowInvalidWindow                 = errors.New("freeze window must end after it starts")
+	ErrGlobalRuleRestrictReadOnlyAppliesToGitPaths         = errors.New("all patterns for restrict read global rule must be for Git references")
+	ErrGlobalRuleRestrictReadHasNoReaders                  = errors.New("restrict read global rule must authorize at least one reader")
+	ErrGlobalRuleNotFound                                  = errors.New("global rule not found")
+	ErrGlobalRuleAlreadyExists                             = errors.New("global rule already exists")
+	ErrCannotUpdateGlobalRuleType                          = errors.New("cannot change type of global rule")
+	ErrInvalidKeyRevocation                                = errors.New("key revocation must specify key ID and RSL entry")
+	ErrInvalidDefaultBranch                                = errors.New("default branch must be a fully qualified branch name, e.g., 'refs/heads/main'")
+	ErrPropagationDirectiveNotFound                        = errors.New("specified propagation directive not found")
+	ErrPropagationDirectiveAlreadyExists                   = errors.New("specified propagation directive already exists")
+	ErrNotAControllerRepository                            = errors.New("current repository is not marked as a controller repository")
+	ErrDuplicatedHookName                                  = errors.New("two hooks with same name found in policy")
+	ErrInvalidHookStage                                    = errors.New("invalid stage for hook")
+	ErrInvalidHookEnvironment                              = errors.New("invalid environment for hook")
+	ErrHookNotFound                                        = errors.New("cannot find hook entry")
+	ErrNoHooksDefined                                      = errors.New("no hooks defined")
 )
 
 // Principal represents an entity that is granted trust by gittuf metadata. In
@@ -87,13 +128,31 @@ type Principal interface {
 	CustomMetadata() map[string]string
 }
 
+// Team represents a principal that groups other principals, typically persons,
+// and carries its own threshold. A team is counted towards a rule's threshold
+// only when the team's threshold of members have approved. A team has no keys
+// of its own, the keys of its members are used for verification.
+type Team interface {
+	Principal
+
+	// GetPrincipalIDs returns the identifiers of the team's members.
+	GetPrincipalIDs() *set.Set[string]
+
+	// GetThreshold returns the number of members who must approve for the
+	// team to be counted towards a rule's threshold.
+	GetThreshold() int
+}
+
 // RootMetadata represents the root of trust metadata for gittuf.
 type RootMetadata interface {
-	// SetExpires sets the expiry time for the metadata.
-	// TODO: Does expiry make sense for the gittuf context? This is currently
-	// unenforced
+	// SetExpires sets the expiry time for the metadata. The expiry time is
+	// expected in the RFC 3339 format.
 	SetExpires(expiry string)
 
+	// GetExpires returns the expiry time for the metadata. Metadata that has
+	// expired fails policy verification if the root of trust enforces expiry.
+	GetExpires() string
+
 	// GetSchemaVersion returns the metadata schema version.
 	GetSchemaVersion() string
 
@@ -109,6 +168,20 @@ type RootMetadata interface {
 	// root metadata.
 	SetRepositoryLocation(location string)
 
+	// GetDefaultBranch returns the fully qualified name of the repository's
+	// default branch declared in the root metadata, if any.
+	GetDefaultBranch() string
+	// SetDefaultBranch declares the fully qualified name of the repository's
+	// default branch in the root metadata.
+	SetDefaultBranch(refName string) error
+
+	// IsExpiryEnforced indicates if policy verification must reject metadata
+	// that has expired.
+	IsExpiryEnforced() bool
+	// SetExpiryEnforced sets whether policy verification must reject metadata
+	// that has expired.
+	SetExpiryEnforced(enforce bool) error
+
 	// GetPrincipals returns all the principals in the root metadata.
 	GetPrincipals() map[string]Principal
 
@@ -179,6 +252,18 @@ type RootMetadata interface {
 	// GetGitHubAppEntries returns the GitHub apps declared in the metadata.
 	GetGitHubAppEntries() (map[string]GitHubApp, error)
 
+	// RevokeKey records that the key identified by keyID is revoked for all
+	// RSL entries recorded after the entry identified by effectiveEntryID.
+	// Signatures made using the key for that entry or earlier remain valid.
+	RevokeKey(keyID, effectiveEntryID string) error
+	// GetKeyRevocations returns the IDs of revoked keys mapped to the RSL
+	// entries after which they are revoked.
+	GetKeyRevocations() map[string]string
+	// RotateKey replaces the key identified by oldKeyID with newKey wherever
+	// it is trusted in the root metadata. It returns true if the metadata was
+	// modified.
+	RotateKey(oldKeyID string, newKey Principal) (bool, error)
+
 	// AddPropagationDirective adds a propagation directive to the root
 	// metadata.
 	AddPropagationDirective(directive PropagationDirective) error
@@ -234,11 +319,14 @@ type RootMetadata interface {
 
 // TargetsMetadata represents gittuf's rule files. Its name is inspired by TUF.
 type TargetsMetadata interface {
-	// SetExpires sets the expiry time for the metadata.
-	// TODO: Does expiry make sense for the gittuf context? This is currently
-	// unenforced
+	// SetExpires sets the expiry time for the metadata. The expiry time is
+	// expected in the RFC 3339 format.
 	SetExpires(expiry string)
 
+	// GetExpires returns the expiry time for the metadata. Metadata that has
+	// expired fails policy verification if the root of trust enforces expiry.
+	GetExpires() string
+
 	// GetSchemaVersion returns the metadata schema version.
 	GetSchemaVersion() string
 
@@ -253,7 +341,8 @@ type TargetsMetadata interface {
 	// GetRules returns all the rules in the metadata.
 	GetRules() []Rule
 
-	// AddRule adds a rule to the metadata file.
+	// AddRule adds a rule to the metadata file. Patterns prefixed with
+	// ExclusionPatternPrefix exclude matching namespaces from the rule.
 	AddRule(ruleName string, authorizedPrincipalIDs, rulePatterns []string, threshold int) error
 	// UpdateRule updates an existing rule identified by ruleName with the
 	// provided parameters.
@@ -263,6 +352,21 @@ type TargetsMetadata interface {
 	ReorderRules(newRuleNames []string) error
 	// RemoveRule deletes the rule identified by the ruleName.
 	RemoveRule(ruleName string) error
+	// RotateKey replaces the key identified by oldKeyID with newKey wherever
+	// it is trusted in the rule file. It returns true if the metadata was
+	// modified.
+	RotateKey(oldKeyID string, newKey Principal) (bool, error)
+	// EnableSeparationOfDuties marks the rule identified by ruleName as
+	// requiring separation of duties, i.e., the principal who signed a change
+	// cannot count towards the rule's threshold for that change.
+	EnableSeparationOfDuties(ruleName string) error
+	// DisableSeparationOfDuties marks the rule identified by ruleName as no
+	// longer requiring separation of duties.
+	DisableSeparationOfDuties(ruleName string) error
+	// SetRuleDetails records the description of the rule identified by
+	// ruleName, who to contact about it, and a link to further context such as
+	// a ticket.
+	SetRuleDetails(ruleName, description, contact, link string) error
 
 	// AddPrincipal adds a principal to the metadata.
 	AddPrincipal(principal Principal) error
@@ -280,11 +384,14 @@ type Rule interface {
 	ID() string
 
 	// Matches indicates if the rule applies to a specified path.
-	Matches(path string) bool
+	Matches(path string, opts ...matchopts.Option) bool
 
 	// GetProtectedNamespaces returns the set of namespaces protected by the
 	// rule.
 	GetProtectedNamespaces() []string
+	// GetExcludedNamespaces returns the set of namespaces excluded from the
+	// rule's protected namespaces.
+	GetExcludedNamespaces() []string
 
 	// GetPrincipalIDs returns the identifiers of the principals that are listed
 	// as trusted by the rule.
@@ -299,6 +406,18 @@ type Rule interface {
 	// current rule's delegated rules as well as other rules already in the
 	// queue are trusted.
 	IsLastTrustedInRuleFile() bool
+
+	// RequiresSeparationOfDuties indicates that the principal who signed a
+	// change must not be counted towards the rule's threshold for that change.
+	RequiresSeparationOfDuties() bool
+
+	// GetDescription returns why the rule exists.
+	GetDescription() string
+	// GetContact returns who to contact about the rule.
+	GetContact() string
+	// GetLink returns a link to further context about the rule, such as a
+	// ticket.
+	GetLink() string
 }
 
 // GlobalRule represents a repository-wide constraint set by the owners in the
@@ -314,7 +433,7 @@ type GlobalRuleThreshold interface {
 	GlobalRule
 
 	// Matches indicates if the rule applies to a specified path.
-	Matches(path string) bool
+	Matches(path string, opts ...matchopts.Option) bool
 
 	// GetProtectedNamespaces returns the set of namespaces protected by the
 	// rule.
@@ -331,11 +450,138 @@ type GlobalRuleBlockForcePushes interface {
 	GlobalRule
 
 	// Matches indicates if the rule applies to a specified path.
-	Matches(path string) bool
+	Matches(path string, opts ...matchopts.Option) bool
+
+	// GetProtectedNamespaces returns the set of namespaces protected by the
+	// rule.
+	GetProtectedNamespaces() []string
+
+	// BlocksForcePushes distinguishes this rule from other global rules that
+	// protect namespaces.
+	BlocksForcePushes() bool
+}
+
+// GlobalRuleRequireSignedCommits requires every new commit in the specified
+// namespaces to be signed by some principal declared in the policy.
+type GlobalRuleRequireSignedCommits interface {
+	GlobalRule
+
+	// Matches indicates if the rule applies to a specified path.
+	Matches(path string, opts ...matchopts.Option) bool
+
+	// GetProtectedNamespaces returns the set of namespaces protected by the
+	// rule.
+	GetProtectedNamespaces() []string
+
+	// RequiresSignedCommits distinguishes this rule from other global rules
+	// that protect namespaces.
+	RequiresSignedCommits() bool
+}
+
+// GlobalRuleRequireSignOff requires every new commit in the specified
+// namespaces to carry a Developer Certificate of Origin (DCO) sign-off, i.e., a
+// "Signed-off-by:" trailer that matches the commit's author.
+type GlobalRuleRequireSignOff interface {
+	GlobalRule
+
+	// Matches indicates if the rule applies to a specified path.
+	Matches(path string, opts ...matchopts.Option) bool
+
+	// GetProtectedNamespaces returns the set of namespaces protected by the
+	// rule.
+	GetProtectedNamespaces() []string
+
+	// AllowsAssociatedIdentities indicates if a sign-off may also match
+	// another associated identity of the person in the policy whose
+	// identities include the commit author's email address.
+	AllowsAssociatedIdentities() bool
+}
+
+// GlobalRuleRequireLinearHistory rejects merge commits, i.e., commits with more
+// than one parent, in the specified namespaces.
+type GlobalRuleRequireLinearHistory interface {
+	GlobalRule
+
+	// Matches indicates if the rule applies to a specified path.
+	Matches(path string, opts ...matchopts.Option) bool
+
+	// GetProtectedNamespaces returns the set of namespaces protected by the
+	// rule.
+	GetProtectedNamespaces() []string
+
+	// RequiresLinearHistory distinguishes this rule from other global rules
+	// that protect namespaces.
+	RequiresLinearHistory() bool
+}
+
+// GlobalRuleRestrictFiles rejects changes to the specified namespaces that
+// introduce files matching denied patterns or blobs larger than a maximum size.
+type GlobalRuleRestrictFiles interface {
+	GlobalRule
+
+	// Matches indicates if the rule applies to a specified path.
+	Matches(path string, opts ...matchopts.Option) bool
 
 	// GetProtectedNamespaces returns the set of namespaces protected by the
 	// rule.
 	GetProtectedNamespaces() []string
+
+	// GetDeniedPatterns returns the patterns of file paths that may not be
+	// introduced.
+	GetDeniedPatterns() []string
+
+	// MatchesDeniedPattern returns the first denied pattern that matches the
+	// specified file path, or an empty string if none match.
+	MatchesDeniedPattern(filePath string) string
+
+	// GetMaxBlobSize returns the maximum size in bytes of a blob that may be
+	// introduced. A size of zero indicates that blob sizes are not restricted.
+	GetMaxBlobSize() uint64
+}
+
+// GlobalRuleFreezeWindow rejects changes to the specified namespaces during a
+// window of time unless they meet the rule's override threshold. The time of a
+// change is the time its RSL entry was recorded.
+type GlobalRuleFreezeWindow interface {
+	GlobalRule
+
+	// Matches indicates if the rule applies to a specified path.
+	Matches(path string, opts ...matchopts.Option) bool
+
+	// GetProtectedNamespaces returns the set of namespaces protected by the
+	// rule.
+	GetProtectedNamespaces() []string
+
+	// GetStart returns the time the freeze begins.
+	GetStart() time.Time
+
+	// GetEnd returns the time the freeze ends.
+	GetEnd() time.Time
+
+	// IsActiveAt indicates if the freeze is in effect at the specified time.
+	IsActiveAt(t time.Time) bool
+
+	// GetOverrideThreshold returns the number of principals that must
+	// approve a change made during the freeze.
+	GetOverrideThreshold() int
+}
+
+// GlobalRuleRestrictRead requires that the contents of commits on the specified
+// namespaces are encrypted to the keys of the principals authorized to read
+// them.
+type GlobalRuleRestrictRead interface {
+	GlobalRule
+
+	// Matches indicates if the rule applies to a specified path.
+	Matches(path string, opts ...matchopts.Option) bool
+
+	// GetProtectedNamespaces returns the set of namespaces protected by the
+	// rule.
+	GetProtectedNamespaces() []string
+
+	// GetReaderPrincipalIDs returns the identifiers of the principals
+	// authorized to read the contents of the namespaces.
+	GetReaderPrincipalIDs() []string
 }
 
 // PropagationDirective represents an instruction to a gittuf client to carry
@@ -6,11 +6,13 @@ package v01
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"slices"
+	"time"
 
 	"github.com/danwakefield/fnmatch"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/tuf"
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
 
@@ -46,6 +48,11 @@ func (r *RootMetadata) SetExpires(expires string) {
 	r.Expires = expires
 }
 
+// GetExpires returns the expiry date of the RootMetadata.
+func (r *RootMetadata) GetExpires() string {
+	return r.Expires
+}
+
 // GetSchemaVersion returns the metadata schema version.
 func (r *RootMetadata) GetSchemaVersion() string {
 	return rootVersion
@@ -72,6 +79,30 @@ func (r *RootMetadata) SetRepositoryLocation(location string) {
 	r.RepositoryLocation = location
 }
 
+// GetDefaultBranch returns an empty string as declaring the default branch is
+// not supported in tufv01 metadata.
+func (r *RootMetadata) GetDefaultBranch() string {
+	return ""
+}
+
+// SetDefaultBranch is not a valid operation for tufv01 metadata, as declaring
+// the default branch was introduced in tufv02.
+func (r *RootMetadata) SetDefaultBranch(_ string) error {
+	return tuf.ErrInvalidOperationForMetadataVersion
+}
+
+// IsExpiryEnforced returns false as enforcing expiry is not supported in
+// tufv01 metadata.
+func (r *RootMetadata) IsExpiryEnforced() bool {
+	return false
+}
+
+// SetExpiryEnforced is not a valid operation for tufv01 metadata, as enforcing
+// expiry was introduced in tufv02.
+func (r *RootMetadata) SetExpiryEnforced(_ bool) error {
+	return tuf.ErrInvalidOperationForMetadataVersion
+}
+
 // AddRootPrincipal adds the specified key to the root metadata and authorizes the key
 // for the root role.
 func (r *RootMetadata) AddRootPrincipal(key tuf.Principal) error {
@@ -387,6 +418,10 @@ func (r *RootMetadata) AddGlobalRule(globalRule tuf.GlobalRule) error {
 		}
 	}
 
+	if err := tuf.ValidateGlobalRulePatterns(globalRule); err != nil {
+		return err
+	}
+
 	allGlobalRules := r.GlobalRules
 	if allGlobalRules == nil {
 		allGlobalRules = []tuf.GlobalRule{}
@@ -434,6 +469,10 @@ func (r *RootMetadata) UpdateGlobalRule(globalRule tuf.GlobalRule) error {
 		}
 	}
 
+	if err := tuf.ValidateGlobalRulePatterns(globalRule); err != nil {
+		return err
+	}
+
 	allGlobalRules := r.GlobalRules
 	updatedGlobalRules := []tuf.GlobalRule{}
 	found := false
@@ -453,6 +492,30 @@ func (r *RootMetadata) UpdateGlobalRule(globalRule tuf.GlobalRule) error {
 				if _, ok := globalRule.(*GlobalRuleBlockForcePushes); !ok {
 					return tuf.ErrCannotUpdateGlobalRuleType
 				}
+			case *GlobalRuleRequireSignedCommits:
+				if _, ok := globalRule.(*GlobalRuleRequireSignedCommits); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRequireSignOff:
+				if _, ok := globalRule.(*GlobalRuleRequireSignOff); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRequireLinearHistory:
+				if _, ok := globalRule.(*GlobalRuleRequireLinearHistory); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRestrictFiles:
+				if _, ok := globalRule.(*GlobalRuleRestrictFiles); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleFreezeWindow:
+				if _, ok := globalRule.(*GlobalRuleFreezeWindow); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRestrictRead:
+				if _, ok := globalRule.(*GlobalRuleRestrictRead); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
 			}
 			found = true
 			updatedGlobalRules = append(updatedGlobalRules, globalRule)
@@ -756,6 +819,54 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 
 			r.GlobalRules = append(r.GlobalRules, globalRule)
 
+		case tuf.GlobalRuleRequireSignedCommitsType:
+			globalRule := &GlobalRuleRequireSignedCommits{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json for global rule: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRequireSignOffType:
+			globalRule := &GlobalRuleRequireSignOff{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json for global rule: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRequireLinearHistoryType:
+			globalRule := &GlobalRuleRequireLinearHistory{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json for global rule: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRestrictFilesType:
+			globalRule := &GlobalRuleRestrictFiles{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json for global rule: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleFreezeWindowType:
+			globalRule := &GlobalRuleFreezeWindow{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json for global rule: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRestrictReadType:
+			globalRule := &GlobalRuleRestrictRead{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json for global rule: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
 		default:
 			return tuf.ErrUnknownGlobalRuleType
 		}
@@ -778,6 +889,67 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RevokeKey is not a valid operation for tufv01 metadata, as key revocations
+// were introduced in tufv02.
+func (r *RootMetadata) RevokeKey(_, _ string) error {
+	return tuf.ErrInvalidOperationForMetadataVersion
+}
+
+// GetKeyRevocations returns nil as key revocations are not supported in tufv01
+// metadata.
+func (r *RootMetadata) GetKeyRevocations() map[string]string {
+	return nil
+}
+
+// RotateKey replaces the key identified by oldKeyID with newKey in the root
+// metadata's keys, in every role, hook, and GitHub app that trusts it, and in
+// the readers of every restrict-read global rule. It returns true if the
+// metadata was modified.
+func (r *RootMetadata) RotateKey(oldKeyID string, newKey tuf.Principal) (bool, error) {
+	key, isKnownType := newKey.(*Key)
+	if !isKnownType {
+		return false, tuf.ErrInvalidPrincipalType
+	}
+	if oldKeyID == "" {
+		return false, tuf.ErrInvalidPrincipalID
+	}
+
+	rotated := false
+	if _, has := r.Keys[oldKeyID]; has {
+		delete(r.Keys, oldKeyID)
+		r.Keys[key.KeyID] = key
+		rotated = true
+	}
+
+	for _, role := range r.Roles {
+		if replaceID(role.KeyIDs, oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	for _, hooks := range r.Hooks {
+		for _, hook := range hooks {
+			if replaceID(hook.PrincipalIDs, oldKeyID, key.KeyID) {
+				rotated = true
+			}
+		}
+	}
+
+	for _, app := range r.GitHubApps {
+		if replaceID(app.PrincipalIDs, oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	for _, globalRule := range r.GlobalRules {
+		if restrictRead, isRestrictRead := globalRule.(*GlobalRuleRestrictRead); isRestrictRead && restrictRead.ReplaceReaderPrincipalID(oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	return rotated, nil
+}
+
 // addKey adds a key to the RootMetadata instance.
 func (r *RootMetadata) addKey(key tuf.Principal) error {
 	if r.Keys == nil {
@@ -823,10 +995,10 @@ func (g *GlobalRuleThreshold) GetName() string {
 	return g.Name
 }
 
-func (g *GlobalRuleThreshold) Matches(path string) bool {
+func (g *GlobalRuleThreshold) Matches(path string, opts ...matchopts.Option) bool {
 	for _, pattern := range g.Paths {
 		// We validate pattern when it's added to / updated in the metadata
-		if matches := fnmatch.Match(pattern, path, 0); matches {
+		if tuf.MatchPattern(pattern, path, opts...) {
 			return true
 		}
 	}
@@ -849,7 +1021,7 @@ type GlobalRuleBlockForcePushes struct {
 
 func NewGlobalRuleBlockForcePushes(name string, paths []string) (*GlobalRuleBlockForcePushes, error) {
 	for _, path := range paths {
-		if !strings.HasPrefix(path, "git:") { // TODO: set prefix correctly
+		if !tuf.IsGitReferencePattern(path) {
 			return nil, tuf.ErrGlobalRuleBlockForcePushesOnlyAppliesToGitPaths
 		}
 	}
@@ -864,10 +1036,10 @@ func (g *GlobalRuleBlockForcePushes) GetName() string {
 	return g.Name
 }
 
-func (g *GlobalRuleBlockForcePushes) Matches(path string) bool {
+func (g *GlobalRuleBlockForcePushes) Matches(path string, opts ...matchopts.Option) bool {
 	for _, pattern := range g.Paths {
 		// We validate pattern when it's added to / updated in the metadata
-		if matches := fnmatch.Match(pattern, path, 0); matches {
+		if tuf.MatchPattern(pattern, path, opts...) {
 			return true
 		}
 	}
@@ -878,6 +1050,323 @@ func (g *GlobalRuleBlockForcePushes) GetProtectedNamespaces() []string {
 	return g.Paths
 }
 
+func (g *GlobalRuleBlockForcePushes) BlocksForcePushes() bool {
+	return true
+}
+
+type GlobalRuleRequireSignedCommits struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	Paths []string `json:"paths"`
+}
+
+func NewGlobalRuleRequireSignedCommits(name string, paths []string) (*GlobalRuleRequireSignedCommits, error) {
+	for _, path := range paths {
+		if !tuf.IsGitReferencePattern(path) {
+			return nil, tuf.ErrGlobalRuleRequireSignedCommitsOnlyAppliesToGitPaths
+		}
+	}
+	return &GlobalRuleRequireSignedCommits{
+		Name:  name,
+		Type:  tuf.GlobalRuleRequireSignedCommitsType,
+		Paths: paths,
+	}, nil
+}
+
+func (g *GlobalRuleRequireSignedCommits) GetName() string {
+	return g.Name
+}
+
+func (g *GlobalRuleRequireSignedCommits) Matches(path string, opts ...matchopts.Option) bool {
+	for _, pattern := range g.Paths {
+		// We validate pattern when it's added to / updated in the metadata
+		if tuf.MatchPattern(pattern, path, opts...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GlobalRuleRequireSignedCommits) GetProtectedNamespaces() []string {
+	return g.Paths
+}
+
+func (g *GlobalRuleRequireSignedCommits) RequiresSignedCommits() bool {
+	return true
+}
+
+type GlobalRuleRequireSignOff struct {
+	Name                      string   `json:"name"`
+	Type                      string   `json:"type"`
+	Paths                     []string `json:"paths"`
+	AllowAssociatedIdentities bool     `json:"allowAssociatedIdentities,omitempty"`
+}
+
+func NewGlobalRuleRequireSignOff(name string, paths []string, allowAssociatedIdentities bool) (*GlobalRuleRequireSignOff, error) {
+	for _, path := range paths {
+		if !tuf.IsGitReferencePattern(path) {
+			return nil, tuf.ErrGlobalRuleRequireSignOffOnlyAppliesToGitPaths
+		}
+	}
+	return &GlobalRuleRequireSignOff{
+		Name:                      name,
+		Type:                      tuf.GlobalRuleRequireSignOffType,
+		Paths:                     paths,
+		AllowAssociatedIdentities: allowAssociatedIdentities,
+	}, nil
+}
+
+func (g *GlobalRuleRequireSignOff) GetName() string {
+	return g.Name
+}
+
+func (g *GlobalRuleRequireSignOff) Matches(path string, opts ...matchopts.Option) bool {
+	for _, pattern := range g.Paths {
+		// We validate pattern when it's added to / updated in the metadata
+		if tuf.MatchPattern(pattern, path, opts...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GlobalRuleRequireSignOff) GetProtectedNamespaces() []string {
+	return g.Paths
+}
+
+func (g *GlobalRuleRequireSignOff) AllowsAssociatedIdentities() bool {
+	return g.AllowAssociatedIdentities
+}
+
+type GlobalRuleRequireLinearHistory struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	Paths []string `json:"paths"`
+}
+
+func NewGlobalRuleRequireLinearHistory(name string, paths []string) (*GlobalRuleRequireLinearHistory, error) {
+	for _, path := range paths {
+		if !tuf.IsGitReferencePattern(path) {
+			return nil, tuf.ErrGlobalRuleRequireLinearHistoryOnlyAppliesToGitPaths
+		}
+	}
+	return &GlobalRuleRequireLinearHistory{
+		Name:  name,
+		Type:  tuf.GlobalRuleRequireLinearHistoryType,
+		Paths: paths,
+	}, nil
+}
+
+func (g *GlobalRuleRequireLinearHistory) GetName() string {
+	return g.Name
+}
+
+func (g *GlobalRuleRequireLinearHistory) Matches(path string, opts ...matchopts.Option) bool {
+	for _, pattern := range g.Paths {
+		// We validate pattern when it's added to / updated in the metadata
+		if tuf.MatchPattern(pattern, path, opts...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GlobalRuleRequireLinearHistory) GetProtectedNamespaces() []string {
+	return g.Paths
+}
+
+func (g *GlobalRuleRequireLinearHistory) RequiresLinearHistory() bool {
+	return true
+}
+
+type GlobalRuleRestrictFiles struct {
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	Paths          []string `json:"paths"`
+	DeniedPatterns []string `json:"deniedPatterns,omitempty"`
+	MaxBlobSize    uint64   `json:"maxBlobSize,omitempty"`
+}
+
+func NewGlobalRuleRestrictFiles(name string, paths, deniedPatterns []string, maxBlobSize uint64) (*GlobalRuleRestrictFiles, error) {
+	for _, path := range paths {
+		if !tuf.IsGitReferencePattern(path) {
+			return nil, tuf.ErrGlobalRuleRestrictFilesOnlyAppliesToGitPaths
+		}
+	}
+	if len(deniedPatterns) == 0 && maxBlobSize == 0 {
+		return nil, tuf.ErrGlobalRuleRestrictFilesHasNoConstraints
+	}
+	return &GlobalRuleRestrictFiles{
+		Name:           name,
+		Type:           tuf.GlobalRuleRestrictFilesType,
+		Paths:          paths,
+		DeniedPatterns: deniedPatterns,
+		MaxBlobSize:    maxBlobSize,
+	}, nil
+}
+
+func (g *GlobalRuleRestrictFiles) GetName() string {
+	return g.Name
+}
+
+func (g *GlobalRuleRestrictFiles) Matches(path string, opts ...matchopts.Option) bool {
+	for _, pattern := range g.Paths {
+		// We validate pattern when it's added to / updated in the metadata
+		if tuf.MatchPattern(pattern, path, opts...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GlobalRuleRestrictFiles) GetProtectedNamespaces() []string {
+	return g.Paths
+}
+
+func (g *GlobalRuleRestrictFiles) GetDeniedPatterns() []string {
+	return g.DeniedPatterns
+}
+
+func (g *GlobalRuleRestrictFiles) MatchesDeniedPattern(filePath string) string {
+	for _, pattern := range g.DeniedPatterns {
+		if matches := fnmatch.Match(pattern, filePath, 0); matches {
+			return pattern
+		}
+	}
+	return ""
+}
+
+func (g *GlobalRuleRestrictFiles) GetMaxBlobSize() uint64 {
+	return g.MaxBlobSize
+}
+
+type GlobalRuleFreezeWindow struct {
+	Name              string    `json:"name"`
+	Type              string    `json:"type"`
+	Paths             []string  `json:"paths"`
+	Start             time.Time `json:"start"`
+	End               time.Time `json:"end"`
+	OverrideThreshold int       `json:"overrideThreshold"`
+}
+
+func NewGlobalRuleFreezeWindow(name string, paths []string, start, end time.Time, overrideThreshold int) (*GlobalRuleFreezeWindow, error) {
+	for _, path := range paths {
+		if !tuf.IsGitReferencePattern(path) {
+			return nil, tuf.ErrGlobalRuleFreezeWindowOnlyAppliesToGitPaths
+		}
+	}
+	if !end.After(start) {
+		return nil, tuf.ErrGlobalRuleFreezeWindowInvalidWindow
+	}
+	if overrideThreshold < 1 {
+		return nil, tuf.ErrInvalidThreshold
+	}
+	return &GlobalRuleFreezeWindow{
+		Name:              name,
+		Type:              tuf.GlobalRuleFreezeWindowType,
+		Paths:             paths,
+		Start:             start.UTC(),
+		End:               end.UTC(),
+		OverrideThreshold: overrideThreshold,
+	}, nil
+}
+
+func (g *GlobalRuleFreezeWindow) GetName() string {
+	return g.Name
+}
+
+func (g *GlobalRuleFreezeWindow) Matches(path string, opts ...matchopts.Option) bool {
+	for _, pattern := range g.Paths {
+		// We validate pattern when it's added to / updated in the metadata
+		if tuf.MatchPattern(pattern, path, opts...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GlobalRuleFreezeWindow) GetProtectedNamespaces() []string {
+	return g.Paths
+}
+
+func (g *GlobalRuleFreezeWindow) GetStart() time.Time {
+	return g.Start
+}
+
+func (g *GlobalRuleFreezeWindow) GetEnd() time.Time {
+	return g.End
+}
+
+func (g *GlobalRuleFreezeWindow) IsActiveAt(t time.Time) bool {
+	return !t.Before(g.Start) && t.Before(g.End)
+}
+
+func (g *GlobalRuleFreezeWindow) GetOverrideThreshold() int {
+	return g.OverrideThreshold
+}
+
+type GlobalRuleRestrictRead struct {
+	Name               string   `json:"name"`
+	Type               string   `json:"type"`
+	Paths              []string `json:"paths"`
+	ReaderPrincipalIDs []string `json:"readerPrincipalIDs"`
+}
+
+func NewGlobalRuleRestrictRead(name string, paths, readerPrincipalIDs []string) (*GlobalRuleRestrictRead, error) {
+	for _, path := range paths {
+		if !tuf.IsGitReferencePattern(path) {
+			return nil, tuf.ErrGlobalRuleRestrictReadOnlyAppliesToGitPaths
+		}
+	}
+	if len(readerPrincipalIDs) == 0 {
+		return nil, tuf.ErrGlobalRuleRestrictReadHasNoReaders
+	}
+	return &GlobalRuleRestrictRead{
+		Name:               name,
+		Type:               tuf.GlobalRuleRestrictReadType,
+		Paths:              paths,
+		ReaderPrincipalIDs: readerPrincipalIDs,
+	}, nil
+}
+
+func (g *GlobalRuleRestrictRead) GetName() string {
+	return g.Name
+}
+
+func (g *GlobalRuleRestrictRead) Matches(path string, opts ...matchopts.Option) bool {
+	for _, pattern := range g.Paths {
+		// We validate pattern when it's added to / updated in the metadata
+		if tuf.MatchPattern(pattern, path, opts...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GlobalRuleRestrictRead) GetProtectedNamespaces() []string {
+	return g.Paths
+}
+
+func (g *GlobalRuleRestrictRead) GetReaderPrincipalIDs() []string {
+	return g.ReaderPrincipalIDs
+}
+
+// ReplaceReaderPrincipalID replaces the reader identified by oldID with newID.
+// It returns true if the rule was modified.
+func (g *GlobalRuleRestrictRead) ReplaceReaderPrincipalID(oldID, newID string) bool {
+	index := slices.Index(g.ReaderPrincipalIDs, oldID)
+	if index == -1 {
+		return false
+	}
+
+	if slices.Contains(g.ReaderPrincipalIDs, newID) {
+		g.ReaderPrincipalIDs = slices.Delete(g.ReaderPrincipalIDs, index, index+1)
+	} else {
+		g.ReaderPrincipalIDs[index] = newID
+	}
+	return true
+}
+
 type PropagationDirective struct {
 	Name                string `json:"name"`
 	UpstreamRepository  string `json:"upstreamRepository"`
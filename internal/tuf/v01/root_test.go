@@ -262,6 +262,61 @@ func TestRootMetadataUnmarshalling(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	globalRuleRequireSignedCommits, err := NewGlobalRuleRequireSignedCommits("gr-requiresignedcommits", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRequireSignedCommits); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRequireSignOff, err := NewGlobalRuleRequireSignOff("gr-requiresignoff", []string{"git:refs/heads/main"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRequireSignOff); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRequireLinearHistory, err := NewGlobalRuleRequireLinearHistory("gr-requirelinearhistory", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRequireLinearHistory); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRestrictFiles, err := NewGlobalRuleRestrictFiles("gr-restrictfiles", []string{"git:refs/heads/main"}, []string{"*.env"}, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRestrictFiles); err != nil {
+		t.Fatal(err)
+	}
+
+	freezeStart := time.Date(2025, time.December, 20, 0, 0, 0, 0, time.UTC)
+	globalRuleFreezeWindow, err := NewGlobalRuleFreezeWindow("gr-freezewindow", []string{"git:refs/heads/main"}, freezeStart, freezeStart.Add(14*24*time.Hour), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleFreezeWindow); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRestrictRead, err := NewGlobalRuleRestrictRead("gr-restrictread", []string{"git:refs/heads/embargo/*"}, []string{"jane.doe@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRestrictRead); err != nil {
+		t.Fatal(err)
+	}
+
 	propagationDirective := NewPropagationDirective("pd", "upstream", "main", "example.com", "main", "example.com")
 
 	if err := rootMetadata.AddPropagationDirective(propagationDirective); err != nil {
@@ -299,6 +354,7 @@ func TestRootMetadataUnmarshalling(t *testing.T) {
 	if err := json.Unmarshal(payload, rootMetadata2); err != nil {
 		t.Fatal()
 	}
+	assert.Equal(t, rootMetadata.GlobalRules, rootMetadata2.GlobalRules)
 
 	sslibKey2 := rootMetadata2.Keys[sslibKey.KeyID]
 
@@ -463,6 +519,42 @@ func TestEnableGitHubAppApprovals(t *testing.T) {
 	assert.True(t, rootMetadata.GitHubApps[appName].Trusted)
 }
 
+func TestRevokeKey(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+
+	err := rootMetadata.RevokeKey("SHA256:key", "1111111111111111111111111111111111111111")
+	assert.ErrorIs(t, err, tuf.ErrInvalidOperationForMetadataVersion)
+	assert.Nil(t, rootMetadata.GetKeyRevocations())
+}
+
+func TestRootMetadataRotateKey(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+	rootKey := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, rootPubKeyBytes))
+	newKey := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+	err := rootMetadata.AddPrimaryRuleFilePrincipal(rootKey)
+	require.Nil(t, err)
+	err = rootMetadata.AddGitHubAppPrincipal(tuf.GitHubAppRoleName, rootKey)
+	require.Nil(t, err)
+	restrictRead, err := NewGlobalRuleRestrictRead("restrict-embargo", []string{"git:refs/heads/embargo/*"}, []string{rootKey.KeyID})
+	require.Nil(t, err)
+	err = rootMetadata.AddGlobalRule(restrictRead)
+	require.Nil(t, err)
+
+	rotated, err := rootMetadata.RotateKey(rootKey.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, map[string]*Key{newKey.KeyID: newKey}, rootMetadata.Keys)
+	assert.Equal(t, set.NewSetFromItems(newKey.KeyID), rootMetadata.Roles[tuf.RootRoleName].KeyIDs)
+	assert.Equal(t, set.NewSetFromItems(newKey.KeyID), rootMetadata.Roles[tuf.TargetsRoleName].KeyIDs)
+	assert.Equal(t, set.NewSetFromItems(newKey.KeyID), rootMetadata.GitHubApps[tuf.GitHubAppRoleName].PrincipalIDs)
+	assert.Equal(t, []string{newKey.KeyID}, restrictRead.ReaderPrincipalIDs)
+
+	rotated, err = rootMetadata.RotateKey(rootKey.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.False(t, rotated)
+}
+
 func TestDisableGitHubAppApprovals(t *testing.T) {
 	rootMetadata := initialTestRootMetadata(t)
 
@@ -852,6 +944,13 @@ func TestGlobalRules(t *testing.T) {
 	assert.Equal(t, "threshold-2-main", rootMetadata.GlobalRules[0].GetName())
 	assert.Equal(t, "block-force-pushes", rootMetadata.GlobalRules[1].GetName())
 
+	signedCommitsGlobalRule, err := NewGlobalRuleRequireSignedCommits("block-force-pushes", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rootMetadata.UpdateGlobalRule(signedCommitsGlobalRule)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+
 	err = rootMetadata.DeleteGlobalRule("threshold-2-main")
 	assert.Nil(t, err)
 	err = rootMetadata.DeleteGlobalRule("block-force-pushes")
@@ -901,6 +1000,317 @@ func TestNewGlobalRuleBlockForcePushes(t *testing.T) {
 	}
 }
 
+func TestNewGlobalRuleRequireSignedCommits(t *testing.T) {
+	tests := map[string]struct {
+		patterns      []string
+		expectedError error
+	}{
+		"no error, single git pattern": {
+			patterns: []string{"git:refs/heads/main"},
+		},
+		"no error, multiple git patterns including wildcards": {
+			patterns: []string{"git:refs/heads/main", "git:refs/heads/release/*"},
+		},
+		"error, single non-git pattern": {
+			patterns:      []string{"file:foo"},
+			expectedError: tuf.ErrGlobalRuleRequireSignedCommitsOnlyAppliesToGitPaths,
+		},
+		"error, mix of git and non-git patterns": {
+			patterns:      []string{"git:refs/heads/main", "file:foo"},
+			expectedError: tuf.ErrGlobalRuleRequireSignedCommitsOnlyAppliesToGitPaths,
+		},
+	}
+
+	for name, test := range tests {
+		rule, err := NewGlobalRuleRequireSignedCommits("test-require-signed-commits", test.patterns)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error '%v' in test '%s'", err, name))
+			assert.Equal(t, test.patterns, rule.Paths)
+			assert.True(t, rule.RequiresSignedCommits())
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error '%v', expected '%v' in test '%s'", err, test.expectedError, name))
+		}
+	}
+}
+
+func TestNewGlobalRuleRequireSignOff(t *testing.T) {
+	tests := map[string]struct {
+		patterns                  []string
+		allowAssociatedIdentities bool
+		expectedError             error
+	}{
+		"no error, single git pattern": {
+			patterns: []string{"git:refs/heads/main"},
+		},
+		"no error, multiple git patterns with associated identities": {
+			patterns:                  []string{"git:refs/heads/main", "git:refs/heads/release/*"},
+			allowAssociatedIdentities: true,
+		},
+		"error, single non-git pattern": {
+			patterns:      []string{"file:foo"},
+			expectedError: tuf.ErrGlobalRuleRequireSignOffOnlyAppliesToGitPaths,
+		},
+		"error, mix of git and non-git patterns": {
+			patterns:      []string{"git:refs/heads/main", "file:foo"},
+			expectedError: tuf.ErrGlobalRuleRequireSignOffOnlyAppliesToGitPaths,
+		},
+	}
+
+	for name, test := range tests {
+		rule, err := NewGlobalRuleRequireSignOff("test-require-sign-off", test.patterns, test.allowAssociatedIdentities)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error '%v' in test '%s'", err, name))
+			assert.Equal(t, test.patterns, rule.Paths)
+			assert.Equal(t, test.allowAssociatedIdentities, rule.AllowsAssociatedIdentities())
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error '%v', expected '%v' in test '%s'", err, test.expectedError, name))
+		}
+	}
+}
+
+func TestNewGlobalRuleRequireLinearHistory(t *testing.T) {
+	tests := map[string]struct {
+		patterns      []string
+		expectedError error
+	}{
+		"no error, multiple git patterns including wildcards": {
+			patterns: []string{"git:refs/heads/main", "git:refs/heads/release/*"},
+		},
+		"error, mix of git and non-git patterns": {
+			patterns:      []string{"git:refs/heads/main", "file:foo"},
+			expectedError: tuf.ErrGlobalRuleRequireLinearHistoryOnlyAppliesToGitPaths,
+		},
+	}
+
+	for name, test := range tests {
+		rule, err := NewGlobalRuleRequireLinearHistory("test-require-linear-history", test.patterns)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error '%v' in test '%s'", err, name))
+			assert.Equal(t, test.patterns, rule.Paths)
+			assert.True(t, rule.RequiresLinearHistory())
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error '%v', expected '%v' in test '%s'", err, test.expectedError, name))
+		}
+	}
+}
+
+func TestNewGlobalRuleRestrictFiles(t *testing.T) {
+	tests := map[string]struct {
+		patterns       []string
+		deniedPatterns []string
+		maxBlobSize    uint64
+		expectedError  error
+	}{
+		"no error, denied patterns only": {
+			patterns:       []string{"git:refs/heads/main"},
+			deniedPatterns: []string{"*.env", "secrets/*"},
+		},
+		"no error, max blob size only": {
+			patterns:    []string{"git:refs/heads/main", "git:refs/heads/release/*"},
+			maxBlobSize: 1024,
+		},
+		"no error, denied patterns and max blob size": {
+			patterns:       []string{"git:refs/heads/main"},
+			deniedPatterns: []string{"*.env"},
+			maxBlobSize:    1024,
+		},
+		"error, mix of git and non-git patterns": {
+			patterns:       []string{"git:refs/heads/main", "file:foo"},
+			deniedPatterns: []string{"*.env"},
+			expectedError:  tuf.ErrGlobalRuleRestrictFilesOnlyAppliesToGitPaths,
+		},
+		"error, no constraints": {
+			patterns:      []string{"git:refs/heads/main"},
+			expectedError: tuf.ErrGlobalRuleRestrictFilesHasNoConstraints,
+		},
+	}
+
+	for name, test := range tests {
+		rule, err := NewGlobalRuleRestrictFiles("test-restrict-files", test.patterns, test.deniedPatterns, test.maxBlobSize)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error '%v' in test '%s'", err, name))
+			assert.Equal(t, test.patterns, rule.Paths)
+			assert.Equal(t, test.deniedPatterns, rule.GetDeniedPatterns())
+			assert.Equal(t, test.maxBlobSize, rule.GetMaxBlobSize())
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error '%v', expected '%v' in test '%s'", err, test.expectedError, name))
+		}
+	}
+
+	t.Run("match denied patterns", func(t *testing.T) {
+		rule, err := NewGlobalRuleRestrictFiles("test-restrict-files", []string{"git:refs/heads/main"}, []string{"*.env", "secrets/*"}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "*.env", rule.MatchesDeniedPattern("config/.env"))
+		assert.Equal(t, "secrets/*", rule.MatchesDeniedPattern("secrets/key"))
+		assert.Equal(t, "", rule.MatchesDeniedPattern("README.md"))
+	})
+}
+
+func TestNewGlobalRuleFreezeWindow(t *testing.T) {
+	start := time.Date(2025, time.December, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(14 * 24 * time.Hour)
+
+	tests := map[string]struct {
+		patterns          []string
+		start             time.Time
+		end               time.Time
+		overrideThreshold int
+		expectedError     error
+	}{
+		"no error": {
+			patterns:          []string{"git:refs/heads/main", "git:refs/heads/release/*"},
+			start:             start,
+			end:               end,
+			overrideThreshold: 2,
+		},
+		"error, mix of git and non-git patterns": {
+			patterns:          []string{"git:refs/heads/main", "file:foo"},
+			start:             start,
+			end:               end,
+			overrideThreshold: 2,
+			expectedError:     tuf.ErrGlobalRuleFreezeWindowOnlyAppliesToGitPaths,
+		},
+		"error, end before start": {
+			patterns:          []string{"git:refs/heads/main"},
+			start:             end,
+			end:               start,
+			overrideThreshold: 2,
+			expectedError:     tuf.ErrGlobalRuleFreezeWindowInvalidWindow,
+		},
+		"error, zero override threshold": {
+			patterns:      []string{"git:refs/heads/main"},
+			start:         start,
+			end:           end,
+			expectedError: tuf.ErrInvalidThreshold,
+		},
+	}
+
+	for name, test := range tests {
+		rule, err := NewGlobalRuleFreezeWindow("test-freeze-window", test.patterns, test.start, test.end, test.overrideThreshold)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error '%v' in test '%s'", err, name))
+			assert.Equal(t, test.patterns, rule.Paths)
+			assert.Equal(t, test.overrideThreshold, rule.GetOverrideThreshold())
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error '%v', expected '%v' in test '%s'", err, test.expectedError, name))
+		}
+	}
+
+	t.Run("is active at", func(t *testing.T) {
+		rule, err := NewGlobalRuleFreezeWindow("test-freeze-window", []string{"git:refs/heads/main"}, start, end, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, rule.IsActiveAt(start.Add(-time.Second)))
+		assert.True(t, rule.IsActiveAt(start))
+		assert.True(t, rule.IsActiveAt(start.Add(time.Hour)))
+		assert.False(t, rule.IsActiveAt(end))
+	})
+}
+
+func TestNewGlobalRuleRestrictRead(t *testing.T) {
+	tests := map[string]struct {
+		patterns      []string
+		readers       []string
+		expectedError error
+	}{
+		"no error": {
+			patterns: []string{"git:refs/heads/embargo/*", "class:tags"},
+			readers:  []string{"jane.doe@example.com"},
+		},
+		"error, mix of git and non-git patterns": {
+			patterns:      []string{"git:refs/heads/embargo/*", "file:foo"},
+			readers:       []string{"jane.doe@example.com"},
+			expectedError: tuf.ErrGlobalRuleRestrictReadOnlyAppliesToGitPaths,
+		},
+		"error, no readers": {
+			patterns:      []string{"git:refs/heads/embargo/*"},
+			expectedError: tuf.ErrGlobalRuleRestrictReadHasNoReaders,
+		},
+	}
+
+	for name, test := range tests {
+		rule, err := NewGlobalRuleRestrictRead("test-restrict-read", test.patterns, test.readers)
+		if test.expectedError == nil {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error '%v' in test '%s'", err, name))
+			assert.Equal(t, test.patterns, rule.GetProtectedNamespaces())
+			assert.Equal(t, test.readers, rule.GetReaderPrincipalIDs())
+			assert.True(t, rule.Matches("git:refs/heads/embargo/fix"))
+			assert.False(t, rule.Matches("git:refs/heads/main"))
+		} else {
+			assert.ErrorIs(t, err, test.expectedError, fmt.Sprintf("unexpected error '%v', expected '%v' in test '%s'", err, test.expectedError, name))
+		}
+	}
+}
+
+func TestGlobalRuleRestrictReadReplaceReaderPrincipalID(t *testing.T) {
+	rule, err := NewGlobalRuleRestrictRead("test-restrict-read", []string{"git:refs/heads/embargo/*"}, []string{"alice", "bob"})
+	require.Nil(t, err)
+
+	assert.True(t, rule.ReplaceReaderPrincipalID("alice", "carol"))
+	assert.Equal(t, []string{"carol", "bob"}, rule.GetReaderPrincipalIDs())
+
+	assert.False(t, rule.ReplaceReaderPrincipalID("alice", "carol"))
+
+	// The new reader is not added again if it is already a reader
+	assert.True(t, rule.ReplaceReaderPrincipalID("bob", "carol"))
+	assert.Equal(t, []string{"carol"}, rule.GetReaderPrincipalIDs())
+}
+
+func TestGlobalRuleInterfacesAreDistinct(t *testing.T) {
+	// Each global rule must implement exactly one of the global rule
+	// interfaces so that the order of cases in type switches does not matter
+	implementedInterfaces := func(rule tuf.GlobalRule) []string {
+		interfaces := []string{}
+		if _, ok := rule.(tuf.GlobalRuleThreshold); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleThresholdType)
+		}
+		if _, ok := rule.(tuf.GlobalRuleBlockForcePushes); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleBlockForcePushesType)
+		}
+		if _, ok := rule.(tuf.GlobalRuleRequireSignedCommits); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleRequireSignedCommitsType)
+		}
+		if _, ok := rule.(tuf.GlobalRuleRequireSignOff); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleRequireSignOffType)
+		}
+		if _, ok := rule.(tuf.GlobalRuleRequireLinearHistory); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleRequireLinearHistoryType)
+		}
+		if _, ok := rule.(tuf.GlobalRuleRestrictFiles); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleRestrictFilesType)
+		}
+		if _, ok := rule.(tuf.GlobalRuleFreezeWindow); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleFreezeWindowType)
+		}
+		if _, ok := rule.(tuf.GlobalRuleRestrictRead); ok {
+			interfaces = append(interfaces, tuf.GlobalRuleRestrictReadType)
+		}
+		return interfaces
+	}
+
+	tests := map[string]tuf.GlobalRule{
+		tuf.GlobalRuleThresholdType:            &GlobalRuleThreshold{},
+		tuf.GlobalRuleBlockForcePushesType:     &GlobalRuleBlockForcePushes{},
+		tuf.GlobalRuleRequireSignedCommitsType: &GlobalRuleRequireSignedCommits{},
+		tuf.GlobalRuleRequireSignOffType:       &GlobalRuleRequireSignOff{},
+		tuf.GlobalRuleRequireLinearHistoryType: &GlobalRuleRequireLinearHistory{},
+		tuf.GlobalRuleRestrictFilesType:        &GlobalRuleRestrictFiles{},
+		tuf.GlobalRuleFreezeWindowType:         &GlobalRuleFreezeWindow{},
+		tuf.GlobalRuleRestrictReadType:         &GlobalRuleRestrictRead{},
+	}
+
+	for ruleType, rule := range tests {
+		t.Run(ruleType, func(t *testing.T) {
+			assert.Equal(t, []string{ruleType}, implementedInterfaces(rule))
+		})
+	}
+}
+
 func TestPropagationDirective(t *testing.T) {
 	name := "test"
 	upstreamRepository := "https://example.com/git/repository"
@@ -8,9 +8,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/danwakefield/fnmatch"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/tuf"
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
 )
 
 const (
@@ -41,6 +41,11 @@ func (t *TargetsMetadata) SetExpires(expires string) {
 	t.Expires = expires
 }
 
+// GetExpires returns the expiry date of the TargetsMetadata.
+func (t *TargetsMetadata) GetExpires() string {
+	return t.Expires
+}
+
 // GetSchemaVersion returns the metadata schema version.
 func (t *TargetsMetadata) GetSchemaVersion() string {
 	return targetsVersion
@@ -76,6 +81,18 @@ func (t *TargetsMetadata) AddRule(ruleName string, authorizedPrincipalIDs, ruleP
 		}
 	}
 
+	for _, pattern := range rulePatterns {
+		if strings.HasPrefix(pattern, tuf.ExclusionPatternPrefix) {
+			// Exclusion patterns were introduced in tufv02
+			return tuf.ErrInvalidOperationForMetadataVersion
+		}
+		if !tuf.IsGlobPattern(pattern) {
+			// Regular expression and ref class patterns were introduced in
+			// tufv02
+			return tuf.ErrInvalidOperationForMetadataVersion
+		}
+	}
+
 	if threshold <= 0 {
 		return tuf.ErrInvalidThreshold
 	}
@@ -115,6 +132,18 @@ func (t *TargetsMetadata) UpdateRule(ruleName string, authorizedPrincipalIDs, ru
 		}
 	}
 
+	for _, pattern := range rulePatterns {
+		if strings.HasPrefix(pattern, tuf.ExclusionPatternPrefix) {
+			// Exclusion patterns were introduced in tufv02
+			return tuf.ErrInvalidOperationForMetadataVersion
+		}
+		if !tuf.IsGlobPattern(pattern) {
+			// Regular expression and ref class patterns were introduced in
+			// tufv02
+			return tuf.ErrInvalidOperationForMetadataVersion
+		}
+	}
+
 	if threshold <= 0 {
 		return tuf.ErrInvalidThreshold
 	}
@@ -242,6 +271,56 @@ func (t *TargetsMetadata) GetRules() []tuf.Rule {
 	return rules
 }
 
+// RotateKey replaces the key identified by oldKeyID with newKey in the rule
+// file's keys and in every rule that trusts it. It returns true if the metadata
+// was modified.
+func (t *TargetsMetadata) RotateKey(oldKeyID string, newKey tuf.Principal) (bool, error) {
+	key, isKnownType := newKey.(*Key)
+	if !isKnownType {
+		return false, tuf.ErrInvalidPrincipalType
+	}
+	if oldKeyID == "" {
+		return false, tuf.ErrInvalidPrincipalID
+	}
+
+	if t.Delegations == nil {
+		return false, nil
+	}
+
+	rotated := false
+	if _, has := t.Delegations.Keys[oldKeyID]; has {
+		delete(t.Delegations.Keys, oldKeyID)
+		t.Delegations.Keys[key.KeyID] = key
+		rotated = true
+	}
+
+	for _, delegation := range t.Delegations.Roles {
+		if replaceID(delegation.KeyIDs, oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	return rotated, nil
+}
+
+// EnableSeparationOfDuties is not a valid operation for tufv01 metadata, as
+// separation of duties was introduced in tufv02.
+func (t *TargetsMetadata) EnableSeparationOfDuties(_ string) error {
+	return tuf.ErrInvalidOperationForMetadataVersion
+}
+
+// DisableSeparationOfDuties is not a valid operation for tufv01 metadata, as
+// separation of duties was introduced in tufv02.
+func (t *TargetsMetadata) DisableSeparationOfDuties(_ string) error {
+	return tuf.ErrInvalidOperationForMetadataVersion
+}
+
+// SetRuleDetails is not a valid operation for tufv01 metadata, as rule details
+// were introduced in tufv02.
+func (t *TargetsMetadata) SetRuleDetails(_, _, _, _ string) error {
+	return tuf.ErrInvalidOperationForMetadataVersion
+}
+
 // AddPrincipal adds a principal to the metadata.
 //
 // TODO: this isn't associated with a specific rule; with the removal of
@@ -326,10 +405,10 @@ func (d *Delegation) ID() string {
 }
 
 // Matches checks if any of the delegation's patterns match the target.
-func (d *Delegation) Matches(target string) bool {
+func (d *Delegation) Matches(target string, opts ...matchopts.Option) bool {
 	for _, pattern := range d.Paths {
 		// We validate pattern when it's added to / updated in the metadata
-		if matches := fnmatch.Match(pattern, target, 0); matches {
+		if tuf.MatchPattern(pattern, target, opts...) {
 			return true
 		}
 	}
@@ -362,3 +441,33 @@ func (d *Delegation) IsLastTrustedInRuleFile() bool {
 func (d *Delegation) GetProtectedNamespaces() []string {
 	return d.Paths
 }
+
+// GetExcludedNamespaces returns nil as exclusion patterns are not supported in
+// tufv01 delegations.
+func (d *Delegation) GetExcludedNamespaces() []string {
+	return nil
+}
+
+// RequiresSeparationOfDuties returns false as separation of duties is not
+// supported in tufv01 delegations.
+func (d *Delegation) RequiresSeparationOfDuties() bool {
+	return false
+}
+
+// GetDescription returns an empty string as rule details are not supported in
+// tufv01 delegations.
+func (d *Delegation) GetDescription() string {
+	return ""
+}
+
+// GetContact returns an empty string as rule details are not supported in
+// tufv01 delegations.
+func (d *Delegation) GetContact() string {
+	return ""
+}
+
+// GetLink returns an empty string as rule details are not supported in tufv01
+// delegations.
+func (d *Delegation) GetLink() string {
+	return ""
+}
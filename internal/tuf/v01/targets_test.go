@@ -270,6 +270,15 @@ func TestAddRuleAndGetRules(t *testing.T) {
 	rules := targetsMetadata.GetRules()
 	assert.Equal(t, 2, len(rules))
 	assert.Equal(t, []tuf.Rule{rule, AllowRule()}, rules)
+
+	err = targetsMetadata.AddRule("test-rule-with-exclusions", []string{key1.KeyID}, []string{"test/", "!test/generated/"}, 1)
+	assert.ErrorIs(t, err, tuf.ErrInvalidOperationForMetadataVersion)
+
+	err = targetsMetadata.AddRule("test-rule-with-regex", []string{key1.KeyID}, []string{"re:git:refs/heads/release-[0-9]+"}, 1)
+	assert.ErrorIs(t, err, tuf.ErrInvalidOperationForMetadataVersion)
+
+	err = targetsMetadata.AddRule("test-rule-with-ref-class", []string{key1.KeyID}, []string{"class:tags"}, 1)
+	assert.ErrorIs(t, err, tuf.ErrInvalidOperationForMetadataVersion)
 }
 
 func TestUpdateDelegation(t *testing.T) {
@@ -415,6 +424,73 @@ func TestRemoveRule(t *testing.T) {
 	assert.Contains(t, targetsMetadata.Delegations.Keys, key.KeyID)
 }
 
+func TestSeparationOfDuties(t *testing.T) {
+	targetsMetadata := initialTestTargetsMetadata(t)
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	if err := targetsMetadata.AddPrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	err := targetsMetadata.AddRule("test-rule", []string{key.KeyID}, []string{"test/"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = targetsMetadata.EnableSeparationOfDuties("test-rule")
+	assert.ErrorIs(t, err, tuf.ErrInvalidOperationForMetadataVersion)
+
+	err = targetsMetadata.DisableSeparationOfDuties("test-rule")
+	assert.ErrorIs(t, err, tuf.ErrInvalidOperationForMetadataVersion)
+
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequiresSeparationOfDuties())
+}
+
+func TestSetRuleDetails(t *testing.T) {
+	targetsMetadata := initialTestTargetsMetadata(t)
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	if err := targetsMetadata.AddPrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	err := targetsMetadata.AddRule("test-rule", []string{key.KeyID}, []string{"test/"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = targetsMetadata.SetRuleDetails("test-rule", "Protects test files", "", "")
+	assert.ErrorIs(t, err, tuf.ErrInvalidOperationForMetadataVersion)
+
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].GetDescription())
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].GetContact())
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].GetLink())
+}
+
+func TestRotateKey(t *testing.T) {
+	targetsMetadata := initialTestTargetsMetadata(t)
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	newKey := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets2PubKeyBytes))
+	if err := targetsMetadata.AddPrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := targetsMetadata.AddRule("test-rule", []string{key.KeyID}, []string{"test/"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := targetsMetadata.RotateKey(key.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, map[string]*Key{newKey.KeyID: newKey}, targetsMetadata.Delegations.Keys)
+	assert.Equal(t, set.NewSetFromItems(newKey.KeyID), targetsMetadata.Delegations.Roles[0].KeyIDs)
+
+	rotated, err = targetsMetadata.RotateKey(key.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.False(t, rotated)
+}
+
 func TestRemovePrincipal(t *testing.T) {
 	targetsMetadata := initialTestTargetsMetadata(t)
 
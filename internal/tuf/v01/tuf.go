@@ -50,3 +50,14 @@ type Role struct {
 	KeyIDs    *set.Set[string] `json:"keyids"`
 	Threshold int              `json:"threshold"`
 }
+
+// replaceID swaps oldID for newID in ids. It returns true if oldID was present.
+func replaceID(ids *set.Set[string], oldID, newID string) bool {
+	if ids == nil || !ids.Has(oldID) {
+		return false
+	}
+
+	ids.Remove(oldID)
+	ids.Add(newID)
+	return true
+}
@@ -6,10 +6,12 @@ package v02
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
 
 const (
@@ -23,6 +25,8 @@ type RootMetadata struct {
 	Expires            string                     `json:"expires"`
 	Version            uint64                     `json:"version"`
 	RepositoryLocation string                     `json:"repositoryLocation,omitempty"`
+	DefaultBranch      string                     `json:"defaultBranch,omitempty"`
+	EnforceExpiry      bool                       `json:"enforceExpiry,omitempty"`
 	Principals         map[string]tuf.Principal   `json:"principals"`
 	Roles              map[string]Role            `json:"roles"`
 	GitHubApps         map[string]*GitHubApp      `json:"githubApps,omitempty"`
@@ -30,6 +34,7 @@ type RootMetadata struct {
 	Propagations       []tuf.PropagationDirective `json:"propagations,omitempty"`
 	MultiRepository    *MultiRepository           `json:"multiRepository,omitempty"`
 	Hooks              map[tuf.HookStage][]*Hook  `json:"hooks,omitempty"`
+	RevokedKeys        map[string]string          `json:"revokedKeys,omitempty"`
 }
 
 // NewRootMetadata returns a new instance of RootMetadata.
@@ -46,6 +51,11 @@ func (r *RootMetadata) SetExpires(expires string) {
 	r.Expires = expires
 }
 
+// GetExpires returns the expiry date of the RootMetadata.
+func (r *RootMetadata) GetExpires() string {
+	return r.Expires
+}
+
 // GetSchemaVersion returns the metadata schema version.
 func (r *RootMetadata) GetSchemaVersion() string {
 	return r.SchemaVersion
@@ -72,6 +82,36 @@ func (r *RootMetadata) SetRepositoryLocation(location string) {
 	r.RepositoryLocation = location
 }
 
+// GetDefaultBranch returns the fully qualified name of the repository's
+// default branch declared in the root metadata, if any.
+func (r *RootMetadata) GetDefaultBranch() string {
+	return r.DefaultBranch
+}
+
+// SetDefaultBranch declares the fully qualified name of the repository's
+// default branch in the root metadata.
+func (r *RootMetadata) SetDefaultBranch(refName string) error {
+	if !strings.HasPrefix(refName, gitinterface.BranchRefPrefix) || refName == gitinterface.BranchRefPrefix {
+		return tuf.ErrInvalidDefaultBranch
+	}
+
+	r.DefaultBranch = refName
+	return nil
+}
+
+// IsExpiryEnforced indicates if policy verification must reject metadata that
+// has expired.
+func (r *RootMetadata) IsExpiryEnforced() bool {
+	return r.EnforceExpiry
+}
+
+// SetExpiryEnforced sets whether policy verification must reject metadata that
+// has expired.
+func (r *RootMetadata) SetExpiryEnforced(enforce bool) error {
+	r.EnforceExpiry = enforce
+	return nil
+}
+
 // AddRootPrincipal adds the specified principal to the root metadata and
 // authorizes the principal for the root role.
 func (r *RootMetadata) AddRootPrincipal(principal tuf.Principal) error {
@@ -368,6 +408,8 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 		Expires            string                     `json:"expires"`
 		Version            uint64                     `json:"version"`
 		RepositoryLocation string                     `json:"repositoryLocation,omitempty"`
+		DefaultBranch      string                     `json:"defaultBranch,omitempty"`
+		EnforceExpiry      bool                       `json:"enforceExpiry,omitempty"`
 		Principals         map[string]json.RawMessage `json:"principals"`
 		Roles              map[string]Role            `json:"roles"`
 		GitHubApps         map[string]*GitHubApp      `json:"githubApps,omitempty"`
@@ -375,6 +417,7 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 		Propagations       []json.RawMessage          `json:"propagations,omitempty"`
 		MultiRepository    *MultiRepository           `json:"multiRepository,omitempty"`
 		Hooks              map[tuf.HookStage][]*Hook  `json:"hooks,omitempty"`
+		RevokedKeys        map[string]string          `json:"revokedKeys,omitempty"`
 	}
 
 	temp := &tempType{}
@@ -387,6 +430,8 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 	r.Expires = temp.Expires
 	r.Version = temp.Version
 	r.RepositoryLocation = temp.RepositoryLocation
+	r.DefaultBranch = temp.DefaultBranch
+	r.EnforceExpiry = temp.EnforceExpiry
 
 	r.Principals = make(map[string]tuf.Principal)
 	for principalID, principalBytes := range temp.Principals {
@@ -447,6 +492,54 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 
 			r.GlobalRules = append(r.GlobalRules, globalRule)
 
+		case tuf.GlobalRuleRequireSignedCommitsType:
+			globalRule := &GlobalRuleRequireSignedCommits{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRequireSignOffType:
+			globalRule := &GlobalRuleRequireSignOff{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRequireLinearHistoryType:
+			globalRule := &GlobalRuleRequireLinearHistory{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRestrictFilesType:
+			globalRule := &GlobalRuleRestrictFiles{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleFreezeWindowType:
+			globalRule := &GlobalRuleFreezeWindow{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
+		case tuf.GlobalRuleRestrictReadType:
+			globalRule := &GlobalRuleRestrictRead{}
+			if err := json.Unmarshal(globalRuleBytes, globalRule); err != nil {
+				return fmt.Errorf("unable to unmarshal json: %w", err)
+			}
+
+			r.GlobalRules = append(r.GlobalRules, globalRule)
+
 		default:
 			return tuf.ErrUnknownGlobalRuleType
 		}
@@ -466,9 +559,76 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 
 	r.Hooks = temp.Hooks
 
+	r.RevokedKeys = temp.RevokedKeys
+
+	return nil
+}
+
+// RevokeKey records that the key identified by keyID is revoked for all RSL
+// entries recorded after the entry identified by effectiveEntryID. If the key
+// is already revoked, the entry after which it is revoked is updated.
+func (r *RootMetadata) RevokeKey(keyID, effectiveEntryID string) error {
+	if keyID == "" || effectiveEntryID == "" {
+		return tuf.ErrInvalidKeyRevocation
+	}
+
+	if r.RevokedKeys == nil {
+		r.RevokedKeys = map[string]string{}
+	}
+	r.RevokedKeys[keyID] = effectiveEntryID
 	return nil
 }
 
+// GetKeyRevocations returns the IDs of revoked keys mapped to the RSL entries
+// after which they are revoked.
+func (r *RootMetadata) GetKeyRevocations() map[string]string {
+	return r.RevokedKeys
+}
+
+// RotateKey replaces the key identified by oldKeyID with newKey wherever it is
+// trusted in the root metadata: as a principal of a role, hook, or GitHub app,
+// as a reader in a restrict-read global rule, or as one of a person's keys. It
+// returns true if the metadata was modified.
+func (r *RootMetadata) RotateKey(oldKeyID string, newKey tuf.Principal) (bool, error) {
+	key, isKey := newKey.(*Key)
+	if !isKey {
+		return false, tuf.ErrInvalidPrincipalType
+	}
+	if oldKeyID == "" {
+		return false, tuf.ErrInvalidPrincipalID
+	}
+
+	rotated := rotateKeyInPrincipals(r.Principals, oldKeyID, key)
+
+	for _, role := range r.Roles {
+		if replaceID(role.PrincipalIDs, oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	for _, hooks := range r.Hooks {
+		for _, hook := range hooks {
+			if replaceID(hook.PrincipalIDs, oldKeyID, key.KeyID) {
+				rotated = true
+			}
+		}
+	}
+
+	for _, app := range r.GitHubApps {
+		if replaceID(app.PrincipalIDs, oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	for _, globalRule := range r.GlobalRules {
+		if restrictRead, isRestrictRead := globalRule.(*GlobalRuleRestrictRead); isRestrictRead && restrictRead.ReplaceReaderPrincipalID(oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	return rotated, nil
+}
+
 // AddGlobalRule adds a new global rule to RootMetadata.
 func (r *RootMetadata) AddGlobalRule(globalRule tuf.GlobalRule) error {
 	if thresholdRule, ok := globalRule.(tuf.GlobalRuleThreshold); ok {
@@ -477,6 +637,10 @@ func (r *RootMetadata) AddGlobalRule(globalRule tuf.GlobalRule) error {
 		}
 	}
 
+	if err := tuf.ValidateGlobalRulePatterns(globalRule); err != nil {
+		return err
+	}
+
 	allGlobalRules := r.GlobalRules
 	if allGlobalRules == nil {
 		allGlobalRules = []tuf.GlobalRule{}
@@ -520,6 +684,10 @@ func (r *RootMetadata) UpdateGlobalRule(globalRule tuf.GlobalRule) error {
 		}
 	}
 
+	if err := tuf.ValidateGlobalRulePatterns(globalRule); err != nil {
+		return err
+	}
+
 	allGlobalRules := r.GlobalRules
 	updatedGlobalRules := []tuf.GlobalRule{}
 	found := false
@@ -539,6 +707,30 @@ func (r *RootMetadata) UpdateGlobalRule(globalRule tuf.GlobalRule) error {
 				if _, ok := globalRule.(*GlobalRuleBlockForcePushes); !ok {
 					return tuf.ErrCannotUpdateGlobalRuleType
 				}
+			case *GlobalRuleRequireSignedCommits:
+				if _, ok := globalRule.(*GlobalRuleRequireSignedCommits); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRequireSignOff:
+				if _, ok := globalRule.(*GlobalRuleRequireSignOff); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRequireLinearHistory:
+				if _, ok := globalRule.(*GlobalRuleRequireLinearHistory); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRestrictFiles:
+				if _, ok := globalRule.(*GlobalRuleRestrictFiles); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleFreezeWindow:
+				if _, ok := globalRule.(*GlobalRuleFreezeWindow); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
+			case *GlobalRuleRestrictRead:
+				if _, ok := globalRule.(*GlobalRuleRestrictRead); !ok {
+					return tuf.ErrCannotUpdateGlobalRuleType
+				}
 			}
 			found = true
 			updatedGlobalRules = append(updatedGlobalRules, globalRule)
@@ -851,9 +1043,21 @@ func (r *RootMetadata) addRole(roleName string, role Role) {
 
 type GlobalRuleThreshold = tufv01.GlobalRuleThreshold
 type GlobalRuleBlockForcePushes = tufv01.GlobalRuleBlockForcePushes
+type GlobalRuleRequireSignedCommits = tufv01.GlobalRuleRequireSignedCommits
+type GlobalRuleRequireSignOff = tufv01.GlobalRuleRequireSignOff
+type GlobalRuleRequireLinearHistory = tufv01.GlobalRuleRequireLinearHistory
+type GlobalRuleRestrictFiles = tufv01.GlobalRuleRestrictFiles
+type GlobalRuleFreezeWindow = tufv01.GlobalRuleFreezeWindow
+type GlobalRuleRestrictRead = tufv01.GlobalRuleRestrictRead
 
 var NewGlobalRuleThreshold = tufv01.NewGlobalRuleThreshold
 var NewGlobalRuleBlockForcePushes = tufv01.NewGlobalRuleBlockForcePushes
+var NewGlobalRuleRequireSignedCommits = tufv01.NewGlobalRuleRequireSignedCommits
+var NewGlobalRuleRequireSignOff = tufv01.NewGlobalRuleRequireSignOff
+var NewGlobalRuleRequireLinearHistory = tufv01.NewGlobalRuleRequireLinearHistory
+var NewGlobalRuleRestrictFiles = tufv01.NewGlobalRuleRestrictFiles
+var NewGlobalRuleFreezeWindow = tufv01.NewGlobalRuleFreezeWindow
+var NewGlobalRuleRestrictRead = tufv01.NewGlobalRuleRestrictRead
 
 type PropagationDirective = tufv01.PropagationDirective
 
@@ -91,6 +91,32 @@ func TestRootMetadata(t *testing.T) {
 		assert.Equal(t, location, currentLocation)
 	})
 
+	t.Run("test default branch", func(t *testing.T) {
+		assert.Equal(t, "", rootMetadata.GetDefaultBranch())
+
+		err := rootMetadata.SetDefaultBranch("refs/heads/main")
+		assert.Nil(t, err)
+		assert.Equal(t, "refs/heads/main", rootMetadata.GetDefaultBranch())
+
+		for _, refName := range []string{"", "main", "refs/heads/", "refs/tags/v1"} {
+			err = rootMetadata.SetDefaultBranch(refName)
+			assert.ErrorIs(t, err, tuf.ErrInvalidDefaultBranch, refName)
+		}
+		assert.Equal(t, "refs/heads/main", rootMetadata.GetDefaultBranch())
+	})
+
+	t.Run("test expiry enforcement", func(t *testing.T) {
+		assert.False(t, rootMetadata.IsExpiryEnforced())
+
+		err := rootMetadata.SetExpiryEnforced(true)
+		assert.Nil(t, err)
+		assert.True(t, rootMetadata.IsExpiryEnforced())
+
+		err = rootMetadata.SetExpiryEnforced(false)
+		assert.Nil(t, err)
+		assert.False(t, rootMetadata.IsExpiryEnforced())
+	})
+
 	t.Run("test propagation directives", func(t *testing.T) {
 		directives := rootMetadata.GetPropagationDirectives()
 		assert.Empty(t, directives)
@@ -269,6 +295,61 @@ func TestRootMetadataUnmarshalling(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	globalRuleRequireSignedCommits, err := tufv01.NewGlobalRuleRequireSignedCommits("gr-requiresignedcommits", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRequireSignedCommits); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRequireSignOff, err := tufv01.NewGlobalRuleRequireSignOff("gr-requiresignoff", []string{"git:refs/heads/main"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRequireSignOff); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRequireLinearHistory, err := tufv01.NewGlobalRuleRequireLinearHistory("gr-requirelinearhistory", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRequireLinearHistory); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRestrictFiles, err := tufv01.NewGlobalRuleRestrictFiles("gr-restrictfiles", []string{"git:refs/heads/main"}, []string{"*.env"}, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRestrictFiles); err != nil {
+		t.Fatal(err)
+	}
+
+	freezeStart := time.Date(2025, time.December, 20, 0, 0, 0, 0, time.UTC)
+	globalRuleFreezeWindow, err := tufv01.NewGlobalRuleFreezeWindow("gr-freezewindow", []string{"git:refs/heads/main"}, freezeStart, freezeStart.Add(14*24*time.Hour), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleFreezeWindow); err != nil {
+		t.Fatal(err)
+	}
+
+	globalRuleRestrictRead, err := tufv01.NewGlobalRuleRestrictRead("gr-restrictread", []string{"git:refs/heads/embargo/*"}, []string{"jane.doe@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rootMetadata.AddGlobalRule(globalRuleRestrictRead); err != nil {
+		t.Fatal(err)
+	}
+
 	propagationDirective := NewPropagationDirective("pd", "upstream", "main", "example.com", "main", "example.com")
 
 	if err := rootMetadata.AddPropagationDirective(propagationDirective); err != nil {
@@ -307,6 +388,7 @@ func TestRootMetadataUnmarshalling(t *testing.T) {
 		t.Log(string(payload))
 		t.Fatal(err)
 	}
+	assert.Equal(t, rootMetadata.GlobalRules, rootMetadata2.GlobalRules)
 
 	sslibKey2 := rootMetadata2.Principals[sslibKey.KeyID]
 
@@ -542,6 +624,101 @@ func TestDisableGitHubAppApprovals(t *testing.T) {
 	assert.False(t, rootMetadata.GitHubApps[appName].Trusted)
 }
 
+func TestRevokeKey(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+	assert.Empty(t, rootMetadata.GetKeyRevocations())
+
+	keyID := "SHA256:key"
+	err := rootMetadata.RevokeKey(keyID, "1111111111111111111111111111111111111111")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{keyID: "1111111111111111111111111111111111111111"}, rootMetadata.GetKeyRevocations())
+
+	// Revoking again updates the effective entry
+	err = rootMetadata.RevokeKey(keyID, "2222222222222222222222222222222222222222")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{keyID: "2222222222222222222222222222222222222222"}, rootMetadata.GetKeyRevocations())
+
+	// Revocations survive a round trip
+	rootMetadataBytes, err := json.Marshal(rootMetadata)
+	require.Nil(t, err)
+	newRootMetadata := &RootMetadata{}
+	err = json.Unmarshal(rootMetadataBytes, newRootMetadata)
+	require.Nil(t, err)
+	assert.Equal(t, rootMetadata.GetKeyRevocations(), newRootMetadata.GetKeyRevocations())
+
+	err = rootMetadata.RevokeKey("", "1111111111111111111111111111111111111111")
+	assert.ErrorIs(t, err, tuf.ErrInvalidKeyRevocation)
+
+	err = rootMetadata.RevokeKey(keyID, "")
+	assert.ErrorIs(t, err, tuf.ErrInvalidKeyRevocation)
+}
+
+func TestRootMetadataRotateKey(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+	rootKey := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, rootPubKeyBytes))
+	targetsKey := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	newKey := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets2PubKeyBytes))
+
+	person := &Person{
+		PersonID:   "jane.doe",
+		PublicKeys: map[string]*Key{targetsKey.KeyID: targetsKey},
+	}
+	err := rootMetadata.AddPrimaryRuleFilePrincipal(person)
+	require.Nil(t, err)
+	err = rootMetadata.AddPrimaryRuleFilePrincipal(rootKey)
+	require.Nil(t, err)
+
+	_, err = rootMetadata.AddHook([]tuf.HookStage{tuf.HookStagePreCommit}, "test-hook", []string{rootKey.KeyID}, map[string]string{"sha1": "1111111111111111111111111111111111111111"}, tuf.HookEnvironmentLua, 100)
+	require.Nil(t, err)
+	err = rootMetadata.AddGitHubAppPrincipal(tuf.GitHubAppRoleName, rootKey)
+	require.Nil(t, err)
+	restrictRead, err := NewGlobalRuleRestrictRead("restrict-embargo", []string{"git:refs/heads/embargo/*"}, []string{rootKey.KeyID, person.PersonID})
+	require.Nil(t, err)
+	err = rootMetadata.AddGlobalRule(restrictRead)
+	require.Nil(t, err)
+
+	rotated, err := rootMetadata.RotateKey(rootKey.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.True(t, rotated)
+
+	rootPrincipals, err := rootMetadata.GetRootPrincipals()
+	require.Nil(t, err)
+	assert.Equal(t, []tuf.Principal{newKey}, rootPrincipals)
+	assert.NotContains(t, rootMetadata.GetPrincipals(), rootKey.KeyID)
+
+	primaryRuleFilePrincipals, err := rootMetadata.GetPrimaryRuleFilePrincipals()
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []tuf.Principal{person, newKey}, primaryRuleFilePrincipals)
+
+	hooks, err := rootMetadata.GetHooks(tuf.HookStagePreCommit)
+	require.Nil(t, err)
+	assert.Equal(t, []string{newKey.KeyID}, hooks[0].GetPrincipalIDs().Contents())
+
+	appPrincipals, err := rootMetadata.GetGitHubAppPrincipals(tuf.GitHubAppRoleName)
+	require.Nil(t, err)
+	assert.Equal(t, []tuf.Principal{newKey}, appPrincipals)
+
+	assert.Equal(t, []string{newKey.KeyID, person.PersonID}, restrictRead.GetReaderPrincipalIDs())
+
+	// Keys held by a person are replaced in place
+	rotated, err = rootMetadata.RotateKey(targetsKey.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, map[string]*Key{newKey.KeyID: newKey}, person.PublicKeys)
+
+	rotated, err = rootMetadata.RotateKey(targetsKey.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.False(t, rotated)
+
+	t.Run("miscellaneous error checking", func(t *testing.T) {
+		_, err := rootMetadata.RotateKey("", newKey)
+		assert.ErrorIs(t, err, tuf.ErrInvalidPrincipalID)
+
+		_, err = rootMetadata.RotateKey(newKey.KeyID, person)
+		assert.ErrorIs(t, err, tuf.ErrInvalidPrincipalType)
+	})
+}
+
 func TestGetGitHubAppEntries(t *testing.T) {
 	rootMetadata := initialTestRootMetadata(t)
 
@@ -748,6 +925,24 @@ func TestIsGitHubAppApprovalTrusted(t *testing.T) {
 	assert.True(t, trusted)
 }
 
+func TestGlobalRuleForDefaultBranch(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+
+	// The default branch need not be declared, as it follows the
+	// repository's symbolic HEAD by default
+	err := rootMetadata.AddGlobalRule(NewGlobalRuleThreshold("threshold-2-default", []string{"class:default-branch"}, 2))
+	assert.Nil(t, err)
+
+	err = rootMetadata.AddGlobalRule(NewGlobalRuleThreshold("threshold-2-branches", []string{"class:branches"}, 2))
+	assert.Nil(t, err)
+	err = rootMetadata.UpdateGlobalRule(NewGlobalRuleThreshold("threshold-2-branches", []string{"class:branches-except-default"}, 2))
+	assert.Nil(t, err)
+	assert.Len(t, rootMetadata.GlobalRules, 2)
+
+	err = rootMetadata.AddGlobalRule(NewGlobalRuleThreshold("threshold-2-invalid", []string{"class:default"}, 2))
+	assert.ErrorIs(t, err, tuf.ErrInvalidPattern)
+}
+
 func TestGlobalRules(t *testing.T) {
 	t.Run("matches", func(t *testing.T) {
 		tests := map[string]struct {
@@ -953,6 +1148,13 @@ func TestGlobalRules(t *testing.T) {
 	assert.Equal(t, "threshold-2-main", rootMetadata.GlobalRules[0].GetName())
 	assert.Equal(t, "block-force-pushes", rootMetadata.GlobalRules[1].GetName())
 
+	signedCommitsGlobalRule, err := NewGlobalRuleRequireSignedCommits("block-force-pushes", []string{"git:refs/heads/main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rootMetadata.UpdateGlobalRule(signedCommitsGlobalRule)
+	assert.ErrorIs(t, err, tuf.ErrCannotUpdateGlobalRuleType)
+
 	err = rootMetadata.DeleteGlobalRule("threshold-2-main")
 	assert.Nil(t, err)
 	err = rootMetadata.DeleteGlobalRule("block-force-pushes")
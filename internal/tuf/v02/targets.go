@@ -9,9 +9,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/danwakefield/fnmatch"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/tuf"
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
 )
 
 const (
@@ -46,6 +46,11 @@ func (t *TargetsMetadata) SetExpires(expires string) {
 	t.Expires = expires
 }
 
+// GetExpires returns the expiry date of the TargetsMetadata.
+func (t *TargetsMetadata) GetExpires() string {
+	return t.Expires
+}
+
 // GetSchemaVersion returns the metadata schema version.
 func (t *TargetsMetadata) GetSchemaVersion() string {
 	return t.SchemaVersion
@@ -76,9 +81,22 @@ func (t *TargetsMetadata) AddRule(ruleName string, authorizedPrincipalIDs, ruleP
 	}
 
 	for _, principalID := range authorizedPrincipalIDs {
-		if _, has := t.Delegations.Principals[principalID]; !has {
+		principal, has := t.Delegations.Principals[principalID]
+		if !has {
 			return tuf.ErrPrincipalNotFound
 		}
+
+		if team, isTeam := principal.(*Team); isTeam {
+			// Check the team's sub-threshold can be met by its members
+			if err := t.Delegations.validateTeam(team); err != nil {
+				return err
+			}
+		}
+	}
+
+	paths, excludedPaths, err := splitRulePatterns(rulePatterns)
+	if err != nil {
+		return err
 	}
 
 	if threshold <= 0 {
@@ -95,9 +113,10 @@ func (t *TargetsMetadata) AddRule(ruleName string, authorizedPrincipalIDs, ruleP
 	}
 
 	newDelegation := &Delegation{
-		Name:        ruleName,
-		Paths:       rulePatterns,
-		Terminating: false,
+		Name:          ruleName,
+		Paths:         paths,
+		ExcludedPaths: excludedPaths,
+		Terminating:   false,
 		Role: Role{
 			PrincipalIDs: set.NewSetFromItems(authorizedPrincipalIDs...),
 			Threshold:    threshold,
@@ -115,9 +134,22 @@ func (t *TargetsMetadata) UpdateRule(ruleName string, authorizedPrincipalIDs, ru
 	}
 
 	for _, principalID := range authorizedPrincipalIDs {
-		if _, has := t.Delegations.Principals[principalID]; !has {
+		principal, has := t.Delegations.Principals[principalID]
+		if !has {
 			return tuf.ErrPrincipalNotFound
 		}
+
+		if team, isTeam := principal.(*Team); isTeam {
+			// Check the team's sub-threshold can be met by its members
+			if err := t.Delegations.validateTeam(team); err != nil {
+				return err
+			}
+		}
+	}
+
+	paths, excludedPaths, err := splitRulePatterns(rulePatterns)
+	if err != nil {
+		return err
 	}
 
 	if threshold <= 0 {
@@ -140,7 +172,8 @@ func (t *TargetsMetadata) UpdateRule(ruleName string, authorizedPrincipalIDs, ru
 		}
 
 		if delegation.Name == ruleName {
-			delegation.Paths = rulePatterns
+			delegation.Paths = paths
+			delegation.ExcludedPaths = excludedPaths
 			delegation.Role = Role{
 				PrincipalIDs: set.NewSetFromItems(authorizedPrincipalIDs...),
 				Threshold:    threshold,
@@ -224,6 +257,90 @@ func (t *TargetsMetadata) RemoveRule(ruleName string) error {
 	return nil
 }
 
+// EnableSeparationOfDuties marks the delegation identified by ruleName as
+// requiring separation of duties.
+func (t *TargetsMetadata) EnableSeparationOfDuties(ruleName string) error {
+	return t.setSeparationOfDuties(ruleName, true)
+}
+
+// DisableSeparationOfDuties marks the delegation identified by ruleName as no
+// longer requiring separation of duties.
+func (t *TargetsMetadata) DisableSeparationOfDuties(ruleName string) error {
+	return t.setSeparationOfDuties(ruleName, false)
+}
+
+func (t *TargetsMetadata) setSeparationOfDuties(ruleName string, required bool) error {
+	if strings.HasPrefix(ruleName, tuf.GittufPrefix) {
+		return tuf.ErrCannotManipulateRulesWithGittufPrefix
+	}
+
+	if t.Delegations != nil {
+		for _, delegation := range t.Delegations.Roles {
+			if delegation.Name == ruleName {
+				delegation.SeparationOfDuties = required
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: '%s'", tuf.ErrRuleNotFound, ruleName)
+}
+
+// SetRuleDetails records the description of the delegation identified by
+// ruleName, who to contact about it, and a link to further context. Empty
+// values clear the corresponding detail.
+func (t *TargetsMetadata) SetRuleDetails(ruleName, description, contact, link string) error {
+	if strings.HasPrefix(ruleName, tuf.GittufPrefix) {
+		return tuf.ErrCannotManipulateRulesWithGittufPrefix
+	}
+
+	if t.Delegations != nil {
+		for _, delegation := range t.Delegations.Roles {
+			if delegation.Name == ruleName {
+				delegation.Description = description
+				delegation.Contact = contact
+				delegation.Link = link
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: '%s'", tuf.ErrRuleNotFound, ruleName)
+}
+
+// RotateKey replaces the key identified by oldKeyID with newKey wherever it is
+// trusted in the rule file: as a principal of a rule or team, or as one of a
+// person's keys. It returns true if the metadata was modified.
+func (t *TargetsMetadata) RotateKey(oldKeyID string, newKey tuf.Principal) (bool, error) {
+	key, isKey := newKey.(*Key)
+	if !isKey {
+		return false, tuf.ErrInvalidPrincipalType
+	}
+	if oldKeyID == "" {
+		return false, tuf.ErrInvalidPrincipalID
+	}
+
+	if t.Delegations == nil {
+		return false, nil
+	}
+
+	rotated := rotateKeyInPrincipals(t.Delegations.Principals, oldKeyID, key)
+
+	for _, principal := range t.Delegations.Principals {
+		if team, isTeam := principal.(*Team); isTeam && replaceID(team.PrincipalIDs, oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	for _, delegation := range t.Delegations.Roles {
+		if replaceID(delegation.PrincipalIDs, oldKeyID, key.KeyID) {
+			rotated = true
+		}
+	}
+
+	return rotated, nil
+}
+
 // GetPrincipals returns all the principals in the rule file.
 func (t *TargetsMetadata) GetPrincipals() map[string]tuf.Principal {
 	principals := map[string]tuf.Principal{}
@@ -315,6 +432,17 @@ func (d *Delegations) UnmarshalJSON(data []byte) error {
 			continue
 		}
 
+		if _, has := tempPrincipal["teamID"]; has {
+			// this is *Team
+			team := &Team{}
+			if err := json.Unmarshal(principalBytes, team); err != nil {
+				return fmt.Errorf("unable to unmarshal json: %w", err)
+			}
+
+			d.Principals[principalID] = team
+			continue
+		}
+
 		return fmt.Errorf("unrecognized principal type '%s'", string(principalBytes))
 	}
 
@@ -323,8 +451,8 @@ func (d *Delegations) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// addPrincipal adds a delegations key or person.  v02 supports Key and Person
-// as principal types.
+// addPrincipal adds a delegations key, person, or team. v02 supports Key,
+// Person, and Team as principal types.
 func (d *Delegations) addPrincipal(principal tuf.Principal) error {
 	if d.Principals == nil {
 		d.Principals = map[string]tuf.Principal{}
@@ -333,6 +461,11 @@ func (d *Delegations) addPrincipal(principal tuf.Principal) error {
 	switch principal := principal.(type) {
 	case *Key, *Person:
 		d.Principals[principal.ID()] = principal
+	case *Team:
+		if err := d.validateTeam(principal); err != nil {
+			return err
+		}
+		d.Principals[principal.ID()] = principal
 	default:
 		return tuf.ErrInvalidPrincipalType
 	}
@@ -341,7 +474,7 @@ func (d *Delegations) addPrincipal(principal tuf.Principal) error {
 }
 
 // updatePrincipal updates an existing principal in the metadata. v02 supports
-// Key and Person as principal types.
+// Key, Person, and Team as principal types.
 func (d *Delegations) updatePrincipal(principal tuf.Principal) error {
 	if principal == nil {
 		return tuf.ErrInvalidPrincipalType
@@ -355,6 +488,11 @@ func (d *Delegations) updatePrincipal(principal tuf.Principal) error {
 	switch principal := principal.(type) {
 	case *Key, *Person:
 		d.Principals[principalID] = principal
+	case *Team:
+		if err := d.validateTeam(principal); err != nil {
+			return err
+		}
+		d.Principals[principalID] = principal
 	default:
 		return tuf.ErrInvalidPrincipalType
 	}
@@ -362,8 +500,8 @@ func (d *Delegations) updatePrincipal(principal tuf.Principal) error {
 	return nil
 }
 
-// removePrincipal removes a delegations key or person. v02 supports Key and
-// Person as principal types.
+// removePrincipal removes a delegations key, person, or team. v02 supports
+// Key, Person, and Team as principal types.
 func (d *Delegations) removePrincipal(principalID string) error {
 	if d.Principals == nil {
 		return tuf.ErrPrincipalNotFound
@@ -376,10 +514,40 @@ func (d *Delegations) removePrincipal(principalID string) error {
 			return tuf.ErrPrincipalStillInUse
 		}
 	}
+	for _, principal := range d.Principals {
+		if team, isTeam := principal.(*Team); isTeam && team.PrincipalIDs != nil && team.PrincipalIDs.Has(principalID) {
+			return tuf.ErrPrincipalStillInUse
+		}
+	}
 	delete(d.Principals, principalID)
 	return nil
 }
 
+// validateTeam checks that the team's members are keys or persons declared in
+// the rule file and that the team's threshold can be met by its members.
+func (d *Delegations) validateTeam(team *Team) error {
+	if team.Threshold <= 0 {
+		return tuf.ErrInvalidThreshold
+	}
+
+	if team.PrincipalIDs == nil || team.PrincipalIDs.Len() < team.Threshold {
+		return tuf.ErrCannotMeetThreshold
+	}
+
+	for _, memberID := range team.PrincipalIDs.Contents() {
+		member, has := d.Principals[memberID]
+		if !has {
+			return fmt.Errorf("%w: team member '%s'", tuf.ErrPrincipalNotFound, memberID)
+		}
+
+		if _, isTeam := member.(*Team); isTeam {
+			return tuf.ErrInvalidTeamMember
+		}
+	}
+
+	return nil
+}
+
 // AllowRule returns the default, last rule for all policy files.
 func AllowRule() *Delegation {
 	return &Delegation{
@@ -396,10 +564,15 @@ func AllowRule() *Delegation {
 // the standard TUF schema by allowing a `custom` field to record details
 // pertaining to the delegation. It implements the tuf.Rule interface.
 type Delegation struct {
-	Name        string           `json:"name"`
-	Paths       []string         `json:"paths"`
-	Terminating bool             `json:"terminating"`
-	Custom      *json.RawMessage `json:"custom,omitempty"`
+	Name               string           `json:"name"`
+	Paths              []string         `json:"paths"`
+	ExcludedPaths      []string         `json:"excludedPaths,omitempty"`
+	Terminating        bool             `json:"terminating"`
+	SeparationOfDuties bool             `json:"separationOfDuties,omitempty"` // SeparationOfDuties prevents the principal who signed a change from counting towards the threshold for it
+	Description        string           `json:"description,omitempty"`        // Description records why the rule exists
+	Contact            string           `json:"contact,omitempty"`            // Contact records who owns the rule
+	Link               string           `json:"link,omitempty"`               // Link points to further context for the rule, such as a ticket
+	Custom             *json.RawMessage `json:"custom,omitempty"`
 	Role
 }
 
@@ -408,11 +581,18 @@ func (d *Delegation) ID() string {
 	return d.Name
 }
 
-// Matches checks if any of the delegation's patterns match the target.
-func (d *Delegation) Matches(target string) bool {
+// Matches checks if any of the delegation's patterns match the target and none
+// of its exclusion patterns do.
+func (d *Delegation) Matches(target string, opts ...matchopts.Option) bool {
+	for _, pattern := range d.ExcludedPaths {
+		if tuf.MatchPattern(pattern, target, opts...) {
+			return false
+		}
+	}
+
 	for _, pattern := range d.Paths {
 		// We validate pattern when it's added to / updated in the metadata
-		if matches := fnmatch.Match(pattern, target, 0); matches {
+		if tuf.MatchPattern(pattern, target, opts...) {
 			return true
 		}
 	}
@@ -445,3 +625,58 @@ func (d *Delegation) IsLastTrustedInRuleFile() bool {
 func (d *Delegation) GetProtectedNamespaces() []string {
 	return d.Paths
 }
+
+// GetExcludedNamespaces returns the set of namespaces excluded from the
+// delegation's protected namespaces.
+func (d *Delegation) GetExcludedNamespaces() []string {
+	return d.ExcludedPaths
+}
+
+// RequiresSeparationOfDuties indicates that the principal who signed a change
+// must not be counted towards the delegation's threshold for that change.
+func (d *Delegation) RequiresSeparationOfDuties() bool {
+	return d.SeparationOfDuties
+}
+
+// GetDescription returns why the delegation exists.
+func (d *Delegation) GetDescription() string {
+	return d.Description
+}
+
+// GetContact returns who to contact about the delegation.
+func (d *Delegation) GetContact() string {
+	return d.Contact
+}
+
+// GetLink returns a link to further context about the delegation.
+func (d *Delegation) GetLink() string {
+	return d.Link
+}
+
+// splitRulePatterns separates the exclusion patterns from the patterns a rule
+// applies to, stripping the exclusion prefix. Each pattern is also validated.
+func splitRulePatterns(rulePatterns []string) ([]string, []string, error) {
+	paths := []string{}
+	excludedPaths := []string{}
+	for _, pattern := range rulePatterns {
+		excludedPath, isExclusion := strings.CutPrefix(pattern, tuf.ExclusionPatternPrefix)
+		if err := tuf.ValidatePattern(excludedPath); err != nil {
+			return nil, nil, err
+		}
+
+		if isExclusion {
+			excludedPaths = append(excludedPaths, excludedPath)
+			continue
+		}
+		paths = append(paths, pattern)
+	}
+
+	if len(excludedPaths) == 0 {
+		return rulePatterns, nil, nil
+	}
+	if len(paths) == 0 {
+		return nil, nil, tuf.ErrRuleHasOnlyExclusionPatterns
+	}
+
+	return paths, excludedPaths, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	"github.com/gittuf/gittuf/internal/tuf"
+	matchopts "github.com/gittuf/gittuf/internal/tuf/options/match"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -122,6 +123,32 @@ func TestDelegationsUnmarshalJSON(t *testing.T) {
 		assert.Equal(t, delegations, got)
 	})
 
+	t.Run("key, person, and team principals", func(t *testing.T) {
+		team := &Team{
+			TeamID:       "maintainers",
+			PrincipalIDs: set.NewSetFromItems(key.KeyID, person.PersonID),
+			Threshold:    2,
+		}
+		delegations := &Delegations{
+			Principals: map[string]tuf.Principal{
+				key.KeyID:       key,
+				person.PersonID: person,
+				team.TeamID:     team,
+			},
+			Roles: []*Delegation{AllowRule()},
+		}
+
+		data, err := json.Marshal(delegations)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := &Delegations{}
+		err = json.Unmarshal(data, got)
+		assert.Nil(t, err)
+		assert.Equal(t, delegations, got)
+	})
+
 	t.Run("invalid JSON", func(t *testing.T) {
 		delegations := &Delegations{}
 
@@ -258,6 +285,58 @@ func TestDelegation(t *testing.T) {
 		}
 	})
 
+	t.Run("matches with exclusions", func(t *testing.T) {
+		tests := map[string]struct {
+			patterns         []string
+			excludedPatterns []string
+			target           string
+			expected         bool
+		}{
+			"not excluded, matches": {
+				patterns:         []string{"file:src/*"},
+				excludedPatterns: []string{"file:src/generated/*"},
+				target:           "file:src/main.go",
+				expected:         true,
+			},
+			"excluded, does not match": {
+				patterns:         []string{"file:src/*"},
+				excludedPatterns: []string{"file:src/generated/*"},
+				target:           "file:src/generated/types.go",
+				expected:         false,
+			},
+			"excluded by one of several exclusions, does not match": {
+				patterns:         []string{"git:refs/heads/*"},
+				excludedPatterns: []string{"git:refs/heads/feature/*", "git:refs/heads/dev"},
+				target:           "git:refs/heads/dev",
+				expected:         false,
+			},
+			"exclusion only, does not match": {
+				patterns:         []string{"file:src/*"},
+				excludedPatterns: []string{"file:docs/*"},
+				target:           "file:docs/README.md",
+				expected:         false,
+			},
+			"regex not excluded, matches": {
+				patterns:         []string{"re:git:refs/heads/release-[0-9]+"},
+				excludedPatterns: []string{"class:default-branch"},
+				target:           "git:refs/heads/release-1",
+				expected:         true,
+			},
+			"default branch excluded, does not match": {
+				patterns:         []string{"class:branches"},
+				excludedPatterns: []string{"class:default-branch"},
+				target:           "git:refs/heads/main",
+				expected:         false,
+			},
+		}
+
+		for name, test := range tests {
+			delegation := Delegation{Paths: test.patterns, ExcludedPaths: test.excludedPatterns}
+			got := delegation.Matches(test.target, matchopts.WithDefaultBranch("refs/heads/main"))
+			assert.Equal(t, test.expected, got, fmt.Sprintf("unexpected result in test '%s'", name))
+		}
+	})
+
 	t.Run("threshold", func(t *testing.T) {
 		delegation := &Delegation{}
 
@@ -289,6 +368,16 @@ func TestDelegation(t *testing.T) {
 		assert.Equal(t, []string{"1", "2"}, protected)
 	})
 
+	t.Run("excluded namespaces", func(t *testing.T) {
+		delegation := &Delegation{
+			Paths:         []string{"1/*"},
+			ExcludedPaths: []string{"1/2/*"},
+		}
+
+		excluded := delegation.GetExcludedNamespaces()
+		assert.Equal(t, []string{"1/2/*"}, excluded)
+	})
+
 	t.Run("principal IDs", func(t *testing.T) {
 		keyIDs := set.NewSetFromItems("1", "2")
 		delegation := &Delegation{
@@ -345,6 +434,35 @@ func TestAddRuleAndGetRules(t *testing.T) {
 	assert.Equal(t, 2, len(rules))
 	assert.Equal(t, []tuf.Rule{rule, AllowRule()}, rules)
 
+	err = targetsMetadata.AddRule("test-rule-with-exclusions", []string{key1.KeyID}, []string{"file:src/*", "!file:src/generated/*"}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &Delegation{
+		Name:          "test-rule-with-exclusions",
+		Paths:         []string{"file:src/*"},
+		ExcludedPaths: []string{"file:src/generated/*"},
+		Terminating:   false,
+		Role:          Role{PrincipalIDs: set.NewSetFromItems(key1.KeyID), Threshold: 1},
+	}, targetsMetadata.Delegations.Roles[1])
+
+	err = targetsMetadata.AddRule("exclusions-only-rule", []string{key1.KeyID}, []string{"!file:src/generated/*"}, 1)
+	assert.ErrorIs(t, err, tuf.ErrRuleHasOnlyExclusionPatterns)
+
+	err = targetsMetadata.AddRule("test-rule-with-regex", []string{key1.KeyID}, []string{"re:git:refs/heads/release-[0-9]+", "!class:default-branch"}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &Delegation{
+		Name:          "test-rule-with-regex",
+		Paths:         []string{"re:git:refs/heads/release-[0-9]+"},
+		ExcludedPaths: []string{"class:default-branch"},
+		Terminating:   false,
+		Role:          Role{PrincipalIDs: set.NewSetFromItems(key1.KeyID), Threshold: 1},
+	}, targetsMetadata.Delegations.Roles[2])
+
+	err = targetsMetadata.AddRule("invalid-regex-rule", []string{key1.KeyID}, []string{"re:git:refs/heads/(release"}, 1)
+	assert.ErrorIs(t, err, tuf.ErrInvalidPattern)
+
+	err = targetsMetadata.AddRule("invalid-ref-class-rule", []string{key1.KeyID}, []string{"!class:remotes", "git:refs/heads/*"}, 1)
+	assert.ErrorIs(t, err, tuf.ErrInvalidPattern)
+
 	t.Run("miscellaneous error checking", func(t *testing.T) {
 		targetsMetadata := initialTestTargetsMetadata(t)
 
@@ -409,6 +527,20 @@ func TestUpdateDelegation(t *testing.T) {
 		Role:        Role{PrincipalIDs: set.NewSetFromItems(key1.KeyID, key2.KeyID), Threshold: 1},
 	}, targetsMetadata.Delegations.Roles[0])
 
+	// Add exclusions to the rule
+	err = targetsMetadata.UpdateRule("test-rule", []string{key1.KeyID}, []string{"test/", "!test/generated/"}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"test/"}, targetsMetadata.Delegations.Roles[0].Paths)
+	assert.Equal(t, []string{"test/generated/"}, targetsMetadata.Delegations.Roles[0].ExcludedPaths)
+
+	// Exclusions are replaced along with the rest of the patterns
+	err = targetsMetadata.UpdateRule("test-rule", []string{key1.KeyID}, []string{"test/"}, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, targetsMetadata.Delegations.Roles[0].ExcludedPaths)
+
+	err = targetsMetadata.UpdateRule("test-rule", []string{key1.KeyID}, []string{"!test/generated/"}, 1)
+	assert.ErrorIs(t, err, tuf.ErrRuleHasOnlyExclusionPatterns)
+
 	t.Run("miscellaneous error checking", func(t *testing.T) {
 		targetsMetadata := initialTestTargetsMetadata(t)
 
@@ -537,6 +669,155 @@ func TestRemoveRule(t *testing.T) {
 	})
 }
 
+func TestSeparationOfDuties(t *testing.T) {
+	targetsMetadata := initialTestTargetsMetadata(t)
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	if err := targetsMetadata.AddPrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	err := targetsMetadata.AddRule("test-rule", []string{key.KeyID}, []string{"test/"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequiresSeparationOfDuties())
+
+	err = targetsMetadata.EnableSeparationOfDuties("test-rule")
+	assert.Nil(t, err)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequiresSeparationOfDuties())
+
+	// Updating the rule must not reset the flag
+	err = targetsMetadata.UpdateRule("test-rule", []string{key.KeyID}, []string{"test/", "other/"}, 1)
+	assert.Nil(t, err)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequiresSeparationOfDuties())
+
+	err = targetsMetadata.DisableSeparationOfDuties("test-rule")
+	assert.Nil(t, err)
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequiresSeparationOfDuties())
+
+	t.Run("miscellaneous error checking", func(t *testing.T) {
+		err = targetsMetadata.EnableSeparationOfDuties("missing-rule")
+		assert.ErrorIs(t, err, tuf.ErrRuleNotFound)
+
+		err = targetsMetadata.EnableSeparationOfDuties(tuf.AllowRuleName)
+		assert.ErrorIs(t, err, tuf.ErrCannotManipulateRulesWithGittufPrefix)
+
+		err = targetsMetadata.DisableSeparationOfDuties(tuf.AllowRuleName)
+		assert.ErrorIs(t, err, tuf.ErrCannotManipulateRulesWithGittufPrefix)
+	})
+}
+
+func TestSetRuleDetails(t *testing.T) {
+	targetsMetadata := initialTestTargetsMetadata(t)
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	if err := targetsMetadata.AddPrincipal(key); err != nil {
+		t.Fatal(err)
+	}
+
+	err := targetsMetadata.AddRule("test-rule", []string{key.KeyID}, []string{"test/"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ruleJSON, err := json.Marshal(targetsMetadata.Delegations.Roles[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, string(ruleJSON), "description")
+
+	err = targetsMetadata.SetRuleDetails("test-rule", "Protects test files", "jane.doe@example.com", "https://example.com/issues/1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Protects test files", targetsMetadata.Delegations.Roles[0].GetDescription())
+	assert.Equal(t, "jane.doe@example.com", targetsMetadata.Delegations.Roles[0].GetContact())
+	assert.Equal(t, "https://example.com/issues/1", targetsMetadata.Delegations.Roles[0].GetLink())
+
+	// Updating the rule must not reset the details
+	err = targetsMetadata.UpdateRule("test-rule", []string{key.KeyID}, []string{"test/", "other/"}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Protects test files", targetsMetadata.Delegations.Roles[0].GetDescription())
+
+	ruleJSON, err = json.Marshal(targetsMetadata.Delegations.Roles[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedRule := &Delegation{}
+	if err := json.Unmarshal(ruleJSON, decodedRule); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, targetsMetadata.Delegations.Roles[0], decodedRule)
+
+	err = targetsMetadata.SetRuleDetails("test-rule", "", "", "")
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].GetDescription())
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].GetContact())
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].GetLink())
+
+	t.Run("miscellaneous error checking", func(t *testing.T) {
+		err = targetsMetadata.SetRuleDetails("missing-rule", "", "", "")
+		assert.ErrorIs(t, err, tuf.ErrRuleNotFound)
+
+		err = targetsMetadata.SetRuleDetails(tuf.AllowRuleName, "", "", "")
+		assert.ErrorIs(t, err, tuf.ErrCannotManipulateRulesWithGittufPrefix)
+	})
+}
+
+func TestTargetsMetadataRotateKey(t *testing.T) {
+	targetsMetadata := initialTestTargetsMetadata(t)
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	newKey := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets2PubKeyBytes))
+	person := &Person{
+		PersonID:   "jane.doe",
+		PublicKeys: map[string]*Key{key.KeyID: key},
+	}
+	team := &Team{
+		TeamID:       "maintainers",
+		PrincipalIDs: set.NewSetFromItems(key.KeyID, person.PersonID),
+		Threshold:    1,
+	}
+
+	for _, principal := range []tuf.Principal{key, person, team} {
+		if err := targetsMetadata.AddPrincipal(principal); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := targetsMetadata.AddRule("key-rule", []string{key.KeyID}, []string{"git:refs/heads/main"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := targetsMetadata.AddRule("team-rule", []string{team.TeamID}, []string{"file:src/*"}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := targetsMetadata.RotateKey(key.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.True(t, rotated)
+
+	principals := targetsMetadata.GetPrincipals()
+	assert.Contains(t, principals, newKey.KeyID)
+	assert.NotContains(t, principals, key.KeyID)
+	assert.Equal(t, map[string]*Key{newKey.KeyID: newKey}, person.PublicKeys)
+	assert.Equal(t, set.NewSetFromItems(newKey.KeyID, person.PersonID), team.PrincipalIDs)
+
+	rules := targetsMetadata.GetRules()
+	assert.Equal(t, set.NewSetFromItems(newKey.KeyID), rules[0].GetPrincipalIDs())
+	assert.Equal(t, set.NewSetFromItems(team.TeamID), rules[1].GetPrincipalIDs())
+
+	rotated, err = targetsMetadata.RotateKey(key.KeyID, newKey)
+	assert.Nil(t, err)
+	assert.False(t, rotated)
+
+	t.Run("miscellaneous error checking", func(t *testing.T) {
+		_, err := targetsMetadata.RotateKey("", newKey)
+		assert.ErrorIs(t, err, tuf.ErrInvalidPrincipalID)
+
+		_, err = targetsMetadata.RotateKey(newKey.KeyID, team)
+		assert.ErrorIs(t, err, tuf.ErrInvalidPrincipalType)
+	})
+}
+
 func TestRemovePrincipal(t *testing.T) {
 	targetsMetadata := initialTestTargetsMetadata(t)
 
@@ -580,6 +861,113 @@ func TestRemovePrincipal(t *testing.T) {
 	assert.NotContains(t, targetsMetadata.Delegations.Principals, key.KeyID)
 }
 
+func TestTeamPrincipal(t *testing.T) {
+	targetsMetadata := initialTestTargetsMetadata(t)
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+	person := &Person{
+		PersonID: "jane.doe",
+		PublicKeys: map[string]*Key{
+			key.KeyID: key,
+		},
+	}
+
+	err := targetsMetadata.AddPrincipal(key)
+	assert.Nil(t, err)
+	err = targetsMetadata.AddPrincipal(person)
+	assert.Nil(t, err)
+
+	t.Run("member not found", func(t *testing.T) {
+		team := &Team{
+			TeamID:       "maintainers",
+			PrincipalIDs: set.NewSetFromItems(person.PersonID, "john.doe"),
+			Threshold:    1,
+		}
+		err := targetsMetadata.AddPrincipal(team)
+		assert.ErrorIs(t, err, tuf.ErrPrincipalNotFound)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		team := &Team{
+			TeamID:       "maintainers",
+			PrincipalIDs: set.NewSetFromItems(person.PersonID),
+			Threshold:    0,
+		}
+		err := targetsMetadata.AddPrincipal(team)
+		assert.ErrorIs(t, err, tuf.ErrInvalidThreshold)
+	})
+
+	t.Run("threshold cannot be met", func(t *testing.T) {
+		team := &Team{
+			TeamID:       "maintainers",
+			PrincipalIDs: set.NewSetFromItems(person.PersonID),
+			Threshold:    2,
+		}
+		err := targetsMetadata.AddPrincipal(team)
+		assert.ErrorIs(t, err, tuf.ErrCannotMeetThreshold)
+	})
+
+	t.Run("team as member", func(t *testing.T) {
+		team := &Team{
+			TeamID:       "maintainers",
+			PrincipalIDs: set.NewSetFromItems(person.PersonID),
+			Threshold:    1,
+		}
+		err := targetsMetadata.AddPrincipal(team)
+		assert.Nil(t, err)
+
+		nestedTeam := &Team{
+			TeamID:       "nested",
+			PrincipalIDs: set.NewSetFromItems(team.TeamID),
+			Threshold:    1,
+		}
+		err = targetsMetadata.AddPrincipal(nestedTeam)
+		assert.ErrorIs(t, err, tuf.ErrInvalidTeamMember)
+
+		err = targetsMetadata.RemovePrincipal(team.TeamID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("add, use, update, and remove team", func(t *testing.T) {
+		team := &Team{
+			TeamID:       "maintainers",
+			PrincipalIDs: set.NewSetFromItems(key.KeyID, person.PersonID),
+			Threshold:    2,
+		}
+		err := targetsMetadata.AddPrincipal(team)
+		assert.Nil(t, err)
+		assert.Equal(t, team, targetsMetadata.Delegations.Principals[team.TeamID])
+
+		// Members of a team cannot be removed
+		err = targetsMetadata.RemovePrincipal(person.PersonID)
+		assert.ErrorIs(t, err, tuf.ErrPrincipalStillInUse)
+
+		err = targetsMetadata.AddRule("test-rule", []string{team.TeamID}, []string{"test/"}, 1)
+		assert.Nil(t, err)
+
+		updatedTeam := &Team{
+			TeamID:       team.TeamID,
+			PrincipalIDs: set.NewSetFromItems(person.PersonID),
+			Threshold:    1,
+		}
+		err = targetsMetadata.UpdatePrincipal(updatedTeam)
+		assert.Nil(t, err)
+		assert.Equal(t, updatedTeam, targetsMetadata.Delegations.Principals[team.TeamID])
+
+		err = targetsMetadata.RemovePrincipal(team.TeamID)
+		assert.ErrorIs(t, err, tuf.ErrPrincipalStillInUse)
+
+		err = targetsMetadata.RemoveRule("test-rule")
+		assert.Nil(t, err)
+
+		err = targetsMetadata.RemovePrincipal(team.TeamID)
+		assert.Nil(t, err)
+
+		err = targetsMetadata.RemovePrincipal(person.PersonID)
+		assert.Nil(t, err)
+	})
+}
+
 func TestUpdatePrincipal(t *testing.T) {
 	targetsMetadata := initialTestTargetsMetadata(t)
 
@@ -9,14 +9,19 @@ package v02
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/tuf"
 	v01 "github.com/gittuf/gittuf/internal/tuf/v01"
 	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 )
 
 const (
 	associatedIdentityKey = "(associated identity)"
+	teamMembersKey        = "(team members)"
+	teamThresholdKey      = "(team threshold)"
 )
 
 // Key defines the structure for how public keys are stored in TUF metadata. It
@@ -51,6 +56,18 @@ func (p *Person) Keys() []*signerverifier.SSLibKey {
 	return keys
 }
 
+// rotateKey replaces the key identified by oldKeyID with newKey in the
+// person's keys. It returns true if the person held the old key.
+func (p *Person) rotateKey(oldKeyID string, newKey *Key) bool {
+	if _, has := p.PublicKeys[oldKeyID]; !has {
+		return false
+	}
+
+	delete(p.PublicKeys, oldKeyID)
+	p.PublicKeys[newKey.KeyID] = newKey
+	return true
+}
+
 func (p *Person) CustomMetadata() map[string]string {
 	var metadata map[string]string
 
@@ -71,9 +88,91 @@ func (p *Person) CustomMetadata() map[string]string {
 	return metadata
 }
 
+// Team groups principals declared in the same rule file. It implements
+// tuf.Team. A team is counted towards a rule's threshold only when Threshold of
+// its members have approved.
+type Team struct {
+	TeamID       string            `json:"teamID"`
+	PrincipalIDs *set.Set[string]  `json:"principalIDs"`
+	Threshold    int               `json:"threshold"`
+	Custom       map[string]string `json:"custom,omitempty"`
+}
+
+func (t *Team) ID() string {
+	return t.TeamID
+}
+
+// Keys returns nil as a team has no keys of its own. The keys of its members
+// are used during verification.
+func (t *Team) Keys() []*signerverifier.SSLibKey {
+	return nil
+}
+
+func (t *Team) CustomMetadata() map[string]string {
+	metadata := map[string]string{
+		teamThresholdKey: fmt.Sprintf("%d", t.Threshold),
+	}
+
+	if t.PrincipalIDs != nil {
+		members := t.PrincipalIDs.Contents()
+		slices.Sort(members)
+		metadata[teamMembersKey] = strings.Join(members, ", ")
+	}
+
+	for key, value := range t.Custom {
+		metadata[key] = value
+	}
+
+	return metadata
+}
+
+// GetPrincipalIDs returns the identifiers of the team's members.
+func (t *Team) GetPrincipalIDs() *set.Set[string] {
+	return t.PrincipalIDs
+}
+
+// GetThreshold returns the number of members who must approve for the team to
+// be counted.
+func (t *Team) GetThreshold() int {
+	return t.Threshold
+}
+
 // Role records common characteristics recorded in a role entry in Root metadata
 // and in a delegation entry.
 type Role struct {
 	PrincipalIDs *set.Set[string] `json:"principalIDs"`
 	Threshold    int              `json:"threshold"`
 }
+
+// replaceID swaps oldID for newID in ids. It returns true if oldID was present.
+func replaceID(ids *set.Set[string], oldID, newID string) bool {
+	if ids == nil || !ids.Has(oldID) {
+		return false
+	}
+
+	ids.Remove(oldID)
+	ids.Add(newID)
+	return true
+}
+
+// rotateKeyInPrincipals replaces the key principal identified by oldKeyID with
+// newKey, and the old key in any person's keys. It returns true if principals
+// was modified.
+func rotateKeyInPrincipals(principals map[string]tuf.Principal, oldKeyID string, newKey *Key) bool {
+	rotated := false
+	if principal, has := principals[oldKeyID]; has {
+		if _, isKey := principal.(*Key); isKey {
+			delete(principals, oldKeyID)
+			principals[newKey.KeyID] = newKey
+			rotated = true
+		}
+	}
+
+	for _, principal := range principals {
+		if person, isPerson := principal.(*Person); isPerson && person.rotateKey(oldKeyID, newKey) {
+			rotated = true
+		}
+	}
+
+	return rotated
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 	"github.com/stretchr/testify/assert"
@@ -102,3 +103,51 @@ func TestPerson(t *testing.T) {
 		assert.Equal(t, test.expectedCustomMetadata, customMetadata, fmt.Sprintf("unexpected custom metadata in test '%s'", name))
 	}
 }
+
+func TestTeam(t *testing.T) {
+	tests := map[string]struct {
+		team                   *Team
+		expectedID             string
+		expectedCustomMetadata map[string]string
+	}{
+		"no custom metadata": {
+			team: &Team{
+				TeamID:       "maintainers",
+				PrincipalIDs: set.NewSetFromItems("jane.doe", "john.doe"),
+				Threshold:    2,
+			},
+			expectedID: "maintainers",
+			expectedCustomMetadata: map[string]string{
+				teamMembersKey:   "jane.doe, john.doe",
+				teamThresholdKey: "2",
+			},
+		},
+		"custom metadata": {
+			team: &Team{
+				TeamID:       "maintainers",
+				PrincipalIDs: set.NewSetFromItems("jane.doe"),
+				Threshold:    1,
+				Custom: map[string]string{
+					"key": "value",
+				},
+			},
+			expectedID: "maintainers",
+			expectedCustomMetadata: map[string]string{
+				teamMembersKey:   "jane.doe",
+				teamThresholdKey: "1",
+				"key":            "value",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		id := test.team.ID()
+		assert.Equal(t, test.expectedID, id, fmt.Sprintf("unexpected team ID in test '%s'", name))
+
+		keys := test.team.Keys()
+		assert.Nil(t, keys, fmt.Sprintf("unexpected keys in test '%s'", name))
+
+		customMetadata := test.team.CustomMetadata()
+		assert.Equal(t, test.expectedCustomMetadata, customMetadata, fmt.Sprintf("unexpected custom metadata in test '%s'", name))
+	}
+}
@@ -230,6 +230,60 @@ func (r *Repository) GetCommitMessage(commitID Hash) (string, error) {
 	return commitMessage, nil
 }
 
+// GetCommitAuthorEmail returns the email address of the commit's author.
+func (r *Repository) GetCommitAuthorEmail(commitID Hash) (string, error) {
+	if err := r.ensureIsCommit(commitID); err != nil {
+		return "", err
+	}
+
+	authorEmail, err := r.executor("show", "-s", "--format=%ae", commitID.String()).executeString()
+	if err != nil {
+		return "", fmt.Errorf("unable to identify author for commit '%s': %w", commitID.String(), err)
+	}
+
+	return authorEmail, nil
+}
+
+// GetCommitTime returns the time the commit was created, as recorded by its
+// committer.
+func (r *Repository) GetCommitTime(commitID Hash) (time.Time, error) {
+	if err := r.ensureIsCommit(commitID); err != nil {
+		return time.Time{}, err
+	}
+
+	commitTime, err := r.executor("show", "-s", "--format=%cI", commitID.String()).executeString()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to identify time for commit '%s': %w", commitID.String(), err)
+	}
+
+	return time.Parse(time.RFC3339, commitTime)
+}
+
+// GetCommitTrailerValues returns the values of all trailers with the specified
+// key (e.g., "Signed-off-by") in the commit's message.
+func (r *Repository) GetCommitTrailerValues(commitID Hash, key string) ([]string, error) {
+	if err := r.ensureIsCommit(commitID); err != nil {
+		return nil, err
+	}
+
+	stdOut, err := r.executor("show", "-s", fmt.Sprintf("--format=%%(trailers:key=%s,valueonly,unfold)", key), commitID.String()).executeString()
+	if err != nil {
+		return nil, fmt.Errorf("unable to identify trailers for commit '%s': %w", commitID.String(), err)
+	}
+
+	values := []string{}
+	for _, value := range strings.Split(stdOut, "\n") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
 // GetCommitTreeID returns the commit's Git tree ID.
 func (r *Repository) GetCommitTreeID(commitID Hash) (Hash, error) {
 	if err := r.ensureIsCommit(commitID); err != nil {
@@ -6,6 +6,7 @@ package gitinterface
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -712,6 +713,100 @@ func TestRepositoryGetCommitMessage(t *testing.T) {
 	})
 }
 
+func TestGetCommitAuthorEmail(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tempDir, false)
+
+	treeBuilder := NewTreeBuilder(repo)
+	emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Initial commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorEmail, err := repo.GetCommitAuthorEmail(commitID)
+	assert.Nil(t, err)
+	assert.Equal(t, testEmail, authorEmail)
+
+	t.Run("non-commit object", func(t *testing.T) {
+		_, err := repo.GetCommitAuthorEmail(emptyTreeID)
+		assert.ErrorContains(t, err, "is not a commit object")
+	})
+}
+
+func TestGetCommitTime(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tempDir, false)
+
+	treeBuilder := NewTreeBuilder(repo)
+	emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Initial commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitTime, err := repo.GetCommitTime(commitID)
+	assert.Nil(t, err)
+	assert.True(t, testClock.Now().Equal(commitTime))
+
+	t.Run("non-commit object", func(t *testing.T) {
+		_, err := repo.GetCommitTime(emptyTreeID)
+		assert.ErrorContains(t, err, "is not a commit object")
+	})
+}
+
+func TestGetCommitTrailerValues(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tempDir, false)
+
+	treeBuilder := NewTreeBuilder(repo)
+	emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		message        string
+		expectedValues []string
+	}{
+		"no trailers": {
+			message:        "Initial commit\n",
+			expectedValues: []string{},
+		},
+		"single trailer": {
+			message:        "Initial commit\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n",
+			expectedValues: []string{"Jane Doe <jane.doe@example.com>"},
+		},
+		"multiple trailers": {
+			message:        "Initial commit\n\nReviewed-by: John Doe <john.doe@example.com>\nSigned-off-by: Jane Doe <jane.doe@example.com>\nSigned-off-by: John Doe <john.doe@example.com>\n",
+			expectedValues: []string{"Jane Doe <jane.doe@example.com>", "John Doe <john.doe@example.com>"},
+		},
+		"trailer-like line in body": {
+			message:        "Initial commit\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n\nMore details\n",
+			expectedValues: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		commitID, err := repo.Commit(emptyTreeID, "refs/heads/main", test.message, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := repo.GetCommitTrailerValues(commitID, "Signed-off-by")
+		assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		assert.Equal(t, test.expectedValues, values, fmt.Sprintf("unexpected trailers in test '%s'", name))
+	}
+}
+
 func TestGetCommitTreeID(t *testing.T) {
 	tempDir := t.TempDir()
 	repo := CreateTestGitRepository(t, tempDir, false)
@@ -12,6 +12,14 @@ import (
 	"strings"
 )
 
+const (
+	// Modes of the files recorded in a Git tree
+	ModeRegularFile    = "100644"
+	ModeExecutableFile = "100755"
+	ModeSymbolicLink   = "120000"
+	ModeGitlink        = "160000"
+)
+
 var (
 	ErrTreeDoesNotHavePath             = errors.New("tree does not have requested path")
 	ErrCopyingBlobIDsDoNotMatch        = errors.New("blob ID in local repository does not match upstream repository")
@@ -153,6 +161,33 @@ func (r *Repository) GetAllFilesInTree(treeID Hash) (map[string]Hash, error) {
 	return files, nil
 }
 
+// GetAllFileModesInTree returns all filepaths and the corresponding modes in
+// the specified tree. Submodules are included as files with ModeGitlink.
+func (r *Repository) GetAllFileModesInTree(treeID Hash) (map[string]string, error) {
+	stdOut, err := r.executor("ls-tree", "-r", treeID.String()).executeString()
+	if err != nil {
+		return nil, fmt.Errorf("unable to enumerate all files in tree: %w", err)
+	}
+
+	if stdOut == "" {
+		return nil, nil
+	}
+
+	modes := map[string]string{}
+	for _, entry := range strings.Split(stdOut, "\n") {
+		// <mode> SP <type> SP <object> TAB <file>
+		mode, rest, _ := strings.Cut(entry, " ")
+		_, path, found := strings.Cut(rest, "\t")
+		if !found {
+			return nil, fmt.Errorf("unable to parse tree entry '%s'", entry)
+		}
+
+		modes[path] = mode
+	}
+
+	return modes, nil
+}
+
 // GetMergeTree computes the merge tree for the commits passed in. The tree is
 // not written to the object store. Assuming a typical merge workflow, the first
 // commit is expected to be the tip of the base branch. As such, the second
@@ -4,6 +4,8 @@
 package gitinterface
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"testing"
 
@@ -1212,3 +1214,46 @@ func TestGetAllFilesInTree(t *testing.T) {
 		assert.ErrorContains(t, err, "unable to enumerate all files in tree")
 	})
 }
+
+func TestGetAllFileModesInTree(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tmpDir, false)
+
+	blobID, err := repo.WriteBlob([]byte("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subtreeID, err := NewTreeBuilder(repo).WriteTreeFromEntries([]TreeEntry{NewEntryBlob("a", blobID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	submoduleID, err := NewHash("0123456789abcdef0123456789abcdef01234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := fmt.Sprintf("100644 blob %s\ta\n100755 blob %s\tb\n120000 blob %s\tc\n160000 commit %s\td\n040000 tree %s\te\n", blobID, blobID, blobID, submoduleID, subtreeID)
+	stdOut, err := repo.executor("mktree").withStdIn(bytes.NewBufferString(input)).executeString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeID, err := NewHash(stdOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modes, err := repo.GetAllFileModesInTree(treeID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"a":   ModeRegularFile,
+		"b":   ModeExecutableFile,
+		"c":   ModeSymbolicLink,
+		"d":   ModeGitlink,
+		"e/a": ModeRegularFile,
+	}, modes)
+
+	_, err = repo.GetAllFileModesInTree(blobID)
+	assert.ErrorContains(t, err, "unable to enumerate all files in tree")
+}